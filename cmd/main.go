@@ -20,9 +20,13 @@ import (
 	pgbootstrap "github.com/EduGoGroup/edugo-shared/bootstrap/postgres"
 
 	"github.com/EduGoGroup/edugo-api-iam-platform/docs"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/service"
+	authHandler "github.com/EduGoGroup/edugo-api-iam-platform/internal/auth/handler"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/cli"
 	"github.com/EduGoGroup/edugo-api-iam-platform/internal/config"
 	"github.com/EduGoGroup/edugo-api-iam-platform/internal/container"
-	auditpostgres "github.com/EduGoGroup/edugo-shared/audit/postgres"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/infrastructure/http/handler"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/infrastructure/jobs"
 	"github.com/EduGoGroup/edugo-shared/auth"
 	"github.com/EduGoGroup/edugo-shared/common/types/enum"
 	"github.com/EduGoGroup/edugo-shared/logger"
@@ -68,6 +72,22 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	// Subcommands write their output (e.g. an exported manifest) to stdout, so
+	// their SQL log goes to stderr and only reports slow queries and errors
+	subcommand := len(os.Args) > 1
+	gormLogOutput := os.Stdout
+	gormLogConfig := gormlogger.Config{
+		SlowThreshold:             500 * time.Millisecond,
+		LogLevel:                  gormlogger.Info,
+		IgnoreRecordNotFoundError: true,
+		Colorful:                  true,
+	}
+	if subcommand {
+		gormLogOutput = os.Stderr
+		gormLogConfig.LogLevel = gormlogger.Warn
+		gormLogConfig.Colorful = false
+	}
+
 	pgFactory := pgbootstrap.NewFactory()
 	gormDB, err := pgFactory.CreateGORMConnection(ctx, bootstrap.PostgreSQLConfig{
 		Host:            cfg.Database.Postgres.Host,
@@ -82,13 +102,8 @@ func main() {
 		ConnMaxLifetime: time.Hour,
 	},
 		bootstrap.WithGORMLogger(gormlogger.New(
-			log.New(os.Stdout, "\r\n", log.LstdFlags),
-			gormlogger.Config{
-				SlowThreshold:             500 * time.Millisecond,
-				LogLevel:                  gormlogger.Info,
-				IgnoreRecordNotFoundError: true,
-				Colorful:                  true,
-			},
+			log.New(gormLogOutput, "\r\n", log.LstdFlags),
+			gormLogConfig,
 		)),
 	)
 	if err != nil {
@@ -106,6 +121,18 @@ func main() {
 	c := container.NewContainer(gormDB, appLogger, cfg, blacklist)
 	defer func() { _ = c.Close() }()
 
+	// Administrative subcommands (e.g. "iam apply") run and exit without starting the server
+	if subcommand {
+		code := cli.Run(context.Background(), c, os.Args[1:])
+		_ = c.Close()
+		os.Exit(code)
+	}
+
+	// Background jobs (expiry sweeps) run until the server shuts down
+	jobsCtx, jobsCancel := context.WithCancel(context.Background())
+	defer jobsCancel()
+	jobs.Start(jobsCtx, c.Jobs, appLogger)
+
 	// 6. Configure Swagger host dynamically
 	docs.SwaggerInfo.Host = fmt.Sprintf("localhost:%d", cfg.Server.Port)
 
@@ -147,17 +174,20 @@ func main() {
 	}
 
 	// ==================== PROTECTED ROUTES (JWT required) ====================
-	auditLogger := auditpostgres.NewPostgresAuditLogger(gormDB, "iam-platform")
-
 	v1 := r.Group("/api/v1")
 	v1.Use(ginmiddleware.JWTAuthMiddlewareWithBlacklist(c.JWTManager, blacklist))
+	v1.Use(authHandler.SessionRevocationGuard(c.Sessions))
+	v1.Use(authHandler.ImpersonationGuard(c.TokenService))
 	v1.Use(ginmiddleware.PostAuthLogging())
-	v1.Use(ginmiddleware.AuditMiddleware(auditLogger))
+	v1.Use(ginmiddleware.AuditMiddleware(c.AuditLogger))
 	{
 		// Auth (protected)
 		v1.POST("/auth/logout", c.AuthHandler.Logout)
 		v1.POST("/auth/switch-context", c.AuthHandler.SwitchContext)
 		v1.GET("/auth/contexts", c.AuthHandler.GetAvailableContexts)
+		v1.POST("/auth/impersonate", c.ImpersonationHandler.Impersonate)
+		v1.POST("/auth/impersonate/end", c.ImpersonationHandler.EndImpersonation)
+		v1.GET("/auth/impersonations", c.ImpersonationHandler.ListMyImpersonations)
 		v1.GET("/auth/contexts/schools/:school_id/units", ginmiddleware.RequirePermission(enum.PermissionContextBrowseUnits), c.AuthHandler.GetSchoolUnits)
 
 		// Roles
@@ -172,6 +202,8 @@ func main() {
 			roles.POST("/:id/permissions", ginmiddleware.RequirePermission(enum.PermissionRolesUpdate), c.RoleHandler.AssignPermission)
 			roles.DELETE("/:id/permissions/:perm_id", ginmiddleware.RequirePermission(enum.PermissionRolesUpdate), c.RoleHandler.RevokePermission)
 			roles.PUT("/:id/permissions/bulk", ginmiddleware.RequirePermission(enum.PermissionRolesUpdate), c.RoleHandler.BulkReplacePermissions)
+			roles.GET("/:id/grant-policy", ginmiddleware.RequirePermission(enum.PermissionRolesRead), c.RoleGrantHandler.GetPolicy)
+			roles.PUT("/:id/grant-policy", ginmiddleware.RequirePermission(enum.PermissionRolesUpdate), c.RoleGrantHandler.UpdatePolicy)
 		}
 
 		// Permissions
@@ -191,28 +223,148 @@ func main() {
 			resources.GET("/:id", ginmiddleware.RequirePermission(enum.PermissionPermissionsMgmtRead), c.ResourceHandler.GetResource)
 			resources.POST("", ginmiddleware.RequirePermission(enum.PermissionPermissionsMgmtCreate), c.ResourceHandler.CreateResource)
 			resources.PUT("/:id", ginmiddleware.RequirePermission(enum.PermissionPermissionsMgmtUpdate), c.ResourceHandler.UpdateResource)
+			resources.GET("/:id/translations", ginmiddleware.RequirePermission(enum.PermissionPermissionsMgmtRead), c.I18nHandler.GetResourceTranslations)
+			resources.PUT("/:id/translations", ginmiddleware.RequirePermission(enum.PermissionPermissionsMgmtUpdate), c.I18nHandler.SaveResourceTranslations)
 		}
 
 		// Menu
-		v1.GET("/menu", c.MenuHandler.GetUserMenu)
-		v1.GET("/menu/full", c.MenuHandler.GetFullMenu)
+		v1.GET("/menu", c.I18nHandler.NegotiateLocale, c.MenuHandler.GetUserMenu)
+		v1.GET("/menu/full", c.I18nHandler.NegotiateLocale, c.MenuHandler.GetFullMenu)
+
+		// Locales and the caller's preferred locale
+		v1.GET("/locales", c.I18nHandler.ListLocales)
+		v1.GET("/me/preferences", c.I18nHandler.GetMyPreferences)
+		v1.PUT("/me/preferences", c.I18nHandler.UpdateMyPreferences)
 
-		// User Roles
+		// Users (lifecycle) and their roles
 		users := v1.Group("/users")
 		{
+			users.GET("", ginmiddleware.RequirePermission(enum.PermissionUsersRead), c.UserHandler.ListUsers)
+			users.POST("", ginmiddleware.RequirePermission(enum.PermissionUsersUpdate), c.UserHandler.CreateUser)
+			users.GET("/:user_id", ginmiddleware.RequirePermission(enum.PermissionUsersRead), c.UserHandler.GetUser)
+			users.PUT("/:user_id", ginmiddleware.RequirePermission(enum.PermissionUsersUpdate), c.UserHandler.UpdateUser)
+			users.POST("/:user_id/deactivate", ginmiddleware.RequirePermission(enum.PermissionUsersUpdate), c.UserHandler.DeactivateUser)
+			users.POST("/:user_id/reactivate", ginmiddleware.RequirePermission(enum.PermissionUsersUpdate), c.UserHandler.ReactivateUser)
+			users.POST("/:user_id/anonymize", ginmiddleware.RequirePermission(enum.PermissionUsersUpdate), c.UserHandler.AnonymizeUser)
 			users.GET("/:user_id/roles", ginmiddleware.RequirePermission(enum.PermissionUsersRead), c.RoleHandler.GetUserRoles)
+			users.GET("/:user_id/effective-permissions", ginmiddleware.RequirePermission(enum.PermissionUsersRead), c.RoleHandler.GetUserEffectivePermissions)
 			users.POST("/:user_id/roles", ginmiddleware.RequirePermission(enum.PermissionUsersUpdate), c.RoleHandler.GrantRole)
 			users.DELETE("/:user_id/roles/:role_id", ginmiddleware.RequirePermission(enum.PermissionUsersUpdate), c.RoleHandler.RevokeRole)
 		}
 
+		// Bulk role assignment imports
+		roleImports := v1.Group("/role-imports")
+		{
+			roleImports.POST("", ginmiddleware.RequirePermission(enum.PermissionUsersUpdate), c.RoleImportHandler.Submit)
+			roleImports.GET("", ginmiddleware.RequirePermission(enum.PermissionUsersRead), c.RoleImportHandler.ListJobs)
+			roleImports.GET("/:id", ginmiddleware.RequirePermission(enum.PermissionUsersRead), c.RoleImportHandler.GetJob)
+			roleImports.GET("/:id/rows", ginmiddleware.RequirePermission(enum.PermissionUsersRead), c.RoleImportHandler.GetRows)
+		}
+
+		// Role grant approvals (two-person rule for privileged roles)
+		roleGrants := v1.Group("/role-grant-requests")
+		{
+			roleGrants.GET("", ginmiddleware.RequirePermission(enum.PermissionUsersRead), c.RoleGrantHandler.ListRequests)
+			roleGrants.GET("/:id", ginmiddleware.RequirePermission(enum.PermissionUsersRead), c.RoleGrantHandler.GetRequest)
+			roleGrants.POST("/:id/approve", ginmiddleware.RequirePermission(enum.PermissionUsersUpdate), ginmiddleware.RequirePermission(enum.PermissionRolesUpdate), c.RoleGrantHandler.ApproveRequest)
+			roleGrants.POST("/:id/reject", ginmiddleware.RequirePermission(enum.PermissionUsersUpdate), ginmiddleware.RequirePermission(enum.PermissionRolesUpdate), c.RoleGrantHandler.RejectRequest)
+		}
+
+		// Self-service access requests
+		accessRequests := v1.Group("/access-requests")
+		{
+			accessRequests.POST("", c.AccessRequestHandler.Create)
+			accessRequests.GET("/mine", c.AccessRequestHandler.ListMine)
+			accessRequests.POST("/:id/cancel", c.AccessRequestHandler.Cancel)
+			accessRequests.GET("", ginmiddleware.RequirePermission(enum.PermissionUsersRead), c.AccessRequestHandler.List)
+			accessRequests.GET("/:id", ginmiddleware.RequirePermission(enum.PermissionUsersRead), c.AccessRequestHandler.Get)
+			accessRequests.POST("/:id/approve", ginmiddleware.RequirePermission(enum.PermissionUsersUpdate), c.AccessRequestHandler.Approve)
+			accessRequests.POST("/:id/reject", ginmiddleware.RequirePermission(enum.PermissionUsersUpdate), c.AccessRequestHandler.Reject)
+		}
+
+		// Self-service role delegations (scoped to the caller's own assignments)
+		delegations := v1.Group("/delegations")
+		{
+			delegations.POST("", c.RoleDelegationHandler.Create)
+			delegations.GET("", c.RoleDelegationHandler.ListMine)
+			delegations.POST("/:id/revoke", c.RoleDelegationHandler.Revoke)
+		}
+
+		// Break-glass emergency elevation (eligibility is checked by the service)
+		breakGlass := v1.Group("/break-glass")
+		{
+			breakGlass.POST("", c.BreakGlassHandler.Activate)
+			breakGlass.POST("/:id/end", c.BreakGlassHandler.End)
+			breakGlass.GET("", ginmiddleware.RequirePermission(enum.PermissionAuditRead), c.BreakGlassHandler.List)
+			breakGlass.POST("/:id/revoke", handler.RequireAnyPermission(service.PermissionBreakGlass), c.BreakGlassHandler.Revoke)
+		}
+
+		// Access review campaigns
+		accessReviews := v1.Group("/access-reviews")
+		{
+			accessReviews.POST("", ginmiddleware.RequirePermission(enum.PermissionUsersUpdate), c.AccessReviewHandler.CreateCampaign)
+			accessReviews.GET("", ginmiddleware.RequirePermission(enum.PermissionUsersRead), c.AccessReviewHandler.ListCampaigns)
+			accessReviews.GET("/my-items", ginmiddleware.RequirePermission(enum.PermissionUsersRead), c.AccessReviewHandler.ListMyItems)
+			accessReviews.GET("/:id", ginmiddleware.RequirePermission(enum.PermissionUsersRead), c.AccessReviewHandler.GetCampaign)
+			accessReviews.GET("/:id/items", ginmiddleware.RequirePermission(enum.PermissionUsersRead), c.AccessReviewHandler.ListItems)
+			accessReviews.POST("/:id/items/:item_id/decision", ginmiddleware.RequirePermission(enum.PermissionUsersUpdate), c.AccessReviewHandler.Decide)
+			accessReviews.GET("/:id/report", ginmiddleware.RequirePermission(enum.PermissionUsersRead), c.AccessReviewHandler.Report)
+		}
+
+		// Separation of duties
+		sod := v1.Group("/sod")
+		{
+			sod.GET("/constraints", ginmiddleware.RequirePermission(enum.PermissionRolesRead), c.SoDHandler.ListConstraints)
+			sod.POST("/constraints", ginmiddleware.RequirePermission(enum.PermissionRolesUpdate), c.SoDHandler.CreateConstraint)
+			sod.DELETE("/constraints/:id", ginmiddleware.RequirePermission(enum.PermissionRolesUpdate), c.SoDHandler.DeleteConstraint)
+			sod.GET("/violations", ginmiddleware.RequirePermission(enum.PermissionRolesRead), ginmiddleware.RequirePermission(enum.PermissionUsersRead), c.SoDHandler.Report)
+		}
+
+		// Authorization decisions
+		authz := v1.Group("/authz")
+		{
+			authz.POST("/check", ginmiddleware.RequirePermission(enum.PermissionUsersRead), c.AuthzHandler.Check)
+			authz.POST("/check/batch", ginmiddleware.RequirePermission(enum.PermissionUsersRead), c.AuthzHandler.BatchCheck)
+		}
+
+		// IAM catalog as code
+		iamCatalog := v1.Group("/iam/manifest")
+		{
+			iamCatalog.GET("", ginmiddleware.RequirePermission(enum.PermissionRolesRead), ginmiddleware.RequirePermission(enum.PermissionPermissionsMgmtRead), c.IAMCatalogHandler.ExportManifest)
+			iamCatalog.POST("/apply", ginmiddleware.RequirePermission(enum.PermissionRolesUpdate), ginmiddleware.RequirePermission(enum.PermissionPermissionsMgmtUpdate), c.IAMCatalogHandler.ApplyManifest)
+		}
+
+		// Glossary: platform defaults and per-school terms (the handler also
+		// checks the school in the path against the active school)
+		glossaryRead := handler.RequireAnyPermission(service.PermissionGlossaryRead, service.PermissionGlossaryManage)
+		glossaryUpdate := handler.RequireAnyPermission(service.PermissionGlossaryUpdate, service.PermissionGlossaryManage)
+		glossaryManage := handler.RequireAnyPermission(service.PermissionGlossaryManage)
+		glossary := v1.Group("/glossary/defaults")
+		{
+			glossary.GET("", glossaryRead, c.GlossaryHandler.ListGlossaryDefaults)
+			glossary.POST("/import", glossaryManage, c.GlossaryHandler.ImportGlossaryDefaults)
+			glossary.PUT("/:term_key", glossaryManage, c.GlossaryHandler.SaveGlossaryDefault)
+			glossary.DELETE("/:term_key", glossaryManage, c.GlossaryHandler.DeleteGlossaryDefault)
+		}
+		schoolGlossary := v1.Group("/schools/:school_id/glossary")
+		{
+			schoolGlossary.GET("", glossaryRead, c.GlossaryHandler.ListSchoolGlossary)
+			schoolGlossary.POST("/import", glossaryUpdate, c.GlossaryHandler.ImportSchoolGlossary)
+			schoolGlossary.GET("/:term_key", glossaryRead, c.GlossaryHandler.GetSchoolGlossaryTerm)
+			schoolGlossary.PUT("/:term_key", glossaryUpdate, c.GlossaryHandler.SaveSchoolGlossaryTerm)
+			schoolGlossary.DELETE("/:term_key", glossaryUpdate, c.GlossaryHandler.DeleteSchoolGlossaryTerm)
+		}
+
 		// Sync
 		syncGroup := v1.Group("/sync")
 		{
-			syncGroup.GET("/bundle", c.SyncHandler.GetBundle)
-			syncGroup.POST("/delta", c.SyncHandler.DeltaSync)
+			syncGroup.GET("/bundle", c.I18nHandler.NegotiateLocale, c.SyncHandler.GetBundle)
+			syncGroup.POST("/delta", c.I18nHandler.NegotiateLocale, c.SyncHandler.DeltaSync)
 		}
 
 		// Screen Config
+		screensPublish := handler.RequireAnyPermission(service.PermissionScreensPublish)
+		screenOverrides := handler.RequireAnyPermission(append([]string{service.PermissionScreensOverride}, service.ScreenOverrideAdminPermissions...)...)
 		screenConfig := v1.Group("/screen-config")
 		{
 			templates := screenConfig.Group("/templates")
@@ -222,6 +374,13 @@ func main() {
 				templates.GET("/:id", ginmiddleware.RequirePermission(enum.PermissionScreenTemplatesRead), c.ScreenConfigHandler.GetTemplate)
 				templates.PUT("/:id", ginmiddleware.RequirePermission(enum.PermissionScreenTemplatesUpdate), c.ScreenConfigHandler.UpdateTemplate)
 				templates.DELETE("/:id", ginmiddleware.RequirePermission(enum.PermissionScreenTemplatesDelete), c.ScreenConfigHandler.DeleteTemplate)
+				templates.GET("/:id/versions", ginmiddleware.RequirePermission(enum.PermissionScreenTemplatesRead), c.ScreenConfigHandler.ListTemplateVersions)
+				templates.GET("/:id/versions/:version", ginmiddleware.RequirePermission(enum.PermissionScreenTemplatesRead), c.ScreenConfigHandler.GetTemplateVersion)
+				templates.GET("/:id/diff", ginmiddleware.RequirePermission(enum.PermissionScreenTemplatesRead), c.ScreenConfigHandler.DiffTemplateVersions)
+				templates.POST("/:id/versions/:version/rollback", ginmiddleware.RequirePermission(enum.PermissionScreenTemplatesUpdate), screensPublish, c.ScreenConfigHandler.RollbackTemplate)
+				templates.GET("/:id/draft", ginmiddleware.RequirePermission(enum.PermissionScreenTemplatesRead), c.ScreenConfigHandler.GetTemplateDraft)
+				templates.DELETE("/:id/draft", ginmiddleware.RequirePermission(enum.PermissionScreenTemplatesUpdate), c.ScreenConfigHandler.DiscardTemplateDraft)
+				templates.POST("/:id/publish", screensPublish, c.ScreenConfigHandler.PublishTemplate)
 			}
 			instances := screenConfig.Group("/instances")
 			{
@@ -231,11 +390,36 @@ func main() {
 				instances.GET("/key/:key", ginmiddleware.RequirePermission(enum.PermissionScreenInstancesRead), c.ScreenConfigHandler.GetInstanceByKey)
 				instances.PUT("/:id", ginmiddleware.RequirePermission(enum.PermissionScreenInstancesUpdate), c.ScreenConfigHandler.UpdateInstance)
 				instances.DELETE("/:id", ginmiddleware.RequirePermission(enum.PermissionScreenInstancesDelete), c.ScreenConfigHandler.DeleteInstance)
+				instances.GET("/:id/versions", ginmiddleware.RequirePermission(enum.PermissionScreenInstancesRead), c.ScreenConfigHandler.ListInstanceVersions)
+				instances.GET("/:id/versions/:version", ginmiddleware.RequirePermission(enum.PermissionScreenInstancesRead), c.ScreenConfigHandler.GetInstanceVersion)
+				instances.GET("/:id/diff", ginmiddleware.RequirePermission(enum.PermissionScreenInstancesRead), c.ScreenConfigHandler.DiffInstanceVersions)
+				instances.POST("/:id/versions/:version/rollback", ginmiddleware.RequirePermission(enum.PermissionScreenInstancesUpdate), screensPublish, c.ScreenConfigHandler.RollbackInstance)
+				instances.GET("/:id/draft", ginmiddleware.RequirePermission(enum.PermissionScreenInstancesRead), c.ScreenConfigHandler.GetInstanceDraft)
+				instances.DELETE("/:id/draft", ginmiddleware.RequirePermission(enum.PermissionScreenInstancesUpdate), c.ScreenConfigHandler.DiscardInstanceDraft)
+				instances.POST("/:id/publish", screensPublish, c.ScreenConfigHandler.PublishInstance)
+				instances.GET("/:id/overrides", screenOverrides, c.ScreenConfigHandler.ListScreenOverrides)
+				instances.POST("/:id/overrides", screenOverrides, c.ScreenConfigHandler.CreateScreenOverride)
+				instances.PUT("/:id/overrides/:overrideId", screenOverrides, c.ScreenConfigHandler.UpdateScreenOverride)
+				instances.DELETE("/:id/overrides/:overrideId", screenOverrides, c.ScreenConfigHandler.DeleteScreenOverride)
+				instances.GET("/:id/translations", ginmiddleware.RequirePermission(enum.PermissionScreenInstancesRead), c.I18nHandler.GetInstanceTranslations)
+				instances.PUT("/:id/translations", ginmiddleware.RequirePermission(enum.PermissionScreenInstancesUpdate), c.I18nHandler.SaveInstanceTranslations)
+			}
+			patterns := screenConfig.Group("/patterns")
+			{
+				patterns.GET("", ginmiddleware.RequirePermission(enum.PermissionScreenTemplatesRead), c.ScreenConfigHandler.ListPatternSchemas)
+				patterns.GET("/:pattern/schema", ginmiddleware.RequirePermission(enum.PermissionScreenTemplatesRead), c.ScreenConfigHandler.GetPatternSchema)
+				patterns.PUT("/:pattern/schema", ginmiddleware.RequirePermission(enum.PermissionScreenTemplatesUpdate), c.ScreenConfigHandler.SavePatternSchema)
+				patterns.DELETE("/:pattern/schema", ginmiddleware.RequirePermission(enum.PermissionScreenTemplatesDelete), c.ScreenConfigHandler.DeletePatternSchema)
 			}
+			screenConfig.GET("/export", ginmiddleware.RequirePermission(enum.PermissionScreenTemplatesRead), ginmiddleware.RequirePermission(enum.PermissionScreenInstancesRead), c.ScreenConfigHandler.ExportBundle)
+			screenConfig.GET("/consistency", ginmiddleware.RequirePermission(enum.PermissionScreenTemplatesRead), ginmiddleware.RequirePermission(enum.PermissionScreenInstancesRead), c.ScreenConfigHandler.CheckConsistency)
+			screenConfig.POST("/preview", ginmiddleware.RequirePermission(enum.PermissionScreenTemplatesRead), ginmiddleware.RequirePermission(enum.PermissionScreenInstancesRead), c.ScreenConfigHandler.PreviewScreen)
+			// The import publishes directly: the handler also requires screens:publish unless dry_run=true
+			screenConfig.POST("/import", ginmiddleware.RequirePermission(enum.PermissionScreenTemplatesUpdate), ginmiddleware.RequirePermission(enum.PermissionScreenInstancesUpdate), c.ScreenConfigHandler.ImportBundle)
 			screenConfig.GET("/version/:key", ginmiddleware.RequirePermission(enum.PermissionScreensRead), c.ScreenConfigHandler.GetScreenVersion)
 			resolve := screenConfig.Group("/resolve")
 			{
-				resolve.GET("/key/:key", ginmiddleware.RequirePermission(enum.PermissionScreensRead), c.ScreenConfigHandler.ResolveScreenByKey)
+				resolve.GET("/key/:key", ginmiddleware.RequirePermission(enum.PermissionScreensRead), c.I18nHandler.NegotiateLocale, c.ScreenConfigHandler.ResolveScreenByKey)
 			}
 			resourceScreens := screenConfig.Group("/resource-screens")
 			{
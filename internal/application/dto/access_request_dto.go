@@ -0,0 +1,43 @@
+package dto
+
+// CreateAccessRequestRequest is a user's request for a role in a school or unit
+type CreateAccessRequestRequest struct {
+	RoleID         string  `json:"role_id" binding:"required"`
+	SchoolID       string  `json:"school_id" binding:"required"`
+	AcademicUnitID *string `json:"academic_unit_id,omitempty"`
+	Justification  string  `json:"justification" binding:"required,min=10,max=2000"`
+}
+
+// DecideAccessRequestRequest carries an admin's decision details. ExpiresAt
+// (RFC3339) bounds the granted role; when empty the default duration applies.
+type DecideAccessRequestRequest struct {
+	Note      string  `json:"note"`
+	ExpiresAt *string `json:"expires_at,omitempty"`
+}
+
+// AccessRequestDTO represents a self-service access request
+type AccessRequestDTO struct {
+	ID                 string  `json:"id"`
+	UserID             string  `json:"user_id"`
+	RoleID             string  `json:"role_id"`
+	RoleName           string  `json:"role_name,omitempty"`
+	SchoolID           string  `json:"school_id"`
+	AcademicUnitID     *string `json:"academic_unit_id,omitempty"`
+	Justification      string  `json:"justification"`
+	Status             string  `json:"status"`
+	DecidedBy          *string `json:"decided_by,omitempty"`
+	DecidedAt          *string `json:"decided_at,omitempty"`
+	DecisionNote       *string `json:"decision_note,omitempty"`
+	GrantExpiresAt     *string `json:"grant_expires_at,omitempty"`
+	UserRoleID         *string `json:"user_role_id,omitempty"`
+	RoleGrantRequestID *string `json:"role_grant_request_id,omitempty"`
+	CreatedAt          string  `json:"created_at"`
+}
+
+// AccessRequestsResponse wraps a list of access requests
+type AccessRequestsResponse struct {
+	Requests []*AccessRequestDTO `json:"requests"`
+	Total    int                 `json:"total"`
+	Page     int                 `json:"page"`
+	Limit    int                 `json:"limit"`
+}
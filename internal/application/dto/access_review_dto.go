@@ -0,0 +1,90 @@
+package dto
+
+// CreateAccessReviewRequest opens an access review campaign. The scope fields
+// are optional and combine; reviewers split the items round-robin.
+type CreateAccessReviewRequest struct {
+	Name           string   `json:"name" binding:"required,max=200"`
+	Description    string   `json:"description"`
+	SchoolID       *string  `json:"school_id,omitempty"`
+	RoleID         *string  `json:"role_id,omitempty"`
+	AcademicUnitID *string  `json:"academic_unit_id,omitempty"`
+	Deadline       string   `json:"deadline" binding:"required"`
+	ReviewerIDs    []string `json:"reviewer_ids" binding:"required,min=1"`
+}
+
+// AccessReviewDecisionRequest certifies or revokes a reviewed assignment
+type AccessReviewDecisionRequest struct {
+	Decision string `json:"decision" binding:"required,oneof=certify revoke"`
+	Note     string `json:"note"`
+}
+
+// AccessReviewProgressDTO counts review items by decision
+type AccessReviewProgressDTO struct {
+	Total       int `json:"total"`
+	Pending     int `json:"pending"`
+	Certified   int `json:"certified"`
+	Revoked     int `json:"revoked"`
+	AutoRevoked int `json:"auto_revoked"`
+}
+
+// AccessReviewCampaignDTO represents an access review campaign
+type AccessReviewCampaignDTO struct {
+	ID             string                   `json:"id"`
+	Name           string                   `json:"name"`
+	Description    string                   `json:"description,omitempty"`
+	SchoolID       *string                  `json:"school_id,omitempty"`
+	RoleID         *string                  `json:"role_id,omitempty"`
+	AcademicUnitID *string                  `json:"academic_unit_id,omitempty"`
+	Deadline       string                   `json:"deadline"`
+	Status         string                   `json:"status"`
+	CreatedBy      *string                  `json:"created_by,omitempty"`
+	CompletedAt    *string                  `json:"completed_at,omitempty"`
+	CreatedAt      string                   `json:"created_at"`
+	Progress       *AccessReviewProgressDTO `json:"progress,omitempty"`
+}
+
+// AccessReviewCampaignsResponse wraps a list of campaigns
+type AccessReviewCampaignsResponse struct {
+	Campaigns []*AccessReviewCampaignDTO `json:"campaigns"`
+	Total     int                        `json:"total"`
+	Page      int                        `json:"page"`
+	Limit     int                        `json:"limit"`
+}
+
+// AccessReviewItemDTO represents a user role assignment under review
+type AccessReviewItemDTO struct {
+	ID             string  `json:"id"`
+	CampaignID     string  `json:"campaign_id"`
+	UserRoleID     string  `json:"user_role_id"`
+	UserID         string  `json:"user_id"`
+	RoleID         string  `json:"role_id"`
+	RoleName       string  `json:"role_name,omitempty"`
+	SchoolID       *string `json:"school_id,omitempty"`
+	AcademicUnitID *string `json:"academic_unit_id,omitempty"`
+	ReviewerID     *string `json:"reviewer_id,omitempty"`
+	Decision       string  `json:"decision"`
+	DecidedBy      *string `json:"decided_by,omitempty"`
+	DecidedAt      *string `json:"decided_at,omitempty"`
+	Note           *string `json:"note,omitempty"`
+}
+
+// AccessReviewItemsResponse wraps a list of review items
+type AccessReviewItemsResponse struct {
+	Items []*AccessReviewItemDTO `json:"items"`
+	Total int                    `json:"total"`
+}
+
+// AccessReviewerProgressDTO is the progress of a single reviewer
+type AccessReviewerProgressDTO struct {
+	ReviewerID string                   `json:"reviewer_id"`
+	Progress   *AccessReviewProgressDTO `json:"progress"`
+}
+
+// AccessReviewReportDTO summarizes what a campaign certified and revoked
+type AccessReviewReportDTO struct {
+	Campaign    *AccessReviewCampaignDTO     `json:"campaign"`
+	ByReviewer  []*AccessReviewerProgressDTO `json:"by_reviewer"`
+	Revocations []*AccessReviewItemDTO       `json:"revocations"`
+	Pending     []*AccessReviewItemDTO       `json:"pending"`
+	GeneratedAt string                       `json:"generated_at"`
+}
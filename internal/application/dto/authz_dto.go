@@ -0,0 +1,31 @@
+package dto
+
+// AuthzCheckRequest represents a single authorization decision request.
+// SubjectID defaults to the authenticated caller when omitted.
+type AuthzCheckRequest struct {
+	SubjectID      string  `json:"subject_id,omitempty"`
+	Action         string  `json:"action" binding:"required"`
+	Resource       string  `json:"resource" binding:"required"`
+	SchoolID       *string `json:"school_id,omitempty"`
+	AcademicUnitID *string `json:"academic_unit_id,omitempty"`
+	Explain        bool    `json:"explain,omitempty"`
+}
+
+// AuthzBatchCheckRequest represents several authorization checks evaluated together
+type AuthzBatchCheckRequest struct {
+	Checks []AuthzCheckRequest `json:"checks" binding:"required,min=1,max=100,dive"`
+}
+
+// AuthzDecisionDTO is the result of an authorization check
+type AuthzDecisionDTO struct {
+	SubjectID  string   `json:"subject_id"`
+	Permission string   `json:"permission"`
+	Allowed    bool     `json:"allowed"`
+	Reason     string   `json:"reason"`
+	Trace      []string `json:"trace,omitempty"`
+}
+
+// AuthzBatchCheckResponse wraps the decisions of a batch check, in request order
+type AuthzBatchCheckResponse struct {
+	Decisions []*AuthzDecisionDTO `json:"decisions"`
+}
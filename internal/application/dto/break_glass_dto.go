@@ -0,0 +1,33 @@
+package dto
+
+// ActivateBreakGlassRequest asks for emergency elevation. SchoolID records the
+// school the emergency concerns; the elevated role itself is global.
+type ActivateBreakGlassRequest struct {
+	Justification string  `json:"justification" binding:"required,min=30,max=2000"`
+	SchoolID      *string `json:"school_id,omitempty"`
+}
+
+// BreakGlassElevationDTO represents an emergency elevation
+type BreakGlassElevationDTO struct {
+	ID            string  `json:"id"`
+	UserID        string  `json:"user_id"`
+	RoleID        string  `json:"role_id"`
+	RoleName      string  `json:"role_name,omitempty"`
+	UserRoleID    string  `json:"user_role_id"`
+	SchoolID      *string `json:"school_id,omitempty"`
+	Justification string  `json:"justification"`
+	ClientIP      *string `json:"client_ip,omitempty"`
+	ActivatedAt   string  `json:"activated_at"`
+	ExpiresAt     string  `json:"expires_at"`
+	EndedAt       *string `json:"ended_at,omitempty"`
+	EndedBy       *string `json:"ended_by,omitempty"`
+	Active        bool    `json:"active"`
+}
+
+// BreakGlassElevationsResponse wraps a list of emergency elevations
+type BreakGlassElevationsResponse struct {
+	Elevations []*BreakGlassElevationDTO `json:"elevations"`
+	Total      int                       `json:"total"`
+	Page       int                       `json:"page"`
+	Limit      int                       `json:"limit"`
+}
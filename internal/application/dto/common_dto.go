@@ -2,6 +2,7 @@ package dto
 
 type ErrorResponse struct {
 	Error   string            `json:"error"`
+	Message string            `json:"message,omitempty"`
 	Code    string            `json:"code"`
 	Details map[string]string `json:"details,omitempty"`
 }
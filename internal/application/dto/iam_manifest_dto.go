@@ -0,0 +1,70 @@
+package dto
+
+// IAMManifestVersion is the manifest format version produced by export
+const IAMManifestVersion = 1
+
+// IAMManifest is the declarative description of the IAM catalog (IAM-as-code).
+// Entities are matched by natural key: resource key, permission name and role name.
+type IAMManifest struct {
+	Version     int                     `json:"version" yaml:"version"`
+	Resources   []IAMManifestResource   `json:"resources" yaml:"resources"`
+	Permissions []IAMManifestPermission `json:"permissions" yaml:"permissions"`
+	Roles       []IAMManifestRole       `json:"roles" yaml:"roles"`
+}
+
+// IAMManifestResource declares a resource; Parent references another resource key
+type IAMManifestResource struct {
+	Key           string `json:"key" yaml:"key"`
+	DisplayName   string `json:"display_name" yaml:"display_name"`
+	Description   string `json:"description,omitempty" yaml:"description,omitempty"`
+	Icon          string `json:"icon,omitempty" yaml:"icon,omitempty"`
+	Parent        string `json:"parent,omitempty" yaml:"parent,omitempty"`
+	SortOrder     int    `json:"sort_order" yaml:"sort_order"`
+	IsMenuVisible bool   `json:"is_menu_visible" yaml:"is_menu_visible"`
+	Scope         string `json:"scope" yaml:"scope"`
+}
+
+// IAMManifestPermission declares a permission attached to a resource key
+type IAMManifestPermission struct {
+	Name        string `json:"name" yaml:"name"`
+	DisplayName string `json:"display_name" yaml:"display_name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Resource    string `json:"resource" yaml:"resource"`
+	Action      string `json:"action" yaml:"action"`
+	Scope       string `json:"scope" yaml:"scope"`
+}
+
+// IAMManifestRole declares a role and the full list of permission names it holds
+type IAMManifestRole struct {
+	Name        string   `json:"name" yaml:"name"`
+	DisplayName string   `json:"display_name" yaml:"display_name"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Scope       string   `json:"scope" yaml:"scope"`
+	Permissions []string `json:"permissions" yaml:"permissions"`
+}
+
+// IAMPlanChange is a single planned change of an apply
+type IAMPlanChange struct {
+	Kind    string   `json:"kind"`
+	Key     string   `json:"key"`
+	Op      string   `json:"op"`
+	Fields  []string `json:"fields,omitempty"`
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+// IAMPlanSummary counts planned changes by operation
+type IAMPlanSummary struct {
+	Create     int `json:"create"`
+	Update     int `json:"update"`
+	Deactivate int `json:"deactivate"`
+	Relink     int `json:"relink"`
+}
+
+// IAMApplyResponse is the plan (and outcome) of applying a manifest
+type IAMApplyResponse struct {
+	DryRun  bool            `json:"dry_run"`
+	Applied bool            `json:"applied"`
+	Summary IAMPlanSummary  `json:"summary"`
+	Changes []IAMPlanChange `json:"changes"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+// CreateDelegationRequest hands some or all of the caller's role assignments
+// in an academic unit to another user. Leave UserRoleIDs empty to delegate
+// every assignment the caller holds in the unit. StartsAt and EndsAt are
+// RFC3339; the delegation starts immediately when StartsAt is omitted.
+type CreateDelegationRequest struct {
+	DelegateID     string   `json:"delegate_id" binding:"required"`
+	AcademicUnitID string   `json:"academic_unit_id" binding:"required"`
+	UserRoleIDs    []string `json:"user_role_ids,omitempty"`
+	StartsAt       *string  `json:"starts_at,omitempty"`
+	EndsAt         string   `json:"ends_at" binding:"required"`
+	Reason         *string  `json:"reason,omitempty" binding:"omitempty,max=500"`
+}
+
+// RoleDelegationDTO represents a time-bounded delegation of a role assignment
+type RoleDelegationDTO struct {
+	ID                  string  `json:"id"`
+	DelegatorID         string  `json:"delegator_id"`
+	DelegateID          string  `json:"delegate_id"`
+	SourceUserRoleID    string  `json:"source_user_role_id"`
+	RoleID              string  `json:"role_id"`
+	RoleName            string  `json:"role_name,omitempty"`
+	SchoolID            string  `json:"school_id"`
+	AcademicUnitID      string  `json:"academic_unit_id"`
+	StartsAt            string  `json:"starts_at"`
+	EndsAt              string  `json:"ends_at"`
+	Reason              *string `json:"reason,omitempty"`
+	Status              string  `json:"status"`
+	DelegatedUserRoleID *string `json:"delegated_user_role_id,omitempty"`
+	RevokedBy           *string `json:"revoked_by,omitempty"`
+	EndedAt             *string `json:"ended_at,omitempty"`
+	CreatedAt           string  `json:"created_at"`
+}
+
+// RoleDelegationsResponse lists the delegations a user has given and received
+type RoleDelegationsResponse struct {
+	Given    []*RoleDelegationDTO `json:"given"`
+	Received []*RoleDelegationDTO `json:"received"`
+}
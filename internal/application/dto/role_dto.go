@@ -107,6 +107,30 @@ type BulkPermissionsRequest struct {
 	PermissionIDs []string `json:"permission_ids" binding:"required"`
 }
 
+// PermissionsDiffDTO describes the permissions added and removed by a bulk replace
+type PermissionsDiffDTO struct {
+	Added     []*PermissionDTO `json:"added"`
+	Removed   []*PermissionDTO `json:"removed"`
+	Unchanged int              `json:"unchanged"`
+}
+
+// MenuImpactDTO describes a menu item gained or lost by users after a permission change
+type MenuImpactDTO struct {
+	Key           string `json:"key"`
+	DisplayName   string `json:"display_name"`
+	AffectedUsers int    `json:"affected_users"`
+}
+
+// BulkPermissionsPreviewResponse is the dry-run result of a bulk permission replace
+type BulkPermissionsPreviewResponse struct {
+	RoleID            string              `json:"role_id"`
+	DryRun            bool                `json:"dry_run"`
+	Diff              *PermissionsDiffDTO `json:"diff"`
+	AffectedUserRoles int                 `json:"affected_user_roles"`
+	MenuItemsGained   []*MenuImpactDTO    `json:"menu_items_gained"`
+	MenuItemsLost     []*MenuImpactDTO    `json:"menu_items_lost"`
+}
+
 // RolePermissionResponse wraps a role permission assignment result
 type RolePermissionResponse struct {
 	RoleID       string `json:"role_id"`
@@ -121,9 +145,37 @@ type GrantRoleRequest struct {
 	ExpiresAt      *string `json:"expires_at,omitempty"`
 }
 
-// GrantRoleResponse wraps the granted user role
+// GrantRoleResponse wraps the granted user role, or the pending approval
+// request when the role requires a second approver
 type GrantRoleResponse struct {
-	UserRole *UserRoleDTO `json:"user_role"`
+	UserRole        *UserRoleDTO         `json:"user_role,omitempty"`
+	ApprovalRequest *RoleGrantRequestDTO `json:"approval_request,omitempty"`
+}
+
+// PermissionGrantDTO describes a role assignment that grants a permission
+type PermissionGrantDTO struct {
+	UserRoleID     string  `json:"user_role_id"`
+	RoleID         string  `json:"role_id"`
+	RoleName       string  `json:"role_name"`
+	SchoolID       *string `json:"school_id,omitempty"`
+	AcademicUnitID *string `json:"academic_unit_id,omitempty"`
+	GrantedAt      string  `json:"granted_at"`
+	ExpiresAt      *string `json:"expires_at,omitempty"`
+}
+
+// EffectivePermissionDTO represents a permission a user holds, with the assignments granting it
+type EffectivePermissionDTO struct {
+	Permission *PermissionDTO        `json:"permission"`
+	GrantedBy  []*PermissionGrantDTO `json:"granted_by"`
+}
+
+// EffectivePermissionsResponse wraps the effective permissions of a user in a context
+type EffectivePermissionsResponse struct {
+	UserID         string                    `json:"user_id"`
+	SchoolID       *string                   `json:"school_id,omitempty"`
+	AcademicUnitID *string                   `json:"academic_unit_id,omitempty"`
+	Permissions    []*EffectivePermissionDTO `json:"permissions"`
+	Total          int                       `json:"total"`
 }
 
 // ToRoleDTO converts a Role entity to RoleDTO
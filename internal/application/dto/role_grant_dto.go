@@ -0,0 +1,46 @@
+package dto
+
+// RoleGrantPolicyDTO describes whether grants of a role need a second approver
+type RoleGrantPolicyDTO struct {
+	RoleID           string  `json:"role_id"`
+	RequiresApproval bool    `json:"requires_approval"`
+	UpdatedBy        *string `json:"updated_by,omitempty"`
+	UpdatedAt        *string `json:"updated_at,omitempty"`
+}
+
+// UpdateRoleGrantPolicyRequest flags or unflags a role as requiring approval
+type UpdateRoleGrantPolicyRequest struct {
+	RequiresApproval *bool `json:"requires_approval" binding:"required"`
+}
+
+// RoleGrantRequestDTO represents a pending or decided role grant request
+type RoleGrantRequestDTO struct {
+	ID             string  `json:"id"`
+	UserID         string  `json:"user_id"`
+	RoleID         string  `json:"role_id"`
+	RoleName       string  `json:"role_name,omitempty"`
+	SchoolID       *string `json:"school_id,omitempty"`
+	AcademicUnitID *string `json:"academic_unit_id,omitempty"`
+	GrantExpiresAt *string `json:"grant_expires_at,omitempty"`
+	RequestedBy    *string `json:"requested_by,omitempty"`
+	Status         string  `json:"status"`
+	DecidedBy      *string `json:"decided_by,omitempty"`
+	DecidedAt      *string `json:"decided_at,omitempty"`
+	DecisionNote   *string `json:"decision_note,omitempty"`
+	UserRoleID     *string `json:"user_role_id,omitempty"`
+	ExpiresAt      string  `json:"expires_at"`
+	CreatedAt      string  `json:"created_at"`
+}
+
+// RoleGrantRequestsResponse wraps a list of role grant requests
+type RoleGrantRequestsResponse struct {
+	Requests []*RoleGrantRequestDTO `json:"requests"`
+	Total    int                    `json:"total"`
+	Page     int                    `json:"page"`
+	Limit    int                    `json:"limit"`
+}
+
+// DecideRoleGrantRequest carries the approver's optional note
+type DecideRoleGrantRequest struct {
+	Note string `json:"note"`
+}
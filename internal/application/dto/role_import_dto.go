@@ -0,0 +1,56 @@
+package dto
+
+// RoleImportJobDTO reports the status and counters of a bulk role import
+type RoleImportJobDTO struct {
+	ID            string  `json:"id"`
+	RequestedBy   string  `json:"requested_by"`
+	FileName      string  `json:"file_name"`
+	Format        string  `json:"format"`
+	DryRun        bool    `json:"dry_run"`
+	Status        string  `json:"status"`
+	TotalRows     int     `json:"total_rows"`
+	ProcessedRows int     `json:"processed_rows"`
+	ValidRows     int     `json:"valid_rows"`
+	InvalidRows   int     `json:"invalid_rows"`
+	SkippedRows   int     `json:"skipped_rows"`
+	AppliedRows   int     `json:"applied_rows"`
+	Progress      int     `json:"progress"`
+	Error         *string `json:"error,omitempty"`
+	StartedAt     *string `json:"started_at,omitempty"`
+	FinishedAt    *string `json:"finished_at,omitempty"`
+	CreatedAt     string  `json:"created_at"`
+}
+
+// RoleImportJobsResponse wraps a list of bulk role imports
+type RoleImportJobsResponse struct {
+	Jobs  []*RoleImportJobDTO `json:"jobs"`
+	Total int                 `json:"total"`
+	Page  int                 `json:"page"`
+	Limit int                 `json:"limit"`
+}
+
+// RoleImportRowDTO is one line of the per-row import report. The raw values
+// are echoed as uploaded; the IDs are those they resolved to.
+type RoleImportRowDTO struct {
+	Line           int     `json:"line"`
+	Email          string  `json:"email"`
+	Role           string  `json:"role"`
+	School         string  `json:"school,omitempty"`
+	Unit           string  `json:"unit,omitempty"`
+	ExpiresAt      string  `json:"expires_at,omitempty"`
+	Status         string  `json:"status"`
+	Message        *string `json:"message,omitempty"`
+	UserID         *string `json:"user_id,omitempty"`
+	RoleID         *string `json:"role_id,omitempty"`
+	SchoolID       *string `json:"school_id,omitempty"`
+	AcademicUnitID *string `json:"academic_unit_id,omitempty"`
+	UserRoleID     *string `json:"user_role_id,omitempty"`
+}
+
+// RoleImportRowsResponse wraps the per-row report of a bulk role import
+type RoleImportRowsResponse struct {
+	Rows  []*RoleImportRowDTO `json:"rows"`
+	Total int                 `json:"total"`
+	Page  int                 `json:"page"`
+	Limit int                 `json:"limit"`
+}
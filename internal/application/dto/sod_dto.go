@@ -0,0 +1,55 @@
+package dto
+
+// CreateSoDConstraintRequest declares two roles or two permissions as mutually
+// exclusive. SchoolID restricts the constraint to one school; omit it for a
+// platform-wide constraint.
+type CreateSoDConstraintRequest struct {
+	Name        string  `json:"name" binding:"required,max=150"`
+	Description *string `json:"description,omitempty"`
+	Kind        string  `json:"kind" binding:"required,oneof=role permission"`
+	LeftID      string  `json:"left_id" binding:"required"`
+	RightID     string  `json:"right_id" binding:"required"`
+	SchoolID    *string `json:"school_id,omitempty"`
+}
+
+// SoDConstraintDTO represents a separation-of-duties constraint
+type SoDConstraintDTO struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+	Kind        string  `json:"kind"`
+	LeftID      string  `json:"left_id"`
+	LeftName    string  `json:"left_name,omitempty"`
+	RightID     string  `json:"right_id"`
+	RightName   string  `json:"right_name,omitempty"`
+	SchoolID    *string `json:"school_id,omitempty"`
+	CreatedBy   *string `json:"created_by,omitempty"`
+	CreatedAt   string  `json:"created_at"`
+}
+
+// SoDConstraintsResponse wraps the active constraints
+type SoDConstraintsResponse struct {
+	Constraints []*SoDConstraintDTO `json:"constraints"`
+}
+
+// SoDViolationDTO is a user whose current assignments break a constraint.
+// LeftUserRoleID and RightUserRoleID are equal when a single role carries
+// both excluded permissions.
+type SoDViolationDTO struct {
+	ConstraintID    string  `json:"constraint_id"`
+	ConstraintName  string  `json:"constraint_name"`
+	Kind            string  `json:"kind"`
+	UserID          string  `json:"user_id"`
+	SchoolID        *string `json:"school_id,omitempty"`
+	LeftUserRoleID  string  `json:"left_user_role_id"`
+	LeftRoleID      string  `json:"left_role_id"`
+	RightUserRoleID string  `json:"right_user_role_id"`
+	RightRoleID     string  `json:"right_role_id"`
+}
+
+// SoDReportResponse lists existing violations of the active constraints
+type SoDReportResponse struct {
+	Violations  []*SoDViolationDTO `json:"violations"`
+	Total       int                `json:"total"`
+	GeneratedAt string             `json:"generated_at"`
+}
@@ -6,14 +6,19 @@ import (
 	authDto "github.com/EduGoGroup/edugo-api-iam-platform/internal/auth/dto"
 )
 
-// SyncBundleResponse represents the full sync bundle with all user data
+// SyncBundleResponse represents the full sync bundle with all user data.
+// Screens, Hashes and Complete stay last: the streamed bundle writes them
+// after the other fields. Complete is only sent, as false, when the screens
+// bucket ended early and holds part of the user's screens.
 type SyncBundleResponse struct {
 	Menu              []MenuItemDTO             `json:"menu"`
 	Permissions       []string                  `json:"permissions"`
-	Screens           map[string]*ScreenBundle  `json:"screens"`
 	AvailableContexts []*authDto.UserContextDTO `json:"available_contexts"`
 	Glossary          map[string]string         `json:"glossary"`
+	Locale            string                    `json:"locale"`
+	Screens           map[string]*ScreenBundle  `json:"screens"`
 	Hashes            map[string]string         `json:"hashes"`
+	Complete          *bool                     `json:"complete,omitempty"`
 }
 
 // ScreenBundle represents a resolved screen definition within the sync bundle
@@ -36,6 +41,7 @@ type DeltaSyncRequest struct {
 type DeltaSyncResponse struct {
 	Changed   map[string]*BucketData `json:"changed"`
 	Unchanged []string               `json:"unchanged"`
+	Locale    string                 `json:"locale"`
 }
 
 // BucketData represents a single changed bucket with its data and new hash
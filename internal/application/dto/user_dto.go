@@ -0,0 +1,56 @@
+package dto
+
+// UserDTO represents a user account in API responses. Anonymized accounts
+// keep their ID so audit events still resolve, but carry no personal data.
+type UserDTO struct {
+	ID         string `json:"id"`
+	Email      string `json:"email"`
+	FirstName  string `json:"first_name"`
+	LastName   string `json:"last_name"`
+	FullName   string `json:"full_name"`
+	IsActive   bool   `json:"is_active"`
+	Anonymized bool   `json:"anonymized"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+// UsersResponse wraps a list of users
+type UsersResponse struct {
+	Users []*UserDTO `json:"users"`
+	Total int        `json:"total"`
+	Page  int        `json:"page"`
+	Limit int        `json:"limit"`
+}
+
+// CreateUserRequest represents the request to create a user account
+type CreateUserRequest struct {
+	Email     string `json:"email" binding:"required,email,max=255"`
+	Password  string `json:"password" binding:"required,min=8,max=72"`
+	FirstName string `json:"first_name" binding:"required,max=100"`
+	LastName  string `json:"last_name" binding:"max=100"`
+}
+
+// UpdateUserRequest represents the request to update a user's profile.
+// Activation is changed through the deactivate and reactivate endpoints.
+type UpdateUserRequest struct {
+	Email     *string `json:"email" binding:"omitempty,email,max=255"`
+	FirstName *string `json:"first_name" binding:"omitempty,max=100"`
+	LastName  *string `json:"last_name" binding:"omitempty,max=100"`
+}
+
+// UserLifecycleRequest carries the reason recorded in the audit trail for a
+// deactivation, reactivation or anonymization
+type UserLifecycleRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// UserLifecycleResponse reports the user's new state and what was revoked.
+// SessionsInvalidated only covers the instance that served the request:
+// revocations are kept in memory, so other replicas accept the user's access
+// tokens until they expire. Refresh tokens are rejected everywhere because
+// the account is inactive.
+type UserLifecycleResponse struct {
+	User                *UserDTO `json:"user"`
+	RevokedAssignments  int      `json:"revoked_assignments"`
+	SessionsInvalidated bool     `json:"sessions_invalidated"`
+}
@@ -0,0 +1,448 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+// Notification events for self-service access requests
+const (
+	NotifyAccessRequested       = "access_request.created"
+	NotifyAccessRequestApproved = "access_request.approved"
+	NotifyAccessRequestRejected = "access_request.rejected"
+)
+
+// selfServiceScopes are the role scopes users may request for themselves
+var selfServiceScopes = map[string]bool{"school": true, "unit": true}
+
+// AccessRequestService lets users ask for a role in a school or unit and lets
+// admins decide. Approvals grant the role through RoleService.GrantRoleToUser.
+type AccessRequestService interface {
+	Create(ctx context.Context, userID string, req *dto.CreateAccessRequestRequest) (*dto.AccessRequestDTO, error)
+	ListMine(ctx context.Context, userID string, filters sharedrepo.ListFilters) (*dto.AccessRequestsResponse, error)
+	List(ctx context.Context, schoolID, status string, filters sharedrepo.ListFilters) (*dto.AccessRequestsResponse, error)
+	Get(ctx context.Context, id string) (*dto.AccessRequestDTO, error)
+	Approve(ctx context.Context, id string, req *dto.DecideAccessRequestRequest, deciderID string) (*dto.AccessRequestDTO, error)
+	Reject(ctx context.Context, id string, req *dto.DecideAccessRequestRequest, deciderID string) (*dto.AccessRequestDTO, error)
+	Cancel(ctx context.Context, id, userID string) (*dto.AccessRequestDTO, error)
+}
+
+type accessRequestService struct {
+	requestRepo     repository.AccessRequestRepository
+	roleRepo        repository.RoleRepository
+	userRoleRepo    repository.UserRoleRepository
+	roleService     RoleService
+	notifier        Notifier
+	logger          logger.Logger
+	auditLogger     audit.AuditLogger
+	defaultGrantTTL time.Duration
+}
+
+// NewAccessRequestService creates a new access request service. Approved
+// grants expire after defaultGrantTTL unless the decider sets an expiry.
+func NewAccessRequestService(requestRepo repository.AccessRequestRepository, roleRepo repository.RoleRepository, userRoleRepo repository.UserRoleRepository, roleService RoleService, notifier Notifier, logger logger.Logger, auditLogger audit.AuditLogger, defaultGrantTTL time.Duration) AccessRequestService {
+	return &accessRequestService{
+		requestRepo:     requestRepo,
+		roleRepo:        roleRepo,
+		userRoleRepo:    userRoleRepo,
+		roleService:     roleService,
+		notifier:        notifier,
+		logger:          logger,
+		auditLogger:     auditLogger,
+		defaultGrantTTL: defaultGrantTTL,
+	}
+}
+
+func (s *accessRequestService) Create(ctx context.Context, userID string, req *dto.CreateAccessRequestRequest) (*dto.AccessRequestDTO, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid user ID")
+	}
+	roleID, err := uuid.Parse(req.RoleID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid role ID")
+	}
+	schoolID, err := uuid.Parse(req.SchoolID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school_id")
+	}
+	var unitID *uuid.UUID
+	if req.AcademicUnitID != nil && *req.AcademicUnitID != "" {
+		aid, err := uuid.Parse(*req.AcademicUnitID)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid academic_unit_id")
+		}
+		unitID = &aid
+	}
+
+	role, err := s.roleRepo.FindByID(ctx, roleID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find role", err)
+	}
+	if role == nil || !role.IsActive {
+		return nil, errors.NewValidationError("role not found")
+	}
+	if !selfServiceScopes[role.Scope] {
+		return nil, errors.NewValidationError("only school or unit roles can be requested")
+	}
+
+	hasRole, err := s.userRoleRepo.UserHasRole(ctx, uid, roleID, &schoolID, unitID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("check user role", err)
+	}
+	if hasRole {
+		return nil, errors.NewAlreadyExistsError("user_role")
+	}
+	pending, err := s.requestRepo.HasPending(ctx, uid, roleID, schoolID, unitID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("check pending access requests", err)
+	}
+	if pending {
+		return nil, errors.NewAlreadyExistsError("access_request")
+	}
+
+	now := time.Now()
+	ar := &model.AccessRequest{
+		ID:             uuid.New(),
+		UserID:         uid,
+		RoleID:         roleID,
+		SchoolID:       schoolID,
+		AcademicUnitID: unitID,
+		Justification:  req.Justification,
+		Status:         model.AccessRequestPending,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := s.requestRepo.Create(ctx, ar); err != nil {
+		return nil, errors.NewDatabaseError("create access request", err)
+	}
+
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action:       "create",
+		ResourceType: "access_request",
+		ResourceID:   ar.ID.String(),
+		Severity:     audit.SeverityInfo,
+		Category:     audit.CategoryAdmin,
+		Metadata:     accessRequestAuditMetadata(ar, role.Name, nil),
+	})
+	s.logger.Info("access request created", "entity_type", "access_request", "request_id", ar.ID, "user_id", userID, "role_name", role.Name)
+
+	d := toAccessRequestDTO(ar, role.Name)
+	notify(ctx, s.notifier, s.logger, Notification{
+		Event:   NotifyAccessRequested,
+		Subject: "Access to " + role.Name + " requested",
+		Data:    map[string]interface{}{"request_id": d.ID, "user_id": d.UserID, "role_name": role.Name, "school_id": d.SchoolID},
+	})
+	return d, nil
+}
+
+func (s *accessRequestService) ListMine(ctx context.Context, userID string, filters sharedrepo.ListFilters) (*dto.AccessRequestsResponse, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid user ID")
+	}
+	return s.list(ctx, repository.AccessRequestFilter{UserID: &uid}, filters)
+}
+
+func (s *accessRequestService) List(ctx context.Context, schoolID, status string, filters sharedrepo.ListFilters) (*dto.AccessRequestsResponse, error) {
+	filter := repository.AccessRequestFilter{Status: status}
+	switch status {
+	case "", model.AccessRequestPending, model.AccessRequestApproved, model.AccessRequestRejected, model.AccessRequestCancelled:
+	default:
+		return nil, errors.NewValidationError("invalid status")
+	}
+	if schoolID != "" {
+		sid, err := uuid.Parse(schoolID)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid school_id")
+		}
+		filter.SchoolID = &sid
+	}
+	return s.list(ctx, filter, filters)
+}
+
+func (s *accessRequestService) list(ctx context.Context, filter repository.AccessRequestFilter, filters sharedrepo.ListFilters) (*dto.AccessRequestsResponse, error) {
+	requests, total, err := s.requestRepo.List(ctx, filter, filters)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list access requests", err)
+	}
+
+	roleNames := make(map[uuid.UUID]string)
+	dtos := make([]*dto.AccessRequestDTO, len(requests))
+	for i, ar := range requests {
+		if _, ok := roleNames[ar.RoleID]; !ok {
+			if role, err := s.roleRepo.FindByID(ctx, ar.RoleID); err == nil && role != nil {
+				roleNames[ar.RoleID] = role.Name
+			}
+		}
+		dtos[i] = toAccessRequestDTO(ar, roleNames[ar.RoleID])
+	}
+
+	page := filters.Page
+	if page == 0 {
+		page = 1
+	}
+	limit := filters.Limit
+	if filters.Page > 0 && filters.Limit == 0 {
+		limit = 50
+	} else if limit == 0 {
+		limit = total
+	}
+	return &dto.AccessRequestsResponse{Requests: dtos, Total: total, Page: page, Limit: limit}, nil
+}
+
+func (s *accessRequestService) Get(ctx context.Context, id string) (*dto.AccessRequestDTO, error) {
+	ar, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toAccessRequestDTO(ar, s.roleName(ctx, ar.RoleID)), nil
+}
+
+// Approve claims the pending request and then grants the requested role
+// through RoleService.GrantRoleToUser, so two deciders cannot both grant it.
+// When the grant fails the request is reopened. When the role requires
+// approval the grant itself becomes a pending role grant request, linked from
+// the access request.
+func (s *accessRequestService) Approve(ctx context.Context, id string, req *dto.DecideAccessRequestRequest, deciderID string) (*dto.AccessRequestDTO, error) {
+	ar, decider, err := s.decidable(ctx, id, deciderID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.defaultGrantTTL)
+	if req.ExpiresAt != nil && *req.ExpiresAt != "" {
+		t, err := time.Parse(time.RFC3339, *req.ExpiresAt)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid expires_at format, use RFC3339")
+		}
+		if !t.After(now) {
+			return nil, errors.NewValidationError("expires_at must be in the future")
+		}
+		expiresAt = t
+	}
+
+	ar.GrantExpiresAt = &expiresAt
+	if err := s.decide(ctx, ar, model.AccessRequestApproved, decider, req.Note, now); err != nil {
+		return nil, err
+	}
+
+	schoolID := ar.SchoolID.String()
+	expires := expiresAt.Format(time.RFC3339)
+	grant, err := s.roleService.GrantRoleToUser(ctx, ar.UserID.String(), &dto.GrantRoleRequest{
+		RoleID:         ar.RoleID.String(),
+		SchoolID:       &schoolID,
+		AcademicUnitID: uuidString(ar.AcademicUnitID),
+		ExpiresAt:      &expires,
+	}, deciderID)
+	if err != nil {
+		if reopenErr := s.requestRepo.Reopen(ctx, ar.ID); reopenErr != nil {
+			s.logger.Error("error reopening access request after failed grant", "request_id", ar.ID, "error", reopenErr)
+		}
+		return nil, err
+	}
+
+	if grant.UserRole != nil {
+		if urID, err := uuid.Parse(grant.UserRole.ID); err == nil {
+			ar.UserRoleID = &urID
+		}
+	}
+	if grant.ApprovalRequest != nil {
+		if grID, err := uuid.Parse(grant.ApprovalRequest.ID); err == nil {
+			ar.RoleGrantRequestID = &grID
+		}
+	}
+	if err := s.requestRepo.Update(ctx, ar); err != nil {
+		return nil, errors.NewDatabaseError("link access request grant", err)
+	}
+
+	roleName := s.roleName(ctx, ar.RoleID)
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action:       "approve",
+		ResourceType: "access_request",
+		ResourceID:   ar.ID.String(),
+		Severity:     audit.SeverityCritical,
+		Category:     audit.CategoryAdmin,
+		Metadata:     accessRequestAuditMetadata(ar, roleName, map[string]interface{}{"decided_by": deciderID, "grant_expires_at": expires}),
+	})
+	s.logger.Info("access request approved", "entity_type", "access_request", "request_id", ar.ID, "decided_by", deciderID)
+
+	d := toAccessRequestDTO(ar, roleName)
+	s.notifyRequester(ctx, NotifyAccessRequestApproved, d)
+	return d, nil
+}
+
+func (s *accessRequestService) Reject(ctx context.Context, id string, req *dto.DecideAccessRequestRequest, deciderID string) (*dto.AccessRequestDTO, error) {
+	ar, decider, err := s.decidable(ctx, id, deciderID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.decide(ctx, ar, model.AccessRequestRejected, decider, req.Note, time.Now()); err != nil {
+		return nil, err
+	}
+
+	roleName := s.roleName(ctx, ar.RoleID)
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action:       "reject",
+		ResourceType: "access_request",
+		ResourceID:   ar.ID.String(),
+		Severity:     audit.SeverityWarning,
+		Category:     audit.CategoryAdmin,
+		Metadata:     accessRequestAuditMetadata(ar, roleName, map[string]interface{}{"decided_by": deciderID}),
+	})
+	s.logger.Info("access request rejected", "entity_type", "access_request", "request_id", ar.ID, "decided_by", deciderID)
+
+	d := toAccessRequestDTO(ar, roleName)
+	s.notifyRequester(ctx, NotifyAccessRequestRejected, d)
+	return d, nil
+}
+
+// Cancel withdraws a pending request. Only the requesting user may cancel it.
+func (s *accessRequestService) Cancel(ctx context.Context, id, userID string) (*dto.AccessRequestDTO, error) {
+	ar, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ar.UserID.String() != userID {
+		return nil, errors.NewNotFoundError("access_request")
+	}
+	if ar.Status != model.AccessRequestPending {
+		return nil, errors.NewConflictError("access request is already " + ar.Status)
+	}
+	ar.Status = model.AccessRequestCancelled
+	ar.UpdatedAt = time.Now()
+	cancelled, err := s.requestRepo.Decide(ctx, ar)
+	if err != nil {
+		return nil, errors.NewDatabaseError("update access request", err)
+	}
+	if !cancelled {
+		return nil, errors.NewConflictError("access request was decided concurrently")
+	}
+
+	roleName := s.roleName(ctx, ar.RoleID)
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action:       "cancel",
+		ResourceType: "access_request",
+		ResourceID:   ar.ID.String(),
+		Severity:     audit.SeverityInfo,
+		Category:     audit.CategoryAdmin,
+		Metadata:     accessRequestAuditMetadata(ar, roleName, nil),
+	})
+	s.logger.Info("access request cancelled", "entity_type", "access_request", "request_id", ar.ID)
+	return toAccessRequestDTO(ar, roleName), nil
+}
+
+// decidable loads a pending request that deciderID is allowed to decide:
+// nobody decides on their own access request.
+func (s *accessRequestService) decidable(ctx context.Context, id, deciderID string) (*model.AccessRequest, uuid.UUID, error) {
+	decider, err := uuid.Parse(deciderID)
+	if err != nil {
+		return nil, uuid.Nil, errors.NewValidationError("invalid decider ID")
+	}
+	ar, err := s.find(ctx, id)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+	if ar.Status != model.AccessRequestPending {
+		return nil, uuid.Nil, errors.NewConflictError("access request is already " + ar.Status)
+	}
+	if ar.UserID == decider {
+		return nil, uuid.Nil, errors.NewValidationError("cannot decide your own access request")
+	}
+	return ar, decider, nil
+}
+
+// decide stores the decision only while the request is still pending
+func (s *accessRequestService) decide(ctx context.Context, ar *model.AccessRequest, status string, decider uuid.UUID, note string, now time.Time) error {
+	ar.Status = status
+	ar.DecidedBy = &decider
+	ar.DecidedAt = &now
+	if note != "" {
+		ar.DecisionNote = &note
+	}
+	ar.UpdatedAt = now
+	decided, err := s.requestRepo.Decide(ctx, ar)
+	if err != nil {
+		return errors.NewDatabaseError("update access request", err)
+	}
+	if !decided {
+		return errors.NewConflictError("access request was decided concurrently")
+	}
+	return nil
+}
+
+func (s *accessRequestService) find(ctx context.Context, id string) (*model.AccessRequest, error) {
+	rid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid request ID")
+	}
+	ar, err := s.requestRepo.FindByID(ctx, rid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find access request", err)
+	}
+	if ar == nil {
+		return nil, errors.NewNotFoundError("access_request")
+	}
+	return ar, nil
+}
+
+func (s *accessRequestService) roleName(ctx context.Context, roleID uuid.UUID) string {
+	if role, err := s.roleRepo.FindByID(ctx, roleID); err == nil && role != nil {
+		return role.Name
+	}
+	return ""
+}
+
+// accessRequestAuditMetadata describes an access request for the audit log
+func accessRequestAuditMetadata(ar *model.AccessRequest, roleName string, extra map[string]interface{}) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"user_id":   ar.UserID.String(),
+		"role_id":   ar.RoleID.String(),
+		"role_name": roleName,
+		"school_id": ar.SchoolID.String(),
+		"status":    ar.Status,
+	}
+	for k, v := range extra {
+		metadata[k] = v
+	}
+	return metadata
+}
+
+func (s *accessRequestService) notifyRequester(ctx context.Context, event string, d *dto.AccessRequestDTO) {
+	notify(ctx, s.notifier, s.logger, Notification{
+		Event:      event,
+		Recipients: []string{d.UserID},
+		Subject:    "Your access request for " + d.RoleName + " was " + d.Status,
+		Data:       map[string]interface{}{"request_id": d.ID, "role_name": d.RoleName, "status": d.Status},
+	})
+}
+
+func toAccessRequestDTO(ar *model.AccessRequest, roleName string) *dto.AccessRequestDTO {
+	return &dto.AccessRequestDTO{
+		ID:                 ar.ID.String(),
+		UserID:             ar.UserID.String(),
+		RoleID:             ar.RoleID.String(),
+		RoleName:           roleName,
+		SchoolID:           ar.SchoolID.String(),
+		AcademicUnitID:     uuidString(ar.AcademicUnitID),
+		Justification:      ar.Justification,
+		Status:             ar.Status,
+		DecidedBy:          uuidString(ar.DecidedBy),
+		DecidedAt:          timeString(ar.DecidedAt),
+		DecisionNote:       ar.DecisionNote,
+		GrantExpiresAt:     timeString(ar.GrantExpiresAt),
+		UserRoleID:         uuidString(ar.UserRoleID),
+		RoleGrantRequestID: uuidString(ar.RoleGrantRequestID),
+		CreatedAt:          ar.CreatedAt.Format(time.RFC3339),
+	}
+}
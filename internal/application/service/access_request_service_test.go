@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedErrors "github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+func newAccessRequestService(arRepo *mockAccessRequestRepo, roleRepo *mockRoleRepo, urRepo *mockUserRoleRepo) AccessRequestService {
+	roleSvc := newRoleService(roleRepo, &mockPermissionRepo{}, urRepo)
+	return NewAccessRequestService(arRepo, roleRepo, urRepo, roleSvc, &mockNotifier{}, &mockLogger{}, &mockAuditLogger{}, 90*24*time.Hour)
+}
+
+func TestAccessRequestService_Create(t *testing.T) {
+	ctx := context.Background()
+	userID, roleID, schoolID := uuid.New(), uuid.New(), uuid.New()
+	teacher := &entities.Role{ID: roleID, Name: "teacher", Scope: "school", IsActive: true}
+
+	t.Run("crea la solicitud pendiente", func(t *testing.T) {
+		var created *model.AccessRequest
+		svc := newAccessRequestService(
+			&mockAccessRequestRepo{createFn: func(ctx context.Context, req *model.AccessRequest) error {
+				created = req
+				return nil
+			}},
+			&mockRoleRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Role, error) { return teacher, nil }},
+			&mockUserRoleRepo{},
+		)
+
+		d, err := svc.Create(ctx, userID.String(), &dto.CreateAccessRequestRequest{
+			RoleID: roleID.String(), SchoolID: schoolID.String(), Justification: "Necesito calificar el curso nuevo",
+		})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if created == nil || created.UserID != userID || d.Status != model.AccessRequestPending {
+			t.Errorf("solicitud incorrecta: %+v", d)
+		}
+	})
+
+	t.Run("rechaza roles de plataforma", func(t *testing.T) {
+		admin := &entities.Role{ID: roleID, Name: "super_admin", Scope: "platform", IsActive: true}
+		svc := newAccessRequestService(&mockAccessRequestRepo{},
+			&mockRoleRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Role, error) { return admin, nil }},
+			&mockUserRoleRepo{},
+		)
+		_, err := svc.Create(ctx, userID.String(), &dto.CreateAccessRequestRequest{RoleID: roleID.String(), SchoolID: schoolID.String(), Justification: "quiero ser admin"})
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	})
+
+	t.Run("rechaza duplicados pendientes", func(t *testing.T) {
+		svc := newAccessRequestService(
+			&mockAccessRequestRepo{hasPendingFn: func(ctx context.Context, u, r, s uuid.UUID, a *uuid.UUID) (bool, error) { return true, nil }},
+			&mockRoleRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Role, error) { return teacher, nil }},
+			&mockUserRoleRepo{},
+		)
+		_, err := svc.Create(ctx, userID.String(), &dto.CreateAccessRequestRequest{RoleID: roleID.String(), SchoolID: schoolID.String(), Justification: "otra vez lo mismo"})
+		assertAppError(t, err, sharedErrors.ErrorCodeAlreadyExists)
+	})
+}
+
+func TestAccessRequestService_Approve(t *testing.T) {
+	ctx := context.Background()
+	userID, roleID, schoolID, adminID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	teacher := &entities.Role{ID: roleID, Name: "teacher", Scope: "school", IsActive: true}
+	pending := func() *model.AccessRequest {
+		return &model.AccessRequest{ID: uuid.New(), UserID: userID, RoleID: roleID, SchoolID: schoolID, Justification: "curso nuevo", Status: model.AccessRequestPending}
+	}
+	roleRepo := &mockRoleRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Role, error) { return teacher, nil }}
+
+	t.Run("otorga el rol con vencimiento por defecto", func(t *testing.T) {
+		ar := pending()
+		var granted *entities.UserRole
+		svc := newAccessRequestService(
+			&mockAccessRequestRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*model.AccessRequest, error) { return ar, nil }},
+			roleRepo,
+			&mockUserRoleRepo{grantFn: func(ctx context.Context, ur *entities.UserRole) error {
+				granted = ur
+				return nil
+			}},
+		)
+
+		d, err := svc.Approve(ctx, ar.ID.String(), &dto.DecideAccessRequestRequest{}, adminID.String())
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if granted == nil || granted.SchoolID == nil || *granted.SchoolID != schoolID || granted.ExpiresAt == nil {
+			t.Fatalf("asignación incorrecta: %+v", granted)
+		}
+		if d.Status != model.AccessRequestApproved || d.UserRoleID == nil || *d.UserRoleID != granted.ID.String() {
+			t.Errorf("solicitud no vinculada a la asignación: %+v", d)
+		}
+	})
+
+	t.Run("nadie aprueba su propia solicitud", func(t *testing.T) {
+		ar := pending()
+		svc := newAccessRequestService(
+			&mockAccessRequestRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*model.AccessRequest, error) { return ar, nil }},
+			roleRepo, &mockUserRoleRepo{},
+		)
+		_, err := svc.Approve(ctx, ar.ID.String(), &dto.DecideAccessRequestRequest{}, userID.String())
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	})
+
+	t.Run("rechaza vencimientos en el pasado", func(t *testing.T) {
+		ar := pending()
+		past := time.Now().Add(-time.Hour).Format(time.RFC3339)
+		svc := newAccessRequestService(
+			&mockAccessRequestRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*model.AccessRequest, error) { return ar, nil }},
+			roleRepo, &mockUserRoleRepo{},
+		)
+		_, err := svc.Approve(ctx, ar.ID.String(), &dto.DecideAccessRequestRequest{ExpiresAt: &past}, adminID.String())
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	})
+
+	t.Run("no otorga si otra decisión ganó la carrera", func(t *testing.T) {
+		ar := pending()
+		granted := false
+		svc := newAccessRequestService(
+			&mockAccessRequestRepo{
+				findByIDFn: func(ctx context.Context, id uuid.UUID) (*model.AccessRequest, error) { return ar, nil },
+				decideFn:   func(ctx context.Context, req *model.AccessRequest) (bool, error) { return false, nil },
+			},
+			roleRepo,
+			&mockUserRoleRepo{grantFn: func(ctx context.Context, ur *entities.UserRole) error {
+				granted = true
+				return nil
+			}},
+		)
+		_, err := svc.Approve(ctx, ar.ID.String(), &dto.DecideAccessRequestRequest{}, adminID.String())
+		assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+		if granted {
+			t.Error("no debería otorgarse el rol")
+		}
+	})
+
+	t.Run("reabre la solicitud si el otorgamiento falla", func(t *testing.T) {
+		ar := pending()
+		var reopened uuid.UUID
+		svc := newAccessRequestService(
+			&mockAccessRequestRepo{
+				findByIDFn: func(ctx context.Context, id uuid.UUID) (*model.AccessRequest, error) { return ar, nil },
+				reopenFn: func(ctx context.Context, id uuid.UUID) error {
+					reopened = id
+					return nil
+				},
+			},
+			roleRepo,
+			&mockUserRoleRepo{grantFn: func(ctx context.Context, ur *entities.UserRole) error { return context.DeadlineExceeded }},
+		)
+		if _, err := svc.Approve(ctx, ar.ID.String(), &dto.DecideAccessRequestRequest{}, adminID.String()); err == nil {
+			t.Fatal("se esperaba un error")
+		}
+		if reopened != ar.ID {
+			t.Error("la solicitud debería volver a pendiente")
+		}
+	})
+}
+
+func TestAccessRequestService_Cancel(t *testing.T) {
+	userID := uuid.New()
+	ar := &model.AccessRequest{ID: uuid.New(), UserID: userID, RoleID: uuid.New(), SchoolID: uuid.New(), Status: model.AccessRequestPending}
+	svc := newAccessRequestService(
+		&mockAccessRequestRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*model.AccessRequest, error) { return ar, nil }},
+		&mockRoleRepo{}, &mockUserRoleRepo{},
+	)
+
+	t.Run("otro usuario no puede cancelarla", func(t *testing.T) {
+		_, err := svc.Cancel(context.Background(), ar.ID.String(), uuid.New().String())
+		assertAppError(t, err, sharedErrors.ErrorCodeNotFound)
+	})
+
+	t.Run("el solicitante la cancela", func(t *testing.T) {
+		d, err := svc.Cancel(context.Background(), ar.ID.String(), userID.String())
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if d.Status != model.AccessRequestCancelled {
+			t.Errorf("estado incorrecto: %s", d.Status)
+		}
+	})
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedErrors "github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+func newAccessReviewService(reviewRepo *mockAccessReviewRepo, urRepo *mockUserRoleRepo) AccessReviewService {
+	return NewAccessReviewService(reviewRepo, urRepo, &mockRoleRepo{}, &mockNotifier{}, &mockLogger{}, &mockAuditLogger{})
+}
+
+func TestAccessReviewService_CreateCampaign(t *testing.T) {
+	ctx := context.Background()
+	schoolID := uuid.New()
+	reviewerA, reviewerB := uuid.New(), uuid.New()
+	deadline := time.Now().Add(14 * 24 * time.Hour).Format(time.RFC3339)
+	sid := schoolID.String()
+
+	t.Run("reparte las asignaciones sin autorevisión", func(t *testing.T) {
+		userRoles := []*entities.UserRole{
+			{ID: uuid.New(), UserID: reviewerA, RoleID: uuid.New(), SchoolID: &schoolID, IsActive: true},
+			{ID: uuid.New(), UserID: uuid.New(), RoleID: uuid.New(), SchoolID: &schoolID, IsActive: true},
+			{ID: uuid.New(), UserID: uuid.New(), RoleID: uuid.New(), SchoolID: &schoolID, IsActive: true},
+		}
+		var gotSchool *uuid.UUID
+		var stored []*model.AccessReviewItem
+		svc := newAccessReviewService(
+			&mockAccessReviewRepo{createCampaignFn: func(ctx context.Context, c *model.AccessReviewCampaign, items []*model.AccessReviewItem) error {
+				stored = items
+				return nil
+			}},
+			&mockUserRoleRepo{findActiveInScopeFn: func(ctx context.Context, s, u, r *uuid.UUID) ([]*entities.UserRole, error) {
+				gotSchool = s
+				return userRoles, nil
+			}},
+		)
+
+		d, err := svc.CreateCampaign(ctx, &dto.CreateAccessReviewRequest{
+			Name: "Revisión 2026-2", SchoolID: &sid, Deadline: deadline, ReviewerIDs: []string{reviewerA.String(), reviewerB.String()},
+		}, uuid.New().String())
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if gotSchool == nil || *gotSchool != schoolID {
+			t.Error("esperaba filtrar por school_id")
+		}
+		if d.Progress.Total != 3 || d.Progress.Pending != 3 {
+			t.Errorf("progreso incorrecto: %+v", d.Progress)
+		}
+		for _, item := range stored {
+			if item.ReviewerID == nil || *item.ReviewerID == item.UserID {
+				t.Errorf("el ítem %s no debe revisarlo su propio titular", item.ID)
+			}
+		}
+	})
+
+	t.Run("rechaza deadline en el pasado", func(t *testing.T) {
+		past := time.Now().Add(-time.Hour).Format(time.RFC3339)
+		svc := newAccessReviewService(&mockAccessReviewRepo{}, &mockUserRoleRepo{})
+		_, err := svc.CreateCampaign(ctx, &dto.CreateAccessReviewRequest{Name: "x", Deadline: past, ReviewerIDs: []string{reviewerA.String()}}, "")
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	})
+}
+
+func TestAccessReviewService_Decide(t *testing.T) {
+	ctx := context.Background()
+	reviewer := uuid.New()
+	campaign := &model.AccessReviewCampaign{ID: uuid.New(), Name: "q3", Status: model.ReviewCampaignActive, Deadline: time.Now().Add(time.Hour)}
+	newItem := func() *model.AccessReviewItem {
+		return &model.AccessReviewItem{ID: uuid.New(), CampaignID: campaign.ID, UserRoleID: uuid.New(), UserID: uuid.New(), RoleID: uuid.New(), ReviewerID: &reviewer, Decision: model.ReviewDecisionPending}
+	}
+	reviewRepo := func(item *model.AccessReviewItem, decideItem func(ctx context.Context, item *model.AccessReviewItem, revoke bool) (bool, error)) *mockAccessReviewRepo {
+		return &mockAccessReviewRepo{
+			findCampaignFn: func(ctx context.Context, id uuid.UUID) (*model.AccessReviewCampaign, error) { return campaign, nil },
+			findItemFn:     func(ctx context.Context, id uuid.UUID) (*model.AccessReviewItem, error) { return item, nil },
+			decideItemFn:   decideItem,
+		}
+	}
+
+	t.Run("revocar desactiva la asignación revisada", func(t *testing.T) {
+		item := newItem()
+		revoked := false
+		svc := newAccessReviewService(reviewRepo(item, func(ctx context.Context, i *model.AccessReviewItem, revoke bool) (bool, error) {
+			revoked = revoke
+			return true, nil
+		}), &mockUserRoleRepo{})
+
+		d, err := svc.Decide(ctx, campaign.ID.String(), item.ID.String(), &dto.AccessReviewDecisionRequest{Decision: "revoke"}, reviewer.String())
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if !revoked || d.Decision != model.ReviewDecisionRevoked {
+			t.Errorf("esperaba revocar %s (%s)", item.UserRoleID, d.Decision)
+		}
+	})
+
+	t.Run("certificar no revoca", func(t *testing.T) {
+		item := newItem()
+		svc := newAccessReviewService(reviewRepo(item, func(ctx context.Context, i *model.AccessReviewItem, revoke bool) (bool, error) {
+			if revoke {
+				t.Error("certificar no debe revocar")
+			}
+			return true, nil
+		}), &mockUserRoleRepo{})
+		d, err := svc.Decide(ctx, campaign.ID.String(), item.ID.String(), &dto.AccessReviewDecisionRequest{Decision: "certify"}, reviewer.String())
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if d.Decision != model.ReviewDecisionCertified {
+			t.Errorf("decisión incorrecta: %s", d.Decision)
+		}
+	})
+
+	t.Run("retorna conflicto si otra decisión gana la carrera", func(t *testing.T) {
+		item := newItem()
+		svc := newAccessReviewService(reviewRepo(item, func(ctx context.Context, i *model.AccessReviewItem, revoke bool) (bool, error) {
+			return false, nil
+		}), &mockUserRoleRepo{})
+		_, err := svc.Decide(ctx, campaign.ID.String(), item.ID.String(), &dto.AccessReviewDecisionRequest{Decision: "revoke"}, reviewer.String())
+		assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+	})
+
+	t.Run("solo el revisor asignado decide", func(t *testing.T) {
+		item := newItem()
+		svc := newAccessReviewService(reviewRepo(item, nil), &mockUserRoleRepo{})
+		_, err := svc.Decide(ctx, campaign.ID.String(), item.ID.String(), &dto.AccessReviewDecisionRequest{Decision: "certify"}, uuid.New().String())
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	})
+}
+
+func TestAccessReviewService_CloseDueCampaigns(t *testing.T) {
+	newCampaign := func() *model.AccessReviewCampaign {
+		return &model.AccessReviewCampaign{ID: uuid.New(), Name: "q3", Status: model.ReviewCampaignActive, Deadline: time.Now().Add(-time.Minute)}
+	}
+	newPending := func(c *model.AccessReviewCampaign) *model.AccessReviewItem {
+		return &model.AccessReviewItem{ID: uuid.New(), CampaignID: c.ID, UserRoleID: uuid.New(), UserID: uuid.New(), RoleID: uuid.New(), Decision: model.ReviewDecisionPending}
+	}
+
+	t.Run("auto revoca los pendientes y completa la campaña", func(t *testing.T) {
+		campaign := newCampaign()
+		pending := newPending(campaign)
+		var revoked []uuid.UUID
+		svc := newAccessReviewService(&mockAccessReviewRepo{
+			findDueCampaignsFn: func(ctx context.Context, now time.Time) ([]*model.AccessReviewCampaign, error) {
+				return []*model.AccessReviewCampaign{campaign}, nil
+			},
+			listItemsFn: func(ctx context.Context, filter repository.AccessReviewItemFilter) ([]*model.AccessReviewItem, error) {
+				if filter.Decision != model.ReviewDecisionPending {
+					t.Errorf("esperaba listar solo pendientes, obtuvo %q", filter.Decision)
+				}
+				return []*model.AccessReviewItem{pending}, nil
+			},
+			decideItemFn: func(ctx context.Context, item *model.AccessReviewItem, revoke bool) (bool, error) {
+				if revoke {
+					revoked = append(revoked, item.UserRoleID)
+				}
+				return true, nil
+			},
+		}, &mockUserRoleRepo{})
+
+		n, err := svc.CloseDueCampaigns(context.Background())
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if n != 1 || len(revoked) != 1 || pending.Decision != model.ReviewDecisionAutoRevoked {
+			t.Errorf("esperaba auto revocar el ítem pendiente: n=%d revocados=%v decisión=%s", n, revoked, pending.Decision)
+		}
+		if campaign.Status != model.ReviewCampaignCompleted || campaign.CompletedAt == nil {
+			t.Errorf("la campaña debe quedar completada: %+v", campaign)
+		}
+	})
+
+	t.Run("un ítem fallido no detiene el resto", func(t *testing.T) {
+		broken, healthy := newCampaign(), newCampaign()
+		items := map[uuid.UUID][]*model.AccessReviewItem{
+			broken.ID:  {newPending(broken), newPending(broken)},
+			healthy.ID: {newPending(healthy)},
+		}
+		failing := items[broken.ID][0].ID
+		decided := 0
+		svc := newAccessReviewService(&mockAccessReviewRepo{
+			findDueCampaignsFn: func(ctx context.Context, now time.Time) ([]*model.AccessReviewCampaign, error) {
+				return []*model.AccessReviewCampaign{broken, healthy}, nil
+			},
+			listItemsFn: func(ctx context.Context, filter repository.AccessReviewItemFilter) ([]*model.AccessReviewItem, error) {
+				return items[*filter.CampaignID], nil
+			},
+			decideItemFn: func(ctx context.Context, item *model.AccessReviewItem, revoke bool) (bool, error) {
+				if item.ID == failing {
+					return false, errors.New("db down")
+				}
+				decided++
+				return true, nil
+			},
+		}, &mockUserRoleRepo{})
+
+		n, err := svc.CloseDueCampaigns(context.Background())
+		assertAppError(t, err, sharedErrors.ErrorCodeDatabaseError)
+		if n != 1 || decided != 2 {
+			t.Errorf("esperaba cerrar 1 campaña y decidir 2 ítems, obtuvo %d/%d", n, decided)
+		}
+		if broken.Status != model.ReviewCampaignActive || healthy.Status != model.ReviewCampaignCompleted {
+			t.Errorf("solo la campaña sana debe completarse: %s/%s", broken.Status, healthy.Status)
+		}
+	})
+}
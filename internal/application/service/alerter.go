@@ -0,0 +1,87 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// Alert is a security event that needs immediate human attention, unlike a
+// Notification, which only asks someone to act on a workflow.
+type Alert struct {
+	Event    string                 `json:"event"`
+	Severity string                 `json:"severity"`
+	Title    string                 `json:"title"`
+	Data     map[string]interface{} `json:"data,omitempty"`
+	RaisedAt time.Time              `json:"raised_at"`
+}
+
+// Alerter delivers security alerts (pager, chat, webhook...). Delivery errors
+// are logged by callers and never fail the operation that raised the alert.
+type Alerter interface {
+	Alert(ctx context.Context, alert Alert) error
+}
+
+type logAlerter struct {
+	logger logger.Logger
+}
+
+// NewLogAlerter returns an Alerter that writes alerts to the error log. It is
+// the default until an alert channel is configured.
+func NewLogAlerter(logger logger.Logger) Alerter {
+	return &logAlerter{logger: logger}
+}
+
+func (a *logAlerter) Alert(ctx context.Context, alert Alert) error {
+	a.logger.Error("security alert", "event", alert.Event, "severity", alert.Severity, "title", alert.Title, "data", alert.Data)
+	return nil
+}
+
+type webhookAlerter struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookAlerter returns an Alerter that POSTs each alert as JSON to url
+func NewWebhookAlerter(url string, timeout time.Duration) Alerter {
+	return &webhookAlerter{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+func (a *webhookAlerter) Alert(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("alert webhook returned %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// raiseAlert sends an alert and logs delivery failures
+func raiseAlert(ctx context.Context, alerter Alerter, log logger.Logger, alert Alert) {
+	if alerter == nil {
+		return
+	}
+	if alert.RaisedAt.IsZero() {
+		alert.RaisedAt = time.Now()
+	}
+	if err := alerter.Alert(ctx, alert); err != nil {
+		log.Error("security alert delivery failed", "event", alert.Event, "error", err)
+	}
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// Authorization decision reasons
+const (
+	AuthzReasonGranted       = "permission_granted"
+	AuthzReasonMissing       = "permission_missing"
+	AuthzReasonNoAssignments = "no_active_assignments"
+)
+
+// AuthzService evaluates authorization decisions against live role assignments
+type AuthzService interface {
+	Check(ctx context.Context, req *dto.AuthzCheckRequest) (*dto.AuthzDecisionDTO, error)
+	BatchCheck(ctx context.Context, req *dto.AuthzBatchCheckRequest) (*dto.AuthzBatchCheckResponse, error)
+}
+
+type authzService struct {
+	userRoleRepo repository.UserRoleRepository
+	logger       logger.Logger
+	cacheTTL     time.Duration
+
+	mu    sync.Mutex
+	cache map[string]authzCacheEntry
+}
+
+type authzCacheEntry struct {
+	permissions map[string]struct{}
+	expiresAt   time.Time
+}
+
+// NewAuthzService creates a new authorization decision service.
+// Permission sets are cached per subject and context for cacheTTL; a zero TTL disables caching.
+func NewAuthzService(userRoleRepo repository.UserRoleRepository, logger logger.Logger, cacheTTL time.Duration) AuthzService {
+	return &authzService{
+		userRoleRepo: userRoleRepo,
+		logger:       logger,
+		cacheTTL:     cacheTTL,
+		cache:        make(map[string]authzCacheEntry),
+	}
+}
+
+func (s *authzService) Check(ctx context.Context, req *dto.AuthzCheckRequest) (*dto.AuthzDecisionDTO, error) {
+	subjectID, schoolID, unitID, err := parseAuthzSubject(req)
+	if err != nil {
+		return nil, err
+	}
+	permission := authzPermissionName(req.Resource, req.Action)
+
+	perms, cached, err := s.loadPermissions(ctx, subjectID, schoolID, unitID)
+	if err != nil {
+		return nil, err
+	}
+
+	decision := &dto.AuthzDecisionDTO{SubjectID: subjectID.String(), Permission: permission}
+	_, decision.Allowed = perms[permission]
+	switch {
+	case decision.Allowed:
+		decision.Reason = AuthzReasonGranted
+	case len(perms) == 0:
+		decision.Reason = AuthzReasonNoAssignments
+	default:
+		decision.Reason = AuthzReasonMissing
+	}
+
+	if req.Explain {
+		decision.Trace = buildAuthzTrace(permission, schoolID, unitID, perms, cached, decision.Allowed)
+	}
+
+	s.logger.Debug("authz decision", "subject_id", decision.SubjectID, "permission", permission, "allowed", decision.Allowed, "reason", decision.Reason)
+	return decision, nil
+}
+
+func (s *authzService) BatchCheck(ctx context.Context, req *dto.AuthzBatchCheckRequest) (*dto.AuthzBatchCheckResponse, error) {
+	if len(req.Checks) == 0 {
+		return nil, errors.NewValidationError("checks must not be empty")
+	}
+	decisions := make([]*dto.AuthzDecisionDTO, len(req.Checks))
+	for i := range req.Checks {
+		decision, err := s.Check(ctx, &req.Checks[i])
+		if err != nil {
+			return nil, err
+		}
+		decisions[i] = decision
+	}
+	return &dto.AuthzBatchCheckResponse{Decisions: decisions}, nil
+}
+
+// loadPermissions returns the subject's permission set for the given context,
+// served from the short-lived cache when possible. Like switch-context, a
+// school or unit context also carries the subject's global roles, and a unit
+// context its school-level roles. The second return value reports whether the
+// set came from the cache.
+func (s *authzService) loadPermissions(ctx context.Context, userID uuid.UUID, schoolID, unitID *uuid.UUID) (map[string]struct{}, bool, error) {
+	key := authzCacheKey(userID, schoolID, unitID)
+	now := time.Now()
+
+	if s.cacheTTL > 0 {
+		s.mu.Lock()
+		entry, ok := s.cache[key]
+		s.mu.Unlock()
+		if ok && now.Before(entry.expiresAt) {
+			return entry.permissions, true, nil
+		}
+	}
+
+	list, err := s.userRoleRepo.GetContextPermissions(ctx, userID, schoolID, unitID)
+	if err != nil {
+		return nil, false, errors.NewDatabaseError("get user permissions", err)
+	}
+	perms := make(map[string]struct{}, len(list))
+	for _, p := range list {
+		perms[p] = struct{}{}
+	}
+
+	if s.cacheTTL > 0 {
+		s.mu.Lock()
+		for k, e := range s.cache {
+			if !now.Before(e.expiresAt) {
+				delete(s.cache, k)
+			}
+		}
+		s.cache[key] = authzCacheEntry{permissions: perms, expiresAt: now.Add(s.cacheTTL)}
+		s.mu.Unlock()
+	}
+	return perms, false, nil
+}
+
+func parseAuthzSubject(req *dto.AuthzCheckRequest) (uuid.UUID, *uuid.UUID, *uuid.UUID, error) {
+	subjectID, err := uuid.Parse(req.SubjectID)
+	if err != nil {
+		return uuid.Nil, nil, nil, errors.NewValidationError("invalid subject_id")
+	}
+	if strings.TrimSpace(req.Action) == "" || strings.TrimSpace(req.Resource) == "" {
+		return uuid.Nil, nil, nil, errors.NewValidationError("action and resource are required")
+	}
+
+	var schoolID *uuid.UUID
+	if req.SchoolID != nil && *req.SchoolID != "" {
+		sid, err := uuid.Parse(*req.SchoolID)
+		if err != nil {
+			return uuid.Nil, nil, nil, errors.NewValidationError("invalid school_id")
+		}
+		schoolID = &sid
+	}
+
+	var unitID *uuid.UUID
+	if req.AcademicUnitID != nil && *req.AcademicUnitID != "" {
+		aid, err := uuid.Parse(*req.AcademicUnitID)
+		if err != nil {
+			return uuid.Nil, nil, nil, errors.NewValidationError("invalid academic_unit_id")
+		}
+		unitID = &aid
+	}
+	return subjectID, schoolID, unitID, nil
+}
+
+// authzPermissionName builds the permission name ("resource:action") used in iam.permissions.
+func authzPermissionName(resource, action string) string {
+	return strings.TrimSpace(resource) + ":" + strings.TrimSpace(action)
+}
+
+func authzCacheKey(userID uuid.UUID, schoolID, unitID *uuid.UUID) string {
+	school, unit := "-", "-"
+	if schoolID != nil {
+		school = schoolID.String()
+	}
+	if unitID != nil {
+		unit = unitID.String()
+	}
+	return userID.String() + "|" + school + "|" + unit
+}
+
+func buildAuthzTrace(permission string, schoolID, unitID *uuid.UUID, perms map[string]struct{}, cached, allowed bool) []string {
+	trace := make([]string, 0, 4)
+	scope := "any school"
+	if schoolID != nil {
+		scope = "global roles and school " + schoolID.String()
+	}
+	if unitID != nil {
+		scope += ", unit " + unitID.String()
+	}
+	source := "live role assignments"
+	if cached {
+		source = "cached role assignments"
+	}
+	trace = append(trace, fmt.Sprintf("resolved %d active permissions for %s from %s", len(perms), scope, source))
+	trace = append(trace, "required permission: "+permission)
+	if allowed {
+		trace = append(trace, "permission found in subject's effective permissions")
+	} else {
+		trace = append(trace, "permission not found in subject's effective permissions")
+	}
+	return trace
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	sharedErrors "github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+func TestAuthzService_Check(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("permite cuando el permiso está asignado", func(t *testing.T) {
+		svc := NewAuthzService(&mockUserRoleRepo{
+			getContextPermsFn: func(ctx context.Context, uid uuid.UUID, schoolID, unitID *uuid.UUID) ([]string, error) {
+				return []string{"users:read", "roles:read"}, nil
+			},
+		}, &mockLogger{}, 0)
+
+		decision, err := svc.Check(ctx, &dto.AuthzCheckRequest{SubjectID: userID.String(), Resource: "users", Action: "read"})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if !decision.Allowed || decision.Reason != AuthzReasonGranted {
+			t.Errorf("esperaba permitido con razón %s, obtuvo %v/%s", AuthzReasonGranted, decision.Allowed, decision.Reason)
+		}
+		if decision.Trace != nil {
+			t.Error("no esperaba traza sin explain")
+		}
+	})
+
+	t.Run("deniega cuando falta el permiso e incluye traza", func(t *testing.T) {
+		svc := NewAuthzService(&mockUserRoleRepo{
+			getContextPermsFn: func(ctx context.Context, uid uuid.UUID, schoolID, unitID *uuid.UUID) ([]string, error) {
+				return []string{"users:read"}, nil
+			},
+		}, &mockLogger{}, 0)
+
+		decision, err := svc.Check(ctx, &dto.AuthzCheckRequest{SubjectID: userID.String(), Resource: "users", Action: "update", Explain: true})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if decision.Allowed || decision.Reason != AuthzReasonMissing {
+			t.Errorf("esperaba denegado con razón %s, obtuvo %v/%s", AuthzReasonMissing, decision.Allowed, decision.Reason)
+		}
+		if len(decision.Trace) == 0 {
+			t.Error("esperaba traza con explain=true")
+		}
+	})
+
+	t.Run("deniega sin asignaciones activas y pasa el contexto al repositorio", func(t *testing.T) {
+		schoolID := uuid.New()
+		var gotSchool *uuid.UUID
+		svc := NewAuthzService(&mockUserRoleRepo{
+			getContextPermsFn: func(ctx context.Context, uid uuid.UUID, sid, unitID *uuid.UUID) ([]string, error) {
+				gotSchool = sid
+				return []string{}, nil
+			},
+		}, &mockLogger{}, 0)
+
+		sid := schoolID.String()
+		decision, err := svc.Check(ctx, &dto.AuthzCheckRequest{SubjectID: userID.String(), Resource: "users", Action: "read", SchoolID: &sid})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if decision.Reason != AuthzReasonNoAssignments {
+			t.Errorf("esperaba razón %s, obtuvo %s", AuthzReasonNoAssignments, decision.Reason)
+		}
+		if gotSchool == nil || *gotSchool != schoolID {
+			t.Error("esperaba que se filtrara por school_id")
+		}
+	})
+
+	t.Run("usa la caché dentro del TTL", func(t *testing.T) {
+		calls := 0
+		svc := NewAuthzService(&mockUserRoleRepo{
+			getContextPermsFn: func(ctx context.Context, uid uuid.UUID, schoolID, unitID *uuid.UUID) ([]string, error) {
+				calls++
+				return []string{"users:read"}, nil
+			},
+		}, &mockLogger{}, time.Minute)
+
+		req := &dto.AuthzCheckRequest{SubjectID: userID.String(), Resource: "users", Action: "read"}
+		for i := 0; i < 3; i++ {
+			if _, err := svc.Check(ctx, req); err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+		}
+		if calls != 1 {
+			t.Errorf("esperaba 1 consulta al repositorio, obtuvo %d", calls)
+		}
+	})
+
+	t.Run("retorna error de validación con subject_id inválido", func(t *testing.T) {
+		svc := NewAuthzService(&mockUserRoleRepo{}, &mockLogger{}, 0)
+		_, err := svc.Check(ctx, &dto.AuthzCheckRequest{SubjectID: "no-uuid", Resource: "users", Action: "read"})
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	})
+
+	t.Run("propaga error de base de datos", func(t *testing.T) {
+		svc := NewAuthzService(&mockUserRoleRepo{
+			getContextPermsFn: func(ctx context.Context, uid uuid.UUID, schoolID, unitID *uuid.UUID) ([]string, error) {
+				return nil, errors.New("db error")
+			},
+		}, &mockLogger{}, 0)
+		_, err := svc.Check(ctx, &dto.AuthzCheckRequest{SubjectID: userID.String(), Resource: "users", Action: "read"})
+		assertAppError(t, err, sharedErrors.ErrorCodeDatabaseError)
+	})
+}
+
+func TestAuthzService_BatchCheck(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New().String()
+
+	svc := NewAuthzService(&mockUserRoleRepo{
+		getContextPermsFn: func(ctx context.Context, uid uuid.UUID, schoolID, unitID *uuid.UUID) ([]string, error) {
+			return []string{"roles:read"}, nil
+		},
+	}, &mockLogger{}, time.Minute)
+
+	resp, err := svc.BatchCheck(ctx, &dto.AuthzBatchCheckRequest{Checks: []dto.AuthzCheckRequest{
+		{SubjectID: userID, Resource: "roles", Action: "read"},
+		{SubjectID: userID, Resource: "roles", Action: "delete"},
+	}})
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if len(resp.Decisions) != 2 {
+		t.Fatalf("esperaba 2 decisiones, obtuvo %d", len(resp.Decisions))
+	}
+	if !resp.Decisions[0].Allowed || resp.Decisions[1].Allowed {
+		t.Errorf("decisiones incorrectas: %v, %v", resp.Decisions[0].Allowed, resp.Decisions[1].Allowed)
+	}
+}
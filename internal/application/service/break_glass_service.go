@@ -0,0 +1,347 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+// PermissionBreakGlass marks the pre-provisioned emergency responders allowed
+// to elevate themselves. Platform staff provision it by adding it to the
+// responders' regular role; it is checked across all of the user's contexts.
+const PermissionBreakGlass = "users:break_glass"
+
+// Alert events raised by break-glass elevations
+const (
+	AlertBreakGlassActivated = "break_glass.activated"
+	AlertBreakGlassEnded     = "break_glass.ended"
+)
+
+// BreakGlassPolicy bounds emergency elevations: which platform role is
+// granted, for how long, and how many activations a user gets per window.
+type BreakGlassPolicy struct {
+	RoleName       string
+	TTL            time.Duration
+	MaxActivations int
+	RateWindow     time.Duration
+}
+
+// BreakGlassService grants emergency, time-boxed global privileges when
+// nobody with the regular rights is available (e.g. a school lost its only
+// school_admin). Every step is audited as critical and alerted immediately.
+type BreakGlassService interface {
+	Activate(ctx context.Context, userID, clientIP string, req *dto.ActivateBreakGlassRequest) (*dto.BreakGlassElevationDTO, error)
+	// End closes an elevation early. Users may end their own; admin lets
+	// platform staff end anyone's.
+	End(ctx context.Context, id, actorID string, admin bool) (*dto.BreakGlassElevationDTO, error)
+	List(ctx context.Context, filters sharedrepo.ListFilters) (*dto.BreakGlassElevationsResponse, error)
+	// ExpireDue revokes elevations past their expiry. It is run periodically
+	// by the job runner.
+	ExpireDue(ctx context.Context) (int, error)
+}
+
+type breakGlassService struct {
+	elevationRepo repository.BreakGlassRepository
+	roleRepo      repository.RoleRepository
+	userRoleRepo  repository.UserRoleRepository
+	alerter       Alerter
+	logger        logger.Logger
+	auditLogger   audit.AuditLogger
+	policy        BreakGlassPolicy
+}
+
+// NewBreakGlassService creates a new break-glass service
+func NewBreakGlassService(elevationRepo repository.BreakGlassRepository, roleRepo repository.RoleRepository, userRoleRepo repository.UserRoleRepository, alerter Alerter, logger logger.Logger, auditLogger audit.AuditLogger, policy BreakGlassPolicy) BreakGlassService {
+	return &breakGlassService{
+		elevationRepo: elevationRepo,
+		roleRepo:      roleRepo,
+		userRoleRepo:  userRoleRepo,
+		alerter:       alerter,
+		logger:        logger,
+		auditLogger:   auditLogger,
+		policy:        policy,
+	}
+}
+
+// Activate grants the caller the break-glass role globally until the policy
+// TTL runs out. Separation-of-duties constraints are deliberately not checked:
+// the elevation exists for when the regular grant flow cannot be used.
+func (s *breakGlassService) Activate(ctx context.Context, userID, clientIP string, req *dto.ActivateBreakGlassRequest) (*dto.BreakGlassElevationDTO, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid user ID")
+	}
+	schoolID, err := parseOptionalUUID(req.SchoolID, "school_id")
+	if err != nil {
+		return nil, err
+	}
+
+	perms, err := s.userRoleRepo.GetUserPermissions(ctx, uid, nil, nil)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get user permissions", err)
+	}
+	if !slices.Contains(perms, PermissionBreakGlass) {
+		s.logger.Warn("break-glass attempt by non-responder", "user_id", userID, "client_ip", clientIP)
+		return nil, errors.NewValidationError("break-glass access is not provisioned for this user")
+	}
+
+	now := time.Now()
+	active, err := s.elevationRepo.FindActiveByUser(ctx, uid, now)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find break-glass elevation", err)
+	}
+	if active != nil {
+		return nil, errors.NewConflictError("a break-glass elevation is already active until " + active.ExpiresAt.Format(time.RFC3339))
+	}
+	used, err := s.elevationRepo.CountSince(ctx, uid, now.Add(-s.policy.RateWindow))
+	if err != nil {
+		return nil, errors.NewDatabaseError("count break-glass elevations", err)
+	}
+	if int(used) >= s.policy.MaxActivations {
+		s.logger.Warn("break-glass rate limit reached", "user_id", userID, "activations", used)
+		return nil, errors.NewConflictError("break-glass activation limit reached (" + strconv.Itoa(s.policy.MaxActivations) + " per " + s.policy.RateWindow.String() + ")")
+	}
+
+	role, err := s.elevationRole(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hasRole, err := s.userRoleRepo.UserHasRole(ctx, uid, role.ID, nil, nil)
+	if err != nil {
+		return nil, errors.NewDatabaseError("check user role", err)
+	}
+	if hasRole {
+		return nil, errors.NewConflictError("user already holds " + role.Name)
+	}
+
+	expiresAt := now.Add(s.policy.TTL)
+	userRole := &entities.UserRole{
+		ID:        uuid.New(),
+		UserID:    uid,
+		RoleID:    role.ID,
+		IsActive:  true,
+		GrantedBy: &uid,
+		GrantedAt: now,
+		ExpiresAt: &expiresAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	e := &model.BreakGlassElevation{
+		ID:            uuid.New(),
+		UserID:        uid,
+		RoleID:        role.ID,
+		UserRoleID:    userRole.ID,
+		SchoolID:      schoolID,
+		Justification: req.Justification,
+		ActivatedAt:   now,
+		ExpiresAt:     expiresAt,
+	}
+	if clientIP != "" {
+		e.ClientIP = &clientIP
+	}
+	// Separation-of-duties constraints are deliberately not checked: the
+	// elevation exists to restore access in an emergency, and is alerted,
+	// audited and time-boxed instead. The repository re-checks the open
+	// elevation and the rate limit while holding a per-user lock.
+	activated, err := s.elevationRepo.Activate(ctx, e, userRole, now.Add(-s.policy.RateWindow), s.policy.MaxActivations)
+	if err != nil {
+		return nil, errors.NewDatabaseError("create break-glass elevation", err)
+	}
+	if !activated {
+		return nil, errors.NewConflictError("another break-glass elevation was activated concurrently")
+	}
+
+	metadata := breakGlassAuditMetadata(e, role.Name)
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		ActorID:      userID,
+		ActorIP:      clientIP,
+		Action:       "break_glass_activate",
+		ResourceType: "break_glass_elevation",
+		ResourceID:   e.ID.String(),
+		Severity:     audit.SeverityCritical,
+		Category:     audit.CategoryAdmin,
+		Metadata:     metadata,
+	})
+	s.logger.Warn("break-glass elevation activated", "entity_type", "break_glass_elevation", "elevation_id", e.ID, "user_id", userID, "role_name", role.Name, "expires_at", expiresAt)
+
+	raiseAlert(ctx, s.alerter, s.logger, Alert{
+		Event:    AlertBreakGlassActivated,
+		Severity: "critical",
+		Title:    "Break-glass elevation to " + role.Name + " activated by user " + userID,
+		Data:     metadata,
+		RaisedAt: now,
+	})
+	return toBreakGlassElevationDTO(e, role.Name, now), nil
+}
+
+func (s *breakGlassService) End(ctx context.Context, id, actorID string, admin bool) (*dto.BreakGlassElevationDTO, error) {
+	actor, err := uuid.Parse(actorID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid user ID")
+	}
+	eid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid elevation ID")
+	}
+	e, err := s.elevationRepo.FindByID(ctx, eid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find break-glass elevation", err)
+	}
+	if e == nil || (!admin && e.UserID != actor) {
+		return nil, errors.NewNotFoundError("break_glass_elevation")
+	}
+	now := time.Now()
+	if e.EndedAt != nil || !e.ExpiresAt.After(now) {
+		return nil, errors.NewConflictError("break-glass elevation is no longer active")
+	}
+
+	if err := s.close(ctx, e, now, &actor); err != nil {
+		return nil, err
+	}
+	roleName := s.roleName(ctx, e.RoleID)
+	metadata := breakGlassAuditMetadata(e, roleName)
+	metadata["ended_by"] = actorID
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		ActorID:      actorID,
+		Action:       "break_glass_end",
+		ResourceType: "break_glass_elevation",
+		ResourceID:   e.ID.String(),
+		Severity:     audit.SeverityCritical,
+		Category:     audit.CategoryAdmin,
+		Metadata:     metadata,
+	})
+	s.logger.Info("break-glass elevation ended", "entity_type", "break_glass_elevation", "elevation_id", e.ID, "ended_by", actorID)
+
+	raiseAlert(ctx, s.alerter, s.logger, Alert{
+		Event:    AlertBreakGlassEnded,
+		Severity: "critical",
+		Title:    "Break-glass elevation of user " + e.UserID.String() + " ended",
+		Data:     metadata,
+		RaisedAt: now,
+	})
+	return toBreakGlassElevationDTO(e, roleName, now), nil
+}
+
+func (s *breakGlassService) List(ctx context.Context, filters sharedrepo.ListFilters) (*dto.BreakGlassElevationsResponse, error) {
+	elevations, total, err := s.elevationRepo.List(ctx, filters)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list break-glass elevations", err)
+	}
+
+	now := time.Now()
+	roleNames := make(map[uuid.UUID]string)
+	dtos := make([]*dto.BreakGlassElevationDTO, len(elevations))
+	for i, e := range elevations {
+		if _, ok := roleNames[e.RoleID]; !ok {
+			roleNames[e.RoleID] = s.roleName(ctx, e.RoleID)
+		}
+		dtos[i] = toBreakGlassElevationDTO(e, roleNames[e.RoleID], now)
+	}
+
+	page, limit := pageAndLimit(filters, total)
+	return &dto.BreakGlassElevationsResponse{Elevations: dtos, Total: total, Page: page, Limit: limit}, nil
+}
+
+func (s *breakGlassService) ExpireDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	expired, err := s.elevationRepo.FindExpired(ctx, now)
+	if err != nil {
+		return 0, errors.NewDatabaseError("find expired break-glass elevations", err)
+	}
+	for _, e := range expired {
+		if err := s.close(ctx, e, now, nil); err != nil {
+			return 0, err
+		}
+		_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action:       "break_glass_expire",
+			ResourceType: "break_glass_elevation",
+			ResourceID:   e.ID.String(),
+			Severity:     audit.SeverityCritical,
+			Category:     audit.CategoryAdmin,
+			Metadata:     breakGlassAuditMetadata(e, s.roleName(ctx, e.RoleID)),
+		})
+		s.logger.Info("break-glass elevation expired", "entity_type", "break_glass_elevation", "elevation_id", e.ID, "user_id", e.UserID)
+	}
+	return len(expired), nil
+}
+
+// close revokes the elevated assignment and marks the elevation ended
+func (s *breakGlassService) close(ctx context.Context, e *model.BreakGlassElevation, now time.Time, endedBy *uuid.UUID) error {
+	if err := s.userRoleRepo.Revoke(ctx, e.UserRoleID); err != nil {
+		return errors.NewDatabaseError("revoke break-glass role", err)
+	}
+	if err := s.elevationRepo.End(ctx, e.ID, now, endedBy); err != nil {
+		return errors.NewDatabaseError("end break-glass elevation", err)
+	}
+	e.EndedAt = &now
+	e.EndedBy = endedBy
+	return nil
+}
+
+// elevationRole resolves the configured platform role granted on elevation
+func (s *breakGlassService) elevationRole(ctx context.Context) (*entities.Role, error) {
+	roles, _, err := s.roleRepo.FindByScope(ctx, "platform", sharedrepo.ListFilters{})
+	if err != nil {
+		return nil, errors.NewDatabaseError("find platform roles", err)
+	}
+	for _, role := range roles {
+		if role.Name == s.policy.RoleName && role.IsActive {
+			return role, nil
+		}
+	}
+	s.logger.Error("break-glass role is not available", "role_name", s.policy.RoleName)
+	return nil, errors.NewValidationError("break-glass role " + s.policy.RoleName + " is not available")
+}
+
+func (s *breakGlassService) roleName(ctx context.Context, roleID uuid.UUID) string {
+	if role, err := s.roleRepo.FindByID(ctx, roleID); err == nil && role != nil {
+		return role.Name
+	}
+	return ""
+}
+
+// breakGlassAuditMetadata describes an elevation for the audit log and alerts
+func breakGlassAuditMetadata(e *model.BreakGlassElevation, roleName string) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"user_id":       e.UserID.String(),
+		"role_id":       e.RoleID.String(),
+		"role_name":     roleName,
+		"user_role_id":  e.UserRoleID.String(),
+		"justification": e.Justification,
+		"activated_at":  e.ActivatedAt.Format(time.RFC3339),
+		"expires_at":    e.ExpiresAt.Format(time.RFC3339),
+	}
+	if e.SchoolID != nil {
+		metadata["school_id"] = e.SchoolID.String()
+	}
+	return metadata
+}
+
+func toBreakGlassElevationDTO(e *model.BreakGlassElevation, roleName string, now time.Time) *dto.BreakGlassElevationDTO {
+	return &dto.BreakGlassElevationDTO{
+		ID:            e.ID.String(),
+		UserID:        e.UserID.String(),
+		RoleID:        e.RoleID.String(),
+		RoleName:      roleName,
+		UserRoleID:    e.UserRoleID.String(),
+		SchoolID:      uuidString(e.SchoolID),
+		Justification: e.Justification,
+		ClientIP:      e.ClientIP,
+		ActivatedAt:   e.ActivatedAt.Format(time.RFC3339),
+		ExpiresAt:     e.ExpiresAt.Format(time.RFC3339),
+		EndedAt:       timeString(e.EndedAt),
+		EndedBy:       uuidString(e.EndedBy),
+		Active:        e.EndedAt == nil && e.ExpiresAt.After(now),
+	}
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	sharedErrors "github.com/EduGoGroup/edugo-shared/common/errors"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+type recordingAuditLogger struct {
+	events []audit.AuditEvent
+}
+
+func (m *recordingAuditLogger) Log(_ context.Context, event audit.AuditEvent) error {
+	m.events = append(m.events, event)
+	return nil
+}
+
+var testBreakGlassPolicy = BreakGlassPolicy{RoleName: "platform_admin", TTL: time.Hour, MaxActivations: 1, RateWindow: 24 * time.Hour}
+
+func breakGlassRoleRepo(adminRole *entities.Role) *mockRoleRepo {
+	return &mockRoleRepo{
+		findByScopeFn: func(ctx context.Context, scope string, filters sharedrepo.ListFilters) ([]*entities.Role, int, error) {
+			return []*entities.Role{adminRole}, 1, nil
+		},
+		findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Role, error) { return adminRole, nil },
+	}
+}
+
+func TestBreakGlassService_Activate(t *testing.T) {
+	ctx := context.Background()
+	responderID := uuid.New()
+	adminRole := &entities.Role{ID: uuid.New(), Name: "platform_admin", Scope: "platform", IsActive: true}
+	req := &dto.ActivateBreakGlassRequest{Justification: "La única administradora del colegio dejó la institución hoy"}
+	responder := func() *mockUserRoleRepo {
+		return &mockUserRoleRepo{getUserPermissionsFn: func(ctx context.Context, userID uuid.UUID, s, u *uuid.UUID) ([]string, error) {
+			return []string{"screens:read", PermissionBreakGlass}, nil
+		}}
+	}
+
+	t.Run("otorga el rol global por tiempo limitado, audita y alerta", func(t *testing.T) {
+		var granted *entities.UserRole
+		var created *model.BreakGlassElevation
+		auditLog := &recordingAuditLogger{}
+		alerter := &mockAlerter{}
+		svc := NewBreakGlassService(&mockBreakGlassRepo{activateFn: func(ctx context.Context, e *model.BreakGlassElevation, ur *entities.UserRole, since time.Time, maxActivations int) (bool, error) {
+			created, granted = e, ur
+			return true, nil
+		}}, breakGlassRoleRepo(adminRole), responder(), alerter, &mockLogger{}, auditLog, testBreakGlassPolicy)
+
+		d, err := svc.Activate(ctx, responderID.String(), "10.0.0.1", req)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if granted == nil || granted.RoleID != adminRole.ID || granted.SchoolID != nil || granted.ExpiresAt == nil {
+			t.Fatalf("asignación incorrecta: %+v", granted)
+		}
+		if granted.ExpiresAt.Sub(granted.GrantedAt) != time.Hour {
+			t.Errorf("la elevación debe durar el TTL de la política")
+		}
+		if created == nil || created.UserRoleID != granted.ID || !d.Active {
+			t.Errorf("elevación incorrecta: %+v", d)
+		}
+		if len(auditLog.events) != 1 || auditLog.events[0].Severity != audit.SeverityCritical || auditLog.events[0].ActorIP != "10.0.0.1" {
+			t.Errorf("se esperaba un evento crítico: %+v", auditLog.events)
+		}
+		if len(alerter.alerts) != 1 || alerter.alerts[0].Event != AlertBreakGlassActivated {
+			t.Errorf("se esperaba una alerta inmediata: %+v", alerter.alerts)
+		}
+	})
+
+	t.Run("rechaza usuarios no aprovisionados", func(t *testing.T) {
+		svc := NewBreakGlassService(&mockBreakGlassRepo{}, breakGlassRoleRepo(adminRole), &mockUserRoleRepo{}, &mockAlerter{}, &mockLogger{}, &mockAuditLogger{}, testBreakGlassPolicy)
+		_, err := svc.Activate(ctx, responderID.String(), "", req)
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	})
+
+	t.Run("aplica el límite de activaciones", func(t *testing.T) {
+		svc := NewBreakGlassService(&mockBreakGlassRepo{countSinceFn: func(ctx context.Context, userID uuid.UUID, since time.Time) (int64, error) {
+			return 1, nil
+		}}, breakGlassRoleRepo(adminRole), responder(), &mockAlerter{}, &mockLogger{}, &mockAuditLogger{}, testBreakGlassPolicy)
+		_, err := svc.Activate(ctx, responderID.String(), "", req)
+		assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+	})
+
+	t.Run("no admite dos elevaciones activas", func(t *testing.T) {
+		svc := NewBreakGlassService(&mockBreakGlassRepo{findActiveByUserFn: func(ctx context.Context, userID uuid.UUID, now time.Time) (*model.BreakGlassElevation, error) {
+			return &model.BreakGlassElevation{ID: uuid.New(), ExpiresAt: now.Add(time.Minute)}, nil
+		}}, breakGlassRoleRepo(adminRole), responder(), &mockAlerter{}, &mockLogger{}, &mockAuditLogger{}, testBreakGlassPolicy)
+		_, err := svc.Activate(ctx, responderID.String(), "", req)
+		assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+	})
+
+	t.Run("una activación concurrente gana y no se alerta", func(t *testing.T) {
+		alerter := &mockAlerter{}
+		svc := NewBreakGlassService(&mockBreakGlassRepo{activateFn: func(ctx context.Context, e *model.BreakGlassElevation, ur *entities.UserRole, since time.Time, maxActivations int) (bool, error) {
+			return false, nil
+		}}, breakGlassRoleRepo(adminRole), responder(), alerter, &mockLogger{}, &mockAuditLogger{}, testBreakGlassPolicy)
+		_, err := svc.Activate(ctx, responderID.String(), "", req)
+		assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+		if len(alerter.alerts) != 0 {
+			t.Errorf("no se esperaban alertas: %+v", alerter.alerts)
+		}
+	})
+}
+
+func TestBreakGlassService_End(t *testing.T) {
+	ctx := context.Background()
+	ownerID, userRoleID := uuid.New(), uuid.New()
+	adminRole := &entities.Role{ID: uuid.New(), Name: "platform_admin", Scope: "platform", IsActive: true}
+	active := func() *model.BreakGlassElevation {
+		return &model.BreakGlassElevation{ID: uuid.New(), UserID: ownerID, RoleID: adminRole.ID, UserRoleID: userRoleID, ActivatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}
+	}
+
+	t.Run("el dueño termina su elevación y se revoca el rol", func(t *testing.T) {
+		e := active()
+		var revoked uuid.UUID
+		svc := NewBreakGlassService(&mockBreakGlassRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*model.BreakGlassElevation, error) { return e, nil }},
+			breakGlassRoleRepo(adminRole), &mockUserRoleRepo{revokeFn: func(ctx context.Context, id uuid.UUID) error {
+				revoked = id
+				return nil
+			}}, &mockAlerter{}, &mockLogger{}, &mockAuditLogger{}, testBreakGlassPolicy)
+
+		d, err := svc.End(ctx, e.ID.String(), ownerID.String(), false)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if d.Active || revoked != userRoleID {
+			t.Errorf("la elevación debe quedar terminada: %+v", d)
+		}
+	})
+
+	t.Run("otros usuarios solo como administradores", func(t *testing.T) {
+		e := active()
+		svc := NewBreakGlassService(&mockBreakGlassRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*model.BreakGlassElevation, error) { return e, nil }},
+			breakGlassRoleRepo(adminRole), &mockUserRoleRepo{}, &mockAlerter{}, &mockLogger{}, &mockAuditLogger{}, testBreakGlassPolicy)
+		_, err := svc.End(ctx, e.ID.String(), uuid.New().String(), false)
+		assertAppError(t, err, sharedErrors.ErrorCodeNotFound)
+
+		if _, err := svc.End(ctx, e.ID.String(), uuid.New().String(), true); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+	})
+}
+
+func TestBreakGlassService_ExpireDue(t *testing.T) {
+	userRoleID := uuid.New()
+	var revoked []uuid.UUID
+	var ended []uuid.UUID
+	expired := &model.BreakGlassElevation{ID: uuid.New(), UserID: uuid.New(), UserRoleID: userRoleID, ExpiresAt: time.Now().Add(-time.Minute)}
+	svc := NewBreakGlassService(&mockBreakGlassRepo{
+		findExpiredFn: func(ctx context.Context, now time.Time) ([]*model.BreakGlassElevation, error) {
+			return []*model.BreakGlassElevation{expired}, nil
+		},
+		endFn: func(ctx context.Context, id uuid.UUID, endedAt time.Time, endedBy *uuid.UUID) error {
+			ended = append(ended, id)
+			return nil
+		},
+	}, &mockRoleRepo{}, &mockUserRoleRepo{revokeFn: func(ctx context.Context, id uuid.UUID) error {
+		revoked = append(revoked, id)
+		return nil
+	}}, &mockAlerter{}, &mockLogger{}, &mockAuditLogger{}, testBreakGlassPolicy)
+
+	n, err := svc.ExpireDue(context.Background())
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if n != 1 || len(revoked) != 1 || revoked[0] != userRoleID || len(ended) != 1 {
+		t.Errorf("la elevación vencida debe revocarse: n=%d revoked=%v ended=%v", n, revoked, ended)
+	}
+}
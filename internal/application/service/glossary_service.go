@@ -0,0 +1,475 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// Glossary permissions. glossary:read and glossary:update apply to the
+// caller's active school; glossary:manage edits the platform defaults and the
+// terms of every school.
+const (
+	PermissionGlossaryRead   = "glossary:read"
+	PermissionGlossaryUpdate = "glossary:update"
+	PermissionGlossaryManage = "glossary:manage"
+)
+
+const (
+	maxGlossaryTermValue   = 255
+	maxGlossaryImportTerms = 1000
+)
+
+var glossaryTermKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_.]{0,99}$`)
+
+// GlossaryTermDTO is a term of a school's effective glossary. DefaultValue is
+// the platform value, if the term has one; Overridden reports whether the
+// school sets its own value.
+type GlossaryTermDTO struct {
+	TermKey      string  `json:"term_key"`
+	TermValue    string  `json:"term_value"`
+	DefaultValue *string `json:"default_value,omitempty"`
+	Overridden   bool    `json:"overridden"`
+	Description  *string `json:"description,omitempty"`
+}
+
+type GlossaryDefaultDTO struct {
+	TermKey     string    `json:"term_key"`
+	TermValue   string    `json:"term_value"`
+	Description *string   `json:"description,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type SaveGlossaryTermRequest struct {
+	TermValue string `json:"term_value" binding:"required"`
+}
+
+type SaveGlossaryDefaultRequest struct {
+	TermValue   string  `json:"term_value" binding:"required"`
+	Description *string `json:"description"`
+}
+
+// ImportGlossaryRequest sets many terms at once as term_key → term_value.
+// With Replace the terms missing from Terms are removed.
+type ImportGlossaryRequest struct {
+	Terms   map[string]string `json:"terms" binding:"required"`
+	Replace bool              `json:"replace"`
+}
+
+type GlossaryImportResultDTO struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Removed   int `json:"removed"`
+}
+
+// GlossaryService manages the platform default terminology and the terms each
+// school overrides
+type GlossaryService interface {
+	ListDefaults(ctx context.Context) ([]*GlossaryDefaultDTO, error)
+	SaveDefault(ctx context.Context, termKey string, req *SaveGlossaryDefaultRequest) (*GlossaryDefaultDTO, error)
+	DeleteDefault(ctx context.Context, termKey string) error
+	ImportDefaults(ctx context.Context, req *ImportGlossaryRequest) (*GlossaryImportResultDTO, error)
+
+	ListSchoolTerms(ctx context.Context, schoolID string) ([]*GlossaryTermDTO, error)
+	GetSchoolTerm(ctx context.Context, schoolID, termKey string) (*GlossaryTermDTO, error)
+	SaveSchoolTerm(ctx context.Context, schoolID, termKey string, req *SaveGlossaryTermRequest) (*GlossaryTermDTO, error)
+	// DeleteSchoolTerm removes the school's value; the default applies again
+	DeleteSchoolTerm(ctx context.Context, schoolID, termKey string) error
+	ImportSchoolTerms(ctx context.Context, schoolID string, req *ImportGlossaryRequest) (*GlossaryImportResultDTO, error)
+
+	// EffectiveGlossary returns the defaults with the school's terms on top as
+	// term_key → term_value. An empty schoolID returns the defaults.
+	EffectiveGlossary(ctx context.Context, schoolID string) (map[string]string, error)
+}
+
+type glossaryService struct {
+	defaultRepo repository.GlossaryDefaultRepository
+	conceptRepo repository.SchoolConceptRepository
+	logger      logger.Logger
+	auditLogger audit.AuditLogger
+}
+
+// NewGlossaryService creates a new glossary service
+func NewGlossaryService(defaultRepo repository.GlossaryDefaultRepository, conceptRepo repository.SchoolConceptRepository, logger logger.Logger, auditLogger audit.AuditLogger) GlossaryService {
+	return &glossaryService{defaultRepo: defaultRepo, conceptRepo: conceptRepo, logger: logger, auditLogger: auditLogger}
+}
+
+func (s *glossaryService) ListDefaults(ctx context.Context) ([]*GlossaryDefaultDTO, error) {
+	defaults, err := s.defaultRepo.List(ctx)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list glossary defaults", err)
+	}
+	dtos := make([]*GlossaryDefaultDTO, len(defaults))
+	for i, d := range defaults {
+		dtos[i] = toGlossaryDefaultDTO(d)
+	}
+	return dtos, nil
+}
+
+func (s *glossaryService) SaveDefault(ctx context.Context, termKey string, req *SaveGlossaryDefaultRequest) (*GlossaryDefaultDTO, error) {
+	if err := validateGlossaryTerm(termKey, req.TermValue); err != nil {
+		return nil, err
+	}
+	existing, err := s.defaultRepo.Get(ctx, termKey)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get glossary default", err)
+	}
+	term := &model.GlossaryDefault{
+		TermKey: termKey, TermValue: strings.TrimSpace(req.TermValue), Description: req.Description, UpdatedAt: time.Now(),
+	}
+	if err := s.defaultRepo.Save(ctx, term); err != nil {
+		return nil, errors.NewDatabaseError("save glossary default", err)
+	}
+
+	action := "create"
+	metadata := map[string]interface{}{"term_value": term.TermValue}
+	if existing != nil {
+		action = "update"
+		metadata["previous_value"] = existing.TermValue
+	}
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action:       action,
+		ResourceType: "glossary_default",
+		ResourceID:   termKey,
+		Severity:     audit.SeverityInfo,
+		Category:     audit.CategoryAdmin,
+		Metadata:     metadata,
+	})
+	s.logger.Info("entity updated", "entity_type", "glossary_default", "entity_id", termKey)
+	return toGlossaryDefaultDTO(term), nil
+}
+
+func (s *glossaryService) DeleteDefault(ctx context.Context, termKey string) error {
+	existing, err := s.defaultRepo.Get(ctx, termKey)
+	if err != nil {
+		return errors.NewDatabaseError("get glossary default", err)
+	}
+	if existing == nil {
+		return errors.NewNotFoundError("glossary_term")
+	}
+	if err := s.defaultRepo.Delete(ctx, termKey); err != nil {
+		return errors.NewDatabaseError("delete glossary default", err)
+	}
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action:       "delete",
+		ResourceType: "glossary_default",
+		ResourceID:   termKey,
+		Severity:     audit.SeverityWarning,
+		Category:     audit.CategoryAdmin,
+		Metadata:     map[string]interface{}{"previous_value": existing.TermValue},
+	})
+	s.logger.Info("entity deleted", "entity_type", "glossary_default", "entity_id", termKey)
+	return nil
+}
+
+func (s *glossaryService) ImportDefaults(ctx context.Context, req *ImportGlossaryRequest) (*GlossaryImportResultDTO, error) {
+	terms, err := normalizeGlossaryImport(req.Terms)
+	if err != nil {
+		return nil, err
+	}
+	defaults, err := s.defaultRepo.List(ctx)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list glossary defaults", err)
+	}
+	current := make(map[string]string, len(defaults))
+	descriptions := make(map[string]*string, len(defaults))
+	for _, d := range defaults {
+		current[d.TermKey] = d.TermValue
+		descriptions[d.TermKey] = d.Description
+	}
+	result := diffGlossaryImport(current, terms, req.Replace)
+
+	now := time.Now()
+	batch := make([]*model.GlossaryDefault, 0, len(terms))
+	for _, key := range slices.Sorted(maps.Keys(terms)) {
+		batch = append(batch, &model.GlossaryDefault{
+			TermKey: key, TermValue: terms[key], Description: descriptions[key], UpdatedAt: now,
+		})
+	}
+	if err := s.defaultRepo.SaveBatch(ctx, batch, req.Replace); err != nil {
+		return nil, errors.NewDatabaseError("import glossary defaults", err)
+	}
+	s.auditImport(ctx, "glossary_default", "", result, req.Replace)
+	return result, nil
+}
+
+func (s *glossaryService) ListSchoolTerms(ctx context.Context, schoolID string) ([]*GlossaryTermDTO, error) {
+	sid, err := parseGlossarySchoolID(schoolID)
+	if err != nil {
+		return nil, err
+	}
+	defaults, overrides, err := s.load(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+	keys := slices.Collect(maps.Keys(overrides))
+	for key := range defaults {
+		if _, ok := overrides[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	terms := make([]*GlossaryTermDTO, len(keys))
+	for i, key := range keys {
+		terms[i] = toGlossaryTermDTO(key, defaults[key], overrides)
+	}
+	return terms, nil
+}
+
+func (s *glossaryService) GetSchoolTerm(ctx context.Context, schoolID, termKey string) (*GlossaryTermDTO, error) {
+	sid, err := parseGlossarySchoolID(schoolID)
+	if err != nil {
+		return nil, err
+	}
+	defaults, overrides, err := s.load(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := overrides[termKey]; !ok && defaults[termKey] == nil {
+		return nil, errors.NewNotFoundError("glossary_term")
+	}
+	return toGlossaryTermDTO(termKey, defaults[termKey], overrides), nil
+}
+
+func (s *glossaryService) SaveSchoolTerm(ctx context.Context, schoolID, termKey string, req *SaveGlossaryTermRequest) (*GlossaryTermDTO, error) {
+	sid, err := parseGlossarySchoolID(schoolID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateGlossaryTerm(termKey, req.TermValue); err != nil {
+		return nil, err
+	}
+	defaults, overrides, err := s.load(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+	value := strings.TrimSpace(req.TermValue)
+	if err := s.conceptRepo.Save(ctx, sid, termKey, value); err != nil {
+		return nil, errors.NewDatabaseError("save glossary term", err)
+	}
+
+	action := "create"
+	metadata := map[string]interface{}{"school_id": schoolID, "term_key": termKey, "term_value": value}
+	if previous, ok := overrides[termKey]; ok {
+		action = "update"
+		metadata["previous_value"] = previous
+	}
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action:       action,
+		ResourceType: "glossary_term",
+		ResourceID:   schoolID + ":" + termKey,
+		Severity:     audit.SeverityInfo,
+		Category:     audit.CategoryAdmin,
+		Metadata:     metadata,
+	})
+	s.logger.Info("entity updated", "entity_type", "glossary_term", "entity_id", termKey, "school_id", schoolID)
+	overrides[termKey] = value
+	return toGlossaryTermDTO(termKey, defaults[termKey], overrides), nil
+}
+
+func (s *glossaryService) DeleteSchoolTerm(ctx context.Context, schoolID, termKey string) error {
+	sid, err := parseGlossarySchoolID(schoolID)
+	if err != nil {
+		return err
+	}
+	deleted, err := s.conceptRepo.Delete(ctx, sid, termKey)
+	if err != nil {
+		return errors.NewDatabaseError("delete glossary term", err)
+	}
+	if !deleted {
+		return errors.NewNotFoundError("glossary_term")
+	}
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action:       "delete",
+		ResourceType: "glossary_term",
+		ResourceID:   schoolID + ":" + termKey,
+		Severity:     audit.SeverityInfo,
+		Category:     audit.CategoryAdmin,
+		Metadata:     map[string]interface{}{"school_id": schoolID, "term_key": termKey},
+	})
+	s.logger.Info("entity deleted", "entity_type", "glossary_term", "entity_id", termKey, "school_id", schoolID)
+	return nil
+}
+
+func (s *glossaryService) ImportSchoolTerms(ctx context.Context, schoolID string, req *ImportGlossaryRequest) (*GlossaryImportResultDTO, error) {
+	sid, err := parseGlossarySchoolID(schoolID)
+	if err != nil {
+		return nil, err
+	}
+	terms, err := normalizeGlossaryImport(req.Terms)
+	if err != nil {
+		return nil, err
+	}
+	_, overrides, err := s.load(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+	result := diffGlossaryImport(overrides, terms, req.Replace)
+	if err := s.conceptRepo.SaveBatch(ctx, sid, terms, req.Replace); err != nil {
+		return nil, errors.NewDatabaseError("import glossary terms", err)
+	}
+	s.auditImport(ctx, "glossary_term", schoolID, result, req.Replace)
+	return result, nil
+}
+
+func (s *glossaryService) EffectiveGlossary(ctx context.Context, schoolID string) (map[string]string, error) {
+	var sid uuid.UUID
+	if schoolID != "" {
+		parsed, err := parseGlossarySchoolID(schoolID)
+		if err != nil {
+			return nil, err
+		}
+		sid = parsed
+	}
+	defaults, overrides, err := s.load(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+	glossary := make(map[string]string, len(defaults)+len(overrides))
+	for key, d := range defaults {
+		glossary[key] = d.TermValue
+	}
+	maps.Copy(glossary, overrides)
+	return glossary, nil
+}
+
+// load returns the platform defaults by key and the terms of schoolID. A nil
+// schoolID loads no school terms.
+func (s *glossaryService) load(ctx context.Context, schoolID uuid.UUID) (map[string]*model.GlossaryDefault, map[string]string, error) {
+	defaults, err := s.defaultRepo.List(ctx)
+	if err != nil {
+		return nil, nil, errors.NewDatabaseError("list glossary defaults", err)
+	}
+	byKey := make(map[string]*model.GlossaryDefault, len(defaults))
+	for _, d := range defaults {
+		byKey[d.TermKey] = d
+	}
+	overrides := make(map[string]string)
+	if schoolID == uuid.Nil {
+		return byKey, overrides, nil
+	}
+	concepts, err := s.conceptRepo.FindBySchoolID(ctx, schoolID)
+	if err != nil {
+		return nil, nil, errors.NewDatabaseError("list glossary terms", err)
+	}
+	for _, c := range concepts {
+		overrides[c.TermKey] = c.TermValue
+	}
+	return byKey, overrides, nil
+}
+
+func (s *glossaryService) auditImport(ctx context.Context, resourceType, schoolID string, result *GlossaryImportResultDTO, replace bool) {
+	metadata := map[string]interface{}{
+		"created": result.Created, "updated": result.Updated, "unchanged": result.Unchanged,
+		"removed": result.Removed, "replace": replace,
+	}
+	resourceID := "platform"
+	if schoolID != "" {
+		metadata["school_id"] = schoolID
+		resourceID = schoolID
+	}
+	severity := audit.SeverityInfo
+	if result.Removed > 0 {
+		severity = audit.SeverityWarning
+	}
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action:       "import",
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Severity:     severity,
+		Category:     audit.CategoryAdmin,
+		Metadata:     metadata,
+	})
+	s.logger.Info("glossary imported", "entity_type", resourceType, "school_id", schoolID,
+		"created", result.Created, "updated", result.Updated, "removed", result.Removed)
+}
+
+func parseGlossarySchoolID(schoolID string) (uuid.UUID, error) {
+	sid, err := uuid.Parse(schoolID)
+	if err != nil {
+		return uuid.Nil, errors.NewValidationError("invalid school_id")
+	}
+	return sid, nil
+}
+
+func validateGlossaryTerm(termKey, termValue string) error {
+	if !glossaryTermKeyPattern.MatchString(termKey) {
+		return errors.NewValidationError("term_key " + termKey + " must be lowercase letters, digits, '_' or '.' and start with a letter")
+	}
+	value := strings.TrimSpace(termValue)
+	if value == "" {
+		return errors.NewValidationError("term_value of " + termKey + " is required")
+	}
+	if utf8.RuneCountInString(value) > maxGlossaryTermValue {
+		return errors.NewValidationError("term_value of " + termKey + " is too long")
+	}
+	return nil
+}
+
+// normalizeGlossaryImport validates imported terms and trims their values
+func normalizeGlossaryImport(terms map[string]string) (map[string]string, error) {
+	if len(terms) > maxGlossaryImportTerms {
+		return nil, errors.NewValidationError(fmt.Sprintf("an import may set at most %d terms", maxGlossaryImportTerms))
+	}
+	normalized := make(map[string]string, len(terms))
+	for _, key := range slices.Sorted(maps.Keys(terms)) {
+		if err := validateGlossaryTerm(key, terms[key]); err != nil {
+			return nil, err
+		}
+		normalized[key] = strings.TrimSpace(terms[key])
+	}
+	return normalized, nil
+}
+
+// diffGlossaryImport counts what importing terms over current changes
+func diffGlossaryImport(current, terms map[string]string, replace bool) *GlossaryImportResultDTO {
+	result := &GlossaryImportResultDTO{}
+	for key, value := range terms {
+		previous, ok := current[key]
+		switch {
+		case !ok:
+			result.Created++
+		case previous != value:
+			result.Updated++
+		default:
+			result.Unchanged++
+		}
+	}
+	if replace {
+		for key := range current {
+			if _, ok := terms[key]; !ok {
+				result.Removed++
+			}
+		}
+	}
+	return result
+}
+
+func toGlossaryTermDTO(key string, def *model.GlossaryDefault, overrides map[string]string) *GlossaryTermDTO {
+	term := &GlossaryTermDTO{TermKey: key}
+	if def != nil {
+		value := def.TermValue
+		term.TermValue, term.DefaultValue, term.Description = value, &value, def.Description
+	}
+	if value, ok := overrides[key]; ok {
+		term.TermValue, term.Overridden = value, true
+	}
+	return term
+}
+
+func toGlossaryDefaultDTO(d *model.GlossaryDefault) *GlossaryDefaultDTO {
+	return &GlossaryDefaultDTO{TermKey: d.TermKey, TermValue: d.TermValue, Description: d.Description, UpdatedAt: d.UpdatedAt}
+}
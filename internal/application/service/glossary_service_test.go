@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	sharedErrors "github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+func newGlossaryService() (GlossaryService, *mockGlossaryDefaultRepo, *mockSchoolConceptRepo) {
+	defaults := &mockGlossaryDefaultRepo{terms: map[string]*model.GlossaryDefault{
+		"grade":   {TermKey: "grade", TermValue: "Grado"},
+		"student": {TermKey: "student", TermValue: "Estudiante"},
+	}}
+	concepts := &mockSchoolConceptRepo{}
+	return NewGlossaryService(defaults, concepts, &mockLogger{}, &mockAuditLogger{}), defaults, concepts
+}
+
+func TestGlossaryService_SchoolTerms(t *testing.T) {
+	ctx := context.Background()
+	schoolID := uuid.NewString()
+
+	t.Run("la escuela sobrescribe los términos por defecto", func(t *testing.T) {
+		svc, _, _ := newGlossaryService()
+		term, err := svc.SaveSchoolTerm(ctx, schoolID, "grade", &SaveGlossaryTermRequest{TermValue: " Año "})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if term.TermValue != "Año" || !term.Overridden || term.DefaultValue == nil || *term.DefaultValue != "Grado" {
+			t.Errorf("término incorrecto: %+v", term)
+		}
+		if _, err := svc.SaveSchoolTerm(ctx, schoolID, "campus", &SaveGlossaryTermRequest{TermValue: "Sede"}); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+
+		terms, err := svc.ListSchoolTerms(ctx, schoolID)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		want := []struct {
+			key, value string
+			overridden bool
+		}{{"campus", "Sede", true}, {"grade", "Año", true}, {"student", "Estudiante", false}}
+		if len(terms) != len(want) {
+			t.Fatalf("esperaba %d términos, obtuvo %d", len(want), len(terms))
+		}
+		for i, w := range want {
+			if terms[i].TermKey != w.key || terms[i].TermValue != w.value || terms[i].Overridden != w.overridden {
+				t.Errorf("término %d: esperaba %+v, obtuvo %+v", i, w, terms[i])
+			}
+		}
+
+		glossary, err := svc.EffectiveGlossary(ctx, schoolID)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if len(glossary) != 3 || glossary["grade"] != "Año" || glossary["student"] != "Estudiante" {
+			t.Errorf("glosario efectivo incorrecto: %v", glossary)
+		}
+		other, _ := svc.EffectiveGlossary(ctx, uuid.NewString())
+		if len(other) != 2 || other["grade"] != "Grado" {
+			t.Errorf("otra escuela debería ver los valores por defecto: %v", other)
+		}
+	})
+
+	t.Run("borrar el término vuelve al valor por defecto", func(t *testing.T) {
+		svc, _, _ := newGlossaryService()
+		if _, err := svc.SaveSchoolTerm(ctx, schoolID, "grade", &SaveGlossaryTermRequest{TermValue: "Año"}); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if err := svc.DeleteSchoolTerm(ctx, schoolID, "grade"); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		term, err := svc.GetSchoolTerm(ctx, schoolID, "grade")
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if term.TermValue != "Grado" || term.Overridden {
+			t.Errorf("esperaba el valor por defecto: %+v", term)
+		}
+
+		err = svc.DeleteSchoolTerm(ctx, schoolID, "grade")
+		assertAppError(t, err, sharedErrors.ErrorCodeNotFound)
+		_, err = svc.GetSchoolTerm(ctx, schoolID, "campus")
+		assertAppError(t, err, sharedErrors.ErrorCodeNotFound)
+	})
+
+	t.Run("valida escuela, clave y valor", func(t *testing.T) {
+		svc, _, _ := newGlossaryService()
+		_, err := svc.SaveSchoolTerm(ctx, "x", "grade", &SaveGlossaryTermRequest{TermValue: "Año"})
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+		_, err = svc.SaveSchoolTerm(ctx, schoolID, "Grade", &SaveGlossaryTermRequest{TermValue: "Año"})
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+		_, err = svc.SaveSchoolTerm(ctx, schoolID, "grade", &SaveGlossaryTermRequest{TermValue: "  "})
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	})
+}
+
+func TestGlossaryService_Import(t *testing.T) {
+	ctx := context.Background()
+	schoolID := uuid.NewString()
+
+	t.Run("importa términos de la escuela", func(t *testing.T) {
+		svc, _, _ := newGlossaryService()
+		if _, err := svc.SaveSchoolTerm(ctx, schoolID, "grade", &SaveGlossaryTermRequest{TermValue: "Año"}); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if _, err := svc.SaveSchoolTerm(ctx, schoolID, "teacher", &SaveGlossaryTermRequest{TermValue: "Profe"}); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+
+		result, err := svc.ImportSchoolTerms(ctx, schoolID, &ImportGlossaryRequest{
+			Terms: map[string]string{"grade": "Curso", "teacher": "Profe", "campus": "Sede"},
+		})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if *result != (GlossaryImportResultDTO{Created: 1, Updated: 1, Unchanged: 1}) {
+			t.Errorf("resultado incorrecto: %+v", result)
+		}
+
+		result, err = svc.ImportSchoolTerms(ctx, schoolID, &ImportGlossaryRequest{
+			Terms: map[string]string{"grade": "Curso"}, Replace: true,
+		})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if *result != (GlossaryImportResultDTO{Unchanged: 1, Removed: 2}) {
+			t.Errorf("resultado incorrecto: %+v", result)
+		}
+		glossary, _ := svc.EffectiveGlossary(ctx, schoolID)
+		if len(glossary) != 2 || glossary["grade"] != "Curso" {
+			t.Errorf("glosario incorrecto: %v", glossary)
+		}
+	})
+
+	t.Run("una importación inválida no cambia nada", func(t *testing.T) {
+		svc, _, concepts := newGlossaryService()
+		_, err := svc.ImportSchoolTerms(ctx, schoolID, &ImportGlossaryRequest{
+			Terms: map[string]string{"grade": "Curso", "bad key": "x"},
+		})
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+		if len(concepts.terms) != 0 {
+			t.Errorf("no debería guardar términos: %v", concepts.terms)
+		}
+	})
+
+	t.Run("importa los valores por defecto conservando descripciones", func(t *testing.T) {
+		svc, defaults, _ := newGlossaryService()
+		description := "Nivel académico"
+		if _, err := svc.SaveDefault(ctx, "grade", &SaveGlossaryDefaultRequest{TermValue: "Grado", Description: &description}); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		result, err := svc.ImportDefaults(ctx, &ImportGlossaryRequest{
+			Terms: map[string]string{"grade": "Nivel", "course": "Materia"}, Replace: true,
+		})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if *result != (GlossaryImportResultDTO{Created: 1, Updated: 1, Removed: 1}) {
+			t.Errorf("resultado incorrecto: %+v", result)
+		}
+		grade := defaults.terms["grade"]
+		if len(defaults.terms) != 2 || grade.TermValue != "Nivel" || grade.Description == nil || *grade.Description != description {
+			t.Errorf("valores por defecto incorrectos: %+v", defaults.terms)
+		}
+
+		err = svc.DeleteDefault(ctx, "student")
+		assertAppError(t, err, sharedErrors.ErrorCodeNotFound)
+	})
+}
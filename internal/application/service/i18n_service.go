@@ -0,0 +1,364 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// i18nKey marks a translatable value inside slot data or a template
+// definition: {"$i18n": {"es": "Alumnos", "en": "Students"}}
+const i18nKey = "$i18n"
+
+// LocaleSettings lists the locales served. Default is the fallback and the
+// language of untranslated names.
+type LocaleSettings struct {
+	Default   string
+	Supported []string
+}
+
+// NewLocaleSettings normalizes the configured locales; the default is always
+// supported
+func NewLocaleSettings(defaultLocale string, supported []string) LocaleSettings {
+	settings := LocaleSettings{Default: normalizeLocale(defaultLocale)}
+	for _, l := range supported {
+		if l = normalizeLocale(l); l != "" && !slices.Contains(settings.Supported, l) {
+			settings.Supported = append(settings.Supported, l)
+		}
+	}
+	if !slices.Contains(settings.Supported, settings.Default) {
+		settings.Supported = append([]string{settings.Default}, settings.Supported...)
+	}
+	return settings
+}
+
+// translatableFields lists the fields that accept translations, by entity type
+var translatableFields = map[string][]string{
+	model.TranslationEntityResource:       {model.TranslationFieldDisplayName},
+	model.TranslationEntityScreenInstance: {model.TranslationFieldName},
+}
+
+type LocalesDTO struct {
+	Default   string   `json:"default"`
+	Supported []string `json:"supported"`
+}
+
+// PreferencesDTO holds the user's saved preferences. A nil Locale means the
+// locale is negotiated from Accept-Language.
+type PreferencesDTO struct {
+	Locale *string `json:"locale"`
+}
+
+type UpdatePreferencesRequest struct {
+	Locale *string `json:"locale"`
+}
+
+// TranslationsDTO holds the translations of an entity as field → locale → value
+type TranslationsDTO struct {
+	EntityType   string                       `json:"entity_type"`
+	EntityID     string                       `json:"entity_id"`
+	Translations map[string]map[string]string `json:"translations"`
+}
+
+// SaveTranslationsRequest replaces every translation of an entity
+type SaveTranslationsRequest struct {
+	Translations map[string]map[string]string `json:"translations" binding:"required"`
+}
+
+// I18nService negotiates response locales and manages translations
+type I18nService interface {
+	Locales() *LocalesDTO
+	// ResolveLocale picks the response locale: the user's saved preference,
+	// else the best Accept-Language match, else the default
+	ResolveLocale(ctx context.Context, userID, acceptLanguage string) string
+	GetPreferences(ctx context.Context, userID string) (*PreferencesDTO, error)
+	UpdatePreferences(ctx context.Context, userID string, req *UpdatePreferencesRequest) (*PreferencesDTO, error)
+	GetTranslations(ctx context.Context, entityType, entityID string) (*TranslationsDTO, error)
+	SaveTranslations(ctx context.Context, entityType, entityID string, req *SaveTranslationsRequest) (*TranslationsDTO, error)
+}
+
+type i18nService struct {
+	translationRepo repository.TranslationRepository
+	preferenceRepo  repository.UserPreferenceRepository
+	resourceRepo    repository.ResourceRepository
+	instanceRepo    repository.ScreenInstanceRepository
+	locales         LocaleSettings
+	logger          logger.Logger
+}
+
+// NewI18nService creates a new i18n service
+func NewI18nService(
+	translationRepo repository.TranslationRepository,
+	preferenceRepo repository.UserPreferenceRepository,
+	resourceRepo repository.ResourceRepository,
+	instanceRepo repository.ScreenInstanceRepository,
+	locales LocaleSettings,
+	logger logger.Logger,
+) I18nService {
+	return &i18nService{
+		translationRepo: translationRepo, preferenceRepo: preferenceRepo,
+		resourceRepo: resourceRepo, instanceRepo: instanceRepo, locales: locales, logger: logger,
+	}
+}
+
+func (s *i18nService) Locales() *LocalesDTO {
+	return &LocalesDTO{Default: s.locales.Default, Supported: slices.Clone(s.locales.Supported)}
+}
+
+func (s *i18nService) ResolveLocale(ctx context.Context, userID, acceptLanguage string) string {
+	if uid, err := uuid.Parse(userID); err == nil {
+		pref, err := s.preferenceRepo.Get(ctx, uid)
+		if err != nil {
+			s.logger.Warn("i18n: error loading user preferences", "user_id", userID, "error", err)
+		} else if pref != nil && pref.Locale != nil && slices.Contains(s.locales.Supported, *pref.Locale) {
+			return *pref.Locale
+		}
+	}
+	return negotiateLocale(acceptLanguage, s.locales)
+}
+
+func (s *i18nService) GetPreferences(ctx context.Context, userID string) (*PreferencesDTO, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid user ID")
+	}
+	pref, err := s.preferenceRepo.Get(ctx, uid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get user preferences", err)
+	}
+	if pref == nil {
+		return &PreferencesDTO{}, nil
+	}
+	return &PreferencesDTO{Locale: pref.Locale}, nil
+}
+
+func (s *i18nService) UpdatePreferences(ctx context.Context, userID string, req *UpdatePreferencesRequest) (*PreferencesDTO, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid user ID")
+	}
+	pref := &model.UserPreference{UserID: uid, UpdatedAt: time.Now()}
+	if req.Locale != nil && *req.Locale != "" {
+		locale := normalizeLocale(*req.Locale)
+		if !slices.Contains(s.locales.Supported, locale) {
+			return nil, errors.NewValidationError("locale must be one of " + strings.Join(s.locales.Supported, ", "))
+		}
+		pref.Locale = &locale
+	}
+	if err := s.preferenceRepo.Save(ctx, pref); err != nil {
+		return nil, errors.NewDatabaseError("save user preferences", err)
+	}
+	s.logger.Info("entity updated", "entity_type", "user_preferences", "entity_id", userID)
+	return &PreferencesDTO{Locale: pref.Locale}, nil
+}
+
+func (s *i18nService) GetTranslations(ctx context.Context, entityType, entityID string) (*TranslationsDTO, error) {
+	id, err := s.findTranslatable(ctx, entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+	translations, err := s.translationRepo.ListForEntity(ctx, entityType, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list translations", err)
+	}
+	return toTranslationsDTO(entityType, id, translations), nil
+}
+
+func (s *i18nService) SaveTranslations(ctx context.Context, entityType, entityID string, req *SaveTranslationsRequest) (*TranslationsDTO, error) {
+	id, err := s.findTranslatable(ctx, entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var translations []*model.Translation
+	for field, values := range req.Translations {
+		if !slices.Contains(translatableFields[entityType], field) {
+			return nil, errors.NewValidationError("field " + strconv.Quote(field) + " is not translatable")
+		}
+		for locale, value := range values {
+			locale = normalizeLocale(locale)
+			if !slices.Contains(s.locales.Supported, locale) {
+				return nil, errors.NewValidationError("locale " + strconv.Quote(locale) + " is not supported")
+			}
+			if strings.TrimSpace(value) == "" {
+				continue
+			}
+			translations = append(translations, &model.Translation{
+				EntityType: entityType, EntityID: id, Field: field, Locale: locale, Value: value, UpdatedAt: now,
+			})
+		}
+	}
+	if err := s.translationRepo.ReplaceForEntity(ctx, entityType, id, translations); err != nil {
+		return nil, errors.NewDatabaseError("save translations", err)
+	}
+	s.logger.Info("entity updated", "entity_type", entityType+"_translations", "entity_id", entityID)
+	return toTranslationsDTO(entityType, id, translations), nil
+}
+
+// findTranslatable checks that the entity exists and returns its ID
+func (s *i18nService) findTranslatable(ctx context.Context, entityType, entityID string) (uuid.UUID, error) {
+	id, err := uuid.Parse(entityID)
+	if err != nil {
+		return uuid.Nil, errors.NewValidationError("invalid " + entityType + " ID")
+	}
+	var exists bool
+	switch entityType {
+	case model.TranslationEntityResource:
+		resource, err := s.resourceRepo.FindByID(ctx, id)
+		if err != nil {
+			return uuid.Nil, errors.NewDatabaseError("get resource", err)
+		}
+		exists = resource != nil
+	case model.TranslationEntityScreenInstance:
+		instance, err := s.instanceRepo.GetByID(ctx, id)
+		if err != nil {
+			return uuid.Nil, errors.NewDatabaseError("get screen instance", err)
+		}
+		exists = instance != nil
+	default:
+		return uuid.Nil, errors.NewValidationError("unknown entity type " + strconv.Quote(entityType))
+	}
+	if !exists {
+		return uuid.Nil, errors.NewNotFoundError(entityType)
+	}
+	return id, nil
+}
+
+// translatedValues returns the translations of a field in one locale by entity
+// ID. Lookup errors are logged and leave the names untranslated.
+func translatedValues(ctx context.Context, repo repository.TranslationRepository, log logger.Logger, entityType, field, locale string) map[uuid.UUID]string {
+	if repo == nil || locale == "" {
+		return nil
+	}
+	translations, err := repo.FindByLocale(ctx, entityType, field, locale)
+	if err != nil {
+		log.Warn("i18n: error loading translations", "entity_type", entityType, "locale", locale, "error", err)
+		return nil
+	}
+	values := make(map[uuid.UUID]string, len(translations))
+	for _, t := range translations {
+		values[t.EntityID] = t.Value
+	}
+	return values
+}
+
+// normalizeLocale lowercases a language tag and uses '-' as separator
+func normalizeLocale(locale string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-")
+}
+
+// negotiateLocale picks the supported locale that best matches an
+// Accept-Language header: by quality, exact tags before their base language
+// (pt-BR matches pt). Without a match it returns the default.
+func negotiateLocale(acceptLanguage string, locales LocaleSettings) string {
+	type candidate struct {
+		tag string
+		q   float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(part, ";")
+		tag := normalizeLocale(fields[0])
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			if v, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{tag, q})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	for _, c := range candidates {
+		if c.tag == "*" {
+			return locales.Default
+		}
+		if slices.Contains(locales.Supported, c.tag) {
+			return c.tag
+		}
+		if base, _, ok := strings.Cut(c.tag, "-"); ok && slices.Contains(locales.Supported, base) {
+			return base
+		}
+	}
+	return locales.Default
+}
+
+// localizeJSON replaces every {"$i18n": {...}} value of doc with its text in
+// locale, falling back to the base language, then fallback, then the first
+// locale available. Documents without translatable values are returned as is.
+func localizeJSON(doc json.RawMessage, locale, fallback string) (json.RawMessage, error) {
+	if !bytes.Contains(doc, []byte(`"`+i18nKey+`"`)) {
+		return doc, nil
+	}
+	v, err := decodeJSONValue(doc)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(localizeValue(v, locale, fallback))
+}
+
+func localizeValue(v any, locale, fallback string) any {
+	switch tv := v.(type) {
+	case map[string]any:
+		if values, ok := tv[i18nKey].(map[string]any); ok && len(tv) == 1 {
+			return pickTranslation(values, locale, fallback)
+		}
+		for k, child := range tv {
+			tv[k] = localizeValue(child, locale, fallback)
+		}
+		return tv
+	case []any:
+		for i, child := range tv {
+			tv[i] = localizeValue(child, locale, fallback)
+		}
+		return tv
+	default:
+		return v
+	}
+}
+
+func pickTranslation(values map[string]any, locale, fallback string) any {
+	base, _, _ := strings.Cut(locale, "-")
+	for _, l := range []string{locale, base, fallback} {
+		if text, ok := values[l]; ok {
+			return text
+		}
+	}
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	slices.Sort(keys)
+	return values[keys[0]]
+}
+
+func toTranslationsDTO(entityType string, id uuid.UUID, translations []*model.Translation) *TranslationsDTO {
+	result := &TranslationsDTO{EntityType: entityType, EntityID: id.String(), Translations: map[string]map[string]string{}}
+	for _, t := range translations {
+		if result.Translations[t.Field] == nil {
+			result.Translations[t.Field] = map[string]string{}
+		}
+		result.Translations[t.Field][t.Locale] = t.Value
+	}
+	return result
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedErrors "github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+func TestNegotiateLocale(t *testing.T) {
+	locales := NewLocaleSettings("es", []string{"es", "en", "pt"})
+	cases := map[string]string{
+		"":                             "es",
+		"en":                           "en",
+		"en-US,en;q=0.9":               "en",
+		"pt-BR":                        "pt",
+		"fr, en;q=0.5, pt;q=0.8":       "pt",
+		"de":                           "es",
+		"*":                            "es",
+		"en;q=0, pt;q=0.1":             "pt",
+		"EN_gb":                        "en",
+		"en;q=0.2, fr;q=1, es;q=0.3 ":  "es",
+		" pt ; q=0.7 , en ; q=0.9 , x": "en",
+	}
+	for header, want := range cases {
+		if got := negotiateLocale(header, locales); got != want {
+			t.Errorf("%q: esperaba %s, obtuvo %s", header, want, got)
+		}
+	}
+}
+
+func TestLocalizeJSON(t *testing.T) {
+	cases := []struct{ name, doc, locale, want string }{
+		{"sin textos traducibles", `{"title": "Alumnos"}`, "en", `{"title": "Alumnos"}`},
+		{"elige el locale", `{"title":{"$i18n":{"es":"Alumnos","en":"Students"}}}`, "en", `{"title":"Students"}`},
+		{"usa el idioma base", `{"title":{"$i18n":{"es":"Alumnos","pt":"Alunos"}}}`, "pt-br", `{"title":"Alunos"}`},
+		{"usa el locale por defecto", `{"title":{"$i18n":{"es":"Alumnos","en":"Students"}}}`, "pt", `{"title":"Alumnos"}`},
+		{"usa el primer locale disponible", `{"title":{"$i18n":{"pt":"Alunos","en":"Students"}}}`, "fr", `{"title":"Students"}`},
+		{"traduce dentro de arrays", `{"columns":[{"label":{"$i18n":{"es":"Nombre","en":"Name"}}}]}`, "en", `{"columns":[{"label":"Name"}]}`},
+		{"respeta objetos con otras claves", `{"a":{"$i18n":{"en":"x"},"b":1}}`, "en", `{"a":{"$i18n":{"en":"x"},"b":1}}`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := localizeJSON(json.RawMessage(tc.doc), tc.locale, "es")
+			if err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("esperaba %s, obtuvo %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func newI18nService(resources map[uuid.UUID]*entities.Resource) (I18nService, *mockTranslationRepo, *mockUserPreferenceRepo) {
+	translations := &mockTranslationRepo{}
+	prefs := &mockUserPreferenceRepo{}
+	resourceRepo := &mockResourceRepo{
+		findByIDFn: func(_ context.Context, id uuid.UUID) (*entities.Resource, error) { return resources[id], nil },
+	}
+	svc := NewI18nService(translations, prefs, resourceRepo, &mockScreenInstanceRepo{},
+		NewLocaleSettings("es", []string{"es", "en", "pt"}), &mockLogger{})
+	return svc, translations, prefs
+}
+
+func TestI18nService_ResolveLocale(t *testing.T) {
+	ctx := context.Background()
+	svc, _, _ := newI18nService(nil)
+	userID := uuid.NewString()
+
+	if got := svc.ResolveLocale(ctx, userID, "en-US"); got != "en" {
+		t.Errorf("sin preferencia debería negociar Accept-Language, obtuvo %s", got)
+	}
+	pt := "PT"
+	if _, err := svc.UpdatePreferences(ctx, userID, &UpdatePreferencesRequest{Locale: &pt}); err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if got := svc.ResolveLocale(ctx, userID, "en-US"); got != "pt" {
+		t.Errorf("la preferencia debería ganar a Accept-Language, obtuvo %s", got)
+	}
+	if _, err := svc.UpdatePreferences(ctx, userID, &UpdatePreferencesRequest{}); err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if got := svc.ResolveLocale(ctx, userID, "en-US"); got != "en" {
+		t.Errorf("al borrar la preferencia debería negociar, obtuvo %s", got)
+	}
+
+	fr := "fr"
+	_, err := svc.UpdatePreferences(ctx, userID, &UpdatePreferencesRequest{Locale: &fr})
+	assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+}
+
+func TestI18nService_SaveTranslations(t *testing.T) {
+	ctx := context.Background()
+	resourceID := uuid.New()
+	svc, _, _ := newI18nService(map[uuid.UUID]*entities.Resource{resourceID: {ID: resourceID, Key: "dashboard"}})
+
+	t.Run("reemplaza las traducciones", func(t *testing.T) {
+		if _, err := svc.SaveTranslations(ctx, model.TranslationEntityResource, resourceID.String(), &SaveTranslationsRequest{
+			Translations: map[string]map[string]string{"display_name": {"en": "Dashboard", "pt": "Painel"}},
+		}); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		saved, err := svc.SaveTranslations(ctx, model.TranslationEntityResource, resourceID.String(), &SaveTranslationsRequest{
+			Translations: map[string]map[string]string{"display_name": {"EN": "Home", "pt": " "}},
+		})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		got, err := svc.GetTranslations(ctx, model.TranslationEntityResource, resourceID.String())
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		names := got.Translations["display_name"]
+		if len(names) != 1 || names["en"] != "Home" || saved.Translations["display_name"]["en"] != "Home" {
+			t.Errorf("traducciones incorrectas: %+v", got.Translations)
+		}
+	})
+
+	t.Run("valida campo, locale y entidad", func(t *testing.T) {
+		_, err := svc.SaveTranslations(ctx, model.TranslationEntityResource, resourceID.String(), &SaveTranslationsRequest{
+			Translations: map[string]map[string]string{"icon": {"en": "x"}},
+		})
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+		_, err = svc.SaveTranslations(ctx, model.TranslationEntityResource, resourceID.String(), &SaveTranslationsRequest{
+			Translations: map[string]map[string]string{"display_name": {"fr": "x"}},
+		})
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+		_, err = svc.GetTranslations(ctx, model.TranslationEntityResource, uuid.NewString())
+		assertAppError(t, err, sharedErrors.ErrorCodeNotFound)
+		_, err = svc.GetTranslations(ctx, "role", resourceID.String())
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	})
+}
+
+func TestScreenConfigService_ResolveLocalized(t *testing.T) {
+	ctx := context.Background()
+	svc, _, _ := newVersionedScreenService()
+	_, inst := newPublishedScreen(t, svc, "students-list", `{"title":{"$i18n":{"es":"Alumnos","en":"Students"}}}`)
+	translations := svc.(*screenConfigService).translationRepo.(*mockTranslationRepo)
+	translations.translations = []*model.Translation{{
+		EntityType: model.TranslationEntityScreenInstance, EntityID: uuid.MustParse(inst.ID),
+		Field: model.TranslationFieldName, Locale: "en", Value: "Students",
+	}}
+
+	cases := []struct{ locale, name, slot string }{
+		{"en", "Students", `{"title":"Students"}`},
+		{"", "Alumnos", `{"title":"Alumnos"}`},
+		{"pt", "Alumnos", `{"title":"Alumnos"}`},
+	}
+	for _, tc := range cases {
+		resolved, err := svc.ResolveScreenByKey(ctx, "students-list", ResolveOptions{Locale: tc.locale})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if resolved.ScreenName != tc.name || string(resolved.SlotData) != tc.slot {
+			t.Errorf("locale %q: obtuvo %s %s", tc.locale, resolved.ScreenName, resolved.SlotData)
+		}
+	}
+
+	all, err := svc.ResolveAllScreens(ctx, ResolveOptions{Locale: "en"})
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if len(all) != 1 || all[0].ScreenName != "Students" || string(all[0].SlotData) != `{"title":"Students"}` {
+		t.Errorf("el bundle debería estar traducido: %+v", all)
+	}
+}
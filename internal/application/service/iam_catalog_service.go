@@ -0,0 +1,651 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// Plan change kinds and operations
+const (
+	IAMKindResource       = "resource"
+	IAMKindPermission     = "permission"
+	IAMKindRole           = "role"
+	IAMKindRolePermission = "role_permissions"
+
+	IAMOpCreate     = "create"
+	IAMOpUpdate     = "update"
+	IAMOpDeactivate = "deactivate"
+	IAMOpRelink     = "relink"
+)
+
+// IAMCatalogService exports and reconciles the IAM catalog (resources, permissions,
+// roles and role-permission links) from a declarative manifest.
+type IAMCatalogService interface {
+	Export(ctx context.Context) (*dto.IAMManifest, error)
+	Apply(ctx context.Context, manifest *dto.IAMManifest, dryRun, prune bool) (*dto.IAMApplyResponse, error)
+}
+
+type iamCatalogService struct {
+	catalogRepo repository.IAMCatalogRepository
+	sod         SoDService
+	logger      logger.Logger
+	auditLogger audit.AuditLogger
+}
+
+// NewIAMCatalogService creates a new IAM catalog service. sod may be nil to
+// skip separation-of-duties checks on the role permissions a manifest sets.
+func NewIAMCatalogService(catalogRepo repository.IAMCatalogRepository, sod SoDService, logger logger.Logger, auditLogger audit.AuditLogger) IAMCatalogService {
+	return &iamCatalogService{catalogRepo: catalogRepo, sod: sod, logger: logger, auditLogger: auditLogger}
+}
+
+// ParseIAMManifest decodes a manifest from YAML or JSON. When format is empty it is
+// detected from the content. Unknown fields are rejected to catch typos early.
+func ParseIAMManifest(data []byte, format string) (*dto.IAMManifest, error) {
+	if format == "" {
+		format = "yaml"
+		if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+			format = "json"
+		}
+	}
+	var m dto.IAMManifest
+	switch format {
+	case "json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&m); err != nil {
+			return nil, errors.NewValidationError("invalid JSON manifest: " + err.Error())
+		}
+	case "yaml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(&m); err != nil {
+			return nil, errors.NewValidationError("invalid YAML manifest: " + err.Error())
+		}
+	default:
+		return nil, errors.NewValidationError("manifest format must be yaml or json")
+	}
+	return &m, nil
+}
+
+// MarshalIAMManifest encodes a manifest as YAML or JSON
+func MarshalIAMManifest(m *dto.IAMManifest, format string) ([]byte, error) {
+	switch format {
+	case "json":
+		return json.MarshalIndent(m, "", "  ")
+	case "yaml", "":
+		return yaml.Marshal(m)
+	default:
+		return nil, errors.NewValidationError("manifest format must be yaml or json")
+	}
+}
+
+func (s *iamCatalogService) Export(ctx context.Context) (*dto.IAMManifest, error) {
+	snap, err := s.catalogRepo.Snapshot(ctx)
+	if err != nil {
+		return nil, errors.NewDatabaseError("load iam catalog", err)
+	}
+
+	resourceKeys := make(map[uuid.UUID]string, len(snap.Resources))
+	resourcesByID := make(map[uuid.UUID]*entities.Resource, len(snap.Resources))
+	for _, r := range snap.Resources {
+		resourceKeys[r.ID] = r.Key
+		resourcesByID[r.ID] = r
+	}
+
+	// An active resource or permission may hang from an inactive resource. The
+	// manifest only resolves references it declares, so those resources are
+	// exported too (and reactivated if the manifest is applied).
+	exported := make(map[uuid.UUID]bool, len(snap.Resources))
+	var include func(id uuid.UUID)
+	include = func(id uuid.UUID) {
+		r, ok := resourcesByID[id]
+		if !ok || exported[id] {
+			return
+		}
+		exported[id] = true
+		if r.ParentID != nil {
+			include(*r.ParentID)
+		}
+	}
+	for _, r := range snap.Resources {
+		if r.IsActive {
+			include(r.ID)
+		}
+	}
+	for _, p := range snap.Permissions {
+		if p.IsActive {
+			include(p.ResourceID)
+		}
+	}
+	permsByRole, _ := roleLinks(snap, nil)
+
+	m := &dto.IAMManifest{
+		Version:     dto.IAMManifestVersion,
+		Resources:   []dto.IAMManifestResource{},
+		Permissions: []dto.IAMManifestPermission{},
+		Roles:       []dto.IAMManifestRole{},
+	}
+	for _, r := range snap.Resources {
+		if !exported[r.ID] {
+			continue
+		}
+		mr := dto.IAMManifestResource{
+			Key:           r.Key,
+			DisplayName:   r.DisplayName,
+			Description:   derefString(r.Description),
+			Icon:          derefString(r.Icon),
+			SortOrder:     r.SortOrder,
+			IsMenuVisible: r.IsMenuVisible,
+			Scope:         r.Scope,
+		}
+		if r.ParentID != nil {
+			mr.Parent = resourceKeys[*r.ParentID]
+		}
+		m.Resources = append(m.Resources, mr)
+	}
+	for _, p := range snap.Permissions {
+		if !p.IsActive {
+			continue
+		}
+		m.Permissions = append(m.Permissions, dto.IAMManifestPermission{
+			Name:        p.Name,
+			DisplayName: p.DisplayName,
+			Description: derefString(p.Description),
+			Resource:    resourceKeys[p.ResourceID],
+			Action:      p.Action,
+			Scope:       p.Scope,
+		})
+	}
+	for _, r := range snap.Roles {
+		if !r.IsActive {
+			continue
+		}
+		perms := permsByRole[r.ID]
+		if perms == nil {
+			perms = []string{}
+		}
+		sort.Strings(perms)
+		m.Roles = append(m.Roles, dto.IAMManifestRole{
+			Name:        r.Name,
+			DisplayName: r.DisplayName,
+			Description: derefString(r.Description),
+			Scope:       r.Scope,
+			Permissions: perms,
+		})
+	}
+
+	sort.Slice(m.Resources, func(i, j int) bool { return m.Resources[i].Key < m.Resources[j].Key })
+	return m, nil
+}
+
+func (s *iamCatalogService) Apply(ctx context.Context, manifest *dto.IAMManifest, dryRun, prune bool) (*dto.IAMApplyResponse, error) {
+	if err := validateIAMManifest(manifest); err != nil {
+		return nil, err
+	}
+
+	// The plan is computed inside the transaction that applies it, against
+	// the catalog as it is once concurrent applies have finished
+	var resp *dto.IAMApplyResponse
+	var checkErr error
+	err := s.catalogRepo.Reconcile(ctx, func(snap *repository.IAMCatalogSnapshot) (*repository.IAMCatalogChangeSet, error) {
+		changes, plan := planIAMCatalog(manifest, snap, prune, time.Now())
+		resp = &dto.IAMApplyResponse{DryRun: dryRun, Changes: plan}
+		for _, c := range plan {
+			switch c.Op {
+			case IAMOpCreate:
+				resp.Summary.Create++
+			case IAMOpUpdate:
+				resp.Summary.Update++
+			case IAMOpDeactivate:
+				resp.Summary.Deactivate++
+			case IAMOpRelink:
+				resp.Summary.Relink++
+			}
+		}
+		if checkErr = s.checkRolePermissions(ctx, changes.RolePermissions); checkErr != nil {
+			return nil, nil
+		}
+		if dryRun || len(plan) == 0 {
+			return nil, nil
+		}
+		return changes, nil
+	})
+	if err != nil {
+		return nil, errors.NewDatabaseError("apply iam manifest", err)
+	}
+	if checkErr != nil {
+		return nil, checkErr
+	}
+	if dryRun || len(resp.Changes) == 0 {
+		return resp, nil
+	}
+	resp.Applied = true
+
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action:       "apply",
+		ResourceType: "iam_catalog",
+		Severity:     audit.SeverityCritical,
+		Category:     audit.CategoryAdmin,
+		Metadata: map[string]interface{}{
+			"create":     resp.Summary.Create,
+			"update":     resp.Summary.Update,
+			"deactivate": resp.Summary.Deactivate,
+			"relink":     resp.Summary.Relink,
+			"prune":      prune,
+		},
+	})
+	s.logger.Info("iam manifest applied", "create", resp.Summary.Create, "update", resp.Summary.Update, "deactivate", resp.Summary.Deactivate, "relink", resp.Summary.Relink)
+	return resp, nil
+}
+
+// checkRolePermissions runs the separation-of-duties check over every role
+// whose permission set the change set replaces, in a stable order
+func (s *iamCatalogService) checkRolePermissions(ctx context.Context, rolePermissions map[uuid.UUID][]uuid.UUID) error {
+	if s.sod == nil {
+		return nil
+	}
+	roleIDs := make([]uuid.UUID, 0, len(rolePermissions))
+	for id := range rolePermissions {
+		roleIDs = append(roleIDs, id)
+	}
+	sort.Slice(roleIDs, func(i, j int) bool { return roleIDs[i].String() < roleIDs[j].String() })
+	for _, id := range roleIDs {
+		if err := s.sod.CheckRolePermissions(ctx, id, rolePermissions[id]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateIAMManifest checks the manifest is self-consistent: unique natural keys,
+// valid scopes, and references (parent, resource, role permissions) that resolve
+// inside the manifest itself.
+func validateIAMManifest(m *dto.IAMManifest) error {
+	if m == nil {
+		return errors.NewValidationError("manifest is required")
+	}
+	if m.Version > dto.IAMManifestVersion {
+		return errors.NewValidationError(fmt.Sprintf("unsupported manifest version %d", m.Version))
+	}
+
+	resources := make(map[string]dto.IAMManifestResource, len(m.Resources))
+	for i, r := range m.Resources {
+		if r.Key == "" || r.DisplayName == "" {
+			return errors.NewValidationError(fmt.Sprintf("resources[%d]: key and display_name are required", i))
+		}
+		if _, dup := resources[r.Key]; dup {
+			return errors.NewValidationError("duplicate resource key: " + r.Key)
+		}
+		if !validScopes[r.Scope] {
+			return errors.NewValidationError(fmt.Sprintf("resource %s: invalid scope %q", r.Key, r.Scope))
+		}
+		resources[r.Key] = r
+	}
+	for _, r := range m.Resources {
+		seen := map[string]bool{r.Key: true}
+		for parent := r.Parent; parent != ""; parent = resources[parent].Parent {
+			if _, ok := resources[parent]; !ok {
+				return errors.NewValidationError(fmt.Sprintf("resource %s: unknown parent %s", r.Key, parent))
+			}
+			if seen[parent] {
+				return errors.NewValidationError(fmt.Sprintf("resource %s: parent cycle detected", r.Key))
+			}
+			seen[parent] = true
+		}
+	}
+
+	permissions := make(map[string]bool, len(m.Permissions))
+	for i, p := range m.Permissions {
+		if p.Name == "" || p.DisplayName == "" || p.Action == "" {
+			return errors.NewValidationError(fmt.Sprintf("permissions[%d]: name, display_name and action are required", i))
+		}
+		if permissions[p.Name] {
+			return errors.NewValidationError("duplicate permission name: " + p.Name)
+		}
+		if _, ok := resources[p.Resource]; !ok {
+			return errors.NewValidationError(fmt.Sprintf("permission %s: unknown resource %s", p.Name, p.Resource))
+		}
+		if !validScopes[p.Scope] {
+			return errors.NewValidationError(fmt.Sprintf("permission %s: invalid scope %q", p.Name, p.Scope))
+		}
+		permissions[p.Name] = true
+	}
+
+	roles := make(map[string]bool, len(m.Roles))
+	for i, r := range m.Roles {
+		if r.Name == "" || r.DisplayName == "" {
+			return errors.NewValidationError(fmt.Sprintf("roles[%d]: name and display_name are required", i))
+		}
+		if roles[r.Name] {
+			return errors.NewValidationError("duplicate role name: " + r.Name)
+		}
+		if !validScopes[r.Scope] {
+			return errors.NewValidationError(fmt.Sprintf("role %s: invalid scope %q", r.Name, r.Scope))
+		}
+		for _, p := range r.Permissions {
+			if !permissions[p] {
+				return errors.NewValidationError(fmt.Sprintf("role %s: unknown permission %s", r.Name, p))
+			}
+		}
+		roles[r.Name] = true
+	}
+	return nil
+}
+
+// planIAMCatalog diffs a validated manifest against the current catalog and returns
+// the change set to persist along with a human-readable plan. Entities are matched
+// by natural key; inactive rows are reactivated rather than duplicated.
+func planIAMCatalog(m *dto.IAMManifest, snap *repository.IAMCatalogSnapshot, prune bool, now time.Time) (*repository.IAMCatalogChangeSet, []dto.IAMPlanChange) {
+	changes := &repository.IAMCatalogChangeSet{RolePermissions: make(map[uuid.UUID][]uuid.UUID)}
+	plan := []dto.IAMPlanChange{}
+
+	// Resources, parent-first so parent IDs are known before children are planned.
+	existingResources := make(map[string]*entities.Resource, len(snap.Resources))
+	for _, r := range snap.Resources {
+		existingResources[r.Key] = r
+	}
+	resourceIDs := make(map[string]uuid.UUID, len(m.Resources))
+	for _, mr := range orderResourcesParentFirst(m.Resources) {
+		var parentID *uuid.UUID
+		if mr.Parent != "" {
+			pid := resourceIDs[mr.Parent]
+			parentID = &pid
+		}
+		current, exists := existingResources[mr.Key]
+		if !exists {
+			res := &entities.Resource{
+				ID:            uuid.New(),
+				Key:           mr.Key,
+				DisplayName:   mr.DisplayName,
+				Description:   optionalString(mr.Description),
+				Icon:          optionalString(mr.Icon),
+				ParentID:      parentID,
+				SortOrder:     mr.SortOrder,
+				IsMenuVisible: mr.IsMenuVisible,
+				Scope:         mr.Scope,
+				IsActive:      true,
+				CreatedAt:     now,
+				UpdatedAt:     now,
+			}
+			resourceIDs[mr.Key] = res.ID
+			changes.UpsertResources = append(changes.UpsertResources, res)
+			plan = append(plan, dto.IAMPlanChange{Kind: IAMKindResource, Key: mr.Key, Op: IAMOpCreate})
+			continue
+		}
+		resourceIDs[mr.Key] = current.ID
+		var fields []string
+		fields = appendIfChanged(fields, "display_name", current.DisplayName != mr.DisplayName)
+		fields = appendIfChanged(fields, "description", derefString(current.Description) != mr.Description)
+		fields = appendIfChanged(fields, "icon", derefString(current.Icon) != mr.Icon)
+		fields = appendIfChanged(fields, "parent", !sameUUIDPtr(current.ParentID, parentID))
+		fields = appendIfChanged(fields, "sort_order", current.SortOrder != mr.SortOrder)
+		fields = appendIfChanged(fields, "is_menu_visible", current.IsMenuVisible != mr.IsMenuVisible)
+		fields = appendIfChanged(fields, "scope", current.Scope != mr.Scope)
+		fields = appendIfChanged(fields, "is_active", !current.IsActive)
+		if len(fields) == 0 {
+			continue
+		}
+		res := *current
+		res.DisplayName = mr.DisplayName
+		res.Description = optionalString(mr.Description)
+		res.Icon = optionalString(mr.Icon)
+		res.ParentID = parentID
+		res.SortOrder = mr.SortOrder
+		res.IsMenuVisible = mr.IsMenuVisible
+		res.Scope = mr.Scope
+		res.IsActive = true
+		res.UpdatedAt = now
+		changes.UpsertResources = append(changes.UpsertResources, &res)
+		plan = append(plan, dto.IAMPlanChange{Kind: IAMKindResource, Key: mr.Key, Op: IAMOpUpdate, Fields: fields})
+	}
+
+	// Permissions
+	existingPerms := make(map[string]*entities.Permission, len(snap.Permissions))
+	for _, p := range snap.Permissions {
+		existingPerms[p.Name] = p
+	}
+	permIDs := make(map[string]uuid.UUID, len(m.Permissions))
+	for _, mp := range m.Permissions {
+		resourceID := resourceIDs[mp.Resource]
+		current, exists := existingPerms[mp.Name]
+		if !exists {
+			perm := &entities.Permission{
+				ID:          uuid.New(),
+				Name:        mp.Name,
+				DisplayName: mp.DisplayName,
+				Description: optionalString(mp.Description),
+				ResourceID:  resourceID,
+				Action:      mp.Action,
+				Scope:       mp.Scope,
+				IsActive:    true,
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			}
+			permIDs[mp.Name] = perm.ID
+			changes.UpsertPermissions = append(changes.UpsertPermissions, perm)
+			plan = append(plan, dto.IAMPlanChange{Kind: IAMKindPermission, Key: mp.Name, Op: IAMOpCreate})
+			continue
+		}
+		permIDs[mp.Name] = current.ID
+		var fields []string
+		fields = appendIfChanged(fields, "display_name", current.DisplayName != mp.DisplayName)
+		fields = appendIfChanged(fields, "description", derefString(current.Description) != mp.Description)
+		fields = appendIfChanged(fields, "resource", current.ResourceID != resourceID)
+		fields = appendIfChanged(fields, "action", current.Action != mp.Action)
+		fields = appendIfChanged(fields, "scope", current.Scope != mp.Scope)
+		fields = appendIfChanged(fields, "is_active", !current.IsActive)
+		if len(fields) == 0 {
+			continue
+		}
+		perm := *current
+		perm.DisplayName = mp.DisplayName
+		perm.Description = optionalString(mp.Description)
+		perm.ResourceID = resourceID
+		perm.Action = mp.Action
+		perm.Scope = mp.Scope
+		perm.IsActive = true
+		perm.UpdatedAt = now
+		changes.UpsertPermissions = append(changes.UpsertPermissions, &perm)
+		plan = append(plan, dto.IAMPlanChange{Kind: IAMKindPermission, Key: mp.Name, Op: IAMOpUpdate, Fields: fields})
+	}
+
+	// Roles and their permission sets
+	existingRoles := make(map[string]*entities.Role, len(snap.Roles))
+	for _, r := range snap.Roles {
+		existingRoles[r.Name] = r
+	}
+	declaredPerms := make(map[string]bool, len(m.Permissions))
+	for _, mp := range m.Permissions {
+		declaredPerms[mp.Name] = true
+	}
+	currentLinks, retainedLinks := roleLinks(snap, declaredPerms)
+	for _, mr := range m.Roles {
+		current, exists := existingRoles[mr.Name]
+		var roleID uuid.UUID
+		if !exists {
+			role := &entities.Role{
+				ID:          uuid.New(),
+				Name:        mr.Name,
+				DisplayName: mr.DisplayName,
+				Description: optionalString(mr.Description),
+				Scope:       mr.Scope,
+				IsActive:    true,
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			}
+			roleID = role.ID
+			changes.UpsertRoles = append(changes.UpsertRoles, role)
+			plan = append(plan, dto.IAMPlanChange{Kind: IAMKindRole, Key: mr.Name, Op: IAMOpCreate})
+		} else {
+			roleID = current.ID
+			var fields []string
+			fields = appendIfChanged(fields, "display_name", current.DisplayName != mr.DisplayName)
+			fields = appendIfChanged(fields, "description", derefString(current.Description) != mr.Description)
+			fields = appendIfChanged(fields, "scope", current.Scope != mr.Scope)
+			fields = appendIfChanged(fields, "is_active", !current.IsActive)
+			if len(fields) > 0 {
+				role := *current
+				role.DisplayName = mr.DisplayName
+				role.Description = optionalString(mr.Description)
+				role.Scope = mr.Scope
+				role.IsActive = true
+				role.UpdatedAt = now
+				changes.UpsertRoles = append(changes.UpsertRoles, &role)
+				plan = append(plan, dto.IAMPlanChange{Kind: IAMKindRole, Key: mr.Name, Op: IAMOpUpdate, Fields: fields})
+			}
+		}
+
+		desired := slices.Compact(slices.Sorted(slices.Values(mr.Permissions)))
+		have := slices.Compact(slices.Sorted(slices.Values(currentLinks[roleID])))
+		added, removed := diffStringSets(have, desired)
+		if len(added) == 0 && len(removed) == 0 {
+			continue
+		}
+		ids := make([]uuid.UUID, len(desired))
+		for i, name := range desired {
+			ids[i] = permIDs[name]
+		}
+		// the set is replaced, so links to inactive permissions are carried over
+		changes.RolePermissions[roleID] = append(ids, retainedLinks[roleID]...)
+		plan = append(plan, dto.IAMPlanChange{Kind: IAMKindRolePermission, Key: mr.Name, Op: IAMOpRelink, Added: added, Removed: removed})
+	}
+
+	if prune {
+		declaredResources := make(map[string]bool, len(m.Resources))
+		for _, r := range m.Resources {
+			declaredResources[r.Key] = true
+		}
+		declaredRoles := make(map[string]bool, len(m.Roles))
+		for _, r := range m.Roles {
+			declaredRoles[r.Name] = true
+		}
+		for _, r := range snap.Roles {
+			if r.IsActive && !declaredRoles[r.Name] {
+				changes.DeactivateRoles = append(changes.DeactivateRoles, r.ID)
+				plan = append(plan, dto.IAMPlanChange{Kind: IAMKindRole, Key: r.Name, Op: IAMOpDeactivate})
+			}
+		}
+		for _, p := range snap.Permissions {
+			if p.IsActive && !declaredPerms[p.Name] {
+				changes.DeactivatePermissions = append(changes.DeactivatePermissions, p.ID)
+				plan = append(plan, dto.IAMPlanChange{Kind: IAMKindPermission, Key: p.Name, Op: IAMOpDeactivate})
+			}
+		}
+		for _, r := range snap.Resources {
+			if r.IsActive && !declaredResources[r.Key] {
+				changes.DeactivateResources = append(changes.DeactivateResources, r.ID)
+				plan = append(plan, dto.IAMPlanChange{Kind: IAMKindResource, Key: r.Key, Op: IAMOpDeactivate})
+			}
+		}
+	}
+
+	return changes, plan
+}
+
+// orderResourcesParentFirst sorts manifest resources so every parent precedes its children.
+// roleLinks returns, per role, the names of the linked permissions that are
+// active or declared in the manifest, and the IDs of the other links. Export
+// and the apply plan both read links through it, so links to inactive
+// permissions are neither exported nor planned for removal.
+func roleLinks(snap *repository.IAMCatalogSnapshot, declared map[string]bool) (map[uuid.UUID][]string, map[uuid.UUID][]uuid.UUID) {
+	permsByID := make(map[uuid.UUID]*entities.Permission, len(snap.Permissions))
+	for _, p := range snap.Permissions {
+		permsByID[p.ID] = p
+	}
+	names := make(map[uuid.UUID][]string)
+	retained := make(map[uuid.UUID][]uuid.UUID)
+	for _, rp := range snap.RolePermissions {
+		p, ok := permsByID[rp.PermissionID]
+		if !ok {
+			continue
+		}
+		if p.IsActive || declared[p.Name] {
+			names[rp.RoleID] = append(names[rp.RoleID], p.Name)
+		} else {
+			retained[rp.RoleID] = append(retained[rp.RoleID], rp.PermissionID)
+		}
+	}
+	return names, retained
+}
+
+func orderResourcesParentFirst(resources []dto.IAMManifestResource) []dto.IAMManifestResource {
+	byKey := make(map[string]dto.IAMManifestResource, len(resources))
+	for _, r := range resources {
+		byKey[r.Key] = r
+	}
+	ordered := make([]dto.IAMManifestResource, 0, len(resources))
+	visited := make(map[string]bool, len(resources))
+	var visit func(r dto.IAMManifestResource)
+	visit = func(r dto.IAMManifestResource) {
+		if visited[r.Key] {
+			return
+		}
+		visited[r.Key] = true
+		if parent, ok := byKey[r.Parent]; ok {
+			visit(parent)
+		}
+		ordered = append(ordered, r)
+	}
+	for _, r := range resources {
+		visit(r)
+	}
+	return ordered
+}
+
+// diffStringSets compares two sorted, de-duplicated slices.
+func diffStringSets(have, want []string) (added, removed []string) {
+	for _, w := range want {
+		if _, found := slices.BinarySearch(have, w); !found {
+			added = append(added, w)
+		}
+	}
+	for _, h := range have {
+		if _, found := slices.BinarySearch(want, h); !found {
+			removed = append(removed, h)
+		}
+	}
+	return added, removed
+}
+
+func appendIfChanged(fields []string, name string, changed bool) []string {
+	if changed {
+		return append(fields, name)
+	}
+	return fields
+}
+
+func sameUUIDPtr(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func optionalString(s string) *string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	return &s
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
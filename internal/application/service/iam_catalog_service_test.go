@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedErrors "github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+const testManifestYAML = `
+version: 1
+resources:
+  - key: admin
+    display_name: Administración
+    scope: platform
+    is_menu_visible: true
+  - key: users
+    display_name: Usuarios
+    parent: admin
+    scope: platform
+    is_menu_visible: true
+permissions:
+  - name: users:read
+    display_name: Ver usuarios
+    resource: users
+    action: read
+    scope: platform
+roles:
+  - name: support
+    display_name: Soporte
+    scope: platform
+    permissions: [users:read]
+`
+
+func newIAMCatalogService(repo *mockIAMCatalogRepo) IAMCatalogService {
+	return NewIAMCatalogService(repo, nil, &mockLogger{}, &mockAuditLogger{})
+}
+
+func TestParseIAMManifest(t *testing.T) {
+	t.Run("decodifica YAML detectando el formato", func(t *testing.T) {
+		m, err := ParseIAMManifest([]byte(testManifestYAML), "")
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if len(m.Resources) != 2 || len(m.Permissions) != 1 || len(m.Roles) != 1 {
+			t.Errorf("manifiesto incompleto: %+v", m)
+		}
+	})
+
+	t.Run("rechaza campos desconocidos", func(t *testing.T) {
+		_, err := ParseIAMManifest([]byte(`{"version":1,"rolez":[]}`), "")
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	})
+}
+
+func TestIAMCatalogService_Apply(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("crea todo en base vacía y aplica en una sola llamada", func(t *testing.T) {
+		m, _ := ParseIAMManifest([]byte(testManifestYAML), "yaml")
+		var applied *repository.IAMCatalogChangeSet
+		svc := newIAMCatalogService(&mockIAMCatalogRepo{
+			applyFn: func(ctx context.Context, changes *repository.IAMCatalogChangeSet) error {
+				applied = changes
+				return nil
+			},
+		})
+
+		resp, err := svc.Apply(ctx, m, false, false)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if !resp.Applied || resp.Summary.Create != 4 || resp.Summary.Relink != 1 {
+			t.Errorf("resumen incorrecto: %+v", resp.Summary)
+		}
+		if applied == nil || len(applied.UpsertResources) != 2 {
+			t.Fatal("esperaba change set con 2 recursos")
+		}
+		parent, child := applied.UpsertResources[0], applied.UpsertResources[1]
+		if parent.Key != "admin" || child.ParentID == nil || *child.ParentID != parent.ID {
+			t.Error("los recursos padre deben aplicarse antes que sus hijos")
+		}
+	})
+
+	t.Run("es idempotente cuando la base coincide con el manifiesto", func(t *testing.T) {
+		m, _ := ParseIAMManifest([]byte(testManifestYAML), "yaml")
+		adminID, usersID, permID, roleID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+		snap := &repository.IAMCatalogSnapshot{
+			Resources: []*entities.Resource{
+				{ID: adminID, Key: "admin", DisplayName: "Administración", Scope: "platform", IsMenuVisible: true, IsActive: true},
+				{ID: usersID, Key: "users", DisplayName: "Usuarios", ParentID: &adminID, Scope: "platform", IsMenuVisible: true, IsActive: true},
+			},
+			Permissions: []*entities.Permission{
+				{ID: permID, Name: "users:read", DisplayName: "Ver usuarios", ResourceID: usersID, Action: "read", Scope: "platform", IsActive: true},
+			},
+			Roles: []*entities.Role{
+				{ID: roleID, Name: "support", DisplayName: "Soporte", Scope: "platform", IsActive: true},
+			},
+			RolePermissions: []*entities.RolePermission{{ID: uuid.New(), RoleID: roleID, PermissionID: permID}},
+		}
+		applyCalled := false
+		svc := newIAMCatalogService(&mockIAMCatalogRepo{
+			snapshotFn: func(ctx context.Context) (*repository.IAMCatalogSnapshot, error) { return snap, nil },
+			applyFn: func(ctx context.Context, changes *repository.IAMCatalogChangeSet) error {
+				applyCalled = true
+				return nil
+			},
+		})
+
+		resp, err := svc.Apply(ctx, m, false, true)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if len(resp.Changes) != 0 || applyCalled {
+			t.Errorf("no esperaba cambios, obtuvo %+v", resp.Changes)
+		}
+	})
+
+	t.Run("ignora y conserva los enlaces a permisos inactivos como el export", func(t *testing.T) {
+		m, _ := ParseIAMManifest([]byte(testManifestYAML), "yaml")
+		adminID, usersID, permID, legacyID, roleID := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+		snap := &repository.IAMCatalogSnapshot{
+			Resources: []*entities.Resource{
+				{ID: adminID, Key: "admin", DisplayName: "Administración", Scope: "platform", IsMenuVisible: true, IsActive: true},
+				{ID: usersID, Key: "users", DisplayName: "Usuarios", ParentID: &adminID, Scope: "platform", IsMenuVisible: true, IsActive: true},
+			},
+			Permissions: []*entities.Permission{
+				{ID: permID, Name: "users:read", DisplayName: "Ver usuarios", ResourceID: usersID, Action: "read", Scope: "platform", IsActive: true},
+				{ID: legacyID, Name: "users:legacy", DisplayName: "Legacy", ResourceID: usersID, Action: "legacy", Scope: "platform"},
+			},
+			Roles: []*entities.Role{
+				{ID: roleID, Name: "support", DisplayName: "Soporte", Scope: "platform", IsActive: true},
+			},
+			RolePermissions: []*entities.RolePermission{
+				{ID: uuid.New(), RoleID: roleID, PermissionID: permID},
+				{ID: uuid.New(), RoleID: roleID, PermissionID: legacyID},
+			},
+		}
+		applyCalled := false
+		svc := newIAMCatalogService(&mockIAMCatalogRepo{
+			snapshotFn: func(ctx context.Context) (*repository.IAMCatalogSnapshot, error) { return snap, nil },
+			applyFn: func(ctx context.Context, changes *repository.IAMCatalogChangeSet) error {
+				applyCalled = true
+				return nil
+			},
+		})
+
+		exported, err := svc.Export(ctx)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		resp, err := svc.Apply(ctx, exported, false, false)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if len(resp.Changes) != 0 || applyCalled {
+			t.Errorf("reaplicar el export no debe cambiar nada, obtuvo %+v", resp.Changes)
+		}
+
+		m.Roles[0].Permissions = nil
+		var got *repository.IAMCatalogChangeSet
+		svc = newIAMCatalogService(&mockIAMCatalogRepo{
+			snapshotFn: func(ctx context.Context) (*repository.IAMCatalogSnapshot, error) { return snap, nil },
+			applyFn: func(ctx context.Context, changes *repository.IAMCatalogChangeSet) error {
+				got = changes
+				return nil
+			},
+		})
+		if _, err := svc.Apply(ctx, m, false, false); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if got == nil || len(got.RolePermissions[roleID]) != 1 || got.RolePermissions[roleID][0] != legacyID {
+			t.Errorf("el reenlace debe conservar el permiso inactivo, obtuvo %+v", got)
+		}
+	})
+
+	t.Run("dry run con prune planifica desactivaciones sin aplicar", func(t *testing.T) {
+		m, _ := ParseIAMManifest([]byte(testManifestYAML), "yaml")
+		snap := &repository.IAMCatalogSnapshot{
+			Roles: []*entities.Role{{ID: uuid.New(), Name: "legacy", DisplayName: "Legacy", Scope: "platform", IsActive: true}},
+		}
+		svc := newIAMCatalogService(&mockIAMCatalogRepo{
+			snapshotFn: func(ctx context.Context) (*repository.IAMCatalogSnapshot, error) { return snap, nil },
+			applyFn: func(ctx context.Context, changes *repository.IAMCatalogChangeSet) error {
+				t.Error("dry run no debe aplicar cambios")
+				return nil
+			},
+		})
+
+		resp, err := svc.Apply(ctx, m, true, true)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if resp.Applied || resp.Summary.Deactivate != 1 {
+			t.Errorf("esperaba 1 desactivación sin aplicar, obtuvo %+v", resp.Summary)
+		}
+	})
+
+	t.Run("rechaza permisos desconocidos en roles", func(t *testing.T) {
+		m := &dto.IAMManifest{Roles: []dto.IAMManifestRole{{Name: "x", DisplayName: "X", Scope: "platform", Permissions: []string{"nope:read"}}}}
+		_, err := newIAMCatalogService(&mockIAMCatalogRepo{}).Apply(ctx, m, true, false)
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	})
+
+	t.Run("propaga error de base de datos al aplicar", func(t *testing.T) {
+		m, _ := ParseIAMManifest([]byte(testManifestYAML), "yaml")
+		svc := newIAMCatalogService(&mockIAMCatalogRepo{
+			applyFn: func(ctx context.Context, changes *repository.IAMCatalogChangeSet) error {
+				return errors.New("db error")
+			},
+		})
+		_, err := svc.Apply(ctx, m, false, false)
+		assertAppError(t, err, sharedErrors.ErrorCodeDatabaseError)
+	})
+}
+
+func TestIAMCatalogService_Export(t *testing.T) {
+	adminID, permID, roleID, rootID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	snap := &repository.IAMCatalogSnapshot{
+		Resources: []*entities.Resource{
+			{ID: adminID, Key: "admin", DisplayName: "Admin", ParentID: &rootID, Scope: "platform", IsActive: true},
+			{ID: rootID, Key: "root", DisplayName: "Root", Scope: "platform"},
+			{ID: uuid.New(), Key: "legacy", DisplayName: "Legacy", Scope: "platform"},
+		},
+		Permissions:     []*entities.Permission{{ID: permID, Name: "admin:read", DisplayName: "Read", ResourceID: adminID, Action: "read", Scope: "platform", IsActive: true}},
+		Roles:           []*entities.Role{{ID: roleID, Name: "root", DisplayName: "Root", Scope: "platform", IsActive: true}},
+		RolePermissions: []*entities.RolePermission{{ID: uuid.New(), RoleID: roleID, PermissionID: permID}},
+	}
+	svc := newIAMCatalogService(&mockIAMCatalogRepo{
+		snapshotFn: func(ctx context.Context) (*repository.IAMCatalogSnapshot, error) { return snap, nil },
+	})
+
+	m, err := svc.Export(context.Background())
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if m.Permissions[0].Resource != "admin" || len(m.Roles[0].Permissions) != 1 {
+		t.Errorf("export incorrecto: %+v", m)
+	}
+	// el padre inactivo se exporta para que el manifiesto resuelva; el resto no
+	if len(m.Resources) != 2 || m.Resources[0].Key != "admin" || m.Resources[0].Parent != "root" {
+		t.Errorf("recursos exportados incorrectos: %+v", m.Resources)
+	}
+	if err := validateIAMManifest(m); err != nil {
+		t.Errorf("el export debe ser un manifiesto válido: %v", err)
+	}
+}
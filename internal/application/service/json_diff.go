@@ -0,0 +1,142 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// JSON change operations reported by diffJSON
+const (
+	JSONChangeAdded   = "added"
+	JSONChangeRemoved = "removed"
+	JSONChangeChanged = "changed"
+)
+
+// JSONChange is one difference between two JSON documents. Path uses the
+// JSONPath-like notation $.columns[0].label; From/To hold the raw values.
+type JSONChange struct {
+	Path string          `json:"path"`
+	Op   string          `json:"op"`
+	From json.RawMessage `json:"from,omitempty"`
+	To   json.RawMessage `json:"to,omitempty"`
+}
+
+var jsonIdentRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// jsonPathKey appends an object member to a path, quoting keys that are not
+// plain identifiers: $.columns, $["display name"]
+func jsonPathKey(parent, key string) string {
+	if jsonIdentRegex.MatchString(key) {
+		return parent + "." + key
+	}
+	return parent + "[" + strconv.Quote(key) + "]"
+}
+
+// jsonPathIndex appends an array index to a path: $.columns[2]
+func jsonPathIndex(parent string, i int) string {
+	return parent + "[" + strconv.Itoa(i) + "]"
+}
+
+// decodeJSONValue decodes a document keeping numbers exact, so 1 and 1.0
+// are reported as a change but large integers do not lose precision
+func decodeJSONValue(raw json.RawMessage) (any, error) {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return v, nil
+}
+
+// diffJSON lists the differences between two JSON documents in a stable
+// order: object members by key, arrays by position.
+func diffJSON(from, to json.RawMessage) ([]JSONChange, error) {
+	a, err := decodeJSONValue(from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := decodeJSONValue(to)
+	if err != nil {
+		return nil, err
+	}
+	changes := []JSONChange{}
+	diffJSONValues("$", a, b, &changes)
+	return changes, nil
+}
+
+func diffJSONValues(path string, a, b any, changes *[]JSONChange) {
+	switch av := a.(type) {
+	case map[string]any:
+		if bv, ok := b.(map[string]any); ok {
+			keys := make([]string, 0, len(av)+len(bv))
+			for k := range av {
+				keys = append(keys, k)
+			}
+			for k := range bv {
+				if _, ok := av[k]; !ok {
+					keys = append(keys, k)
+				}
+			}
+			slices.Sort(keys)
+			for _, k := range keys {
+				aChild, inA := av[k]
+				bChild, inB := bv[k]
+				childPath := jsonPathKey(path, k)
+				switch {
+				case !inA:
+					*changes = append(*changes, JSONChange{Path: childPath, Op: JSONChangeAdded, To: rawJSON(bChild)})
+				case !inB:
+					*changes = append(*changes, JSONChange{Path: childPath, Op: JSONChangeRemoved, From: rawJSON(aChild)})
+				default:
+					diffJSONValues(childPath, aChild, bChild, changes)
+				}
+			}
+			return
+		}
+	case []any:
+		if bv, ok := b.([]any); ok {
+			for i := 0; i < max(len(av), len(bv)); i++ {
+				childPath := jsonPathIndex(path, i)
+				switch {
+				case i >= len(av):
+					*changes = append(*changes, JSONChange{Path: childPath, Op: JSONChangeAdded, To: rawJSON(bv[i])})
+				case i >= len(bv):
+					*changes = append(*changes, JSONChange{Path: childPath, Op: JSONChangeRemoved, From: rawJSON(av[i])})
+				default:
+					diffJSONValues(childPath, av[i], bv[i], changes)
+				}
+			}
+			return
+		}
+	}
+	if !reflect.DeepEqual(a, b) {
+		*changes = append(*changes, JSONChange{Path: path, Op: JSONChangeChanged, From: rawJSON(a), To: rawJSON(b)})
+	}
+}
+
+func rawJSON(v any) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// jsonChanged reports whether two documents differ; documents that do not
+// parse are compared byte for byte
+func jsonChanged(a, b json.RawMessage) bool {
+	changes, err := diffJSON(a, b)
+	if err != nil {
+		return !bytes.Equal(a, b)
+	}
+	return len(changes) > 0
+}
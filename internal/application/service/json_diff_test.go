@@ -0,0 +1,61 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDiffJSON(t *testing.T) {
+	t.Run("documentos iguales no tienen cambios", func(t *testing.T) {
+		changes, err := diffJSON(json.RawMessage(`{"a":1,"b":[1,2]}`), json.RawMessage(`{"b":[1,2],"a":1}`))
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if len(changes) != 0 {
+			t.Errorf("esperaba 0 cambios, obtuvo %+v", changes)
+		}
+	})
+
+	t.Run("reporta rutas exactas ordenadas por clave", func(t *testing.T) {
+		from := json.RawMessage(`{"title":"Lista","columns":[{"label":"Nombre"},{"label":"Edad"}],"old":true}`)
+		to := json.RawMessage(`{"title":"Listado","columns":[{"label":"Nombre completo"}],"display name":"x"}`)
+		changes, err := diffJSON(from, to)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		want := []struct{ path, op string }{
+			{`$.columns[0].label`, JSONChangeChanged},
+			{`$.columns[1]`, JSONChangeRemoved},
+			{`$["display name"]`, JSONChangeAdded},
+			{`$.old`, JSONChangeRemoved},
+			{`$.title`, JSONChangeChanged},
+		}
+		if len(changes) != len(want) {
+			t.Fatalf("esperaba %d cambios, obtuvo %+v", len(want), changes)
+		}
+		for i, w := range want {
+			if changes[i].Path != w.path || changes[i].Op != w.op {
+				t.Errorf("cambio %d: esperaba %s %s, obtuvo %s %s", i, w.op, w.path, changes[i].Op, changes[i].Path)
+			}
+		}
+		if string(changes[4].From) != `"Lista"` || string(changes[4].To) != `"Listado"` {
+			t.Errorf("valores incorrectos: %s -> %s", changes[4].From, changes[4].To)
+		}
+	})
+
+	t.Run("cambio de tipo se reporta en el nodo", func(t *testing.T) {
+		changes, err := diffJSON(json.RawMessage(`{"a":{"b":1}}`), json.RawMessage(`{"a":[1]}`))
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if len(changes) != 1 || changes[0].Path != "$.a" || changes[0].Op != JSONChangeChanged {
+			t.Errorf("cambio incorrecto: %+v", changes)
+		}
+	})
+
+	t.Run("rechaza JSON inválido", func(t *testing.T) {
+		if _, err := diffJSON(json.RawMessage(`{`), json.RawMessage(`{}`)); err == nil {
+			t.Fatal("esperaba error")
+		}
+	})
+}
@@ -0,0 +1,373 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxSchemaViolations bounds the violations reported for one document
+const maxSchemaViolations = 20
+
+// SchemaViolation is one place where a document does not match its schema.
+// Path uses the same notation as JSONChange: $.columns[0].label.
+type SchemaViolation struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (v SchemaViolation) String() string {
+	return v.Path + ": " + v.Message
+}
+
+// jsonSchema is a compiled JSON Schema. It supports the keywords screen
+// patterns need: type, enum, const, properties, required,
+// additionalProperties, items, min/maxItems, min/maxLength, pattern,
+// minimum/maximum and anyOf. Annotations (title, description, default…) are
+// ignored; any other keyword is rejected at compile time, so a schema never
+// looks stricter than what is actually enforced.
+type jsonSchema struct {
+	// never is set by the boolean schema false
+	never                bool
+	types                []string
+	enum                 []any
+	constant             any
+	hasConst             bool
+	properties           map[string]*jsonSchema
+	required             []string
+	additionalProperties *jsonSchema
+	items                *jsonSchema
+	minItems, maxItems   *int
+	minLength, maxLength *int
+	pattern              *regexp.Regexp
+	minimum, maximum     *float64
+	anyOf                []*jsonSchema
+}
+
+var jsonSchemaTypes = []string{"object", "array", "string", "number", "integer", "boolean", "null"}
+
+// jsonSchemaKeywords are the keywords compileSchemaObject accepts: the
+// assertions it enforces plus annotations that carry no validation.
+var jsonSchemaKeywords = map[string]bool{
+	"type": true, "enum": true, "const": true, "properties": true, "required": true,
+	"additionalProperties": true, "items": true, "minItems": true, "maxItems": true,
+	"minLength": true, "maxLength": true, "pattern": true, "minimum": true, "maximum": true, "anyOf": true,
+	"$schema": true, "$id": true, "$comment": true, "title": true, "description": true,
+	"default": true, "examples": true, "deprecated": true, "readOnly": true, "writeOnly": true,
+}
+
+// compileJSONSchema parses a schema document. Errors name the offending
+// keyword by its path inside the schema.
+func compileJSONSchema(raw json.RawMessage) (*jsonSchema, error) {
+	v, err := decodeJSONValue(raw)
+	if err != nil {
+		return nil, err
+	}
+	return compileSchemaValue("$", v)
+}
+
+func compileSchemaValue(path string, v any) (*jsonSchema, error) {
+	switch sv := v.(type) {
+	case bool:
+		return &jsonSchema{never: !sv}, nil
+	case map[string]any:
+		return compileSchemaObject(path, sv)
+	default:
+		return nil, fmt.Errorf("%s: schema must be an object or a boolean", path)
+	}
+}
+
+func compileSchemaObject(path string, m map[string]any) (*jsonSchema, error) {
+	keywords := make([]string, 0, len(m))
+	for keyword := range m {
+		keywords = append(keywords, keyword)
+	}
+	slices.Sort(keywords)
+	for _, keyword := range keywords {
+		if !jsonSchemaKeywords[keyword] {
+			return nil, fmt.Errorf("%s: unsupported keyword", jsonPathKey(path, keyword))
+		}
+	}
+
+	s := &jsonSchema{}
+	var err error
+
+	if t, ok := m["type"]; ok {
+		switch tv := t.(type) {
+		case string:
+			s.types = []string{tv}
+		case []any:
+			for _, item := range tv {
+				name, ok := item.(string)
+				if !ok {
+					return nil, fmt.Errorf("%s: type entries must be strings", jsonPathKey(path, "type"))
+				}
+				s.types = append(s.types, name)
+			}
+		default:
+			return nil, fmt.Errorf("%s: must be a string or an array of strings", jsonPathKey(path, "type"))
+		}
+		for _, name := range s.types {
+			if !slices.Contains(jsonSchemaTypes, name) {
+				return nil, fmt.Errorf("%s: unknown type %q", jsonPathKey(path, "type"), name)
+			}
+		}
+	}
+	if e, ok := m["enum"]; ok {
+		values, ok := e.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: must be an array", jsonPathKey(path, "enum"))
+		}
+		s.enum = values
+	}
+	if c, ok := m["const"]; ok {
+		s.constant, s.hasConst = c, true
+	}
+	if p, ok := m["properties"]; ok {
+		props, ok := p.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: must be an object", jsonPathKey(path, "properties"))
+		}
+		s.properties = make(map[string]*jsonSchema, len(props))
+		for name, sub := range props {
+			if s.properties[name], err = compileSchemaValue(jsonPathKey(jsonPathKey(path, "properties"), name), sub); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if r, ok := m["required"]; ok {
+		names, ok := r.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: must be an array of strings", jsonPathKey(path, "required"))
+		}
+		for _, n := range names {
+			name, ok := n.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: must be an array of strings", jsonPathKey(path, "required"))
+			}
+			s.required = append(s.required, name)
+		}
+	}
+	if a, ok := m["additionalProperties"]; ok {
+		if s.additionalProperties, err = compileSchemaValue(jsonPathKey(path, "additionalProperties"), a); err != nil {
+			return nil, err
+		}
+	}
+	if i, ok := m["items"]; ok {
+		if s.items, err = compileSchemaValue(jsonPathKey(path, "items"), i); err != nil {
+			return nil, err
+		}
+	}
+	for keyword, dst := range map[string]**int{
+		"minItems": &s.minItems, "maxItems": &s.maxItems, "minLength": &s.minLength, "maxLength": &s.maxLength,
+	} {
+		if n, ok := m[keyword]; ok {
+			if *dst, err = schemaCount(jsonPathKey(path, keyword), n); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for keyword, dst := range map[string]**float64{"minimum": &s.minimum, "maximum": &s.maximum} {
+		if n, ok := m[keyword]; ok {
+			num, ok := n.(json.Number)
+			if !ok {
+				return nil, fmt.Errorf("%s: must be a number", jsonPathKey(path, keyword))
+			}
+			f, err := num.Float64()
+			if err != nil {
+				return nil, fmt.Errorf("%s: must be a number", jsonPathKey(path, keyword))
+			}
+			*dst = &f
+		}
+	}
+	if p, ok := m["pattern"]; ok {
+		expr, ok := p.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: must be a string", jsonPathKey(path, "pattern"))
+		}
+		if s.pattern, err = regexp.Compile(expr); err != nil {
+			return nil, fmt.Errorf("%s: invalid regular expression: %v", jsonPathKey(path, "pattern"), err)
+		}
+	}
+	if a, ok := m["anyOf"]; ok {
+		options, ok := a.([]any)
+		if !ok || len(options) == 0 {
+			return nil, fmt.Errorf("%s: must be a non-empty array", jsonPathKey(path, "anyOf"))
+		}
+		for i, option := range options {
+			sub, err := compileSchemaValue(jsonPathIndex(jsonPathKey(path, "anyOf"), i), option)
+			if err != nil {
+				return nil, err
+			}
+			s.anyOf = append(s.anyOf, sub)
+		}
+	}
+	return s, nil
+}
+
+func schemaCount(path string, v any) (*int, error) {
+	num, ok := v.(json.Number)
+	if !ok {
+		return nil, fmt.Errorf("%s: must be a non-negative integer", path)
+	}
+	n, err := num.Int64()
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("%s: must be a non-negative integer", path)
+	}
+	count := int(n)
+	return &count, nil
+}
+
+// validate checks doc against the schema and returns the violations found,
+// at most maxSchemaViolations
+func (s *jsonSchema) validate(doc json.RawMessage) ([]SchemaViolation, error) {
+	v, err := decodeJSONValue(doc)
+	if err != nil {
+		return nil, err
+	}
+	var violations []SchemaViolation
+	s.validateValue("$", v, &violations)
+	if len(violations) > maxSchemaViolations {
+		violations = violations[:maxSchemaViolations]
+	}
+	return violations, nil
+}
+
+func (s *jsonSchema) validateValue(path string, v any, violations *[]SchemaViolation) {
+	report := func(at, format string, args ...any) {
+		*violations = append(*violations, SchemaViolation{Path: at, Message: fmt.Sprintf(format, args...)})
+	}
+	if s.never {
+		report(path, "is not allowed")
+		return
+	}
+	if len(s.types) > 0 && !slices.ContainsFunc(s.types, func(t string) bool { return jsonTypeMatches(t, v) }) {
+		report(path, "expected %s, got %s", strings.Join(s.types, " or "), jsonTypeOf(v))
+		return
+	}
+	if s.enum != nil && !slices.ContainsFunc(s.enum, func(e any) bool { return jsonValuesEqual(e, v) }) {
+		report(path, "must be one of %s", rawJSON(s.enum))
+	}
+	if s.hasConst && !jsonValuesEqual(s.constant, v) {
+		report(path, "must be %s", rawJSON(s.constant))
+	}
+	if len(s.anyOf) > 0 {
+		matched := slices.ContainsFunc(s.anyOf, func(option *jsonSchema) bool {
+			var probe []SchemaViolation
+			option.validateValue(path, v, &probe)
+			return len(probe) == 0
+		})
+		if !matched {
+			report(path, "does not match any of the allowed schemas")
+		}
+	}
+
+	switch tv := v.(type) {
+	case map[string]any:
+		for _, name := range s.required {
+			if _, ok := tv[name]; !ok {
+				report(jsonPathKey(path, name), "is required")
+			}
+		}
+		keys := make([]string, 0, len(tv))
+		for k := range tv {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			if sub, ok := s.properties[k]; ok {
+				sub.validateValue(jsonPathKey(path, k), tv[k], violations)
+			} else if s.additionalProperties != nil {
+				s.additionalProperties.validateValue(jsonPathKey(path, k), tv[k], violations)
+			}
+		}
+	case []any:
+		if s.minItems != nil && len(tv) < *s.minItems {
+			report(path, "must have at least %d items", *s.minItems)
+		}
+		if s.maxItems != nil && len(tv) > *s.maxItems {
+			report(path, "must have at most %d items", *s.maxItems)
+		}
+		if s.items != nil {
+			for i, item := range tv {
+				s.items.validateValue(jsonPathIndex(path, i), item, violations)
+			}
+		}
+	case string:
+		length := utf8.RuneCountInString(tv)
+		if s.minLength != nil && length < *s.minLength {
+			report(path, "must be at least %d characters", *s.minLength)
+		}
+		if s.maxLength != nil && length > *s.maxLength {
+			report(path, "must be at most %d characters", *s.maxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(tv) {
+			report(path, "must match pattern %s", s.pattern.String())
+		}
+	case json.Number:
+		f, err := tv.Float64()
+		if err != nil {
+			return
+		}
+		if s.minimum != nil && f < *s.minimum {
+			report(path, "must be >= %v", *s.minimum)
+		}
+		if s.maximum != nil && f > *s.maximum {
+			report(path, "must be <= %v", *s.maximum)
+		}
+	}
+}
+
+func jsonTypeOf(v any) string {
+	switch tv := v.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		if jsonTypeMatches("integer", tv) {
+			return "integer"
+		}
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return "null"
+	}
+}
+
+func jsonTypeMatches(t string, v any) bool {
+	switch t {
+	case "integer":
+		num, ok := v.(json.Number)
+		if !ok {
+			return false
+		}
+		f, err := num.Float64()
+		return err == nil && f == math.Trunc(f)
+	case "number":
+		_, ok := v.(json.Number)
+		return ok
+	default:
+		return jsonTypeOf(v) == t
+	}
+}
+
+// jsonValuesEqual compares decoded values, treating 1 and 1.0 as equal
+func jsonValuesEqual(a, b any) bool {
+	an, aok := a.(json.Number)
+	bn, bok := b.(json.Number)
+	if aok && bok {
+		af, aerr := an.Float64()
+		bf, berr := bn.Float64()
+		return aerr == nil && berr == nil && af == bf
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const listSlotSchema = `{
+	"type": "object",
+	"required": ["title", "columns"],
+	"additionalProperties": false,
+	"properties": {
+		"title": {"type": "string", "minLength": 1},
+		"page_size": {"type": "integer", "minimum": 1, "maximum": 100},
+		"mode": {"enum": ["table", "cards"]},
+		"columns": {
+			"type": "array",
+			"minItems": 1,
+			"items": {
+				"type": "object",
+				"required": ["field"],
+				"properties": {
+					"field": {"type": "string", "pattern": "^[a-z_]+$"},
+					"label": {"type": ["string", "null"]},
+					"width": {"anyOf": [{"type": "integer"}, {"const": "auto"}]}
+				}
+			}
+		}
+	}
+}`
+
+func TestJSONSchema_Validate(t *testing.T) {
+	schema, err := compileJSONSchema(json.RawMessage(listSlotSchema))
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+
+	t.Run("documento válido no tiene violaciones", func(t *testing.T) {
+		violations, err := schema.validate(json.RawMessage(`{
+			"title": "Alumnos", "page_size": 20, "mode": "cards",
+			"columns": [{"field": "name", "label": null, "width": "auto"}, {"field": "age", "width": 80}]
+		}`))
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if len(violations) != 0 {
+			t.Errorf("esperaba 0 violaciones, obtuvo %+v", violations)
+		}
+	})
+
+	t.Run("reporta la ruta exacta de cada violación", func(t *testing.T) {
+		violations, err := schema.validate(json.RawMessage(`{
+			"page_size": 2.5, "mode": "grid", "extra": true,
+			"columns": [{"field": "name"}, {"label": 3, "field": "Bad-Field", "width": "wide"}]
+		}`))
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		want := []SchemaViolation{
+			{`$.title`, "is required"},
+			{`$.columns[1].field`, "must match pattern ^[a-z_]+$"},
+			{`$.columns[1].label`, "expected string or null, got integer"},
+			{`$.columns[1].width`, "does not match any of the allowed schemas"},
+			{`$.extra`, "is not allowed"},
+			{`$.mode`, `must be one of ["table","cards"]`},
+			{`$.page_size`, "expected integer, got number"},
+		}
+		if len(violations) != len(want) {
+			t.Fatalf("esperaba %d violaciones, obtuvo %+v", len(want), violations)
+		}
+		for i, w := range want {
+			if violations[i] != w {
+				t.Errorf("violación %d: esperaba %s, obtuvo %s", i, w, violations[i])
+			}
+		}
+	})
+
+	t.Run("límites de longitud y rango", func(t *testing.T) {
+		violations, _ := schema.validate(json.RawMessage(`{"title": "", "page_size": 500, "columns": []}`))
+		want := map[string]string{
+			`$.columns`:   "must have at least 1 items",
+			`$.page_size`: "must be <= 100",
+			`$.title`:     "must be at least 1 characters",
+		}
+		if len(violations) != len(want) {
+			t.Fatalf("esperaba %d violaciones, obtuvo %+v", len(want), violations)
+		}
+		for _, v := range violations {
+			if want[v.Path] != v.Message {
+				t.Errorf("violación inesperada: %s", v)
+			}
+		}
+	})
+
+	t.Run("tipo raíz incorrecto", func(t *testing.T) {
+		violations, _ := schema.validate(json.RawMessage(`[]`))
+		if len(violations) != 1 || violations[0].Path != "$" || violations[0].Message != "expected object, got array" {
+			t.Errorf("violación incorrecta: %+v", violations)
+		}
+	})
+}
+
+func TestCompileJSONSchema_Errors(t *testing.T) {
+	cases := map[string]string{
+		`{"type": "text"}`:                          `$.type: unknown type "text"`,
+		`{"properties": {"a": {"pattern": "("}}}`:   `$.properties.a.pattern: invalid regular expression`,
+		`{"items": {"minItems": -1}}`:               `$.items.minItems: must be a non-negative integer`,
+		`{"anyOf": []}`:                             `$.anyOf: must be a non-empty array`,
+		`{"properties": {"a": 1}}`:                  `$.properties.a: schema must be an object or a boolean`,
+		`{"required": "title"}`:                     `$.required: must be an array of strings`,
+		`{"properties": {"b": {"maximum": "ten"}}}`: `$.properties.b.maximum: must be a number`,
+		`{"additionalProperties": {"type": ["x"]}}`: `$.additionalProperties.type: unknown type "x"`,
+		`{"$ref": "#/definitions/a"}`:               `$["$ref"]: unsupported keyword`,
+		`{"items": {"oneOf": [true]}}`:              `$.items.oneOf: unsupported keyword`,
+		`{"properties": {"a": {"format": "date"}}}`: `$.properties.a.format: unsupported keyword`,
+		`{"minProperties": 1, "allOf": [true]}`:     `$.allOf: unsupported keyword`,
+	}
+	for schema, want := range cases {
+		_, err := compileJSONSchema(json.RawMessage(schema))
+		if err == nil || !strings.HasPrefix(err.Error(), want) {
+			t.Errorf("%s: esperaba %q, obtuvo %v", schema, want, err)
+		}
+	}
+}
+
+func TestCompileJSONSchema_AcceptsAnnotations(t *testing.T) {
+	schema := `{"$schema": "https://json-schema.org/draft/2020-12/schema", "title": "Lista", "description": "Slots", "properties": {"a": {"type": "string", "default": "x", "examples": ["y"]}}}`
+	if _, err := compileJSONSchema(json.RawMessage(schema)); err != nil {
+		t.Fatalf("las anotaciones no deben rechazarse: %v", err)
+	}
+}
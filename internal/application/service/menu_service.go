@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
 	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	"github.com/EduGoGroup/edugo-shared/common/errors"
@@ -15,22 +16,25 @@ import (
 
 // MenuService defines the menu service interface
 type MenuService interface {
-	GetMenuForUser(ctx context.Context, permissions []string) (*dto.MenuResponse, error)
-	GetFullMenu(ctx context.Context) (*dto.MenuResponse, error)
+	// GetMenuForUser and GetFullMenu return display names in locale when
+	// translated; an empty locale keeps the stored names
+	GetMenuForUser(ctx context.Context, permissions []string, locale string) (*dto.MenuResponse, error)
+	GetFullMenu(ctx context.Context, locale string) (*dto.MenuResponse, error)
 }
 
 type menuService struct {
 	resourceRepo       repository.ResourceRepository
 	resourceScreenRepo repository.ResourceScreenRepository
+	translationRepo    repository.TranslationRepository
 	logger             logger.Logger
 }
 
 // NewMenuService creates a new menu service
-func NewMenuService(resourceRepo repository.ResourceRepository, resourceScreenRepo repository.ResourceScreenRepository, logger logger.Logger) MenuService {
-	return &menuService{resourceRepo: resourceRepo, resourceScreenRepo: resourceScreenRepo, logger: logger}
+func NewMenuService(resourceRepo repository.ResourceRepository, resourceScreenRepo repository.ResourceScreenRepository, translationRepo repository.TranslationRepository, logger logger.Logger) MenuService {
+	return &menuService{resourceRepo: resourceRepo, resourceScreenRepo: resourceScreenRepo, translationRepo: translationRepo, logger: logger}
 }
 
-func (s *menuService) GetMenuForUser(ctx context.Context, permissions []string) (*dto.MenuResponse, error) {
+func (s *menuService) GetMenuForUser(ctx context.Context, permissions []string, locale string) (*dto.MenuResponse, error) {
 	resourceKeys := extractResourceKeys(permissions)
 	if len(resourceKeys) == 0 {
 		return &dto.MenuResponse{Items: []dto.MenuItemDTO{}}, nil
@@ -40,6 +44,7 @@ func (s *menuService) GetMenuForUser(ctx context.Context, permissions []string)
 	if err != nil {
 		return nil, errors.NewDatabaseError("find menu resources", err)
 	}
+	allResources = s.localizeResources(ctx, allResources, locale)
 
 	resourceByKey := make(map[string]*entities.Resource)
 	resourceByID := make(map[uuid.UUID]*entities.Resource)
@@ -87,11 +92,12 @@ func (s *menuService) GetMenuForUser(ctx context.Context, permissions []string)
 	return &dto.MenuResponse{Items: items}, nil
 }
 
-func (s *menuService) GetFullMenu(ctx context.Context) (*dto.MenuResponse, error) {
+func (s *menuService) GetFullMenu(ctx context.Context, locale string) (*dto.MenuResponse, error) {
 	allResources, err := s.resourceRepo.FindMenuVisible(ctx)
 	if err != nil {
 		return nil, errors.NewDatabaseError("find menu resources", err)
 	}
+	allResources = s.localizeResources(ctx, allResources, locale)
 
 	allKeys := make(map[string]bool)
 	for _, r := range allResources {
@@ -104,6 +110,25 @@ func (s *menuService) GetFullMenu(ctx context.Context) (*dto.MenuResponse, error
 	return &dto.MenuResponse{Items: items}, nil
 }
 
+// localizeResources returns the resources with their display names in
+// locale. Resources without a translation keep their stored name.
+func (s *menuService) localizeResources(ctx context.Context, resources []*entities.Resource, locale string) []*entities.Resource {
+	names := translatedValues(ctx, s.translationRepo, s.logger, model.TranslationEntityResource, model.TranslationFieldDisplayName, locale)
+	if len(names) == 0 {
+		return resources
+	}
+	localized := make([]*entities.Resource, len(resources))
+	for i, r := range resources {
+		localized[i] = r
+		if name, ok := names[r.ID]; ok {
+			cp := *r
+			cp.DisplayName = name
+			localized[i] = &cp
+		}
+	}
+	return localized
+}
+
 func (s *menuService) loadScreenMappings(ctx context.Context, resources []*entities.Resource) map[string]map[string]string {
 	result := make(map[string]map[string]string)
 	if s.resourceScreenRepo == nil {
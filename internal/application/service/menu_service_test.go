@@ -5,13 +5,14 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	sharedErrors "github.com/EduGoGroup/edugo-shared/common/errors"
 	"github.com/google/uuid"
 )
 
 func newMenuService(resourceRepo *mockResourceRepo, screenRepo *mockResourceScreenRepo) MenuService {
-	return NewMenuService(resourceRepo, screenRepo, &mockLogger{})
+	return NewMenuService(resourceRepo, screenRepo, &mockTranslationRepo{}, &mockLogger{})
 }
 
 // ─── extractResourceKeys (función pura interna) ───────────────────────────────
@@ -59,7 +60,7 @@ func TestMenuService_GetMenuForUser(t *testing.T) {
 
 	t.Run("retorna menú vacío cuando no hay permisos", func(t *testing.T) {
 		svc := newMenuService(&mockResourceRepo{}, nil)
-		resp, err := svc.GetMenuForUser(ctx, []string{})
+		resp, err := svc.GetMenuForUser(ctx, []string{}, "")
 		if err != nil {
 			t.Fatalf("error inesperado: %v", err)
 		}
@@ -86,7 +87,7 @@ func TestMenuService_GetMenuForUser(t *testing.T) {
 		}
 
 		svc := newMenuService(resourceRepo, screenRepo)
-		resp, err := svc.GetMenuForUser(ctx, []string{"dashboard:read", "dashboard:write"})
+		resp, err := svc.GetMenuForUser(ctx, []string{"dashboard:read", "dashboard:write"}, "")
 		if err != nil {
 			t.Fatalf("error inesperado: %v", err)
 		}
@@ -115,7 +116,7 @@ func TestMenuService_GetMenuForUser(t *testing.T) {
 
 		svc := newMenuService(resourceRepo, screenRepo)
 		perms := []string{"dashboard:read", "dashboard:write"}
-		resp, err := svc.GetMenuForUser(ctx, perms)
+		resp, err := svc.GetMenuForUser(ctx, perms, "")
 		if err != nil {
 			t.Fatalf("error inesperado: %v", err)
 		}
@@ -140,7 +141,7 @@ func TestMenuService_GetMenuForUser(t *testing.T) {
 
 		svc := newMenuService(resourceRepo, screenRepo)
 		// El usuario solo tiene permiso en el hijo
-		resp, err := svc.GetMenuForUser(ctx, []string{"admin.users:read"})
+		resp, err := svc.GetMenuForUser(ctx, []string{"admin.users:read"}, "")
 		if err != nil {
 			t.Fatalf("error inesperado: %v", err)
 		}
@@ -178,7 +179,7 @@ func TestMenuService_GetMenuForUser(t *testing.T) {
 		}
 
 		svc := newMenuService(resourceRepo, screenRepo)
-		resp, err := svc.GetMenuForUser(ctx, []string{"dashboard:read"})
+		resp, err := svc.GetMenuForUser(ctx, []string{"dashboard:read"}, "")
 		if err != nil {
 			t.Fatalf("error inesperado: %v", err)
 		}
@@ -195,7 +196,7 @@ func TestMenuService_GetMenuForUser(t *testing.T) {
 			findMenuVisibleFn: func(ctx context.Context) ([]*entities.Resource, error) { return nil, errors.New("db fail") },
 		}
 		svc := newMenuService(resourceRepo, nil)
-		_, err := svc.GetMenuForUser(ctx, []string{"resource:read"})
+		_, err := svc.GetMenuForUser(ctx, []string{"resource:read"}, "")
 		assertAppError(t, err, sharedErrors.ErrorCodeDatabaseError)
 	})
 }
@@ -271,7 +272,7 @@ func TestMenuService_AccessMode(t *testing.T) {
 			getByResourceKeyFn: func(ctx context.Context, key string) ([]*entities.ResourceScreen, error) { return nil, nil },
 		}
 		svc := newMenuService(resourceRepo, screenRepo)
-		resp, err := svc.GetMenuForUser(ctx, []string{"dashboard:read", "dashboard:update"})
+		resp, err := svc.GetMenuForUser(ctx, []string{"dashboard:read", "dashboard:update"}, "")
 		if err != nil {
 			t.Fatalf("error inesperado: %v", err)
 		}
@@ -292,7 +293,7 @@ func TestMenuService_AccessMode(t *testing.T) {
 			getByResourceKeyFn: func(ctx context.Context, key string) ([]*entities.ResourceScreen, error) { return nil, nil },
 		}
 		svc := newMenuService(resourceRepo, screenRepo)
-		resp, err := svc.GetMenuForUser(ctx, []string{"dashboard:read"})
+		resp, err := svc.GetMenuForUser(ctx, []string{"dashboard:read"}, "")
 		if err != nil {
 			t.Fatalf("error inesperado: %v", err)
 		}
@@ -312,7 +313,7 @@ func TestMenuService_AccessMode(t *testing.T) {
 			getByResourceKeyFn: func(ctx context.Context, key string) ([]*entities.ResourceScreen, error) { return nil, nil },
 		}
 		svc := newMenuService(resourceRepo, screenRepo)
-		resp, err := svc.GetFullMenu(ctx)
+		resp, err := svc.GetFullMenu(ctx, "")
 		if err != nil {
 			t.Fatalf("error inesperado: %v", err)
 		}
@@ -336,7 +337,7 @@ func TestMenuService_AccessMode(t *testing.T) {
 		}
 		svc := newMenuService(resourceRepo, screenRepo)
 		// admin:read (view) + admin.users:read + admin.users:create (edit)
-		resp, err := svc.GetMenuForUser(ctx, []string{"admin:read", "admin.users:read", "admin.users:create"})
+		resp, err := svc.GetMenuForUser(ctx, []string{"admin:read", "admin.users:read", "admin.users:create"}, "")
 		if err != nil {
 			t.Fatalf("error inesperado: %v", err)
 		}
@@ -367,7 +368,7 @@ func TestMenuService_GetFullMenu(t *testing.T) {
 		}
 
 		svc := newMenuService(resourceRepo, screenRepo)
-		resp, err := svc.GetFullMenu(ctx)
+		resp, err := svc.GetFullMenu(ctx, "")
 		if err != nil {
 			t.Fatalf("error inesperado: %v", err)
 		}
@@ -381,7 +382,7 @@ func TestMenuService_GetFullMenu(t *testing.T) {
 			findMenuVisibleFn: func(ctx context.Context) ([]*entities.Resource, error) { return []*entities.Resource{}, nil },
 		}
 		svc := newMenuService(resourceRepo, nil)
-		resp, err := svc.GetFullMenu(ctx)
+		resp, err := svc.GetFullMenu(ctx, "")
 		if err != nil {
 			t.Fatalf("error inesperado: %v", err)
 		}
@@ -395,7 +396,7 @@ func TestMenuService_GetFullMenu(t *testing.T) {
 			findMenuVisibleFn: func(ctx context.Context) ([]*entities.Resource, error) { return nil, errors.New("db fail") },
 		}
 		svc := newMenuService(resourceRepo, nil)
-		_, err := svc.GetFullMenu(ctx)
+		_, err := svc.GetFullMenu(ctx, "")
 		assertAppError(t, err, sharedErrors.ErrorCodeDatabaseError)
 	})
 
@@ -416,7 +417,7 @@ func TestMenuService_GetFullMenu(t *testing.T) {
 		}
 
 		svc := newMenuService(resourceRepo, screenRepo)
-		resp, err := svc.GetFullMenu(ctx)
+		resp, err := svc.GetFullMenu(ctx, "")
 		if err != nil {
 			t.Fatalf("error inesperado: %v", err)
 		}
@@ -427,4 +428,32 @@ func TestMenuService_GetFullMenu(t *testing.T) {
 			t.Errorf("esperaba 2 hijos, obtuvo %d", len(resp.Items[0].Children))
 		}
 	})
+
+	t.Run("traduce los nombres al locale pedido", func(t *testing.T) {
+		dashboardID := uuid.New()
+		resources := []*entities.Resource{
+			{ID: dashboardID, Key: "dashboard", DisplayName: "Tablero", Scope: "platform", IsMenuVisible: true},
+		}
+		resourceRepo := &mockResourceRepo{
+			findMenuVisibleFn: func(ctx context.Context) ([]*entities.Resource, error) { return resources, nil },
+		}
+		translations := &mockTranslationRepo{translations: []*model.Translation{{
+			EntityType: model.TranslationEntityResource, EntityID: dashboardID,
+			Field: model.TranslationFieldDisplayName, Locale: "en", Value: "Dashboard",
+		}}}
+		svc := NewMenuService(resourceRepo, nil, translations, &mockLogger{})
+
+		for locale, want := range map[string]string{"en": "Dashboard", "pt": "Tablero", "": "Tablero"} {
+			resp, err := svc.GetFullMenu(ctx, locale)
+			if err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+			if resp.Items[0].DisplayName != want {
+				t.Errorf("locale %q: esperaba %s, obtuvo %s", locale, want, resp.Items[0].DisplayName)
+			}
+		}
+		if resources[0].DisplayName != "Tablero" {
+			t.Errorf("no debería modificar el recurso original: %s", resources[0].DisplayName)
+		}
+	})
 }
@@ -1,8 +1,14 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"maps"
+	"slices"
+	"time"
 
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	"github.com/EduGoGroup/edugo-shared/audit"
 	"github.com/EduGoGroup/edugo-shared/logger"
@@ -143,11 +149,16 @@ func (m *mockPermissionRepo) HasActiveRolePermissions(ctx context.Context, permi
 type mockUserRoleRepo struct {
 	findByUserFn          func(ctx context.Context, userID uuid.UUID) ([]*entities.UserRole, error)
 	findByUserInContextFn func(ctx context.Context, userID uuid.UUID, schoolID *uuid.UUID, unitID *uuid.UUID) ([]*entities.UserRole, error)
+	findActiveByRoleFn    func(ctx context.Context, roleID uuid.UUID) ([]*entities.UserRole, error)
+	findActiveInScopeFn   func(ctx context.Context, schoolID, unitID, roleID *uuid.UUID) ([]*entities.UserRole, error)
+	findActiveByUsersFn   func(ctx context.Context, userIDs []uuid.UUID) ([]*entities.UserRole, error)
 	grantFn               func(ctx context.Context, userRole *entities.UserRole) error
 	revokeFn              func(ctx context.Context, id uuid.UUID) error
 	revokeByUserAndRoleFn func(ctx context.Context, userID, roleID uuid.UUID, schoolID, unitID *uuid.UUID) error
+	deactivateExpiredFn   func(ctx context.Context, now time.Time) ([]*entities.UserRole, error)
 	userHasRoleFn         func(ctx context.Context, userID, roleID uuid.UUID, schoolID, unitID *uuid.UUID) (bool, error)
 	getUserPermissionsFn  func(ctx context.Context, userID uuid.UUID, schoolID, unitID *uuid.UUID) ([]string, error)
+	getContextPermsFn     func(ctx context.Context, userID uuid.UUID, schoolID, unitID *uuid.UUID) ([]string, error)
 }
 
 func (m *mockUserRoleRepo) FindByUser(ctx context.Context, userID uuid.UUID) ([]*entities.UserRole, error) {
@@ -156,6 +167,24 @@ func (m *mockUserRoleRepo) FindByUser(ctx context.Context, userID uuid.UUID) ([]
 	}
 	return nil, nil
 }
+func (m *mockUserRoleRepo) FindActiveByRole(ctx context.Context, roleID uuid.UUID) ([]*entities.UserRole, error) {
+	if m.findActiveByRoleFn != nil {
+		return m.findActiveByRoleFn(ctx, roleID)
+	}
+	return nil, nil
+}
+func (m *mockUserRoleRepo) FindActiveInScope(ctx context.Context, schoolID, unitID, roleID *uuid.UUID) ([]*entities.UserRole, error) {
+	if m.findActiveInScopeFn != nil {
+		return m.findActiveInScopeFn(ctx, schoolID, unitID, roleID)
+	}
+	return nil, nil
+}
+func (m *mockUserRoleRepo) FindActiveByUsers(ctx context.Context, userIDs []uuid.UUID) ([]*entities.UserRole, error) {
+	if m.findActiveByUsersFn != nil {
+		return m.findActiveByUsersFn(ctx, userIDs)
+	}
+	return nil, nil
+}
 func (m *mockUserRoleRepo) FindByUserInContext(ctx context.Context, userID uuid.UUID, schoolID *uuid.UUID, unitID *uuid.UUID) ([]*entities.UserRole, error) {
 	if m.findByUserInContextFn != nil {
 		return m.findByUserInContextFn(ctx, userID, schoolID, unitID)
@@ -180,6 +209,12 @@ func (m *mockUserRoleRepo) RevokeByUserAndRole(ctx context.Context, userID, role
 	}
 	return nil
 }
+func (m *mockUserRoleRepo) DeactivateExpired(ctx context.Context, now time.Time) ([]*entities.UserRole, error) {
+	if m.deactivateExpiredFn != nil {
+		return m.deactivateExpiredFn(ctx, now)
+	}
+	return nil, nil
+}
 func (m *mockUserRoleRepo) UserHasRole(ctx context.Context, userID, roleID uuid.UUID, schoolID, unitID *uuid.UUID) (bool, error) {
 	if m.userHasRoleFn != nil {
 		return m.userHasRoleFn(ctx, userID, roleID, schoolID, unitID)
@@ -192,6 +227,12 @@ func (m *mockUserRoleRepo) GetUserPermissions(ctx context.Context, userID uuid.U
 	}
 	return nil, nil
 }
+func (m *mockUserRoleRepo) GetContextPermissions(ctx context.Context, userID uuid.UUID, schoolID, unitID *uuid.UUID) ([]string, error) {
+	if m.getContextPermsFn != nil {
+		return m.getContextPermsFn(ctx, userID, schoolID, unitID)
+	}
+	return nil, nil
+}
 
 // ─── ResourceRepository mock ─────────────────────────────────────────────────
 
@@ -262,6 +303,13 @@ func (m *mockScreenTemplateRepo) List(ctx context.Context, filter sharedrepo.Lis
 	}
 	return nil, 0, nil
 }
+func (m *mockScreenTemplateRepo) ListAfter(ctx context.Context, after uuid.UUID, limit int) ([]*entities.ScreenTemplate, error) {
+	all, _, err := m.List(ctx, sharedrepo.ListFilters{})
+	if err != nil {
+		return nil, err
+	}
+	return keysetPage(all, func(t *entities.ScreenTemplate) uuid.UUID { return t.ID }, after, limit), nil
+}
 func (m *mockScreenTemplateRepo) Update(ctx context.Context, template *entities.ScreenTemplate) error {
 	if m.updateFn != nil {
 		return m.updateFn(ctx, template)
@@ -275,6 +323,20 @@ func (m *mockScreenTemplateRepo) Delete(ctx context.Context, id uuid.UUID) error
 	return nil
 }
 
+// keysetPage emulates ListAfter over the rows served by a List mock
+func keysetPage[T any](rows []*T, id func(*T) uuid.UUID, after uuid.UUID, limit int) []*T {
+	compare := func(a, b uuid.UUID) int { return bytes.Compare(a[:], b[:]) }
+	sorted := slices.Clone(rows)
+	slices.SortFunc(sorted, func(a, b *T) int { return compare(id(a), id(b)) })
+	var page []*T
+	for _, row := range sorted {
+		if compare(id(row), after) > 0 && len(page) < limit {
+			page = append(page, row)
+		}
+	}
+	return page
+}
+
 // ─── ScreenInstanceRepository mock ───────────────────────────────────────────
 
 type mockScreenInstanceRepo struct {
@@ -282,6 +344,7 @@ type mockScreenInstanceRepo struct {
 	getByIDFn        func(ctx context.Context, id uuid.UUID) (*entities.ScreenInstance, error)
 	getByScreenKeyFn func(ctx context.Context, key string) (*entities.ScreenInstance, error)
 	listFn           func(ctx context.Context, filter sharedrepo.ListFilters) ([]*entities.ScreenInstance, int, error)
+	listByTemplateFn func(ctx context.Context, templateID uuid.UUID) ([]*entities.ScreenInstance, error)
 	updateFn         func(ctx context.Context, instance *entities.ScreenInstance) error
 	deleteFn         func(ctx context.Context, id uuid.UUID) error
 }
@@ -310,6 +373,19 @@ func (m *mockScreenInstanceRepo) List(ctx context.Context, filter sharedrepo.Lis
 	}
 	return nil, 0, nil
 }
+func (m *mockScreenInstanceRepo) ListAfter(ctx context.Context, after uuid.UUID, limit int) ([]*entities.ScreenInstance, error) {
+	all, _, err := m.List(ctx, sharedrepo.ListFilters{})
+	if err != nil {
+		return nil, err
+	}
+	return keysetPage(all, func(i *entities.ScreenInstance) uuid.UUID { return i.ID }, after, limit), nil
+}
+func (m *mockScreenInstanceRepo) ListByTemplate(ctx context.Context, templateID uuid.UUID) ([]*entities.ScreenInstance, error) {
+	if m.listByTemplateFn != nil {
+		return m.listByTemplateFn(ctx, templateID)
+	}
+	return nil, nil
+}
 func (m *mockScreenInstanceRepo) Update(ctx context.Context, instance *entities.ScreenInstance) error {
 	if m.updateFn != nil {
 		return m.updateFn(ctx, instance)
@@ -327,9 +403,11 @@ func (m *mockScreenInstanceRepo) Delete(ctx context.Context, id uuid.UUID) error
 
 type mockResourceScreenRepo struct {
 	createFn            func(ctx context.Context, rs *entities.ResourceScreen) error
+	getByIDFn           func(ctx context.Context, id uuid.UUID) (*entities.ResourceScreen, error)
 	getByResourceIDFn   func(ctx context.Context, resourceID uuid.UUID) ([]*entities.ResourceScreen, error)
 	getByResourceKeyFn  func(ctx context.Context, key string) ([]*entities.ResourceScreen, error)
 	getByResourceKeysFn func(ctx context.Context, keys []string) ([]*entities.ResourceScreen, error)
+	getByScreenKeysFn   func(ctx context.Context, keys []string) ([]*entities.ResourceScreen, error)
 	deleteFn            func(ctx context.Context, id uuid.UUID) error
 }
 
@@ -342,6 +420,15 @@ func (m *mockResourceScreenRepo) Create(ctx context.Context, rs *entities.Resour
 	}
 	return nil
 }
+func (m *mockResourceScreenRepo) GetByID(ctx context.Context, id uuid.UUID) (*entities.ResourceScreen, error) {
+	if m == nil {
+		return nil, nil
+	}
+	if m.getByIDFn != nil {
+		return m.getByIDFn(ctx, id)
+	}
+	return nil, nil
+}
 func (m *mockResourceScreenRepo) GetByResourceID(ctx context.Context, resourceID uuid.UUID) ([]*entities.ResourceScreen, error) {
 	if m == nil {
 		return nil, nil
@@ -385,6 +472,15 @@ func (m *mockResourceScreenRepo) GetByResourceKeys(ctx context.Context, keys []s
 	}
 	return nil, nil
 }
+func (m *mockResourceScreenRepo) GetByScreenKeys(ctx context.Context, keys []string) ([]*entities.ResourceScreen, error) {
+	if m == nil {
+		return nil, nil
+	}
+	if m.getByScreenKeysFn != nil {
+		return m.getByScreenKeysFn(ctx, keys)
+	}
+	return nil, nil
+}
 func (m *mockResourceScreenRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	if m == nil {
 		return nil
@@ -435,3 +531,972 @@ func (m *mockRolePermRepo) Exists(ctx context.Context, roleID, permissionID uuid
 	}
 	return false, nil
 }
+
+// ─── IAMCatalogRepository mock ───────────────────────────────────────────────
+
+type mockIAMCatalogRepo struct {
+	snapshotFn func(ctx context.Context) (*repository.IAMCatalogSnapshot, error)
+	applyFn    func(ctx context.Context, changes *repository.IAMCatalogChangeSet) error
+}
+
+func (m *mockIAMCatalogRepo) Snapshot(ctx context.Context) (*repository.IAMCatalogSnapshot, error) {
+	if m.snapshotFn != nil {
+		return m.snapshotFn(ctx)
+	}
+	return &repository.IAMCatalogSnapshot{}, nil
+}
+func (m *mockIAMCatalogRepo) Reconcile(ctx context.Context, plan func(snap *repository.IAMCatalogSnapshot) (*repository.IAMCatalogChangeSet, error)) error {
+	snap, err := m.Snapshot(ctx)
+	if err != nil {
+		return err
+	}
+	changes, err := plan(snap)
+	if err != nil || changes == nil || m.applyFn == nil {
+		return err
+	}
+	return m.applyFn(ctx, changes)
+}
+
+// ─── RoleGrant mocks ─────────────────────────────────────────────────────────
+
+type mockRoleGrantPolicyRepo struct {
+	findByRoleFn func(ctx context.Context, roleID uuid.UUID) (*model.RoleGrantPolicy, error)
+	upsertFn     func(ctx context.Context, policy *model.RoleGrantPolicy) error
+}
+
+func (m *mockRoleGrantPolicyRepo) FindByRole(ctx context.Context, roleID uuid.UUID) (*model.RoleGrantPolicy, error) {
+	if m.findByRoleFn != nil {
+		return m.findByRoleFn(ctx, roleID)
+	}
+	return nil, nil
+}
+func (m *mockRoleGrantPolicyRepo) Upsert(ctx context.Context, policy *model.RoleGrantPolicy) error {
+	if m.upsertFn != nil {
+		return m.upsertFn(ctx, policy)
+	}
+	return nil
+}
+
+type mockRoleGrantRequestRepo struct {
+	createFn        func(ctx context.Context, req *model.RoleGrantRequest) error
+	findByIDFn      func(ctx context.Context, id uuid.UUID) (*model.RoleGrantRequest, error)
+	listFn          func(ctx context.Context, status string, filters sharedrepo.ListFilters) ([]*model.RoleGrantRequest, int, error)
+	decideFn        func(ctx context.Context, req *model.RoleGrantRequest, grant *entities.UserRole) (bool, error)
+	hasPendingFn    func(ctx context.Context, userID, roleID uuid.UUID, schoolID, unitID *uuid.UUID) (bool, error)
+	expirePendingFn func(ctx context.Context, now time.Time) ([]*model.RoleGrantRequest, error)
+}
+
+func (m *mockRoleGrantRequestRepo) Create(ctx context.Context, req *model.RoleGrantRequest) (bool, error) {
+	if m.createFn != nil {
+		return true, m.createFn(ctx, req)
+	}
+	return true, nil
+}
+func (m *mockRoleGrantRequestRepo) FindByID(ctx context.Context, id uuid.UUID) (*model.RoleGrantRequest, error) {
+	if m.findByIDFn != nil {
+		return m.findByIDFn(ctx, id)
+	}
+	return nil, nil
+}
+func (m *mockRoleGrantRequestRepo) List(ctx context.Context, status string, filters sharedrepo.ListFilters) ([]*model.RoleGrantRequest, int, error) {
+	if m.listFn != nil {
+		return m.listFn(ctx, status, filters)
+	}
+	return nil, 0, nil
+}
+func (m *mockRoleGrantRequestRepo) Decide(ctx context.Context, req *model.RoleGrantRequest, grant *entities.UserRole) (bool, error) {
+	if m.decideFn != nil {
+		return m.decideFn(ctx, req, grant)
+	}
+	return true, nil
+}
+func (m *mockRoleGrantRequestRepo) HasPending(ctx context.Context, userID, roleID uuid.UUID, schoolID, unitID *uuid.UUID) (bool, error) {
+	if m.hasPendingFn != nil {
+		return m.hasPendingFn(ctx, userID, roleID, schoolID, unitID)
+	}
+	return false, nil
+}
+func (m *mockRoleGrantRequestRepo) ExpirePending(ctx context.Context, now time.Time) ([]*model.RoleGrantRequest, error) {
+	if m.expirePendingFn != nil {
+		return m.expirePendingFn(ctx, now)
+	}
+	return nil, nil
+}
+
+type mockNotifier struct {
+	sent []Notification
+}
+
+func (m *mockNotifier) Notify(ctx context.Context, n Notification) error {
+	m.sent = append(m.sent, n)
+	return nil
+}
+
+// ─── AccessRequestRepository mock ────────────────────────────────────────────
+
+type mockAccessRequestRepo struct {
+	createFn     func(ctx context.Context, req *model.AccessRequest) error
+	findByIDFn   func(ctx context.Context, id uuid.UUID) (*model.AccessRequest, error)
+	listFn       func(ctx context.Context, filter repository.AccessRequestFilter, filters sharedrepo.ListFilters) ([]*model.AccessRequest, int, error)
+	updateFn     func(ctx context.Context, req *model.AccessRequest) error
+	decideFn     func(ctx context.Context, req *model.AccessRequest) (bool, error)
+	reopenFn     func(ctx context.Context, id uuid.UUID) error
+	hasPendingFn func(ctx context.Context, userID, roleID, schoolID uuid.UUID, unitID *uuid.UUID) (bool, error)
+}
+
+func (m *mockAccessRequestRepo) Create(ctx context.Context, req *model.AccessRequest) error {
+	if m.createFn != nil {
+		return m.createFn(ctx, req)
+	}
+	return nil
+}
+func (m *mockAccessRequestRepo) FindByID(ctx context.Context, id uuid.UUID) (*model.AccessRequest, error) {
+	if m.findByIDFn != nil {
+		return m.findByIDFn(ctx, id)
+	}
+	return nil, nil
+}
+func (m *mockAccessRequestRepo) List(ctx context.Context, filter repository.AccessRequestFilter, filters sharedrepo.ListFilters) ([]*model.AccessRequest, int, error) {
+	if m.listFn != nil {
+		return m.listFn(ctx, filter, filters)
+	}
+	return nil, 0, nil
+}
+func (m *mockAccessRequestRepo) Update(ctx context.Context, req *model.AccessRequest) error {
+	if m.updateFn != nil {
+		return m.updateFn(ctx, req)
+	}
+	return nil
+}
+func (m *mockAccessRequestRepo) Decide(ctx context.Context, req *model.AccessRequest) (bool, error) {
+	if m.decideFn != nil {
+		return m.decideFn(ctx, req)
+	}
+	return true, nil
+}
+func (m *mockAccessRequestRepo) Reopen(ctx context.Context, id uuid.UUID) error {
+	if m.reopenFn != nil {
+		return m.reopenFn(ctx, id)
+	}
+	return nil
+}
+func (m *mockAccessRequestRepo) HasPending(ctx context.Context, userID, roleID, schoolID uuid.UUID, unitID *uuid.UUID) (bool, error) {
+	if m.hasPendingFn != nil {
+		return m.hasPendingFn(ctx, userID, roleID, schoolID, unitID)
+	}
+	return false, nil
+}
+
+// ─── AccessReviewRepository mock ─────────────────────────────────────────────
+
+type mockAccessReviewRepo struct {
+	createCampaignFn   func(ctx context.Context, campaign *model.AccessReviewCampaign, items []*model.AccessReviewItem) error
+	findCampaignFn     func(ctx context.Context, id uuid.UUID) (*model.AccessReviewCampaign, error)
+	listCampaignsFn    func(ctx context.Context, status string, filters sharedrepo.ListFilters) ([]*model.AccessReviewCampaign, int, error)
+	updateCampaignFn   func(ctx context.Context, campaign *model.AccessReviewCampaign) error
+	findDueCampaignsFn func(ctx context.Context, now time.Time) ([]*model.AccessReviewCampaign, error)
+	findItemFn         func(ctx context.Context, id uuid.UUID) (*model.AccessReviewItem, error)
+	listItemsFn        func(ctx context.Context, filter repository.AccessReviewItemFilter) ([]*model.AccessReviewItem, error)
+	decideItemFn       func(ctx context.Context, item *model.AccessReviewItem, revoke bool) (bool, error)
+}
+
+func (m *mockAccessReviewRepo) CreateCampaign(ctx context.Context, campaign *model.AccessReviewCampaign, items []*model.AccessReviewItem) error {
+	if m.createCampaignFn != nil {
+		return m.createCampaignFn(ctx, campaign, items)
+	}
+	return nil
+}
+func (m *mockAccessReviewRepo) FindCampaign(ctx context.Context, id uuid.UUID) (*model.AccessReviewCampaign, error) {
+	if m.findCampaignFn != nil {
+		return m.findCampaignFn(ctx, id)
+	}
+	return nil, nil
+}
+func (m *mockAccessReviewRepo) ListCampaigns(ctx context.Context, status string, filters sharedrepo.ListFilters) ([]*model.AccessReviewCampaign, int, error) {
+	if m.listCampaignsFn != nil {
+		return m.listCampaignsFn(ctx, status, filters)
+	}
+	return nil, 0, nil
+}
+func (m *mockAccessReviewRepo) UpdateCampaign(ctx context.Context, campaign *model.AccessReviewCampaign) error {
+	if m.updateCampaignFn != nil {
+		return m.updateCampaignFn(ctx, campaign)
+	}
+	return nil
+}
+func (m *mockAccessReviewRepo) FindDueCampaigns(ctx context.Context, now time.Time) ([]*model.AccessReviewCampaign, error) {
+	if m.findDueCampaignsFn != nil {
+		return m.findDueCampaignsFn(ctx, now)
+	}
+	return nil, nil
+}
+func (m *mockAccessReviewRepo) FindItem(ctx context.Context, id uuid.UUID) (*model.AccessReviewItem, error) {
+	if m.findItemFn != nil {
+		return m.findItemFn(ctx, id)
+	}
+	return nil, nil
+}
+func (m *mockAccessReviewRepo) ListItems(ctx context.Context, filter repository.AccessReviewItemFilter) ([]*model.AccessReviewItem, error) {
+	if m.listItemsFn != nil {
+		return m.listItemsFn(ctx, filter)
+	}
+	return nil, nil
+}
+func (m *mockAccessReviewRepo) DecideItem(ctx context.Context, item *model.AccessReviewItem, revoke bool) (bool, error) {
+	if m.decideItemFn != nil {
+		return m.decideItemFn(ctx, item, revoke)
+	}
+	return true, nil
+}
+
+// ─── SoDConstraintRepository mock ────────────────────────────────────────────
+
+type mockSoDConstraintRepo struct {
+	createFn     func(ctx context.Context, constraint *model.SoDConstraint) error
+	findByIDFn   func(ctx context.Context, id uuid.UUID) (*model.SoDConstraint, error)
+	findActiveFn func(ctx context.Context) ([]*model.SoDConstraint, error)
+	existsFn     func(ctx context.Context, kind string, leftID, rightID uuid.UUID, schoolID *uuid.UUID) (bool, error)
+	deactivateFn func(ctx context.Context, id uuid.UUID) error
+}
+
+func (m *mockSoDConstraintRepo) Create(ctx context.Context, constraint *model.SoDConstraint) error {
+	if m.createFn != nil {
+		return m.createFn(ctx, constraint)
+	}
+	return nil
+}
+func (m *mockSoDConstraintRepo) FindByID(ctx context.Context, id uuid.UUID) (*model.SoDConstraint, error) {
+	if m.findByIDFn != nil {
+		return m.findByIDFn(ctx, id)
+	}
+	return nil, nil
+}
+func (m *mockSoDConstraintRepo) FindActive(ctx context.Context) ([]*model.SoDConstraint, error) {
+	if m.findActiveFn != nil {
+		return m.findActiveFn(ctx)
+	}
+	return nil, nil
+}
+func (m *mockSoDConstraintRepo) Exists(ctx context.Context, kind string, leftID, rightID uuid.UUID, schoolID *uuid.UUID) (bool, error) {
+	if m.existsFn != nil {
+		return m.existsFn(ctx, kind, leftID, rightID, schoolID)
+	}
+	return false, nil
+}
+func (m *mockSoDConstraintRepo) Deactivate(ctx context.Context, id uuid.UUID) error {
+	if m.deactivateFn != nil {
+		return m.deactivateFn(ctx, id)
+	}
+	return nil
+}
+
+// ─── RoleDelegationRepository mock ───────────────────────────────────────────
+
+type mockRoleDelegationRepo struct {
+	createFn   func(ctx context.Context, delegations []*model.RoleDelegation, grants []*entities.UserRole) error
+	findByIDFn func(ctx context.Context, id uuid.UUID) (*model.RoleDelegation, error)
+	listFn     func(ctx context.Context, filter repository.RoleDelegationFilter) ([]*model.RoleDelegation, error)
+	updateFn   func(ctx context.Context, delegation *model.RoleDelegation) error
+	activateFn func(ctx context.Context, delegation *model.RoleDelegation, grant *entities.UserRole) (bool, error)
+	overlapsFn func(ctx context.Context, sourceUserRoleID, delegateID uuid.UUID, startsAt, endsAt time.Time) (bool, error)
+}
+
+func (m *mockRoleDelegationRepo) Create(ctx context.Context, delegations []*model.RoleDelegation, grants []*entities.UserRole) error {
+	if m.createFn != nil {
+		return m.createFn(ctx, delegations, grants)
+	}
+	return nil
+}
+func (m *mockRoleDelegationRepo) FindByID(ctx context.Context, id uuid.UUID) (*model.RoleDelegation, error) {
+	if m.findByIDFn != nil {
+		return m.findByIDFn(ctx, id)
+	}
+	return nil, nil
+}
+func (m *mockRoleDelegationRepo) List(ctx context.Context, filter repository.RoleDelegationFilter) ([]*model.RoleDelegation, error) {
+	if m.listFn != nil {
+		return m.listFn(ctx, filter)
+	}
+	return nil, nil
+}
+func (m *mockRoleDelegationRepo) Update(ctx context.Context, delegation *model.RoleDelegation) error {
+	if m.updateFn != nil {
+		return m.updateFn(ctx, delegation)
+	}
+	return nil
+}
+func (m *mockRoleDelegationRepo) Activate(ctx context.Context, delegation *model.RoleDelegation, grant *entities.UserRole) (bool, error) {
+	if m.activateFn != nil {
+		return m.activateFn(ctx, delegation, grant)
+	}
+	return true, nil
+}
+func (m *mockRoleDelegationRepo) Overlaps(ctx context.Context, sourceUserRoleID, delegateID uuid.UUID, startsAt, endsAt time.Time) (bool, error) {
+	if m.overlapsFn != nil {
+		return m.overlapsFn(ctx, sourceUserRoleID, delegateID, startsAt, endsAt)
+	}
+	return false, nil
+}
+
+// ─── BreakGlassRepository mock ───────────────────────────────────────────────
+
+type mockBreakGlassRepo struct {
+	activateFn         func(ctx context.Context, elevation *model.BreakGlassElevation, grant *entities.UserRole, since time.Time, maxActivations int) (bool, error)
+	findByIDFn         func(ctx context.Context, id uuid.UUID) (*model.BreakGlassElevation, error)
+	findActiveByUserFn func(ctx context.Context, userID uuid.UUID, now time.Time) (*model.BreakGlassElevation, error)
+	countSinceFn       func(ctx context.Context, userID uuid.UUID, since time.Time) (int64, error)
+	listFn             func(ctx context.Context, filters sharedrepo.ListFilters) ([]*model.BreakGlassElevation, int, error)
+	findExpiredFn      func(ctx context.Context, now time.Time) ([]*model.BreakGlassElevation, error)
+	endFn              func(ctx context.Context, id uuid.UUID, endedAt time.Time, endedBy *uuid.UUID) error
+}
+
+func (m *mockBreakGlassRepo) Activate(ctx context.Context, elevation *model.BreakGlassElevation, grant *entities.UserRole, since time.Time, maxActivations int) (bool, error) {
+	if m.activateFn != nil {
+		return m.activateFn(ctx, elevation, grant, since, maxActivations)
+	}
+	return true, nil
+}
+func (m *mockBreakGlassRepo) FindByID(ctx context.Context, id uuid.UUID) (*model.BreakGlassElevation, error) {
+	if m.findByIDFn != nil {
+		return m.findByIDFn(ctx, id)
+	}
+	return nil, nil
+}
+func (m *mockBreakGlassRepo) FindActiveByUser(ctx context.Context, userID uuid.UUID, now time.Time) (*model.BreakGlassElevation, error) {
+	if m.findActiveByUserFn != nil {
+		return m.findActiveByUserFn(ctx, userID, now)
+	}
+	return nil, nil
+}
+func (m *mockBreakGlassRepo) CountSince(ctx context.Context, userID uuid.UUID, since time.Time) (int64, error) {
+	if m.countSinceFn != nil {
+		return m.countSinceFn(ctx, userID, since)
+	}
+	return 0, nil
+}
+func (m *mockBreakGlassRepo) List(ctx context.Context, filters sharedrepo.ListFilters) ([]*model.BreakGlassElevation, int, error) {
+	if m.listFn != nil {
+		return m.listFn(ctx, filters)
+	}
+	return nil, 0, nil
+}
+func (m *mockBreakGlassRepo) FindExpired(ctx context.Context, now time.Time) ([]*model.BreakGlassElevation, error) {
+	if m.findExpiredFn != nil {
+		return m.findExpiredFn(ctx, now)
+	}
+	return nil, nil
+}
+func (m *mockBreakGlassRepo) End(ctx context.Context, id uuid.UUID, endedAt time.Time, endedBy *uuid.UUID) error {
+	if m.endFn != nil {
+		return m.endFn(ctx, id, endedAt, endedBy)
+	}
+	return nil
+}
+
+type mockAlerter struct {
+	alerts []Alert
+}
+
+func (m *mockAlerter) Alert(ctx context.Context, alert Alert) error {
+	m.alerts = append(m.alerts, alert)
+	return nil
+}
+
+// ─── UserRepository mock ─────────────────────────────────────────────────────
+
+type mockUserRepo struct {
+	findByEmailFn   func(ctx context.Context, email string) (*entities.User, error)
+	findByIDFn      func(ctx context.Context, id uuid.UUID) (*entities.User, error)
+	updateFn        func(ctx context.Context, user *entities.User) error
+	createFn        func(ctx context.Context, user *entities.User) error
+	existsByEmailFn func(ctx context.Context, email string) (bool, error)
+	listFn          func(ctx context.Context, filters sharedrepo.ListFilters) ([]*entities.User, int64, error)
+}
+
+func (m *mockUserRepo) FindByEmail(ctx context.Context, email string) (*entities.User, error) {
+	if m.findByEmailFn != nil {
+		return m.findByEmailFn(ctx, email)
+	}
+	return nil, nil
+}
+func (m *mockUserRepo) FindByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	if m.findByIDFn != nil {
+		return m.findByIDFn(ctx, id)
+	}
+	return nil, nil
+}
+func (m *mockUserRepo) Update(ctx context.Context, user *entities.User) error {
+	if m.updateFn != nil {
+		return m.updateFn(ctx, user)
+	}
+	return nil
+}
+func (m *mockUserRepo) Create(ctx context.Context, user *entities.User) error {
+	if m.createFn != nil {
+		return m.createFn(ctx, user)
+	}
+	return nil
+}
+func (m *mockUserRepo) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	if m.existsByEmailFn != nil {
+		return m.existsByEmailFn(ctx, email)
+	}
+	return false, nil
+}
+func (m *mockUserRepo) Delete(ctx context.Context, id uuid.UUID) error { return nil }
+func (m *mockUserRepo) List(ctx context.Context, filters sharedrepo.ListFilters) ([]*entities.User, int64, error) {
+	if m.listFn != nil {
+		return m.listFn(ctx, filters)
+	}
+	return nil, 0, nil
+}
+
+type mockSessionRevoker struct {
+	revoked []string
+}
+
+func (m *mockSessionRevoker) RevokeUserSessions(userID string) {
+	m.revoked = append(m.revoked, userID)
+}
+
+type mockUserErasureRepo struct {
+	anonymizeFn func(ctx context.Context, user *entities.User, loginIdentifier string) error
+}
+
+func (m *mockUserErasureRepo) Anonymize(ctx context.Context, user *entities.User, loginIdentifier string) error {
+	if m.anonymizeFn != nil {
+		return m.anonymizeFn(ctx, user, loginIdentifier)
+	}
+	return nil
+}
+
+// ─── School / AcademicUnit repository mocks ──────────────────────────────────
+
+type mockSchoolRepo struct {
+	findByIDFn   func(ctx context.Context, id uuid.UUID) (*entities.School, error)
+	findByCodeFn func(ctx context.Context, code string) (*entities.School, error)
+}
+
+func (m *mockSchoolRepo) FindByID(ctx context.Context, id uuid.UUID) (*entities.School, error) {
+	if m.findByIDFn != nil {
+		return m.findByIDFn(ctx, id)
+	}
+	return nil, nil
+}
+func (m *mockSchoolRepo) FindByCode(ctx context.Context, code string) (*entities.School, error) {
+	if m.findByCodeFn != nil {
+		return m.findByCodeFn(ctx, code)
+	}
+	return nil, nil
+}
+func (m *mockSchoolRepo) Create(ctx context.Context, school *entities.School) error { return nil }
+func (m *mockSchoolRepo) Update(ctx context.Context, school *entities.School) error { return nil }
+func (m *mockSchoolRepo) Delete(ctx context.Context, id uuid.UUID) error            { return nil }
+func (m *mockSchoolRepo) List(ctx context.Context, filters sharedrepo.ListFilters) ([]*entities.School, int64, error) {
+	return nil, 0, nil
+}
+func (m *mockSchoolRepo) ExistsByCode(ctx context.Context, code string) (bool, error) {
+	return false, nil
+}
+
+type mockAcademicUnitRepo struct {
+	findByIDFn       func(ctx context.Context, id uuid.UUID) (*entities.AcademicUnit, error)
+	findBySchoolIDFn func(ctx context.Context, schoolID uuid.UUID, filters sharedrepo.ListFilters) ([]*entities.AcademicUnit, int64, error)
+}
+
+func (m *mockAcademicUnitRepo) FindByID(ctx context.Context, id uuid.UUID) (*entities.AcademicUnit, error) {
+	if m.findByIDFn != nil {
+		return m.findByIDFn(ctx, id)
+	}
+	return nil, nil
+}
+func (m *mockAcademicUnitRepo) FindBySchoolID(ctx context.Context, schoolID uuid.UUID, filters sharedrepo.ListFilters) ([]*entities.AcademicUnit, int64, error) {
+	if m.findBySchoolIDFn != nil {
+		return m.findBySchoolIDFn(ctx, schoolID, filters)
+	}
+	return nil, 0, nil
+}
+
+// ─── RoleImportRepository mock ───────────────────────────────────────────────
+
+// mockRoleImportRepo keeps jobs and rows in memory
+type mockRoleImportRepo struct {
+	jobs     map[uuid.UUID]*model.RoleImportJob
+	rows     map[uuid.UUID][]*model.RoleImportRow
+	applied  []*entities.UserRole
+	applyErr error
+}
+
+func newMockRoleImportRepo() *mockRoleImportRepo {
+	return &mockRoleImportRepo{jobs: map[uuid.UUID]*model.RoleImportJob{}, rows: map[uuid.UUID][]*model.RoleImportRow{}}
+}
+
+func (m *mockRoleImportRepo) Create(ctx context.Context, job *model.RoleImportJob, rows []*model.RoleImportRow) error {
+	m.jobs[job.ID] = job
+	m.rows[job.ID] = rows
+	return nil
+}
+func (m *mockRoleImportRepo) FindByID(ctx context.Context, id uuid.UUID) (*model.RoleImportJob, error) {
+	return m.jobs[id], nil
+}
+func (m *mockRoleImportRepo) List(ctx context.Context, requestedBy *uuid.UUID, filters sharedrepo.ListFilters) ([]*model.RoleImportJob, int, error) {
+	var jobs []*model.RoleImportJob
+	for _, j := range m.jobs {
+		jobs = append(jobs, j)
+	}
+	return jobs, len(jobs), nil
+}
+func (m *mockRoleImportRepo) FindPending(ctx context.Context, limit int) ([]*model.RoleImportJob, error) {
+	var jobs []*model.RoleImportJob
+	for _, j := range m.jobs {
+		if j.Status == model.RoleImportPending {
+			jobs = append(jobs, j)
+		}
+	}
+	return jobs, nil
+}
+func (m *mockRoleImportRepo) Claim(ctx context.Context, id uuid.UUID, now time.Time) (bool, error) {
+	j := m.jobs[id]
+	if j == nil || j.Status != model.RoleImportPending {
+		return false, nil
+	}
+	j.Status = model.RoleImportRunning
+	return true, nil
+}
+func (m *mockRoleImportRepo) UpdateJob(ctx context.Context, job *model.RoleImportJob) error {
+	m.jobs[job.ID] = job
+	return nil
+}
+func (m *mockRoleImportRepo) FindRows(ctx context.Context, jobID uuid.UUID, status string, filters sharedrepo.ListFilters) ([]*model.RoleImportRow, int, error) {
+	var rows []*model.RoleImportRow
+	for _, r := range m.rows[jobID] {
+		if status == "" || r.Status == status {
+			rows = append(rows, r)
+		}
+	}
+	return rows, len(rows), nil
+}
+func (m *mockRoleImportRepo) SaveRows(ctx context.Context, rows []*model.RoleImportRow) error {
+	return nil
+}
+func (m *mockRoleImportRepo) Apply(ctx context.Context, userRoles []*entities.UserRole, rows []*model.RoleImportRow) error {
+	if m.applyErr != nil {
+		return m.applyErr
+	}
+	m.applied = append(m.applied, userRoles...)
+	return nil
+}
+
+// ─── ScreenVersionRepository mock ────────────────────────────────────────────
+
+// mockScreenVersionRepo keeps the history in memory
+type mockScreenVersionRepo struct {
+	templateVersions []*model.ScreenTemplateVersion
+	instanceVersions []*model.ScreenInstanceVersion
+}
+
+func (m *mockScreenVersionRepo) CreateTemplateVersion(ctx context.Context, v *model.ScreenTemplateVersion) error {
+	m.templateVersions = append(m.templateVersions, v)
+	return nil
+}
+func (m *mockScreenVersionRepo) ListTemplateVersions(ctx context.Context, templateID uuid.UUID, filters sharedrepo.ListFilters) ([]*model.ScreenTemplateVersion, int, error) {
+	var result []*model.ScreenTemplateVersion
+	for i := len(m.templateVersions) - 1; i >= 0; i-- {
+		if m.templateVersions[i].TemplateID == templateID {
+			result = append(result, m.templateVersions[i])
+		}
+	}
+	return result, len(result), nil
+}
+func (m *mockScreenVersionRepo) GetTemplateVersion(ctx context.Context, templateID uuid.UUID, version int) (*model.ScreenTemplateVersion, error) {
+	for _, v := range m.templateVersions {
+		if v.TemplateID == templateID && v.Version == version {
+			return v, nil
+		}
+	}
+	return nil, nil
+}
+func (m *mockScreenVersionRepo) CreateInstanceVersion(ctx context.Context, v *model.ScreenInstanceVersion) error {
+	m.instanceVersions = append(m.instanceVersions, v)
+	return nil
+}
+func (m *mockScreenVersionRepo) ListInstanceVersions(ctx context.Context, instanceID uuid.UUID, filters sharedrepo.ListFilters) ([]*model.ScreenInstanceVersion, int, error) {
+	var result []*model.ScreenInstanceVersion
+	for i := len(m.instanceVersions) - 1; i >= 0; i-- {
+		if m.instanceVersions[i].InstanceID == instanceID {
+			result = append(result, m.instanceVersions[i])
+		}
+	}
+	return result, len(result), nil
+}
+func (m *mockScreenVersionRepo) GetInstanceVersion(ctx context.Context, instanceID uuid.UUID, version int) (*model.ScreenInstanceVersion, error) {
+	for _, v := range m.instanceVersions {
+		if v.InstanceID == instanceID && v.Version == version {
+			return v, nil
+		}
+	}
+	return nil, nil
+}
+func (m *mockScreenVersionRepo) LatestInstanceVersion(ctx context.Context, instanceID uuid.UUID) (int, error) {
+	latest := 0
+	for _, v := range m.instanceVersions {
+		if v.InstanceID == instanceID && v.Version > latest {
+			latest = v.Version
+		}
+	}
+	return latest, nil
+}
+
+// ─── ScreenDraftRepository mock ──────────────────────────────────────────────
+
+// mockScreenDraftRepo keeps drafts in memory
+type mockScreenDraftRepo struct {
+	templateDrafts map[uuid.UUID]*model.ScreenTemplateDraft
+	instanceDrafts map[uuid.UUID]*model.ScreenInstanceDraft
+}
+
+func (m *mockScreenDraftRepo) SaveTemplateDraft(ctx context.Context, d *model.ScreenTemplateDraft) error {
+	if m.templateDrafts == nil {
+		m.templateDrafts = map[uuid.UUID]*model.ScreenTemplateDraft{}
+	}
+	cp := *d
+	m.templateDrafts[d.TemplateID] = &cp
+	return nil
+}
+func (m *mockScreenDraftRepo) GetTemplateDraft(ctx context.Context, templateID uuid.UUID) (*model.ScreenTemplateDraft, error) {
+	if d, ok := m.templateDrafts[templateID]; ok {
+		cp := *d
+		return &cp, nil
+	}
+	return nil, nil
+}
+func (m *mockScreenDraftRepo) ListTemplateDrafts(ctx context.Context) ([]*model.ScreenTemplateDraft, error) {
+	var result []*model.ScreenTemplateDraft
+	for _, d := range m.templateDrafts {
+		cp := *d
+		result = append(result, &cp)
+	}
+	return result, nil
+}
+func (m *mockScreenDraftRepo) DeleteTemplateDraft(ctx context.Context, templateID uuid.UUID) error {
+	delete(m.templateDrafts, templateID)
+	return nil
+}
+func (m *mockScreenDraftRepo) FindDueTemplateDrafts(ctx context.Context, now time.Time) ([]*model.ScreenTemplateDraft, error) {
+	var result []*model.ScreenTemplateDraft
+	for _, d := range m.templateDrafts {
+		if d.PublishAt != nil && !d.PublishAt.After(now) {
+			cp := *d
+			result = append(result, &cp)
+		}
+	}
+	return result, nil
+}
+func (m *mockScreenDraftRepo) SaveInstanceDraft(ctx context.Context, d *model.ScreenInstanceDraft) error {
+	if m.instanceDrafts == nil {
+		m.instanceDrafts = map[uuid.UUID]*model.ScreenInstanceDraft{}
+	}
+	cp := *d
+	m.instanceDrafts[d.InstanceID] = &cp
+	return nil
+}
+func (m *mockScreenDraftRepo) GetInstanceDraft(ctx context.Context, instanceID uuid.UUID) (*model.ScreenInstanceDraft, error) {
+	if d, ok := m.instanceDrafts[instanceID]; ok {
+		cp := *d
+		return &cp, nil
+	}
+	return nil, nil
+}
+func (m *mockScreenDraftRepo) ListInstanceDrafts(ctx context.Context) ([]*model.ScreenInstanceDraft, error) {
+	var result []*model.ScreenInstanceDraft
+	for _, d := range m.instanceDrafts {
+		cp := *d
+		result = append(result, &cp)
+	}
+	return result, nil
+}
+func (m *mockScreenDraftRepo) DeleteInstanceDraft(ctx context.Context, instanceID uuid.UUID) error {
+	delete(m.instanceDrafts, instanceID)
+	return nil
+}
+func (m *mockScreenDraftRepo) FindDueInstanceDrafts(ctx context.Context, now time.Time) ([]*model.ScreenInstanceDraft, error) {
+	var result []*model.ScreenInstanceDraft
+	for _, d := range m.instanceDrafts {
+		if d.PublishAt != nil && !d.PublishAt.After(now) {
+			cp := *d
+			result = append(result, &cp)
+		}
+	}
+	return result, nil
+}
+
+// ─── ScreenPatternSchemaRepository mock ──────────────────────────────────────
+
+type mockScreenPatternSchemaRepo struct {
+	schemas map[string]*model.ScreenPatternSchema
+}
+
+func (m *mockScreenPatternSchemaRepo) Get(ctx context.Context, pattern string) (*model.ScreenPatternSchema, error) {
+	return m.schemas[pattern], nil
+}
+func (m *mockScreenPatternSchemaRepo) List(ctx context.Context) ([]*model.ScreenPatternSchema, error) {
+	var result []*model.ScreenPatternSchema
+	for _, s := range m.schemas {
+		result = append(result, s)
+	}
+	return result, nil
+}
+func (m *mockScreenPatternSchemaRepo) Save(ctx context.Context, s *model.ScreenPatternSchema) error {
+	if m.schemas == nil {
+		m.schemas = map[string]*model.ScreenPatternSchema{}
+	}
+	m.schemas[s.Pattern] = s
+	return nil
+}
+func (m *mockScreenPatternSchemaRepo) Delete(ctx context.Context, pattern string) error {
+	delete(m.schemas, pattern)
+	return nil
+}
+
+// ─── ScreenOverrideRepository mock ───────────────────────────────────────────
+
+type mockScreenOverrideRepo struct {
+	overrides []*model.ScreenOverride
+}
+
+func sameRole(a, b *uuid.UUID) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
+
+func (m *mockScreenOverrideRepo) Create(ctx context.Context, o *model.ScreenOverride) error {
+	m.overrides = append(m.overrides, o)
+	return nil
+}
+func (m *mockScreenOverrideRepo) GetByID(ctx context.Context, id uuid.UUID) (*model.ScreenOverride, error) {
+	for _, o := range m.overrides {
+		if o.ID == id {
+			cp := *o
+			return &cp, nil
+		}
+	}
+	return nil, nil
+}
+func (m *mockScreenOverrideRepo) GetByContext(ctx context.Context, instanceID, schoolID uuid.UUID, roleID *uuid.UUID) (*model.ScreenOverride, error) {
+	for _, o := range m.overrides {
+		if o.InstanceID == instanceID && o.SchoolID == schoolID && sameRole(o.RoleID, roleID) {
+			return o, nil
+		}
+	}
+	return nil, nil
+}
+func (m *mockScreenOverrideRepo) Update(ctx context.Context, o *model.ScreenOverride) error {
+	for i, existing := range m.overrides {
+		if existing.ID == o.ID {
+			m.overrides[i] = o
+		}
+	}
+	return nil
+}
+func (m *mockScreenOverrideRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	m.overrides = slices.DeleteFunc(m.overrides, func(o *model.ScreenOverride) bool { return o.ID == id })
+	return nil
+}
+func (m *mockScreenOverrideRepo) ListByInstance(ctx context.Context, instanceID uuid.UUID, schoolID *uuid.UUID) ([]*model.ScreenOverride, error) {
+	var result []*model.ScreenOverride
+	for _, o := range m.overrides {
+		if o.InstanceID == instanceID && (schoolID == nil || o.SchoolID == *schoolID) {
+			result = append(result, o)
+		}
+	}
+	return result, nil
+}
+func (m *mockScreenOverrideRepo) FindForContext(ctx context.Context, schoolID uuid.UUID, roleID *uuid.UUID, instanceID *uuid.UUID) ([]*model.ScreenOverride, error) {
+	var result []*model.ScreenOverride
+	for _, o := range m.overrides {
+		if o.SchoolID != schoolID || (o.RoleID != nil && !sameRole(o.RoleID, roleID)) {
+			continue
+		}
+		if instanceID != nil && o.InstanceID != *instanceID {
+			continue
+		}
+		result = append(result, o)
+	}
+	return result, nil
+}
+
+// ─── TranslationRepository mock ──────────────────────────────────────────────
+
+type mockTranslationRepo struct {
+	translations []*model.Translation
+}
+
+func (m *mockTranslationRepo) ListForEntity(ctx context.Context, entityType string, entityID uuid.UUID) ([]*model.Translation, error) {
+	var result []*model.Translation
+	for _, t := range m.translations {
+		if t.EntityType == entityType && t.EntityID == entityID {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+func (m *mockTranslationRepo) FindByLocale(ctx context.Context, entityType, field, locale string) ([]*model.Translation, error) {
+	var result []*model.Translation
+	for _, t := range m.translations {
+		if t.EntityType == entityType && t.Field == field && t.Locale == locale {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+func (m *mockTranslationRepo) ReplaceForEntity(ctx context.Context, entityType string, entityID uuid.UUID, translations []*model.Translation) error {
+	m.translations = slices.DeleteFunc(m.translations, func(t *model.Translation) bool {
+		return t.EntityType == entityType && t.EntityID == entityID
+	})
+	m.translations = append(m.translations, translations...)
+	return nil
+}
+
+// ─── UserPreferenceRepository mock ───────────────────────────────────────────
+
+type mockUserPreferenceRepo struct {
+	prefs map[uuid.UUID]*model.UserPreference
+}
+
+func (m *mockUserPreferenceRepo) Get(ctx context.Context, userID uuid.UUID) (*model.UserPreference, error) {
+	return m.prefs[userID], nil
+}
+func (m *mockUserPreferenceRepo) Save(ctx context.Context, pref *model.UserPreference) error {
+	if m.prefs == nil {
+		m.prefs = map[uuid.UUID]*model.UserPreference{}
+	}
+	m.prefs[pref.UserID] = pref
+	return nil
+}
+
+// ─── SchoolConceptRepository mock ────────────────────────────────────────────
+
+type mockSchoolConceptRepo struct {
+	terms map[uuid.UUID]map[string]string
+}
+
+func (m *mockSchoolConceptRepo) school(schoolID uuid.UUID) map[string]string {
+	if m.terms == nil {
+		m.terms = map[uuid.UUID]map[string]string{}
+	}
+	if m.terms[schoolID] == nil {
+		m.terms[schoolID] = map[string]string{}
+	}
+	return m.terms[schoolID]
+}
+func (m *mockSchoolConceptRepo) FindBySchoolID(ctx context.Context, schoolID uuid.UUID) ([]*entities.SchoolConcept, error) {
+	var result []*entities.SchoolConcept
+	for key, value := range m.terms[schoolID] {
+		result = append(result, &entities.SchoolConcept{TermKey: key, TermValue: value})
+	}
+	return result, nil
+}
+func (m *mockSchoolConceptRepo) Save(ctx context.Context, schoolID uuid.UUID, termKey, termValue string) error {
+	m.school(schoolID)[termKey] = termValue
+	return nil
+}
+func (m *mockSchoolConceptRepo) Delete(ctx context.Context, schoolID uuid.UUID, termKey string) (bool, error) {
+	terms := m.school(schoolID)
+	_, ok := terms[termKey]
+	delete(terms, termKey)
+	return ok, nil
+}
+func (m *mockSchoolConceptRepo) SaveBatch(ctx context.Context, schoolID uuid.UUID, terms map[string]string, replace bool) error {
+	if replace {
+		clear(m.school(schoolID))
+	}
+	maps.Copy(m.school(schoolID), terms)
+	return nil
+}
+
+// ─── GlossaryDefaultRepository mock ──────────────────────────────────────────
+
+type mockGlossaryDefaultRepo struct {
+	terms map[string]*model.GlossaryDefault
+}
+
+func (m *mockGlossaryDefaultRepo) List(ctx context.Context) ([]*model.GlossaryDefault, error) {
+	var result []*model.GlossaryDefault
+	for _, key := range slices.Sorted(maps.Keys(m.terms)) {
+		result = append(result, m.terms[key])
+	}
+	return result, nil
+}
+func (m *mockGlossaryDefaultRepo) Get(ctx context.Context, termKey string) (*model.GlossaryDefault, error) {
+	return m.terms[termKey], nil
+}
+func (m *mockGlossaryDefaultRepo) Save(ctx context.Context, term *model.GlossaryDefault) error {
+	return m.SaveBatch(ctx, []*model.GlossaryDefault{term}, false)
+}
+func (m *mockGlossaryDefaultRepo) Delete(ctx context.Context, termKey string) error {
+	delete(m.terms, termKey)
+	return nil
+}
+func (m *mockGlossaryDefaultRepo) SaveBatch(ctx context.Context, terms []*model.GlossaryDefault, replace bool) error {
+	if replace || m.terms == nil {
+		m.terms = map[string]*model.GlossaryDefault{}
+	}
+	for _, t := range terms {
+		m.terms[t.TermKey] = t
+	}
+	return nil
+}
+
+// ─── ScreenConfigBundleRepository mock ───────────────────────────────────────
+
+// mockScreenConfigBundleRepo serves a fixed snapshot and records the applied
+// change set
+type mockScreenConfigBundleRepo struct {
+	snapshot  *repository.ScreenConfigSnapshot
+	applied   *repository.ScreenConfigChangeSet
+	snapshots int
+	applyFn   func(ctx context.Context, changes *repository.ScreenConfigChangeSet) error
+}
+
+func (m *mockScreenConfigBundleRepo) Snapshot(ctx context.Context) (*repository.ScreenConfigSnapshot, error) {
+	m.snapshots++
+	if m.snapshot == nil {
+		return &repository.ScreenConfigSnapshot{}, nil
+	}
+	return m.snapshot, nil
+}
+func (m *mockScreenConfigBundleRepo) Apply(ctx context.Context, changes *repository.ScreenConfigChangeSet) error {
+	m.applied = changes
+	if m.applyFn != nil {
+		return m.applyFn(ctx, changes)
+	}
+	return nil
+}
+
+func (m *mockScreenConfigBundleRepo) Reconcile(ctx context.Context, plan func(snap *repository.ScreenConfigSnapshot) (*repository.ScreenConfigChangeSet, error)) error {
+	snap, err := m.Snapshot(ctx)
+	if err != nil {
+		return err
+	}
+	changes, err := plan(snap)
+	if err != nil || changes == nil {
+		return err
+	}
+	return m.Apply(ctx, changes)
+}
+
+// VisibleLeafResource answers from the snapshot, like the query does
+func (m *mockScreenConfigBundleRepo) VisibleLeafResource(ctx context.Context, id uuid.UUID) (*entities.Resource, error) {
+	if m.snapshot == nil {
+		return nil, nil
+	}
+	var resource *entities.Resource
+	for _, r := range m.snapshot.Resources {
+		if r.IsActive && r.ParentID != nil && *r.ParentID == id {
+			return nil, nil
+		}
+		if r.ID == id && r.IsActive && r.IsMenuVisible {
+			resource = r
+		}
+	}
+	return resource, nil
+}
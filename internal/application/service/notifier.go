@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// Notification events emitted by IAM workflows
+const (
+	NotifyRoleGrantRequested = "role_grant.requested"
+	NotifyRoleGrantApproved  = "role_grant.approved"
+	NotifyRoleGrantRejected  = "role_grant.rejected"
+	NotifyRoleGrantExpired   = "role_grant.expired"
+)
+
+// Notification describes a workflow event someone may need to act on.
+// Recipients are user IDs; an empty list means "whoever can act on it"
+// (e.g. every approver), which the Notifier implementation resolves.
+type Notification struct {
+	Event      string
+	Recipients []string
+	Subject    string
+	Data       map[string]interface{}
+}
+
+// Notifier delivers workflow notifications (email, chat, webhooks...).
+// Delivery errors are logged by callers and never fail the workflow.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+type logNotifier struct {
+	logger logger.Logger
+}
+
+// NewLogNotifier returns a Notifier that only writes notifications to the log.
+// It is the default until a delivery channel is configured.
+func NewLogNotifier(logger logger.Logger) Notifier {
+	return &logNotifier{logger: logger}
+}
+
+func (n *logNotifier) Notify(ctx context.Context, notification Notification) error {
+	n.logger.Info("notification", "event", notification.Event, "subject", notification.Subject, "recipients", notification.Recipients)
+	return nil
+}
+
+// notify sends a notification and logs delivery failures
+func notify(ctx context.Context, notifier Notifier, log logger.Logger, n Notification) {
+	if notifier == nil {
+		return
+	}
+	if err := notifier.Notify(ctx, n); err != nil {
+		log.Warn("notification failed", "event", n.Event, "error", err)
+	}
+}
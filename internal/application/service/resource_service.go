@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
@@ -18,17 +19,18 @@ type ResourceService interface {
 	ListResources(ctx context.Context, filters sharedrepo.ListFilters) (*dto.ResourcesResponse, error)
 	GetResource(ctx context.Context, id string) (*dto.ResourceDTO, error)
 	CreateResource(ctx context.Context, req dto.CreateResourceRequest) (*dto.ResourceDTO, error)
-	UpdateResource(ctx context.Context, id string, req dto.UpdateResourceRequest) (*dto.ResourceDTO, error)
+	UpdateResource(ctx context.Context, id string, req dto.UpdateResourceRequest, cascade bool) (*dto.ResourceDTO, error)
 }
 
 type resourceService struct {
 	resourceRepo repository.ResourceRepository
+	screenRepo   repository.ScreenConfigBundleRepository
 	logger       logger.Logger
 }
 
 // NewResourceService creates a new resource service
-func NewResourceService(resourceRepo repository.ResourceRepository, logger logger.Logger) ResourceService {
-	return &resourceService{resourceRepo: resourceRepo, logger: logger}
+func NewResourceService(resourceRepo repository.ResourceRepository, screenRepo repository.ScreenConfigBundleRepository, logger logger.Logger) ResourceService {
+	return &resourceService{resourceRepo: resourceRepo, screenRepo: screenRepo, logger: logger}
 }
 
 func (s *resourceService) ListResources(ctx context.Context, filters sharedrepo.ListFilters) (*dto.ResourcesResponse, error) {
@@ -107,7 +109,10 @@ func (s *resourceService) CreateResource(ctx context.Context, req dto.CreateReso
 	return &d, nil
 }
 
-func (s *resourceService) UpdateResource(ctx context.Context, id string, req dto.UpdateResourceRequest) (*dto.ResourceDTO, error) {
+// UpdateResource updates a resource. Deactivating a resource that still has
+// active children or linked screens is refused unless cascade is set, which
+// deactivates the subtree and unlinks its screens.
+func (s *resourceService) UpdateResource(ctx context.Context, id string, req dto.UpdateResourceRequest, cascade bool) (*dto.ResourceDTO, error) {
 	rid, err := uuid.Parse(id)
 	if err != nil {
 		return nil, errors.NewValidationError("invalid resource ID")
@@ -150,6 +155,11 @@ func (s *resourceService) UpdateResource(ctx context.Context, id string, req dto
 		resource.Scope = *req.Scope
 	}
 	if req.IsActive != nil {
+		if resource.IsActive && !*req.IsActive {
+			if err := s.releaseResource(ctx, resource, cascade); err != nil {
+				return nil, err
+			}
+		}
 		resource.IsActive = *req.IsActive
 	}
 
@@ -162,3 +172,36 @@ func (s *resourceService) UpdateResource(ctx context.Context, id string, req dto
 	d := dto.ToResourceDTO(resource)
 	return &d, nil
 }
+
+// releaseResource checks what still hangs from a resource being deactivated.
+// With cascade the active descendants are deactivated and the screens of the
+// whole subtree unlinked before the resource itself is updated.
+func (s *resourceService) releaseResource(ctx context.Context, resource *entities.Resource, cascade bool) error {
+	snap, err := s.screenRepo.Snapshot(ctx)
+	if err != nil {
+		return errors.NewDatabaseError("load screen config", err)
+	}
+	descendants := activeDescendants(snap, resource.ID)
+	subtree := map[uuid.UUID]bool{resource.ID: true}
+	changes := &repository.ScreenConfigChangeSet{}
+	for _, d := range descendants {
+		subtree[d.ID] = true
+		changes.DeactivateResources = append(changes.DeactivateResources, d.ID)
+	}
+	links := activeLinksOfResources(snap, subtree)
+	if len(descendants) == 0 && len(links) == 0 {
+		return nil
+	}
+	if !cascade {
+		return errors.NewConflictError(fmt.Sprintf(
+			"resource %s has %d active child resources and %d linked screens; retry with cascade=true to deactivate them",
+			resource.Key, len(descendants), len(links)))
+	}
+	changes.DeleteResourceScreens = resourceScreenIDs(links)
+	if err := s.screenRepo.Apply(ctx, changes); err != nil {
+		return errors.NewDatabaseError("deactivate resource", err)
+	}
+	s.logger.Info("resource released", "entity_id", resource.ID.String(),
+		"cascade_resources", len(descendants), "cascade_links", len(links))
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	sharedErrors "github.com/EduGoGroup/edugo-shared/common/errors"
 	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
@@ -13,7 +14,7 @@ import (
 )
 
 func newResourceService(repo *mockResourceRepo) ResourceService {
-	return NewResourceService(repo, &mockLogger{})
+	return NewResourceService(repo, &mockScreenConfigBundleRepo{}, &mockLogger{})
 }
 
 // ─── ListResources ────────────────────────────────────────────────────────────
@@ -350,7 +351,7 @@ func TestResourceService_UpdateResource(t *testing.T) {
 
 		newName := "New Name"
 		req := dto.UpdateResourceRequest{DisplayName: &newName}
-		resp, err := svc.UpdateResource(ctx, id.String(), req)
+		resp, err := svc.UpdateResource(ctx, id.String(), req, false)
 		if err != nil {
 			t.Fatalf("error inesperado: %v", err)
 		}
@@ -374,7 +375,7 @@ func TestResourceService_UpdateResource(t *testing.T) {
 
 		emptyStr := ""
 		req := dto.UpdateResourceRequest{ParentID: &emptyStr}
-		resp, err := svc.UpdateResource(ctx, id.String(), req)
+		resp, err := svc.UpdateResource(ctx, id.String(), req, false)
 		if err != nil {
 			t.Fatalf("error inesperado: %v", err)
 		}
@@ -385,7 +386,7 @@ func TestResourceService_UpdateResource(t *testing.T) {
 
 	t.Run("retorna error de validación con ID inválido", func(t *testing.T) {
 		svc := newResourceService(&mockResourceRepo{})
-		_, err := svc.UpdateResource(ctx, "bad-uuid", dto.UpdateResourceRequest{})
+		_, err := svc.UpdateResource(ctx, "bad-uuid", dto.UpdateResourceRequest{}, false)
 		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
 	})
 
@@ -394,7 +395,7 @@ func TestResourceService_UpdateResource(t *testing.T) {
 			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Resource, error) { return nil, nil },
 		}
 		svc := newResourceService(repo)
-		_, err := svc.UpdateResource(ctx, uuid.New().String(), dto.UpdateResourceRequest{})
+		_, err := svc.UpdateResource(ctx, uuid.New().String(), dto.UpdateResourceRequest{}, false)
 		assertAppError(t, err, sharedErrors.ErrorCodeNotFound)
 	})
 
@@ -407,7 +408,7 @@ func TestResourceService_UpdateResource(t *testing.T) {
 		svc := newResourceService(repo)
 		bad := "not-uuid"
 		req := dto.UpdateResourceRequest{ParentID: &bad}
-		_, err := svc.UpdateResource(ctx, id.String(), req)
+		_, err := svc.UpdateResource(ctx, id.String(), req, false)
 		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
 	})
 
@@ -421,7 +422,44 @@ func TestResourceService_UpdateResource(t *testing.T) {
 		svc := newResourceService(repo)
 		newName := "Updated"
 		req := dto.UpdateResourceRequest{DisplayName: &newName}
-		_, err := svc.UpdateResource(ctx, id.String(), req)
+		_, err := svc.UpdateResource(ctx, id.String(), req, false)
 		assertAppError(t, err, sharedErrors.ErrorCodeDatabaseError)
 	})
+	t.Run("desactivar un recurso con pantallas requiere cascade", func(t *testing.T) {
+		id := uuid.New()
+		existing := &entities.Resource{ID: id, Key: "students", DisplayName: "Alumnos", Scope: "school", IsActive: true}
+		child := &entities.Resource{ID: uuid.New(), Key: "grades", ParentID: &id, IsActive: true}
+		link := &entities.ResourceScreen{ID: uuid.New(), ResourceID: child.ID, ResourceKey: "grades", ScreenKey: "grades-list", IsActive: true}
+		var updated *entities.Resource
+		repo := &mockResourceRepo{
+			findByIDFn: func(ctx context.Context, gotID uuid.UUID) (*entities.Resource, error) { return existing, nil },
+			updateFn:   func(ctx context.Context, r *entities.Resource) error { updated = r; return nil },
+		}
+		screens := &mockScreenConfigBundleRepo{snapshot: &repository.ScreenConfigSnapshot{
+			Resources:       []*entities.Resource{existing, child},
+			ResourceScreens: []*entities.ResourceScreen{link},
+		}}
+		svc := NewResourceService(repo, screens, &mockLogger{})
+		inactive := false
+		req := dto.UpdateResourceRequest{IsActive: &inactive}
+
+		_, err := svc.UpdateResource(ctx, id.String(), req, false)
+		assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+		if updated != nil || screens.applied != nil {
+			t.Fatal("no debería escribir sin cascade")
+		}
+
+		existing.IsActive = true
+		if _, err := svc.UpdateResource(ctx, id.String(), req, true); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		changes := screens.applied
+		if changes == nil || len(changes.DeactivateResources) != 1 || changes.DeactivateResources[0] != child.ID ||
+			len(changes.DeleteResourceScreens) != 1 || changes.DeleteResourceScreens[0] != link.ID {
+			t.Errorf("cascade incorrecto: %+v", changes)
+		}
+		if updated == nil || updated.IsActive {
+			t.Error("el recurso debería quedar inactivo")
+		}
+	})
 }
@@ -0,0 +1,369 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedErrors "github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+// activeUsers finds every user as existing and active
+var activeUsers = &mockUserRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	return &entities.User{ID: id, IsActive: true}, nil
+}}
+
+func newRoleDelegationService(dRepo *mockRoleDelegationRepo, urRepo *mockUserRoleRepo) RoleDelegationService {
+	return newRoleDelegationServiceWithUsers(dRepo, activeUsers, urRepo)
+}
+
+func newRoleDelegationServiceWithUsers(dRepo *mockRoleDelegationRepo, userRepo *mockUserRepo, urRepo *mockUserRoleRepo) RoleDelegationService {
+	return newRoleDelegationServiceWithPolicies(dRepo, userRepo, urRepo, &mockRoleGrantPolicyRepo{})
+}
+
+func newRoleDelegationServiceWithPolicies(dRepo *mockRoleDelegationRepo, userRepo *mockUserRepo, urRepo *mockUserRoleRepo, policies *mockRoleGrantPolicyRepo) RoleDelegationService {
+	roleRepo := &mockRoleRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Role, error) {
+		return &entities.Role{ID: id, Name: "teacher", Scope: "unit", IsActive: true}, nil
+	}}
+	approvals := NewRoleGrantApprovalService(policies, &mockRoleGrantRequestRepo{}, roleRepo, urRepo, nil, &mockNotifier{},
+		&mockLogger{}, &mockAuditLogger{}, 72*time.Hour)
+	return NewRoleDelegationService(dRepo, userRepo, urRepo, roleRepo, nil, approvals, &mockNotifier{}, &mockLogger{}, &mockAuditLogger{}, 90*24*time.Hour)
+}
+
+func TestRoleDelegationService_Create(t *testing.T) {
+	ctx := context.Background()
+	teacherID, substituteID, schoolID, unitID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	own := &entities.UserRole{ID: uuid.New(), UserID: teacherID, RoleID: uuid.New(), SchoolID: &schoolID, AcademicUnitID: &unitID, IsActive: true}
+	holdings := &mockUserRoleRepo{findByUserInContextFn: func(ctx context.Context, userID uuid.UUID, s *uuid.UUID, u *uuid.UUID) ([]*entities.UserRole, error) {
+		if userID == teacherID {
+			return []*entities.UserRole{own}, nil
+		}
+		return nil, nil
+	}}
+	endsAt := time.Now().Add(14 * 24 * time.Hour).Format(time.RFC3339)
+
+	t.Run("activa de inmediato y otorga un rol con vencimiento", func(t *testing.T) {
+		var granted *entities.UserRole
+		var created *model.RoleDelegation
+		svc := newRoleDelegationService(&mockRoleDelegationRepo{
+			createFn: func(ctx context.Context, ds []*model.RoleDelegation, grants []*entities.UserRole) error {
+				if len(ds) != 1 || len(grants) != 1 {
+					t.Fatalf("esperaba una delegación y un rol en la misma escritura: %d, %d", len(ds), len(grants))
+				}
+				created, granted = ds[0], grants[0]
+				return nil
+			},
+		}, holdings)
+
+		result, err := svc.Create(ctx, teacherID.String(), &dto.CreateDelegationRequest{
+			DelegateID: substituteID.String(), AcademicUnitID: unitID.String(), EndsAt: endsAt,
+		})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if len(result) != 1 || result[0].Status != model.DelegationActive {
+			t.Fatalf("delegación incorrecta: %+v", result)
+		}
+		if created == nil || created.SourceUserRoleID != own.ID {
+			t.Errorf("debe copiar la asignación del delegante: %+v", created)
+		}
+		if created.Status != model.DelegationActive || created.DelegatedUserRoleID == nil || *created.DelegatedUserRoleID != granted.ID {
+			t.Errorf("la delegación debe guardarse activa y apuntar al rol otorgado: %+v", created)
+		}
+		if granted == nil || granted.UserID != substituteID || granted.RoleID != own.RoleID || granted.ExpiresAt == nil {
+			t.Fatalf("rol delegado incorrecto: %+v", granted)
+		}
+		if *granted.AcademicUnitID != unitID || *granted.GrantedBy != teacherID {
+			t.Errorf("el rol debe quedar en la unidad y otorgado por el delegante: %+v", granted)
+		}
+	})
+
+	t.Run("programa las delegaciones futuras sin otorgar", func(t *testing.T) {
+		svc := newRoleDelegationService(&mockRoleDelegationRepo{createFn: func(ctx context.Context, ds []*model.RoleDelegation, grants []*entities.UserRole) error {
+			if len(grants) != 0 {
+				t.Error("no debe otorgar antes del inicio")
+			}
+			return nil
+		}}, holdings)
+		startsAt := time.Now().Add(24 * time.Hour).Format(time.RFC3339)
+		result, err := svc.Create(ctx, teacherID.String(), &dto.CreateDelegationRequest{
+			DelegateID: substituteID.String(), AcademicUnitID: unitID.String(), StartsAt: &startsAt, EndsAt: endsAt,
+		})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if result[0].Status != model.DelegationScheduled {
+			t.Errorf("status = %s, want scheduled", result[0].Status)
+		}
+	})
+
+	t.Run("exige un delegado existente y activo", func(t *testing.T) {
+		req := &dto.CreateDelegationRequest{DelegateID: substituteID.String(), AcademicUnitID: unitID.String(), EndsAt: endsAt}
+		svc := newRoleDelegationServiceWithUsers(&mockRoleDelegationRepo{}, &mockUserRepo{}, holdings)
+		_, err := svc.Create(ctx, teacherID.String(), req)
+		assertAppError(t, err, sharedErrors.ErrorCodeNotFound)
+
+		inactive := &mockUserRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+			return &entities.User{ID: id, IsActive: false}, nil
+		}}
+		svc = newRoleDelegationServiceWithUsers(&mockRoleDelegationRepo{}, inactive, holdings)
+		_, err = svc.Create(ctx, teacherID.String(), req)
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	})
+
+	t.Run("rechaza asignaciones ajenas", func(t *testing.T) {
+		svc := newRoleDelegationService(&mockRoleDelegationRepo{}, holdings)
+		_, err := svc.Create(ctx, teacherID.String(), &dto.CreateDelegationRequest{
+			DelegateID: substituteID.String(), AcademicUnitID: unitID.String(), EndsAt: endsAt, UserRoleIDs: []string{uuid.New().String()},
+		})
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	})
+
+	t.Run("no permite redelegar lo recibido", func(t *testing.T) {
+		svc := newRoleDelegationService(&mockRoleDelegationRepo{listFn: func(ctx context.Context, f repository.RoleDelegationFilter) ([]*model.RoleDelegation, error) {
+			return []*model.RoleDelegation{{DelegatedUserRoleID: &own.ID, Status: model.DelegationActive}}, nil
+		}}, holdings)
+		_, err := svc.Create(ctx, teacherID.String(), &dto.CreateDelegationRequest{
+			DelegateID: substituteID.String(), AcademicUnitID: unitID.String(), EndsAt: endsAt,
+		})
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	})
+
+	t.Run("valida la ventana", func(t *testing.T) {
+		svc := newRoleDelegationService(&mockRoleDelegationRepo{}, holdings)
+		tooLong := time.Now().Add(200 * 24 * time.Hour).Format(time.RFC3339)
+		_, err := svc.Create(ctx, teacherID.String(), &dto.CreateDelegationRequest{
+			DelegateID: substituteID.String(), AcademicUnitID: unitID.String(), EndsAt: tooLong,
+		})
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+
+		_, err = svc.Create(ctx, teacherID.String(), &dto.CreateDelegationRequest{
+			DelegateID: teacherID.String(), AcademicUnitID: unitID.String(), EndsAt: endsAt,
+		})
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	})
+
+	t.Run("no puede superar el vencimiento de la asignación", func(t *testing.T) {
+		soon := time.Now().Add(24 * time.Hour)
+		expiring := *own
+		expiring.ExpiresAt = &soon
+		svc := newRoleDelegationService(&mockRoleDelegationRepo{}, &mockUserRoleRepo{
+			findByUserInContextFn: func(ctx context.Context, userID uuid.UUID, s *uuid.UUID, u *uuid.UUID) ([]*entities.UserRole, error) {
+				return []*entities.UserRole{&expiring}, nil
+			},
+		})
+		_, err := svc.Create(ctx, teacherID.String(), &dto.CreateDelegationRequest{
+			DelegateID: substituteID.String(), AcademicUnitID: unitID.String(), EndsAt: endsAt,
+		})
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	})
+
+	t.Run("no delega roles que requieren aprobación", func(t *testing.T) {
+		policies := &mockRoleGrantPolicyRepo{findByRoleFn: func(ctx context.Context, roleID uuid.UUID) (*model.RoleGrantPolicy, error) {
+			return &model.RoleGrantPolicy{RoleID: roleID, RequiresApproval: true}, nil
+		}}
+		svc := newRoleDelegationServiceWithPolicies(&mockRoleDelegationRepo{createFn: func(ctx context.Context, ds []*model.RoleDelegation, grants []*entities.UserRole) error {
+			t.Error("no debería guardar la delegación")
+			return nil
+		}}, activeUsers, holdings, policies)
+		_, err := svc.Create(ctx, teacherID.String(), &dto.CreateDelegationRequest{
+			DelegateID: substituteID.String(), AcademicUnitID: unitID.String(), EndsAt: endsAt,
+		})
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	})
+
+	t.Run("un fallo al guardar no deja delegaciones parciales", func(t *testing.T) {
+		second := &entities.UserRole{ID: uuid.New(), UserID: teacherID, RoleID: uuid.New(), SchoolID: &schoolID, AcademicUnitID: &unitID, IsActive: true}
+		both := &mockUserRoleRepo{findByUserInContextFn: func(ctx context.Context, userID uuid.UUID, s *uuid.UUID, u *uuid.UUID) ([]*entities.UserRole, error) {
+			if userID == teacherID {
+				return []*entities.UserRole{own, second}, nil
+			}
+			return nil, nil
+		}}
+		calls := 0
+		svc := newRoleDelegationService(&mockRoleDelegationRepo{createFn: func(ctx context.Context, ds []*model.RoleDelegation, grants []*entities.UserRole) error {
+			calls++
+			if len(ds) != 2 {
+				t.Errorf("esperaba ambas delegaciones en una escritura, obtuvo %d", len(ds))
+			}
+			return errors.New("db down")
+		}}, both)
+		_, err := svc.Create(ctx, teacherID.String(), &dto.CreateDelegationRequest{
+			DelegateID: substituteID.String(), AcademicUnitID: unitID.String(), EndsAt: endsAt,
+		})
+		assertAppError(t, err, sharedErrors.ErrorCodeDatabaseError)
+		if calls != 1 {
+			t.Errorf("esperaba una sola escritura, obtuvo %d", calls)
+		}
+	})
+}
+
+func TestRoleDelegationService_Revoke(t *testing.T) {
+	ctx := context.Background()
+	delegatorID, delegateID, urID := uuid.New(), uuid.New(), uuid.New()
+	active := func() *model.RoleDelegation {
+		return &model.RoleDelegation{ID: uuid.New(), DelegatorID: delegatorID, DelegateID: delegateID, DelegatedUserRoleID: &urID, Status: model.DelegationActive}
+	}
+
+	t.Run("revoca el rol delegado", func(t *testing.T) {
+		d := active()
+		var revoked uuid.UUID
+		svc := newRoleDelegationService(
+			&mockRoleDelegationRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*model.RoleDelegation, error) { return d, nil }},
+			&mockUserRoleRepo{revokeFn: func(ctx context.Context, id uuid.UUID) error {
+				revoked = id
+				return nil
+			}},
+		)
+		result, err := svc.Revoke(ctx, d.ID.String(), delegateID.String())
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if result.Status != model.DelegationRevoked || revoked != urID {
+			t.Errorf("revocación incorrecta: %+v", result)
+		}
+	})
+
+	t.Run("terceros no la ven", func(t *testing.T) {
+		d := active()
+		svc := newRoleDelegationService(
+			&mockRoleDelegationRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*model.RoleDelegation, error) { return d, nil }},
+			&mockUserRoleRepo{},
+		)
+		_, err := svc.Revoke(ctx, d.ID.String(), uuid.New().String())
+		assertAppError(t, err, sharedErrors.ErrorCodeNotFound)
+	})
+}
+
+func TestRoleDelegationService_ProcessDue(t *testing.T) {
+	ctx := context.Background()
+	delegatorID, schoolID, unitID := uuid.New(), uuid.New(), uuid.New()
+	sourceID, grantedID := uuid.New(), uuid.New()
+	now := time.Now()
+
+	toStart := &model.RoleDelegation{ID: uuid.New(), DelegatorID: delegatorID, DelegateID: uuid.New(), SourceUserRoleID: sourceID,
+		SchoolID: schoolID, AcademicUnitID: unitID, StartsAt: now.Add(-time.Minute), EndsAt: now.Add(time.Hour), Status: model.DelegationScheduled}
+	ended := &model.RoleDelegation{ID: uuid.New(), DelegatorID: delegatorID, DelegateID: uuid.New(), SourceUserRoleID: sourceID,
+		SchoolID: schoolID, AcademicUnitID: unitID, StartsAt: now.Add(-2 * time.Hour), EndsAt: now.Add(-time.Minute),
+		DelegatedUserRoleID: &grantedID, Status: model.DelegationActive}
+	future := &model.RoleDelegation{ID: uuid.New(), DelegatorID: delegatorID, SourceUserRoleID: sourceID,
+		StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour), Status: model.DelegationScheduled}
+
+	var granted []*entities.UserRole
+	var revoked []uuid.UUID
+	svc := newRoleDelegationService(
+		&mockRoleDelegationRepo{
+			listFn: func(ctx context.Context, f repository.RoleDelegationFilter) ([]*model.RoleDelegation, error) {
+				return []*model.RoleDelegation{toStart, ended, future}, nil
+			},
+			activateFn: func(ctx context.Context, d *model.RoleDelegation, ur *entities.UserRole) (bool, error) {
+				granted = append(granted, ur)
+				return true, nil
+			},
+		},
+		&mockUserRoleRepo{
+			findByUserInContextFn: func(ctx context.Context, userID uuid.UUID, s *uuid.UUID, u *uuid.UUID) ([]*entities.UserRole, error) {
+				return []*entities.UserRole{{ID: sourceID, UserID: delegatorID, SchoolID: &schoolID, AcademicUnitID: &unitID, IsActive: true}}, nil
+			},
+			revokeFn: func(ctx context.Context, id uuid.UUID) error {
+				revoked = append(revoked, id)
+				return nil
+			},
+		},
+	)
+
+	n, err := svc.ProcessDue(ctx)
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("procesadas = %d, want 2", n)
+	}
+	if toStart.Status != model.DelegationActive || len(granted) != 1 {
+		t.Errorf("la delegación vencida de inicio debe activarse: %+v", toStart)
+	}
+	if ended.Status != model.DelegationExpired || len(revoked) != 1 || revoked[0] != grantedID {
+		t.Errorf("la delegación terminada debe expirar y revocar su rol: %+v", ended)
+	}
+	if future.Status != model.DelegationScheduled {
+		t.Errorf("la delegación futura no debe cambiar: %+v", future)
+	}
+
+	t.Run("revoca si el delegante perdió la asignación", func(t *testing.T) {
+		d := &model.RoleDelegation{ID: uuid.New(), DelegatorID: delegatorID, SourceUserRoleID: sourceID, SchoolID: schoolID, AcademicUnitID: unitID,
+			StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour), DelegatedUserRoleID: &grantedID, Status: model.DelegationActive}
+		svc := newRoleDelegationService(
+			&mockRoleDelegationRepo{listFn: func(ctx context.Context, f repository.RoleDelegationFilter) ([]*model.RoleDelegation, error) {
+				return []*model.RoleDelegation{d}, nil
+			}},
+			&mockUserRoleRepo{},
+		)
+		if _, err := svc.ProcessDue(ctx); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if d.Status != model.DelegationRevoked {
+			t.Errorf("status = %s, want revoked", d.Status)
+		}
+	})
+
+	held := &mockUserRoleRepo{findByUserInContextFn: func(ctx context.Context, userID uuid.UUID, s *uuid.UUID, u *uuid.UUID) ([]*entities.UserRole, error) {
+		return []*entities.UserRole{{ID: sourceID, UserID: delegatorID, SchoolID: &schoolID, AcademicUnitID: &unitID, IsActive: true}}, nil
+	}}
+	scheduled := func() *model.RoleDelegation {
+		return &model.RoleDelegation{ID: uuid.New(), DelegatorID: delegatorID, DelegateID: uuid.New(), SourceUserRoleID: sourceID,
+			SchoolID: schoolID, AcademicUnitID: unitID, StartsAt: now.Add(-time.Minute), EndsAt: now.Add(time.Hour), Status: model.DelegationScheduled}
+	}
+
+	t.Run("revoca sin otorgar si el delegado ya no está activo", func(t *testing.T) {
+		d := scheduled()
+		svc := newRoleDelegationServiceWithUsers(
+			&mockRoleDelegationRepo{
+				listFn: func(ctx context.Context, f repository.RoleDelegationFilter) ([]*model.RoleDelegation, error) {
+					return []*model.RoleDelegation{d}, nil
+				},
+				activateFn: func(ctx context.Context, d *model.RoleDelegation, ur *entities.UserRole) (bool, error) {
+					t.Error("no debe otorgar a un delegado inactivo")
+					return true, nil
+				},
+			},
+			&mockUserRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+				return &entities.User{ID: id, IsActive: false}, nil
+			}},
+			held,
+		)
+		if _, err := svc.ProcessDue(ctx); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if d.Status != model.DelegationRevoked {
+			t.Errorf("status = %s, want revoked", d.Status)
+		}
+	})
+
+	t.Run("no cuenta ni modifica la delegación si otra ejecución la activó", func(t *testing.T) {
+		d := scheduled()
+		svc := newRoleDelegationService(
+			&mockRoleDelegationRepo{
+				listFn: func(ctx context.Context, f repository.RoleDelegationFilter) ([]*model.RoleDelegation, error) {
+					return []*model.RoleDelegation{d}, nil
+				},
+				activateFn: func(ctx context.Context, d *model.RoleDelegation, ur *entities.UserRole) (bool, error) {
+					return false, nil
+				},
+			},
+			held,
+		)
+		n, err := svc.ProcessDue(ctx)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if n != 0 || d.Status != model.DelegationScheduled || d.DelegatedUserRoleID != nil {
+			t.Errorf("procesadas = %d, delegación = %+v", n, d)
+		}
+	})
+}
@@ -0,0 +1,463 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+// RoleGrantApprovalService implements the two-person rule for privileged roles:
+// grants of roles flagged as requiring approval are stored as pending requests
+// and only reach UserRoleRepository.Grant once a second admin approves them.
+type RoleGrantApprovalService interface {
+	RequiresApproval(ctx context.Context, roleID uuid.UUID) (bool, error)
+	RequestGrant(ctx context.Context, userRole *entities.UserRole, role *entities.Role) (*dto.RoleGrantRequestDTO, error)
+	GetPolicy(ctx context.Context, roleID string) (*dto.RoleGrantPolicyDTO, error)
+	SetPolicy(ctx context.Context, roleID string, req *dto.UpdateRoleGrantPolicyRequest, updatedBy string) (*dto.RoleGrantPolicyDTO, error)
+	ListRequests(ctx context.Context, status string, filters sharedrepo.ListFilters) (*dto.RoleGrantRequestsResponse, error)
+	GetRequest(ctx context.Context, id string) (*dto.RoleGrantRequestDTO, error)
+	Approve(ctx context.Context, id string, req *dto.DecideRoleGrantRequest, approverID string) (*dto.RoleGrantRequestDTO, error)
+	Reject(ctx context.Context, id string, req *dto.DecideRoleGrantRequest, approverID string) (*dto.RoleGrantRequestDTO, error)
+	ExpirePending(ctx context.Context) (int, error)
+}
+
+type roleGrantApprovalService struct {
+	policyRepo   repository.RoleGrantPolicyRepository
+	requestRepo  repository.RoleGrantRequestRepository
+	roleRepo     repository.RoleRepository
+	userRoleRepo repository.UserRoleRepository
+	sod          SoDService
+	notifier     Notifier
+	logger       logger.Logger
+	auditLogger  audit.AuditLogger
+	requestTTL   time.Duration
+}
+
+// NewRoleGrantApprovalService creates a new role grant approval service.
+// Pending requests expire after requestTTL; sod may be nil to skip
+// separation-of-duties checks on approval.
+func NewRoleGrantApprovalService(policyRepo repository.RoleGrantPolicyRepository, requestRepo repository.RoleGrantRequestRepository, roleRepo repository.RoleRepository, userRoleRepo repository.UserRoleRepository, sod SoDService, notifier Notifier, logger logger.Logger, auditLogger audit.AuditLogger, requestTTL time.Duration) RoleGrantApprovalService {
+	return &roleGrantApprovalService{
+		policyRepo:   policyRepo,
+		requestRepo:  requestRepo,
+		roleRepo:     roleRepo,
+		userRoleRepo: userRoleRepo,
+		sod:          sod,
+		notifier:     notifier,
+		logger:       logger,
+		auditLogger:  auditLogger,
+		requestTTL:   requestTTL,
+	}
+}
+
+func (s *roleGrantApprovalService) RequiresApproval(ctx context.Context, roleID uuid.UUID) (bool, error) {
+	policy, err := s.policyRepo.FindByRole(ctx, roleID)
+	if err != nil {
+		return false, errors.NewDatabaseError("find role grant policy", err)
+	}
+	return policy != nil && policy.RequiresApproval, nil
+}
+
+// RequestGrant stores the grant described by userRole as a pending request.
+// userRole.GrantedBy is recorded as the requester.
+func (s *roleGrantApprovalService) RequestGrant(ctx context.Context, userRole *entities.UserRole, role *entities.Role) (*dto.RoleGrantRequestDTO, error) {
+	pending, err := s.requestRepo.HasPending(ctx, userRole.UserID, userRole.RoleID, userRole.SchoolID, userRole.AcademicUnitID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("check pending grant requests", err)
+	}
+	if pending {
+		return nil, errors.NewAlreadyExistsError("role_grant_request")
+	}
+
+	now := time.Now()
+	req := &model.RoleGrantRequest{
+		ID:             uuid.New(),
+		UserID:         userRole.UserID,
+		RoleID:         userRole.RoleID,
+		SchoolID:       userRole.SchoolID,
+		AcademicUnitID: userRole.AcademicUnitID,
+		GrantExpiresAt: userRole.ExpiresAt,
+		RequestedBy:    userRole.GrantedBy,
+		Status:         model.GrantRequestPending,
+		ExpiresAt:      now.Add(s.requestTTL),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	created, err := s.requestRepo.Create(ctx, req)
+	if err != nil {
+		return nil, errors.NewDatabaseError("create role grant request", err)
+	}
+	if !created {
+		return nil, errors.NewAlreadyExistsError("role_grant_request")
+	}
+
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action:       "request",
+		ResourceType: "role_grant_request",
+		ResourceID:   req.ID.String(),
+		Severity:     audit.SeverityCritical,
+		Category:     audit.CategoryAdmin,
+		Metadata:     map[string]interface{}{"user_id": req.UserID.String(), "role_id": req.RoleID.String(), "role_name": role.Name},
+	})
+	s.logger.Info("role grant pending approval", "entity_type", "role_grant_request", "request_id", req.ID, "user_id", req.UserID, "role_name", role.Name)
+
+	d := toRoleGrantRequestDTO(req, role.Name)
+	notify(ctx, s.notifier, s.logger, Notification{
+		Event:   NotifyRoleGrantRequested,
+		Subject: "Role grant of " + role.Name + " awaiting approval",
+		Data:    map[string]interface{}{"request_id": d.ID, "user_id": d.UserID, "role_name": role.Name, "expires_at": d.ExpiresAt},
+	})
+	return d, nil
+}
+
+func (s *roleGrantApprovalService) GetPolicy(ctx context.Context, roleID string) (*dto.RoleGrantPolicyDTO, error) {
+	rid, err := uuid.Parse(roleID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid role ID")
+	}
+	policy, err := s.policyRepo.FindByRole(ctx, rid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find role grant policy", err)
+	}
+	if policy == nil {
+		return &dto.RoleGrantPolicyDTO{RoleID: roleID}, nil
+	}
+	return toRoleGrantPolicyDTO(policy), nil
+}
+
+func (s *roleGrantApprovalService) SetPolicy(ctx context.Context, roleID string, req *dto.UpdateRoleGrantPolicyRequest, updatedBy string) (*dto.RoleGrantPolicyDTO, error) {
+	rid, err := uuid.Parse(roleID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid role ID")
+	}
+	role, err := s.roleRepo.FindByID(ctx, rid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find role", err)
+	}
+	if role == nil {
+		return nil, errors.NewNotFoundError("role")
+	}
+
+	policy := &model.RoleGrantPolicy{RoleID: rid, RequiresApproval: *req.RequiresApproval, UpdatedAt: time.Now()}
+	if uid, err := uuid.Parse(updatedBy); err == nil {
+		policy.UpdatedBy = &uid
+	}
+	if err := s.policyRepo.Upsert(ctx, policy); err != nil {
+		return nil, errors.NewDatabaseError("update role grant policy", err)
+	}
+
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action:       "update",
+		ResourceType: "role_grant_policy",
+		ResourceID:   roleID,
+		Severity:     audit.SeverityCritical,
+		Category:     audit.CategoryAdmin,
+		Metadata:     map[string]interface{}{"role_name": role.Name, "requires_approval": policy.RequiresApproval},
+	})
+	s.logger.Info("role grant policy updated", "entity_type", "role_grant_policy", "role_id", roleID, "requires_approval", policy.RequiresApproval)
+	return toRoleGrantPolicyDTO(policy), nil
+}
+
+func (s *roleGrantApprovalService) ListRequests(ctx context.Context, status string, filters sharedrepo.ListFilters) (*dto.RoleGrantRequestsResponse, error) {
+	switch status {
+	case "", model.GrantRequestPending, model.GrantRequestApproved, model.GrantRequestRejected, model.GrantRequestExpired:
+	default:
+		return nil, errors.NewValidationError("invalid status")
+	}
+	requests, total, err := s.requestRepo.List(ctx, status, filters)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list role grant requests", err)
+	}
+
+	roleNames := make(map[uuid.UUID]string)
+	dtos := make([]*dto.RoleGrantRequestDTO, len(requests))
+	for i, req := range requests {
+		dtos[i] = toRoleGrantRequestDTO(req, s.roleName(ctx, roleNames, req.RoleID))
+	}
+
+	page := filters.Page
+	if page == 0 {
+		page = 1
+	}
+	limit := filters.Limit
+	if filters.Page > 0 && filters.Limit == 0 {
+		limit = 50
+	} else if limit == 0 {
+		limit = total
+	}
+	return &dto.RoleGrantRequestsResponse{Requests: dtos, Total: total, Page: page, Limit: limit}, nil
+}
+
+func (s *roleGrantApprovalService) GetRequest(ctx context.Context, id string) (*dto.RoleGrantRequestDTO, error) {
+	req, err := s.findRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toRoleGrantRequestDTO(req, s.roleName(ctx, nil, req.RoleID)), nil
+}
+
+func (s *roleGrantApprovalService) Approve(ctx context.Context, id string, decision *dto.DecideRoleGrantRequest, approverID string) (*dto.RoleGrantRequestDTO, error) {
+	req, approver, err := s.decidable(ctx, id, approverID)
+	if err != nil {
+		return nil, err
+	}
+	role, err := s.roleRepo.FindByID(ctx, req.RoleID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find role", err)
+	}
+	if role == nil || !role.IsActive {
+		return nil, errors.NewConflictError("role no longer exists")
+	}
+
+	hasRole, err := s.userRoleRepo.UserHasRole(ctx, req.UserID, req.RoleID, req.SchoolID, req.AcademicUnitID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("check user role", err)
+	}
+	if hasRole {
+		return nil, errors.NewAlreadyExistsError("user_role")
+	}
+
+	now := time.Now()
+	userRole := &entities.UserRole{
+		ID:             uuid.New(),
+		UserID:         req.UserID,
+		RoleID:         req.RoleID,
+		SchoolID:       req.SchoolID,
+		AcademicUnitID: req.AcademicUnitID,
+		IsActive:       true,
+		GrantedBy:      req.RequestedBy,
+		GrantedAt:      now,
+		ExpiresAt:      req.GrantExpiresAt,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	// Constraints or the user's other roles may have changed since the request
+	if s.sod != nil {
+		if err := s.sod.CheckGrant(ctx, userRole); err != nil {
+			return nil, err
+		}
+	}
+
+	req.UserRoleID = &userRole.ID
+	if err := s.decide(ctx, req, userRole, model.GrantRequestApproved, approver, decision.Note, now); err != nil {
+		return nil, err
+	}
+
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action:       "assign",
+		ResourceType: "user_role",
+		ResourceID:   userRole.ID.String(),
+		Severity:     audit.SeverityCritical,
+		Category:     audit.CategoryAdmin,
+		Metadata: map[string]interface{}{
+			"user_id": req.UserID.String(), "role_id": req.RoleID.String(), "role_name": role.Name,
+			"request_id": req.ID.String(), "approved_by": approverID,
+		},
+	})
+	s.logger.Info("role grant approved", "entity_type", "role_grant_request", "request_id", req.ID, "approved_by", approverID, "role_name", role.Name)
+
+	d := toRoleGrantRequestDTO(req, role.Name)
+	s.notifyDecision(ctx, NotifyRoleGrantApproved, d)
+	return d, nil
+}
+
+func (s *roleGrantApprovalService) Reject(ctx context.Context, id string, decision *dto.DecideRoleGrantRequest, approverID string) (*dto.RoleGrantRequestDTO, error) {
+	req, approver, err := s.decidable(ctx, id, approverID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.decide(ctx, req, nil, model.GrantRequestRejected, approver, decision.Note, time.Now()); err != nil {
+		return nil, err
+	}
+
+	roleName := s.roleName(ctx, nil, req.RoleID)
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action:       "reject",
+		ResourceType: "role_grant_request",
+		ResourceID:   req.ID.String(),
+		Severity:     audit.SeverityCritical,
+		Category:     audit.CategoryAdmin,
+		Metadata:     map[string]interface{}{"user_id": req.UserID.String(), "role_id": req.RoleID.String(), "role_name": roleName, "rejected_by": approverID},
+	})
+	s.logger.Info("role grant rejected", "entity_type", "role_grant_request", "request_id", req.ID, "rejected_by", approverID)
+
+	d := toRoleGrantRequestDTO(req, roleName)
+	s.notifyDecision(ctx, NotifyRoleGrantRejected, d)
+	return d, nil
+}
+
+// ExpirePending expires every pending request past its deadline. It is run
+// periodically by the background job runner.
+func (s *roleGrantApprovalService) ExpirePending(ctx context.Context) (int, error) {
+	expired, err := s.requestRepo.ExpirePending(ctx, time.Now())
+	if err != nil {
+		return 0, errors.NewDatabaseError("expire role grant requests", err)
+	}
+	roleNames := make(map[uuid.UUID]string)
+	for _, req := range expired {
+		_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action:       "expire",
+			ResourceType: "role_grant_request",
+			ResourceID:   req.ID.String(),
+			Severity:     audit.SeverityWarning,
+			Category:     audit.CategoryAdmin,
+			Metadata:     map[string]interface{}{"user_id": req.UserID.String(), "role_id": req.RoleID.String()},
+		})
+		s.notifyDecision(ctx, NotifyRoleGrantExpired, toRoleGrantRequestDTO(req, s.roleName(ctx, roleNames, req.RoleID)))
+	}
+	if len(expired) > 0 {
+		s.logger.Info("role grant requests expired", "entity_type", "role_grant_request", "count", len(expired))
+	}
+	return len(expired), nil
+}
+
+// decidable loads a request and checks it can still be decided by approverID:
+// it must be pending, not past its deadline, and the approver must be neither
+// the requester nor the user receiving the role.
+func (s *roleGrantApprovalService) decidable(ctx context.Context, id, approverID string) (*model.RoleGrantRequest, uuid.UUID, error) {
+	approver, err := uuid.Parse(approverID)
+	if err != nil {
+		return nil, uuid.Nil, errors.NewValidationError("invalid approver ID")
+	}
+	req, err := s.findRequest(ctx, id)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+	if req.Status != model.GrantRequestPending {
+		return nil, uuid.Nil, errors.NewConflictError("role grant request is already " + req.Status)
+	}
+	if !time.Now().Before(req.ExpiresAt) {
+		if _, err := s.ExpirePending(ctx); err != nil {
+			s.logger.Warn("error expiring role grant requests", "error", err)
+		}
+		return nil, uuid.Nil, errors.NewConflictError("role grant request has expired")
+	}
+	if req.RequestedBy != nil && *req.RequestedBy == approver {
+		return nil, uuid.Nil, errors.NewValidationError("a role grant request must be decided by a different admin than the requester")
+	}
+	if req.UserID == approver {
+		return nil, uuid.Nil, errors.NewValidationError("cannot decide a role grant request for yourself")
+	}
+	return req, approver, nil
+}
+
+// decide records the decision, granting grant when set, only if the request
+// is still pending: a concurrent decision or expiry since decidable ran
+// surfaces as a conflict and nothing is written.
+func (s *roleGrantApprovalService) decide(ctx context.Context, req *model.RoleGrantRequest, grant *entities.UserRole, status string, approver uuid.UUID, note string, now time.Time) error {
+	req.Status = status
+	req.DecidedBy = &approver
+	req.DecidedAt = &now
+	if note != "" {
+		req.DecisionNote = &note
+	}
+	req.UpdatedAt = now
+	decided, err := s.requestRepo.Decide(ctx, req, grant)
+	if err != nil {
+		return errors.NewDatabaseError("decide role grant request", err)
+	}
+	if !decided {
+		return errors.NewConflictError("role grant request is no longer pending")
+	}
+	return nil
+}
+
+func (s *roleGrantApprovalService) findRequest(ctx context.Context, id string) (*model.RoleGrantRequest, error) {
+	rid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid request ID")
+	}
+	req, err := s.requestRepo.FindByID(ctx, rid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find role grant request", err)
+	}
+	if req == nil {
+		return nil, errors.NewNotFoundError("role_grant_request")
+	}
+	return req, nil
+}
+
+// roleName resolves a role name, memoizing lookups in memo when given
+func (s *roleGrantApprovalService) roleName(ctx context.Context, memo map[uuid.UUID]string, roleID uuid.UUID) string {
+	if name, ok := memo[roleID]; ok {
+		return name
+	}
+	name := ""
+	if role, err := s.roleRepo.FindByID(ctx, roleID); err == nil && role != nil {
+		name = role.Name
+	}
+	if memo != nil {
+		memo[roleID] = name
+	}
+	return name
+}
+
+func (s *roleGrantApprovalService) notifyDecision(ctx context.Context, event string, d *dto.RoleGrantRequestDTO) {
+	var recipients []string
+	if d.RequestedBy != nil {
+		recipients = append(recipients, *d.RequestedBy)
+	}
+	notify(ctx, s.notifier, s.logger, Notification{
+		Event:      event,
+		Recipients: recipients,
+		Subject:    "Role grant of " + d.RoleName + " " + d.Status,
+		Data:       map[string]interface{}{"request_id": d.ID, "user_id": d.UserID, "role_name": d.RoleName, "status": d.Status},
+	})
+}
+
+func toRoleGrantPolicyDTO(p *model.RoleGrantPolicy) *dto.RoleGrantPolicyDTO {
+	updatedAt := p.UpdatedAt.Format(time.RFC3339)
+	d := &dto.RoleGrantPolicyDTO{RoleID: p.RoleID.String(), RequiresApproval: p.RequiresApproval, UpdatedAt: &updatedAt}
+	if p.UpdatedBy != nil {
+		by := p.UpdatedBy.String()
+		d.UpdatedBy = &by
+	}
+	return d
+}
+
+func toRoleGrantRequestDTO(req *model.RoleGrantRequest, roleName string) *dto.RoleGrantRequestDTO {
+	return &dto.RoleGrantRequestDTO{
+		ID:             req.ID.String(),
+		UserID:         req.UserID.String(),
+		RoleID:         req.RoleID.String(),
+		RoleName:       roleName,
+		SchoolID:       uuidString(req.SchoolID),
+		AcademicUnitID: uuidString(req.AcademicUnitID),
+		GrantExpiresAt: timeString(req.GrantExpiresAt),
+		RequestedBy:    uuidString(req.RequestedBy),
+		Status:         req.Status,
+		DecidedBy:      uuidString(req.DecidedBy),
+		DecidedAt:      timeString(req.DecidedAt),
+		DecisionNote:   req.DecisionNote,
+		UserRoleID:     uuidString(req.UserRoleID),
+		ExpiresAt:      req.ExpiresAt.Format(time.RFC3339),
+		CreatedAt:      req.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// uuidString formats an optional UUID
+func uuidString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+// timeString formats an optional timestamp as RFC3339
+func timeString(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format(time.RFC3339)
+	return &s
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedErrors "github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+func newRoleGrantApprovalService(policyRepo *mockRoleGrantPolicyRepo, requestRepo *mockRoleGrantRequestRepo, roleRepo *mockRoleRepo, urRepo *mockUserRoleRepo, notifier Notifier) RoleGrantApprovalService {
+	return NewRoleGrantApprovalService(policyRepo, requestRepo, roleRepo, urRepo, nil, notifier, &mockLogger{}, &mockAuditLogger{}, 72*time.Hour)
+}
+
+func TestRoleService_GrantRoleToUser_RequiresApproval(t *testing.T) {
+	ctx := context.Background()
+	userID, roleID, requester := uuid.New(), uuid.New(), uuid.New()
+	role := &entities.Role{ID: roleID, Name: "super_admin", Scope: "platform", IsActive: true}
+	roleRepo := &mockRoleRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Role, error) { return role, nil }}
+	urRepo := &mockUserRoleRepo{
+		grantFn: func(ctx context.Context, ur *entities.UserRole) error {
+			t.Error("un rol que requiere aprobación no debe otorgarse directamente")
+			return nil
+		},
+	}
+	var created *model.RoleGrantRequest
+	notifier := &mockNotifier{}
+	approvals := newRoleGrantApprovalService(
+		&mockRoleGrantPolicyRepo{findByRoleFn: func(ctx context.Context, id uuid.UUID) (*model.RoleGrantPolicy, error) {
+			return &model.RoleGrantPolicy{RoleID: id, RequiresApproval: true}, nil
+		}},
+		&mockRoleGrantRequestRepo{createFn: func(ctx context.Context, req *model.RoleGrantRequest) error {
+			created = req
+			return nil
+		}},
+		roleRepo, urRepo, notifier,
+	)
+	svc := NewRoleService(roleRepo, &mockPermissionRepo{}, urRepo, &mockRolePermRepo{}, NewMenuService(&mockResourceRepo{}, &mockResourceScreenRepo{}, &mockTranslationRepo{}, &mockLogger{}), approvals, nil, &mockLogger{}, &mockAuditLogger{})
+
+	resp, err := svc.GrantRoleToUser(ctx, userID.String(), &dto.GrantRoleRequest{RoleID: roleID.String()}, requester.String())
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if resp.UserRole != nil || resp.ApprovalRequest == nil {
+		t.Fatal("esperaba una solicitud pendiente en lugar de la asignación")
+	}
+	if created == nil || created.Status != model.GrantRequestPending || *created.RequestedBy != requester {
+		t.Errorf("solicitud incorrecta: %+v", created)
+	}
+	if len(notifier.sent) != 1 || notifier.sent[0].Event != NotifyRoleGrantRequested {
+		t.Errorf("esperaba notificación %s, obtuvo %+v", NotifyRoleGrantRequested, notifier.sent)
+	}
+}
+
+func TestRoleGrantApprovalService_Approve(t *testing.T) {
+	ctx := context.Background()
+	userID, roleID, requester, approver := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	role := &entities.Role{ID: roleID, Name: "school_admin", Scope: "school", IsActive: true}
+	roleRepo := &mockRoleRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Role, error) { return role, nil }}
+	pending := func() *model.RoleGrantRequest {
+		return &model.RoleGrantRequest{
+			ID: uuid.New(), UserID: userID, RoleID: roleID, RequestedBy: &requester,
+			Status: model.GrantRequestPending, ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+
+	t.Run("un segundo admin aprueba y se otorga el rol", func(t *testing.T) {
+		req := pending()
+		var granted *entities.UserRole
+		var updated *model.RoleGrantRequest
+		notifier := &mockNotifier{}
+		svc := newRoleGrantApprovalService(&mockRoleGrantPolicyRepo{},
+			&mockRoleGrantRequestRepo{
+				findByIDFn: func(ctx context.Context, id uuid.UUID) (*model.RoleGrantRequest, error) { return req, nil },
+				decideFn: func(ctx context.Context, r *model.RoleGrantRequest, grant *entities.UserRole) (bool, error) {
+					updated, granted = r, grant
+					return true, nil
+				},
+			},
+			roleRepo, &mockUserRoleRepo{}, notifier,
+		)
+
+		d, err := svc.Approve(ctx, req.ID.String(), &dto.DecideRoleGrantRequest{Note: "ok"}, approver.String())
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if granted == nil || granted.UserID != userID || *granted.GrantedBy != requester {
+			t.Errorf("asignación incorrecta: %+v", granted)
+		}
+		if d.Status != model.GrantRequestApproved || updated == nil || *updated.DecidedBy != approver || *updated.UserRoleID != granted.ID {
+			t.Errorf("decisión no registrada: %+v", d)
+		}
+		if len(notifier.sent) != 1 || notifier.sent[0].Recipients[0] != requester.String() {
+			t.Errorf("esperaba notificar al solicitante, obtuvo %+v", notifier.sent)
+		}
+	})
+
+	t.Run("el solicitante no puede aprobar su propia solicitud", func(t *testing.T) {
+		req := pending()
+		svc := newRoleGrantApprovalService(&mockRoleGrantPolicyRepo{},
+			&mockRoleGrantRequestRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*model.RoleGrantRequest, error) { return req, nil }},
+			roleRepo, &mockUserRoleRepo{}, nil,
+		)
+		_, err := svc.Approve(ctx, req.ID.String(), &dto.DecideRoleGrantRequest{}, requester.String())
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	})
+
+	t.Run("rechaza aprobar solicitudes vencidas", func(t *testing.T) {
+		req := pending()
+		req.ExpiresAt = time.Now().Add(-time.Minute)
+		svc := newRoleGrantApprovalService(&mockRoleGrantPolicyRepo{},
+			&mockRoleGrantRequestRepo{
+				findByIDFn: func(ctx context.Context, id uuid.UUID) (*model.RoleGrantRequest, error) { return req, nil },
+				decideFn: func(ctx context.Context, r *model.RoleGrantRequest, grant *entities.UserRole) (bool, error) {
+					t.Error("no debe otorgar una solicitud vencida")
+					return true, nil
+				},
+			},
+			roleRepo, &mockUserRoleRepo{}, nil,
+		)
+		_, err := svc.Approve(ctx, req.ID.String(), &dto.DecideRoleGrantRequest{}, approver.String())
+		assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+	})
+
+	t.Run("rechaza decidir solicitudes ya decididas", func(t *testing.T) {
+		req := pending()
+		req.Status = model.GrantRequestRejected
+		svc := newRoleGrantApprovalService(&mockRoleGrantPolicyRepo{},
+			&mockRoleGrantRequestRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*model.RoleGrantRequest, error) { return req, nil }},
+			roleRepo, &mockUserRoleRepo{}, nil,
+		)
+		_, err := svc.Approve(ctx, req.ID.String(), &dto.DecideRoleGrantRequest{}, approver.String())
+		assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+	})
+
+	t.Run("retorna conflicto si otra decisión gana la carrera", func(t *testing.T) {
+		req := pending()
+		notifier := &mockNotifier{}
+		svc := newRoleGrantApprovalService(&mockRoleGrantPolicyRepo{},
+			&mockRoleGrantRequestRepo{
+				findByIDFn: func(ctx context.Context, id uuid.UUID) (*model.RoleGrantRequest, error) { return req, nil },
+				decideFn: func(ctx context.Context, r *model.RoleGrantRequest, grant *entities.UserRole) (bool, error) {
+					return false, nil
+				},
+			},
+			roleRepo, &mockUserRoleRepo{}, notifier,
+		)
+		_, err := svc.Approve(ctx, req.ID.String(), &dto.DecideRoleGrantRequest{}, approver.String())
+		assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+		if len(notifier.sent) != 0 {
+			t.Errorf("no debería notificar una decisión perdida: %+v", notifier.sent)
+		}
+	})
+
+	t.Run("retorna not found si la solicitud no existe", func(t *testing.T) {
+		svc := newRoleGrantApprovalService(&mockRoleGrantPolicyRepo{}, &mockRoleGrantRequestRepo{}, roleRepo, &mockUserRoleRepo{}, nil)
+		_, err := svc.Approve(ctx, uuid.New().String(), &dto.DecideRoleGrantRequest{}, approver.String())
+		assertAppError(t, err, sharedErrors.ErrorCodeNotFound)
+	})
+}
+
+func TestRoleGrantApprovalService_Reject(t *testing.T) {
+	requester, approver := uuid.New(), uuid.New()
+	req := &model.RoleGrantRequest{
+		ID: uuid.New(), UserID: uuid.New(), RoleID: uuid.New(), RequestedBy: &requester,
+		Status: model.GrantRequestPending, ExpiresAt: time.Now().Add(time.Hour),
+	}
+	svc := newRoleGrantApprovalService(&mockRoleGrantPolicyRepo{},
+		&mockRoleGrantRequestRepo{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*model.RoleGrantRequest, error) { return req, nil },
+			decideFn: func(ctx context.Context, r *model.RoleGrantRequest, grant *entities.UserRole) (bool, error) {
+				if grant != nil {
+					t.Error("una solicitud rechazada no debe otorgar el rol")
+				}
+				return true, nil
+			},
+		},
+		&mockRoleRepo{}, &mockUserRoleRepo{}, nil,
+	)
+
+	d, err := svc.Reject(context.Background(), req.ID.String(), &dto.DecideRoleGrantRequest{Note: "no justificado"}, approver.String())
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if d.Status != model.GrantRequestRejected || d.DecisionNote == nil || *d.DecisionNote != "no justificado" {
+		t.Errorf("rechazo incorrecto: %+v", d)
+	}
+}
+
+func TestRoleGrantApprovalService_ExpirePending(t *testing.T) {
+	requester := uuid.New()
+	notifier := &mockNotifier{}
+	svc := newRoleGrantApprovalService(&mockRoleGrantPolicyRepo{},
+		&mockRoleGrantRequestRepo{expirePendingFn: func(ctx context.Context, now time.Time) ([]*model.RoleGrantRequest, error) {
+			return []*model.RoleGrantRequest{
+				{ID: uuid.New(), UserID: uuid.New(), RoleID: uuid.New(), RequestedBy: &requester, Status: model.GrantRequestExpired},
+				{ID: uuid.New(), UserID: uuid.New(), RoleID: uuid.New(), Status: model.GrantRequestExpired},
+			}, nil
+		}},
+		&mockRoleRepo{}, &mockUserRoleRepo{}, notifier,
+	)
+
+	n, err := svc.ExpirePending(context.Background())
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if n != 2 || len(notifier.sent) != 2 {
+		t.Errorf("esperaba 2 solicitudes vencidas notificadas, obtuvo %d/%d", n, len(notifier.sent))
+	}
+}
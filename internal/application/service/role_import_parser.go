@@ -0,0 +1,326 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Role import file formats
+const (
+	RoleImportFormatCSV  = "csv"
+	RoleImportFormatXLSX = "xlsx"
+)
+
+// roleImportColumns maps accepted header names to the record field they fill
+var roleImportColumns = map[string]string{
+	"email":            "email",
+	"user_email":       "email",
+	"role":             "role",
+	"role_name":        "role",
+	"school":           "school",
+	"school_id":        "school",
+	"school_code":      "school",
+	"unit":             "unit",
+	"academic_unit":    "unit",
+	"academic_unit_id": "unit",
+	"unit_id":          "unit",
+	"expires_at":       "expires_at",
+	"expiry":           "expires_at",
+}
+
+// roleImportRecord is one data line of an import file, as written
+type roleImportRecord struct {
+	Line      int
+	Email     string
+	RoleName  string
+	School    string
+	Unit      string
+	ExpiresAt string
+}
+
+// parseRoleImportFile reads a CSV or XLSX file whose first row is a header
+// naming the columns (email and role are required). Blank lines are skipped;
+// Line keeps the spreadsheet row number so reports point at the right place.
+func parseRoleImportFile(fileName string, data []byte, maxRows int) (string, []roleImportRecord, error) {
+	var format string
+	var table [][]string
+	var err error
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".csv":
+		format = RoleImportFormatCSV
+		table, err = readCSVTable(data)
+	case ".xlsx":
+		format = RoleImportFormatXLSX
+		table, err = readXLSXTable(data)
+	default:
+		return "", nil, fmt.Errorf("unsupported file type %q, use .csv or .xlsx", path.Ext(fileName))
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	if len(table) == 0 {
+		return "", nil, fmt.Errorf("file is empty")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range table[0] {
+		key := strings.ToLower(strings.TrimSpace(name))
+		field, ok := roleImportColumns[key]
+		if !ok {
+			return "", nil, fmt.Errorf("unknown column %q", name)
+		}
+		if _, dup := columns[field]; dup {
+			return "", nil, fmt.Errorf("column %q appears more than once", field)
+		}
+		columns[field] = i
+	}
+	for _, required := range []string{"email", "role"} {
+		if _, ok := columns[required]; !ok {
+			return "", nil, fmt.Errorf("missing required column %q", required)
+		}
+	}
+
+	cell := func(row []string, field string) string {
+		i, ok := columns[field]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	var records []roleImportRecord
+	for i, row := range table[1:] {
+		if isBlankRow(row) {
+			continue
+		}
+		if len(records) == maxRows {
+			return "", nil, fmt.Errorf("file exceeds the limit of %d rows", maxRows)
+		}
+		records = append(records, roleImportRecord{
+			Line:      i + 2,
+			Email:     cell(row, "email"),
+			RoleName:  cell(row, "role"),
+			School:    cell(row, "school"),
+			Unit:      cell(row, "unit"),
+			ExpiresAt: cell(row, "expires_at"),
+		})
+	}
+	if len(records) == 0 {
+		return "", nil, fmt.Errorf("file has no data rows")
+	}
+	return format, records, nil
+}
+
+func isBlankRow(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// readCSVTable reads comma- or semicolon-separated files (spreadsheets in
+// Spanish locales export with ';'), ignoring a UTF-8 BOM.
+func readCSVTable(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		r.Comma = ';'
+	}
+	table, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	return table, nil
+}
+
+// readXLSXTable reads the first worksheet of an Office Open XML workbook.
+// Only cell values are read; formulas contribute their cached result.
+func readXLSXTable(data []byte) ([][]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid XLSX: %w", err)
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	var sharedStrings []string
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		var sst struct {
+			Items []xlsxRichText `xml:"si"`
+		}
+		if err := decodeZipXML(f, &sst); err != nil {
+			return nil, err
+		}
+		sharedStrings = make([]string, len(sst.Items))
+		for i, item := range sst.Items {
+			sharedStrings[i] = item.String()
+		}
+	}
+
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+	var sheet struct {
+		Rows []struct {
+			Index int `xml:"r,attr"`
+			Cells []struct {
+				Ref    string       `xml:"r,attr"`
+				Type   string       `xml:"t,attr"`
+				Value  string       `xml:"v"`
+				Inline xlsxRichText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := decodeZipXML(files[sheetPath], &sheet); err != nil {
+		return nil, err
+	}
+
+	var table [][]string
+	for _, row := range sheet.Rows {
+		// Keep row numbers aligned with the spreadsheet when rows are missing
+		for row.Index > len(table)+1 {
+			table = append(table, nil)
+		}
+		var values []string
+		for i, c := range row.Cells {
+			col := i
+			if c.Ref != "" {
+				col = xlsxColumnIndex(c.Ref)
+			}
+			for len(values) <= col {
+				values = append(values, "")
+			}
+			switch c.Type {
+			case "s":
+				idx, err := strconv.Atoi(c.Value)
+				if err != nil || idx < 0 || idx >= len(sharedStrings) {
+					return nil, fmt.Errorf("invalid XLSX: bad shared string in %s", c.Ref)
+				}
+				values[col] = sharedStrings[idx]
+			case "inlineStr":
+				values[col] = c.Inline.String()
+			default:
+				values[col] = c.Value
+			}
+		}
+		table = append(table, values)
+	}
+	return table, nil
+}
+
+// xlsxRichText is a shared or inline string: plain <t> or rich-text runs
+type xlsxRichText struct {
+	Text string   `xml:"t"`
+	Runs []string `xml:"r>t"`
+}
+
+func (t xlsxRichText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	return t.Text + strings.Join(t.Runs, "")
+}
+
+// firstSheetPath resolves the first sheet of the workbook through its
+// relationships, falling back to the conventional sheet1.xml.
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+	wb, okWB := files["xl/workbook.xml"]
+	rels, okRels := files["xl/_rels/workbook.xml.rels"]
+	if okWB && okRels {
+		var workbook struct {
+			Sheets []struct {
+				RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+			} `xml:"sheets>sheet"`
+		}
+		var relationships struct {
+			Items []struct {
+				ID     string `xml:"Id,attr"`
+				Target string `xml:"Target,attr"`
+			} `xml:"Relationship"`
+		}
+		if decodeZipXML(wb, &workbook) == nil && decodeZipXML(rels, &relationships) == nil && len(workbook.Sheets) > 0 {
+			for _, rel := range relationships.Items {
+				if rel.ID != workbook.Sheets[0].RelID {
+					continue
+				}
+				target := strings.TrimPrefix(rel.Target, "/")
+				if !strings.HasPrefix(target, "xl/") {
+					target = path.Join("xl", target)
+				}
+				if _, ok := files[target]; ok {
+					return target, nil
+				}
+			}
+		}
+	}
+	if _, ok := files[fallback]; ok {
+		return fallback, nil
+	}
+	return "", fmt.Errorf("invalid XLSX: no worksheet found")
+}
+
+func decodeZipXML(f *zip.File, v any) error {
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("invalid XLSX: %w", err)
+	}
+	defer rc.Close()
+	if err := xml.NewDecoder(io.LimitReader(rc, 64<<20)).Decode(v); err != nil {
+		return fmt.Errorf("invalid XLSX %s: %w", f.Name, err)
+	}
+	return nil
+}
+
+// xlsxColumnIndex converts the letters of a cell reference ("C12") to a
+// zero-based column index
+func xlsxColumnIndex(ref string) int {
+	col := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A'+1)
+	}
+	return col - 1
+}
+
+// parseImportExpiry accepts RFC3339 timestamps, plain dates (end of that day,
+// UTC) and spreadsheet date serials, which is how XLSX stores date cells.
+func parseImportExpiry(raw string) (*time.Time, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &t, nil
+	}
+	if t, err := time.Parse("2006-01-02", raw); err == nil {
+		t = t.Add(24*time.Hour - time.Second)
+		return &t, nil
+	}
+	if serial, err := strconv.ParseFloat(raw, 64); err == nil && serial > 0 {
+		t := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC).Add(time.Duration(serial * float64(24*time.Hour)))
+		if serial == float64(int64(serial)) {
+			t = t.Add(24*time.Hour - time.Second)
+		}
+		return &t, nil
+	}
+	return nil, fmt.Errorf("invalid expires_at %q, use YYYY-MM-DD or RFC3339", raw)
+}
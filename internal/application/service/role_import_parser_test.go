@@ -0,0 +1,87 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestParseRoleImportFile_CSV(t *testing.T) {
+	t.Run("lee columnas por encabezado y omite líneas en blanco", func(t *testing.T) {
+		data := []byte("\xef\xbb\xbfEmail;Role;School;Unit;Expires_At\nana@edugo.test;teacher;COL-01;Primaria;2030-01-31\n;;;;\nluis@edugo.test;student;COL-01;;\n")
+		format, records, err := parseRoleImportFile("inicio.csv", data, 100)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if format != RoleImportFormatCSV || len(records) != 2 {
+			t.Fatalf("resultado incorrecto: %s %+v", format, records)
+		}
+		if records[0].Line != 2 || records[0].Unit != "Primaria" || records[1].Line != 4 || records[1].RoleName != "student" {
+			t.Errorf("registros incorrectos: %+v", records)
+		}
+	})
+
+	t.Run("rechaza columnas faltantes, desconocidas y exceso de filas", func(t *testing.T) {
+		if _, _, err := parseRoleImportFile("a.csv", []byte("email,school\nx@y.z,COL\n"), 100); err == nil {
+			t.Errorf("se esperaba error por columna role faltante")
+		}
+		if _, _, err := parseRoleImportFile("a.csv", []byte("email,role,colour\nx@y.z,teacher,red\n"), 100); err == nil {
+			t.Errorf("se esperaba error por columna desconocida")
+		}
+		if _, _, err := parseRoleImportFile("a.csv", []byte("email,role\na@b.c,teacher\nd@e.f,teacher\n"), 1); err == nil {
+			t.Errorf("se esperaba error por límite de filas")
+		}
+		if _, _, err := parseRoleImportFile("a.txt", []byte("email,role\n"), 1); err == nil {
+			t.Errorf("se esperaba error por tipo de archivo")
+		}
+	})
+}
+
+func TestParseRoleImportFile_XLSX(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	files := map[string]string{
+		"xl/workbook.xml":            `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Roles" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="worksheet" Target="worksheets/roles.xml"/></Relationships>`,
+		"xl/sharedStrings.xml":       `<sst><si><t>email</t></si><si><t>role</t></si><si><t>expires_at</t></si><si><r><t>ana@</t></r><r><t>edugo.test</t></r></si><si><t>teacher</t></si></sst>`,
+		"xl/worksheets/roles.xml": `<worksheet><sheetData>` +
+			`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c></row>` +
+			`<row r="3"><c r="A3" t="s"><v>3</v></c><c r="B3" t="inlineStr"><is><t>teacher</t></is></c><c r="C3"><v>47484</v></c></row>` +
+			`</sheetData></worksheet>`,
+	}
+	for name, content := range files {
+		w, _ := zw.Create(name)
+		_, _ = w.Write([]byte(content))
+	}
+	_ = zw.Close()
+
+	format, records, err := parseRoleImportFile("inicio.xlsx", buf.Bytes(), 100)
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if format != RoleImportFormatXLSX || len(records) != 1 {
+		t.Fatalf("resultado incorrecto: %s %+v", format, records)
+	}
+	rec := records[0]
+	if rec.Line != 3 || rec.Email != "ana@edugo.test" || rec.RoleName != "teacher" {
+		t.Errorf("registro incorrecto: %+v", rec)
+	}
+	expires, err := parseImportExpiry(rec.ExpiresAt)
+	if err != nil || expires.Format("2006-01-02") != "2030-01-01" {
+		t.Errorf("la fecha serial debe convertirse: %v %v", expires, err)
+	}
+}
+
+func TestParseImportExpiry(t *testing.T) {
+	d, err := parseImportExpiry("2030-06-30")
+	if err != nil || !d.Equal(time.Date(2030, 6, 30, 23, 59, 59, 0, time.UTC)) {
+		t.Errorf("una fecha vence al final del día: %v %v", d, err)
+	}
+	if d, err := parseImportExpiry(""); d != nil || err != nil {
+		t.Errorf("vacío significa sin vencimiento")
+	}
+	if _, err := parseImportExpiry("30/06/2030"); err == nil {
+		t.Errorf("se esperaba error de formato")
+	}
+}
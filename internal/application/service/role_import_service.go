@@ -0,0 +1,638 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	// roleImportBatch is how many pending jobs one ProcessPending run picks up
+	roleImportBatch = 5
+	// roleImportProgressEvery is how often, in rows, validation progress is saved
+	roleImportProgressEvery = 200
+)
+
+// RoleImportService grants roles in bulk from CSV/XLSX files (email, role,
+// school, unit, expires_at). Uploads are queued and processed in the
+// background: every row is validated and, unless it is a dry run, the valid
+// rows are applied in a single transaction.
+type RoleImportService interface {
+	Submit(ctx context.Context, requestedBy, fileName string, data []byte, dryRun bool) (*dto.RoleImportJobDTO, error)
+	GetJob(ctx context.Context, id string) (*dto.RoleImportJobDTO, error)
+	ListJobs(ctx context.Context, filters sharedrepo.ListFilters) (*dto.RoleImportJobsResponse, error)
+	// GetRows returns the per-row report; status narrows it (e.g. "invalid")
+	GetRows(ctx context.Context, id, status string, filters sharedrepo.ListFilters) (*dto.RoleImportRowsResponse, error)
+	// ProcessPending runs queued imports. It is run periodically by the job runner.
+	ProcessPending(ctx context.Context) (int, error)
+}
+
+type roleImportService struct {
+	importRepo       repository.RoleImportRepository
+	userRepo         sharedrepo.UserRepository
+	roleRepo         repository.RoleRepository
+	schoolRepo       sharedrepo.SchoolRepository
+	academicUnitRepo sharedrepo.AcademicUnitRepository
+	userRoleRepo     repository.UserRoleRepository
+	sod              SoDService
+	approvals        RoleGrantApprovalService
+	logger           logger.Logger
+	auditLogger      audit.AuditLogger
+	maxRows          int
+}
+
+// NewRoleImportService creates a new bulk role import service. Rows are held
+// to the same rules as single grants: sod and approvals may be nil to skip
+// separation-of-duties checks or the two-person rule.
+func NewRoleImportService(importRepo repository.RoleImportRepository, userRepo sharedrepo.UserRepository, roleRepo repository.RoleRepository, schoolRepo sharedrepo.SchoolRepository, academicUnitRepo sharedrepo.AcademicUnitRepository, userRoleRepo repository.UserRoleRepository, sod SoDService, approvals RoleGrantApprovalService, logger logger.Logger, auditLogger audit.AuditLogger, maxRows int) RoleImportService {
+	return &roleImportService{
+		importRepo:       importRepo,
+		userRepo:         userRepo,
+		roleRepo:         roleRepo,
+		schoolRepo:       schoolRepo,
+		academicUnitRepo: academicUnitRepo,
+		userRoleRepo:     userRoleRepo,
+		sod:              sod,
+		approvals:        approvals,
+		logger:           logger,
+		auditLogger:      auditLogger,
+		maxRows:          maxRows,
+	}
+}
+
+func (s *roleImportService) Submit(ctx context.Context, requestedBy, fileName string, data []byte, dryRun bool) (*dto.RoleImportJobDTO, error) {
+	requester, err := uuid.Parse(requestedBy)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid user ID")
+	}
+	format, records, err := parseRoleImportFile(fileName, data, s.maxRows)
+	if err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	now := time.Now()
+	job := &model.RoleImportJob{
+		ID:          uuid.New(),
+		RequestedBy: requester,
+		FileName:    fileName,
+		Format:      format,
+		DryRun:      dryRun,
+		Status:      model.RoleImportPending,
+		TotalRows:   len(records),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	rows := make([]*model.RoleImportRow, len(records))
+	for i, rec := range records {
+		rows[i] = &model.RoleImportRow{
+			ID:           uuid.New(),
+			JobID:        job.ID,
+			LineNumber:   rec.Line,
+			Email:        rec.Email,
+			RoleName:     rec.RoleName,
+			School:       rec.School,
+			Unit:         rec.Unit,
+			ExpiresAtRaw: rec.ExpiresAt,
+			Status:       model.RoleImportRowPending,
+		}
+	}
+	if err := s.importRepo.Create(ctx, job, rows); err != nil {
+		return nil, errors.NewDatabaseError("create role import", err)
+	}
+
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action:       "create",
+		ResourceType: "role_import_job",
+		ResourceID:   job.ID.String(),
+		Severity:     audit.SeverityInfo,
+		Category:     audit.CategoryAdmin,
+		Metadata:     map[string]interface{}{"file_name": fileName, "format": format, "rows": len(rows), "dry_run": dryRun},
+	})
+	s.logger.Info("role import queued", "entity_type", "role_import_job", "job_id", job.ID, "rows", len(rows), "dry_run", dryRun)
+	return toRoleImportJobDTO(job), nil
+}
+
+func (s *roleImportService) GetJob(ctx context.Context, id string) (*dto.RoleImportJobDTO, error) {
+	job, err := s.findJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toRoleImportJobDTO(job), nil
+}
+
+func (s *roleImportService) ListJobs(ctx context.Context, filters sharedrepo.ListFilters) (*dto.RoleImportJobsResponse, error) {
+	jobs, total, err := s.importRepo.List(ctx, nil, filters)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list role imports", err)
+	}
+	dtos := make([]*dto.RoleImportJobDTO, len(jobs))
+	for i, j := range jobs {
+		dtos[i] = toRoleImportJobDTO(j)
+	}
+	page, limit := pageAndLimit(filters, total)
+	return &dto.RoleImportJobsResponse{Jobs: dtos, Total: total, Page: page, Limit: limit}, nil
+}
+
+func (s *roleImportService) GetRows(ctx context.Context, id, status string, filters sharedrepo.ListFilters) (*dto.RoleImportRowsResponse, error) {
+	job, err := s.findJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	rows, total, err := s.importRepo.FindRows(ctx, job.ID, status, filters)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list role import rows", err)
+	}
+	dtos := make([]*dto.RoleImportRowDTO, len(rows))
+	for i, r := range rows {
+		dtos[i] = toRoleImportRowDTO(r)
+	}
+	page, limit := pageAndLimit(filters, total)
+	return &dto.RoleImportRowsResponse{Rows: dtos, Total: total, Page: page, Limit: limit}, nil
+}
+
+func (s *roleImportService) ProcessPending(ctx context.Context) (int, error) {
+	jobs, err := s.importRepo.FindPending(ctx, roleImportBatch)
+	if err != nil {
+		return 0, err
+	}
+	processed := 0
+	for _, job := range jobs {
+		claimed, err := s.importRepo.Claim(ctx, job.ID, time.Now())
+		if err != nil {
+			return processed, err
+		}
+		if !claimed {
+			continue
+		}
+		job.Status = model.RoleImportRunning
+		if err := s.run(ctx, job); err != nil {
+			s.fail(ctx, job, err)
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// run validates every row of a claimed job and applies the valid ones. Only
+// infrastructure errors are returned; row problems end up in the report.
+func (s *roleImportService) run(ctx context.Context, job *model.RoleImportJob) error {
+	now := time.Now()
+	job.StartedAt = &now
+	rows, _, err := s.importRepo.FindRows(ctx, job.ID, "", sharedrepo.ListFilters{})
+	if err != nil {
+		return fmt.Errorf("load rows: %w", err)
+	}
+
+	v := newRoleImportValidator(s, job)
+	for i, row := range rows {
+		if err := v.validate(ctx, row); err != nil {
+			return fmt.Errorf("validate line %d: %w", row.LineNumber, err)
+		}
+		job.ProcessedRows = i + 1
+		if job.ProcessedRows%roleImportProgressEvery == 0 {
+			job.UpdatedAt = time.Now()
+			if err := s.importRepo.UpdateJob(ctx, job); err != nil {
+				s.logger.Error("error saving role import progress", "job_id", job.ID, "error", err)
+			}
+		}
+	}
+	job.ValidRows, job.InvalidRows, job.SkippedRows = countRowStatuses(rows)
+
+	if job.DryRun || job.ValidRows == 0 {
+		if err := s.importRepo.SaveRows(ctx, rows); err != nil {
+			return fmt.Errorf("save rows: %w", err)
+		}
+		return s.finish(ctx, job)
+	}
+
+	var userRoles []*entities.UserRole
+	applied := make([]*model.RoleImportRow, 0, job.ValidRows)
+	for _, row := range rows {
+		if row.Status != model.RoleImportRowValid {
+			continue
+		}
+		ur := v.assignments[row.ID]
+		row.Status, row.UserRoleID = model.RoleImportRowApplied, &ur.ID
+		userRoles = append(userRoles, ur)
+		applied = append(applied, row)
+	}
+	if err := s.importRepo.Apply(ctx, userRoles, rows); err != nil {
+		// Nothing was granted; keep the validation outcome for the report
+		for _, row := range applied {
+			row.Status, row.UserRoleID = model.RoleImportRowValid, nil
+		}
+		if saveErr := s.importRepo.SaveRows(ctx, rows); saveErr != nil {
+			s.logger.Error("error saving role import rows", "job_id", job.ID, "error", saveErr)
+		}
+		return fmt.Errorf("apply assignments: %w", err)
+	}
+	job.AppliedRows = len(applied)
+
+	for _, row := range applied {
+		ur := v.assignments[row.ID]
+		_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+			ActorID:      job.RequestedBy.String(),
+			Action:       "assign",
+			ResourceType: "user_role",
+			ResourceID:   ur.ID.String(),
+			Severity:     audit.SeverityCritical,
+			Category:     audit.CategoryAdmin,
+			Metadata:     map[string]interface{}{"user_id": ur.UserID.String(), "role_id": ur.RoleID.String(), "role_name": row.RoleName, "import_job_id": job.ID.String(), "line": row.LineNumber},
+		})
+	}
+	return s.finish(ctx, job)
+}
+
+func (s *roleImportService) finish(ctx context.Context, job *model.RoleImportJob) error {
+	now := time.Now()
+	job.Status = model.RoleImportCompleted
+	job.FinishedAt = &now
+	job.UpdatedAt = now
+	if err := s.importRepo.UpdateJob(ctx, job); err != nil {
+		return fmt.Errorf("complete job: %w", err)
+	}
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		ActorID:      job.RequestedBy.String(),
+		Action:       "complete",
+		ResourceType: "role_import_job",
+		ResourceID:   job.ID.String(),
+		Severity:     audit.SeverityWarning,
+		Category:     audit.CategoryAdmin,
+		Metadata: map[string]interface{}{
+			"dry_run": job.DryRun, "total": job.TotalRows, "valid": job.ValidRows,
+			"invalid": job.InvalidRows, "skipped": job.SkippedRows, "applied": job.AppliedRows,
+		},
+	})
+	s.logger.Info("role import completed", "entity_type", "role_import_job", "job_id", job.ID, "applied", job.AppliedRows, "invalid", job.InvalidRows)
+	return nil
+}
+
+func (s *roleImportService) fail(ctx context.Context, job *model.RoleImportJob, cause error) {
+	s.logger.Error("role import failed", "entity_type", "role_import_job", "job_id", job.ID, "error", cause)
+	now := time.Now()
+	msg := cause.Error()
+	job.Status = model.RoleImportFailed
+	job.Error = &msg
+	job.AppliedRows = 0
+	job.FinishedAt = &now
+	job.UpdatedAt = now
+	if err := s.importRepo.UpdateJob(ctx, job); err != nil {
+		s.logger.Error("error marking role import as failed", "job_id", job.ID, "error", err)
+	}
+}
+
+func (s *roleImportService) findJob(ctx context.Context, id string) (*model.RoleImportJob, error) {
+	jid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid import job ID")
+	}
+	job, err := s.importRepo.FindByID(ctx, jid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find role import", err)
+	}
+	if job == nil {
+		return nil, errors.NewNotFoundError("role_import_job")
+	}
+	return job, nil
+}
+
+// roleImportValidator resolves and checks the rows of one job, caching the
+// lookups shared by many rows (roles, schools, units, users).
+type roleImportValidator struct {
+	s           *roleImportService
+	job         *model.RoleImportJob
+	now         time.Time
+	roles       map[string]*entities.Role
+	users       map[string]*entities.User
+	schools     map[string]*entities.School
+	units       map[uuid.UUID][]*entities.AcademicUnit
+	seen        map[string]int
+	assignments map[uuid.UUID]*entities.UserRole
+	// byUser holds the batch's valid assignments per user so SoD also catches
+	// conflicts between rows of the same file.
+	byUser map[uuid.UUID][]*entities.UserRole
+}
+
+func newRoleImportValidator(s *roleImportService, job *model.RoleImportJob) *roleImportValidator {
+	return &roleImportValidator{
+		s:           s,
+		job:         job,
+		now:         time.Now(),
+		users:       make(map[string]*entities.User),
+		schools:     make(map[string]*entities.School),
+		units:       make(map[uuid.UUID][]*entities.AcademicUnit),
+		seen:        make(map[string]int),
+		assignments: make(map[uuid.UUID]*entities.UserRole),
+		byUser:      make(map[uuid.UUID][]*entities.UserRole),
+	}
+}
+
+// validate sets the row's status and message. Every problem of the row is
+// reported, not only the first one.
+func (v *roleImportValidator) validate(ctx context.Context, row *model.RoleImportRow) error {
+	var problems []string
+
+	user, err := v.user(ctx, row.Email)
+	if err != nil {
+		return err
+	}
+	switch {
+	case row.Email == "":
+		problems = append(problems, "email is required")
+	case user == nil:
+		problems = append(problems, "user not found")
+	case !user.IsActive:
+		problems = append(problems, "user is inactive")
+	}
+
+	role, err := v.role(ctx, row.RoleName)
+	if err != nil {
+		return err
+	}
+	switch {
+	case row.RoleName == "":
+		problems = append(problems, "role is required")
+	case role == nil:
+		problems = append(problems, "role not found")
+	case !role.IsActive:
+		problems = append(problems, "role is inactive")
+	}
+
+	var school *entities.School
+	var unit *entities.AcademicUnit
+	if role != nil && role.Scope == "platform" {
+		if row.School != "" || row.Unit != "" {
+			problems = append(problems, "platform roles cannot be scoped to a school or unit")
+		}
+	} else if row.School == "" {
+		if row.RoleName != "" && role != nil {
+			problems = append(problems, "school is required for "+role.Scope+" roles")
+		}
+	} else {
+		if school, err = v.school(ctx, row.School); err != nil {
+			return err
+		}
+		if school == nil {
+			problems = append(problems, "school not found")
+		} else if row.Unit != "" {
+			var problem string
+			if unit, problem, err = v.unit(ctx, school.ID, row.Unit); err != nil {
+				return err
+			}
+			if problem != "" {
+				problems = append(problems, problem)
+			}
+		}
+	}
+
+	expiresAt, err := parseImportExpiry(row.ExpiresAtRaw)
+	if err != nil {
+		problems = append(problems, err.Error())
+	} else if expiresAt != nil && !expiresAt.After(v.now) {
+		problems = append(problems, "expires_at must be in the future")
+	}
+
+	if user != nil {
+		row.UserID = &user.ID
+	}
+	if role != nil {
+		row.RoleID = &role.ID
+	}
+	if school != nil {
+		row.SchoolID = &school.ID
+	}
+	if unit != nil {
+		row.AcademicUnitID = &unit.ID
+	}
+	row.ExpiresAt = expiresAt
+	if len(problems) > 0 {
+		return v.mark(row, model.RoleImportRowInvalid, strings.Join(problems, "; "))
+	}
+
+	key := fmt.Sprintf("%s|%s|%s|%s", user.ID, role.ID, optionalUUIDKey(row.SchoolID), optionalUUIDKey(row.AcademicUnitID))
+	if line, dup := v.seen[key]; dup {
+		return v.mark(row, model.RoleImportRowInvalid, fmt.Sprintf("duplicate of line %d", line))
+	}
+	v.seen[key] = row.LineNumber
+
+	hasRole, err := v.s.userRoleRepo.UserHasRole(ctx, user.ID, role.ID, row.SchoolID, row.AcademicUnitID)
+	if err != nil {
+		return err
+	}
+	if hasRole {
+		return v.mark(row, model.RoleImportRowSkipped, "user already holds this role")
+	}
+	if v.s.approvals != nil {
+		required, err := v.s.approvals.RequiresApproval(ctx, role.ID)
+		if err != nil {
+			return err
+		}
+		if required {
+			return v.mark(row, model.RoleImportRowInvalid, "role requires approval; grant it individually")
+		}
+	}
+
+	userRole := &entities.UserRole{
+		ID:             uuid.New(),
+		UserID:         user.ID,
+		RoleID:         role.ID,
+		SchoolID:       row.SchoolID,
+		AcademicUnitID: row.AcademicUnitID,
+		IsActive:       true,
+		GrantedBy:      &v.job.RequestedBy,
+		GrantedAt:      v.now,
+		ExpiresAt:      expiresAt,
+		CreatedAt:      v.now,
+		UpdatedAt:      v.now,
+	}
+	if v.s.sod != nil {
+		if err := v.s.sod.CheckGrant(ctx, userRole, v.byUser[user.ID]...); err != nil {
+			return v.mark(row, model.RoleImportRowInvalid, err.Error())
+		}
+	}
+
+	v.assignments[row.ID] = userRole
+	v.byUser[user.ID] = append(v.byUser[user.ID], userRole)
+	return v.mark(row, model.RoleImportRowValid, "")
+}
+
+func (v *roleImportValidator) mark(row *model.RoleImportRow, status, message string) error {
+	row.Status = status
+	row.Message = nil
+	if message != "" {
+		row.Message = &message
+	}
+	return nil
+}
+
+func (v *roleImportValidator) user(ctx context.Context, email string) (*entities.User, error) {
+	email = normalizeEmail(email)
+	if email == "" {
+		return nil, nil
+	}
+	if u, ok := v.users[email]; ok {
+		return u, nil
+	}
+	u, err := v.s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	v.users[email] = u
+	return u, nil
+}
+
+func (v *roleImportValidator) role(ctx context.Context, name string) (*entities.Role, error) {
+	if name == "" {
+		return nil, nil
+	}
+	if v.roles == nil {
+		roles, _, err := v.s.roleRepo.FindAll(ctx, sharedrepo.ListFilters{})
+		if err != nil {
+			return nil, err
+		}
+		v.roles = make(map[string]*entities.Role, len(roles))
+		for _, r := range roles {
+			v.roles[strings.ToLower(r.Name)] = r
+		}
+	}
+	return v.roles[strings.ToLower(name)], nil
+}
+
+// school resolves a school by ID or by code
+func (v *roleImportValidator) school(ctx context.Context, key string) (*entities.School, error) {
+	if s, ok := v.schools[key]; ok {
+		return s, nil
+	}
+	var school *entities.School
+	var err error
+	if id, parseErr := uuid.Parse(key); parseErr == nil {
+		school, err = v.s.schoolRepo.FindByID(ctx, id)
+	} else {
+		school, err = v.s.schoolRepo.FindByCode(ctx, key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	v.schools[key] = school
+	return school, nil
+}
+
+// unit resolves an academic unit of the school by ID or by (unique) name
+func (v *roleImportValidator) unit(ctx context.Context, schoolID uuid.UUID, key string) (*entities.AcademicUnit, string, error) {
+	units, ok := v.units[schoolID]
+	if !ok {
+		var err error
+		units, _, err = v.s.academicUnitRepo.FindBySchoolID(ctx, schoolID, sharedrepo.ListFilters{})
+		if err != nil {
+			return nil, "", err
+		}
+		v.units[schoolID] = units
+	}
+	id, parseErr := uuid.Parse(key)
+	var match *entities.AcademicUnit
+	for _, u := range units {
+		if parseErr == nil && u.ID == id {
+			return u, "", nil
+		}
+		if parseErr != nil && strings.EqualFold(u.Name, key) {
+			if match != nil {
+				return nil, "unit name is ambiguous in this school, use its ID", nil
+			}
+			match = u
+		}
+	}
+	if match == nil {
+		return nil, "unit not found in school", nil
+	}
+	return match, "", nil
+}
+
+func optionalUUIDKey(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+func countRowStatuses(rows []*model.RoleImportRow) (valid, invalid, skipped int) {
+	for _, r := range rows {
+		switch r.Status {
+		case model.RoleImportRowValid:
+			valid++
+		case model.RoleImportRowInvalid:
+			invalid++
+		case model.RoleImportRowSkipped:
+			skipped++
+		}
+	}
+	return valid, invalid, skipped
+}
+
+func pageAndLimit(filters sharedrepo.ListFilters, total int) (int, int) {
+	page := filters.Page
+	if page == 0 {
+		page = 1
+	}
+	limit := filters.Limit
+	if filters.Page > 0 && filters.Limit == 0 {
+		limit = 50
+	} else if limit == 0 {
+		limit = total
+	}
+	return page, limit
+}
+
+func toRoleImportJobDTO(j *model.RoleImportJob) *dto.RoleImportJobDTO {
+	progress := 0
+	if j.TotalRows > 0 {
+		progress = j.ProcessedRows * 100 / j.TotalRows
+	}
+	return &dto.RoleImportJobDTO{
+		ID:            j.ID.String(),
+		RequestedBy:   j.RequestedBy.String(),
+		FileName:      j.FileName,
+		Format:        j.Format,
+		DryRun:        j.DryRun,
+		Status:        j.Status,
+		TotalRows:     j.TotalRows,
+		ProcessedRows: j.ProcessedRows,
+		ValidRows:     j.ValidRows,
+		InvalidRows:   j.InvalidRows,
+		SkippedRows:   j.SkippedRows,
+		AppliedRows:   j.AppliedRows,
+		Progress:      progress,
+		Error:         j.Error,
+		StartedAt:     timeString(j.StartedAt),
+		FinishedAt:    timeString(j.FinishedAt),
+		CreatedAt:     j.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func toRoleImportRowDTO(r *model.RoleImportRow) *dto.RoleImportRowDTO {
+	return &dto.RoleImportRowDTO{
+		Line:           r.LineNumber,
+		Email:          r.Email,
+		Role:           r.RoleName,
+		School:         r.School,
+		Unit:           r.Unit,
+		ExpiresAt:      r.ExpiresAtRaw,
+		Status:         r.Status,
+		Message:        r.Message,
+		UserID:         uuidString(r.UserID),
+		RoleID:         uuidString(r.RoleID),
+		SchoolID:       uuidString(r.SchoolID),
+		AcademicUnitID: uuidString(r.AcademicUnitID),
+		UserRoleID:     uuidString(r.UserRoleID),
+	}
+}
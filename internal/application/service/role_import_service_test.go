@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedErrors "github.com/EduGoGroup/edugo-shared/common/errors"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+type roleImportFixture struct {
+	repo     *mockRoleImportRepo
+	svc      RoleImportService
+	school   *entities.School
+	unit     *entities.AcademicUnit
+	teacher  *entities.Role
+	auditor  *entities.Role
+	ana      *entities.User
+	inactive *entities.User
+}
+
+func newRoleImportFixture(hasRole func(userID, roleID uuid.UUID) bool) *roleImportFixture {
+	return newRoleImportFixtureWithSoD(hasRole, nil)
+}
+
+// newRoleImportFixtureWithSoD also knows an "auditor" role and checks the given
+// separation-of-duties constraints.
+func newRoleImportFixtureWithSoD(hasRole func(userID, roleID uuid.UUID) bool, constraints func(f *roleImportFixture) []*model.SoDConstraint) *roleImportFixture {
+	f := &roleImportFixture{repo: newMockRoleImportRepo()}
+	f.school = &entities.School{ID: uuid.New(), Name: "Colegio Uno"}
+	f.unit = &entities.AcademicUnit{ID: uuid.New(), SchoolID: f.school.ID, Name: "Primaria"}
+	f.teacher = &entities.Role{ID: uuid.New(), Name: "teacher", Scope: "unit", IsActive: true}
+	f.auditor = &entities.Role{ID: uuid.New(), Name: "auditor", Scope: "school", IsActive: true}
+	f.ana = &entities.User{ID: uuid.New(), Email: "ana@edugo.test", IsActive: true}
+	f.inactive = &entities.User{ID: uuid.New(), Email: "baja@edugo.test"}
+	users := map[string]*entities.User{f.ana.Email: f.ana, f.inactive.Email: f.inactive}
+	userRoles := &mockUserRoleRepo{userHasRoleFn: func(ctx context.Context, userID, roleID uuid.UUID, s, u *uuid.UUID) (bool, error) {
+		return hasRole != nil && hasRole(userID, roleID), nil
+	}}
+	var sod SoDService
+	if constraints != nil {
+		sod = newSoDService(constraints(f), &mockRoleRepo{}, &mockPermissionRepo{}, userRoles)
+	}
+
+	f.svc = NewRoleImportService(f.repo,
+		&mockUserRepo{findByEmailFn: func(ctx context.Context, email string) (*entities.User, error) { return users[email], nil }},
+		&mockRoleRepo{findAllFn: func(ctx context.Context, filters sharedrepo.ListFilters) ([]*entities.Role, int, error) {
+			roles := []*entities.Role{f.teacher}
+			if constraints != nil {
+				roles = append(roles, f.auditor)
+			}
+			return roles, len(roles), nil
+		}},
+		&mockSchoolRepo{findByCodeFn: func(ctx context.Context, code string) (*entities.School, error) {
+			if code == "COL-01" {
+				return f.school, nil
+			}
+			return nil, nil
+		}},
+		&mockAcademicUnitRepo{findBySchoolIDFn: func(ctx context.Context, schoolID uuid.UUID, filters sharedrepo.ListFilters) ([]*entities.AcademicUnit, int64, error) {
+			return []*entities.AcademicUnit{f.unit}, 1, nil
+		}},
+		userRoles, sod, nil, &mockLogger{}, &mockAuditLogger{}, 1000)
+	return f
+}
+
+const roleImportCSV = "email,role,school,unit,expires_at\n" +
+	"ana@edugo.test,teacher,COL-01,primaria,2099-12-31\n" +
+	"baja@edugo.test,teacher,COL-01,Primaria,\n" +
+	"nadie@edugo.test,director,COL-99,,ayer\n" +
+	"ana@edugo.test,teacher,COL-01,Primaria,\n"
+
+func TestRoleImportService_DryRun(t *testing.T) {
+	f := newRoleImportFixture(nil)
+	ctx := context.Background()
+	job, err := f.svc.Submit(ctx, uuid.New().String(), "inicio.csv", []byte(roleImportCSV), true)
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if job.Status != model.RoleImportPending || job.TotalRows != 4 {
+		t.Fatalf("el trabajo debe quedar en cola: %+v", job)
+	}
+
+	n, err := f.svc.ProcessPending(ctx)
+	if err != nil || n != 1 {
+		t.Fatalf("se esperaba procesar un trabajo: n=%d err=%v", n, err)
+	}
+	got, _ := f.svc.GetJob(ctx, job.ID)
+	if got.Status != model.RoleImportCompleted || got.ValidRows != 1 || got.InvalidRows != 3 || got.AppliedRows != 0 || got.Progress != 100 {
+		t.Errorf("contadores incorrectos: %+v", got)
+	}
+	if len(f.repo.applied) != 0 {
+		t.Errorf("un dry run no debe otorgar roles")
+	}
+
+	report, _ := f.svc.GetRows(ctx, job.ID, "", sharedrepo.ListFilters{})
+	if len(report.Rows) != 4 {
+		t.Fatalf("se esperaba una fila de reporte por línea: %d", len(report.Rows))
+	}
+	if report.Rows[0].Status != model.RoleImportRowValid || *report.Rows[0].AcademicUnitID != f.unit.ID.String() {
+		t.Errorf("la fila válida debe resolver la unidad por nombre: %+v", report.Rows[0])
+	}
+	if !strings.Contains(*report.Rows[1].Message, "inactive") {
+		t.Errorf("mensaje incorrecto: %v", *report.Rows[1].Message)
+	}
+	msg := *report.Rows[2].Message
+	for _, want := range []string{"user not found", "role not found", "invalid expires_at"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("se esperaban todos los problemas de la fila, falta %q en %q", want, msg)
+		}
+	}
+	if !strings.Contains(*report.Rows[3].Message, "duplicate of line 2") {
+		t.Errorf("se esperaba detectar el duplicado: %v", *report.Rows[3].Message)
+	}
+}
+
+func TestRoleImportService_Apply(t *testing.T) {
+	ctx := context.Background()
+	csv := "email,role,school,unit\nana@edugo.test,teacher,COL-01,Primaria\nana@edugo.test,teacher,COL-01,\n"
+
+	t.Run("otorga las filas válidas en una sola transacción", func(t *testing.T) {
+		f := newRoleImportFixture(nil)
+		requester := uuid.New()
+		job, err := f.svc.Submit(ctx, requester.String(), "inicio.csv", []byte(csv), false)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if _, err := f.svc.ProcessPending(ctx); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		got, _ := f.svc.GetJob(ctx, job.ID)
+		if got.Status != model.RoleImportCompleted || got.AppliedRows != 2 || len(f.repo.applied) != 2 {
+			t.Fatalf("se esperaban dos asignaciones: %+v", got)
+		}
+		if *f.repo.applied[0].GrantedBy != requester || f.repo.applied[1].AcademicUnitID != nil {
+			t.Errorf("asignación incorrecta: %+v", f.repo.applied[1])
+		}
+		report, _ := f.svc.GetRows(ctx, job.ID, model.RoleImportRowApplied, sharedrepo.ListFilters{})
+		if report.Total != 2 || report.Rows[0].UserRoleID == nil {
+			t.Errorf("el reporte debe enlazar las asignaciones creadas: %+v", report.Rows)
+		}
+	})
+
+	t.Run("si la transacción falla no se otorga nada", func(t *testing.T) {
+		f := newRoleImportFixture(nil)
+		f.repo.applyErr = errors.New("deadlock")
+		job, _ := f.svc.Submit(ctx, uuid.New().String(), "inicio.csv", []byte(csv), false)
+		if _, err := f.svc.ProcessPending(ctx); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		got, _ := f.svc.GetJob(ctx, job.ID)
+		if got.Status != model.RoleImportFailed || got.Error == nil || got.AppliedRows != 0 {
+			t.Errorf("el trabajo debe fallar sin aplicar filas: %+v", got)
+		}
+		report, _ := f.svc.GetRows(ctx, job.ID, model.RoleImportRowValid, sharedrepo.ListFilters{})
+		if report.Total != 2 || report.Rows[0].UserRoleID != nil {
+			t.Errorf("las filas deben volver a quedar válidas: %+v", report.Rows)
+		}
+	})
+}
+
+func TestRoleImportService_SkipsExistingAssignments(t *testing.T) {
+	ctx := context.Background()
+	f := newRoleImportFixture(func(userID, roleID uuid.UUID) bool { return true })
+	job, _ := f.svc.Submit(ctx, uuid.New().String(), "inicio.csv", []byte("email,role,school\nana@edugo.test,teacher,COL-01\n"), false)
+	if _, err := f.svc.ProcessPending(ctx); err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	got, _ := f.svc.GetJob(ctx, job.ID)
+	if got.Status != model.RoleImportCompleted || got.SkippedRows != 1 || len(f.repo.applied) != 0 {
+		t.Errorf("una asignación existente debe omitirse: %+v", got)
+	}
+}
+
+func TestRoleImportService_SoDWithinBatch(t *testing.T) {
+	ctx := context.Background()
+	f := newRoleImportFixtureWithSoD(nil, func(f *roleImportFixture) []*model.SoDConstraint {
+		return []*model.SoDConstraint{{ID: uuid.New(), Name: "docente-auditor", Kind: model.SoDKindRole, LeftID: f.teacher.ID, RightID: f.auditor.ID}}
+	})
+	csv := "email,role,school,unit\nana@edugo.test,teacher,COL-01,Primaria\nana@edugo.test,auditor,COL-01,\n"
+	job, _ := f.svc.Submit(ctx, uuid.New().String(), "inicio.csv", []byte(csv), true)
+	if _, err := f.svc.ProcessPending(ctx); err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	got, _ := f.svc.GetJob(ctx, job.ID)
+	if got.ValidRows != 1 || got.InvalidRows != 1 {
+		t.Fatalf("la segunda fila debe chocar con la primera del mismo archivo: %+v", got)
+	}
+	report, _ := f.svc.GetRows(ctx, job.ID, model.RoleImportRowInvalid, sharedrepo.ListFilters{})
+	if report.Total != 1 || !strings.Contains(*report.Rows[0].Message, "docente-auditor") {
+		t.Errorf("mensaje incorrecto: %+v", report.Rows)
+	}
+}
+
+func TestRoleImportService_Submit_InvalidFile(t *testing.T) {
+	f := newRoleImportFixture(nil)
+	_, err := f.svc.Submit(context.Background(), uuid.New().String(), "inicio.csv", []byte("email\nana@edugo.test\n"), false)
+	assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	if len(f.repo.jobs) != 0 {
+		t.Errorf("un archivo inválido no debe encolarse")
+	}
+}
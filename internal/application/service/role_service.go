@@ -2,6 +2,9 @@ package service
 
 import (
 	"context"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
@@ -27,9 +30,12 @@ type RoleService interface {
 	AssignPermission(ctx context.Context, roleID string, req *dto.AssignPermissionRequest) (*dto.RolePermissionResponse, error)
 	RevokePermission(ctx context.Context, roleID, permissionID string) error
 	BulkReplacePermissions(ctx context.Context, roleID string, req *dto.BulkPermissionsRequest) (*dto.PermissionsResponse, error)
+	PreviewBulkReplacePermissions(ctx context.Context, roleID string, req *dto.BulkPermissionsRequest) (*dto.BulkPermissionsPreviewResponse, error)
 	GetUserRoles(ctx context.Context, userID string) (*dto.UserRolesResponse, error)
+	GetUserEffectivePermissions(ctx context.Context, userID string, schoolID, unitID string) (*dto.EffectivePermissionsResponse, error)
 	GrantRoleToUser(ctx context.Context, userID string, req *dto.GrantRoleRequest, grantedBy string) (*dto.GrantRoleResponse, error)
 	RevokeRoleFromUser(ctx context.Context, userID, roleID string) error
+	ExpireGrants(ctx context.Context) (int, error)
 }
 
 type roleService struct {
@@ -37,13 +43,18 @@ type roleService struct {
 	permissionRepo repository.PermissionRepository
 	userRoleRepo   repository.UserRoleRepository
 	rolePermRepo   repository.RolePermissionRepository
+	menuService    MenuService
+	approvals      RoleGrantApprovalService
+	sod            SoDService
 	logger         logger.Logger
 	auditLogger    audit.AuditLogger
 }
 
-// NewRoleService creates a new role service
-func NewRoleService(roleRepo repository.RoleRepository, permissionRepo repository.PermissionRepository, userRoleRepo repository.UserRoleRepository, rolePermRepo repository.RolePermissionRepository, logger logger.Logger, auditLogger audit.AuditLogger) RoleService {
-	return &roleService{roleRepo: roleRepo, permissionRepo: permissionRepo, userRoleRepo: userRoleRepo, rolePermRepo: rolePermRepo, logger: logger, auditLogger: auditLogger}
+// NewRoleService creates a new role service. approvals may be nil, in which
+// case every grant is applied immediately; sod may be nil to skip
+// separation-of-duties checks.
+func NewRoleService(roleRepo repository.RoleRepository, permissionRepo repository.PermissionRepository, userRoleRepo repository.UserRoleRepository, rolePermRepo repository.RolePermissionRepository, menuService MenuService, approvals RoleGrantApprovalService, sod SoDService, logger logger.Logger, auditLogger audit.AuditLogger) RoleService {
+	return &roleService{roleRepo: roleRepo, permissionRepo: permissionRepo, userRoleRepo: userRoleRepo, rolePermRepo: rolePermRepo, menuService: menuService, approvals: approvals, sod: sod, logger: logger, auditLogger: auditLogger}
 }
 
 func (s *roleService) GetRoles(ctx context.Context, scope string, filters sharedrepo.ListFilters) (*dto.RolesResponse, error) {
@@ -250,6 +261,17 @@ func (s *roleService) AssignPermission(ctx context.Context, roleID string, req *
 		return nil, errors.NewAlreadyExistsError("role_permission")
 	}
 
+	if s.sod != nil {
+		current, err := s.permissionRepo.FindByRole(ctx, rid)
+		if err != nil {
+			return nil, errors.NewDatabaseError("find role permissions", err)
+		}
+		permIDs := append(permissionIDs(current), pid)
+		if err := s.sod.CheckRolePermissions(ctx, rid, permIDs); err != nil {
+			return nil, err
+		}
+	}
+
 	rp := &entities.RolePermission{
 		ID:           uuid.New(),
 		RoleID:       rid,
@@ -282,46 +304,306 @@ func (s *roleService) RevokePermission(ctx context.Context, roleID, permissionID
 }
 
 func (s *roleService) BulkReplacePermissions(ctx context.Context, roleID string, req *dto.BulkPermissionsRequest) (*dto.PermissionsResponse, error) {
+	rid, role, permIDs, next, err := s.resolveBulkPermissions(ctx, roleID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.permissionRepo.FindByRole(ctx, rid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find role permissions", err)
+	}
+	diff := diffPermissions(current, next)
+
+	if s.sod != nil {
+		if err := s.sod.CheckRolePermissions(ctx, rid, permIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.rolePermRepo.BulkReplace(ctx, rid, permIDs); err != nil {
+		return nil, errors.NewDatabaseError("bulk replace permissions", err)
+	}
+
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action:       "update",
+		ResourceType: "role_permissions",
+		ResourceID:   roleID,
+		Severity:     audit.SeverityCritical,
+		Category:     audit.CategoryAdmin,
+		Metadata: map[string]interface{}{
+			"role_name": role.Name,
+			"added":     permissionDTONames(diff.Added),
+			"removed":   permissionDTONames(diff.Removed),
+			"unchanged": diff.Unchanged,
+		},
+	})
+	s.logger.Info("permissions bulk replaced", "role_id", roleID, "count", len(permIDs), "added", len(diff.Added), "removed", len(diff.Removed))
+
+	perms, err := s.permissionRepo.FindByRole(ctx, rid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find role permissions", err)
+	}
+	return &dto.PermissionsResponse{Permissions: dto.ToPermissionDTOList(perms)}, nil
+}
+
+// PreviewBulkReplacePermissions computes what BulkReplacePermissions would change
+// without persisting anything: the permission diff, how many active assignments
+// of the role are affected and which menu items their users would gain or lose.
+func (s *roleService) PreviewBulkReplacePermissions(ctx context.Context, roleID string, req *dto.BulkPermissionsRequest) (*dto.BulkPermissionsPreviewResponse, error) {
+	rid, _, _, next, err := s.resolveBulkPermissions(ctx, roleID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.permissionRepo.FindByRole(ctx, rid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find role permissions", err)
+	}
+
+	userRoles, err := s.userRoleRepo.FindActiveByRole(ctx, rid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find role assignments", err)
+	}
+
+	resp := &dto.BulkPermissionsPreviewResponse{
+		RoleID:            roleID,
+		DryRun:            true,
+		Diff:              diffPermissions(current, next),
+		AffectedUserRoles: len(userRoles),
+		MenuItemsGained:   []*dto.MenuImpactDTO{},
+		MenuItemsLost:     []*dto.MenuImpactDTO{},
+	}
+	if len(userRoles) == 0 || (len(resp.Diff.Added) == 0 && len(resp.Diff.Removed) == 0) {
+		return resp, nil
+	}
+
+	resp.MenuItemsGained, resp.MenuItemsLost, err = s.menuImpact(ctx, rid, userRoles, permissionNames(current), permissionNames(next))
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// resolveBulkPermissions validates a bulk request and loads the role and target permissions.
+func (s *roleService) resolveBulkPermissions(ctx context.Context, roleID string, req *dto.BulkPermissionsRequest) (uuid.UUID, *entities.Role, []uuid.UUID, []*entities.Permission, error) {
 	rid, err := uuid.Parse(roleID)
 	if err != nil {
-		return nil, errors.NewValidationError("invalid role ID")
+		return uuid.Nil, nil, nil, nil, errors.NewValidationError("invalid role ID")
 	}
 
 	role, err := s.roleRepo.FindByID(ctx, rid)
 	if err != nil {
-		return nil, errors.NewDatabaseError("find role", err)
+		return uuid.Nil, nil, nil, nil, errors.NewDatabaseError("find role", err)
 	}
 	if role == nil {
-		return nil, errors.NewNotFoundError("role")
+		return uuid.Nil, nil, nil, nil, errors.NewNotFoundError("role")
 	}
 
 	permIDs := make([]uuid.UUID, len(req.PermissionIDs))
+	perms := make([]*entities.Permission, 0, len(req.PermissionIDs))
 	for i, pidStr := range req.PermissionIDs {
 		pid, err := uuid.Parse(pidStr)
 		if err != nil {
-			return nil, errors.NewValidationError("invalid permission ID: " + pidStr)
+			return uuid.Nil, nil, nil, nil, errors.NewValidationError("invalid permission ID: " + pidStr)
 		}
 		perm, err := s.permissionRepo.FindByID(ctx, pid)
 		if err != nil {
-			return nil, errors.NewDatabaseError("find permission", err)
+			return uuid.Nil, nil, nil, nil, errors.NewDatabaseError("find permission", err)
 		}
 		if perm == nil {
-			return nil, errors.NewNotFoundError("permission " + pidStr)
+			return uuid.Nil, nil, nil, nil, errors.NewNotFoundError("permission " + pidStr)
 		}
 		permIDs[i] = pid
+		perms = append(perms, perm)
 	}
+	return rid, role, permIDs, perms, nil
+}
 
-	if err := s.rolePermRepo.BulkReplace(ctx, rid, permIDs); err != nil {
-		return nil, errors.NewDatabaseError("bulk replace permissions", err)
+// menuImpact simulates the menu of every affected assignment before and after the
+// change. Other roles the user holds in the same context are taken into account,
+// so a permission still granted elsewhere is not reported as lost. The
+// assignments of every affected user are loaded in one batch.
+func (s *roleService) menuImpact(ctx context.Context, roleID uuid.UUID, userRoles []*entities.UserRole, before, after []string) ([]*dto.MenuImpactDTO, []*dto.MenuImpactDTO, error) {
+	rolePerms := make(map[uuid.UUID][]string)
+	menus := make(map[string]map[string]string)
+	gainedUsers := make(map[string]map[uuid.UUID]bool)
+	lostUsers := make(map[string]map[uuid.UUID]bool)
+	displayNames := make(map[string]string)
+
+	held, err := s.heldRolesByUser(ctx, userRoles)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	s.logger.Info("permissions bulk replaced", "role_id", roleID, "count", len(permIDs))
+	for _, ur := range userRoles {
+		var others []string
+		for _, other := range held[ur.UserID] {
+			if other.RoleID == roleID || !inUserRoleContext(other, ur.SchoolID, ur.AcademicUnitID) {
+				continue
+			}
+			perms, loaded := rolePerms[other.RoleID]
+			if !loaded {
+				found, err := s.permissionRepo.FindByRole(ctx, other.RoleID)
+				if err != nil {
+					return nil, nil, errors.NewDatabaseError("find role permissions", err)
+				}
+				perms = permissionNames(found)
+				rolePerms[other.RoleID] = perms
+			}
+			others = append(others, perms...)
+		}
 
-	perms, err := s.permissionRepo.FindByRole(ctx, rid)
+		beforeMenu, err := s.menuItemsFor(ctx, menus, append(slices.Clone(others), before...))
+		if err != nil {
+			return nil, nil, err
+		}
+		afterMenu, err := s.menuItemsFor(ctx, menus, append(slices.Clone(others), after...))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for key, name := range afterMenu {
+			if _, ok := beforeMenu[key]; !ok {
+				if gainedUsers[key] == nil {
+					gainedUsers[key] = make(map[uuid.UUID]bool)
+				}
+				gainedUsers[key][ur.UserID] = true
+				displayNames[key] = name
+			}
+		}
+		for key, name := range beforeMenu {
+			if _, ok := afterMenu[key]; !ok {
+				if lostUsers[key] == nil {
+					lostUsers[key] = make(map[uuid.UUID]bool)
+				}
+				lostUsers[key][ur.UserID] = true
+				displayNames[key] = name
+			}
+		}
+	}
+
+	return toMenuImpactList(gainedUsers, displayNames), toMenuImpactList(lostUsers, displayNames), nil
+}
+
+// heldRolesByUser loads the active assignments of the users behind userRoles
+// in one query, grouped by user.
+func (s *roleService) heldRolesByUser(ctx context.Context, userRoles []*entities.UserRole) (map[uuid.UUID][]*entities.UserRole, error) {
+	userIDs := make([]uuid.UUID, 0, len(userRoles))
+	seen := make(map[uuid.UUID]bool, len(userRoles))
+	for _, ur := range userRoles {
+		if !seen[ur.UserID] {
+			seen[ur.UserID] = true
+			userIDs = append(userIDs, ur.UserID)
+		}
+	}
+	held, err := s.userRoleRepo.FindActiveByUsers(ctx, userIDs)
 	if err != nil {
-		return nil, errors.NewDatabaseError("find role permissions", err)
+		return nil, errors.NewDatabaseError("find user roles", err)
 	}
-	return &dto.PermissionsResponse{Permissions: dto.ToPermissionDTOList(perms)}, nil
+	byUser := make(map[uuid.UUID][]*entities.UserRole, len(userIDs))
+	for _, ur := range held {
+		byUser[ur.UserID] = append(byUser[ur.UserID], ur)
+	}
+	return byUser, nil
+}
+
+// inUserRoleContext reports whether ur falls in the (school, unit) context,
+// matching FindByUserInContext: a nil school or unit does not filter.
+func inUserRoleContext(ur *entities.UserRole, schoolID, unitID *uuid.UUID) bool {
+	if schoolID != nil && (ur.SchoolID == nil || *ur.SchoolID != *schoolID) {
+		return false
+	}
+	if unitID != nil && (ur.AcademicUnitID == nil || *ur.AcademicUnitID != *unitID) {
+		return false
+	}
+	return true
+}
+
+// menuItemsFor returns the flattened menu (key -> display name) for a permission
+// set, memoized by the sorted set so identical contexts hit MenuService once.
+func (s *roleService) menuItemsFor(ctx context.Context, memo map[string]map[string]string, permissions []string) (map[string]string, error) {
+	slices.Sort(permissions)
+	permissions = slices.Compact(permissions)
+	signature := strings.Join(permissions, ",")
+	if items, ok := memo[signature]; ok {
+		return items, nil
+	}
+	menu, err := s.menuService.GetMenuForUser(ctx, permissions, "")
+	if err != nil {
+		return nil, err
+	}
+	items := make(map[string]string)
+	flattenMenuItems(menu.Items, items)
+	memo[signature] = items
+	return items, nil
+}
+
+func flattenMenuItems(items []dto.MenuItemDTO, out map[string]string) {
+	for _, item := range items {
+		out[item.Key] = item.DisplayName
+		flattenMenuItems(item.Children, out)
+	}
+}
+
+func toMenuImpactList(users map[string]map[uuid.UUID]bool, displayNames map[string]string) []*dto.MenuImpactDTO {
+	list := make([]*dto.MenuImpactDTO, 0, len(users))
+	for key, affected := range users {
+		list = append(list, &dto.MenuImpactDTO{Key: key, DisplayName: displayNames[key], AffectedUsers: len(affected)})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+	return list
+}
+
+// diffPermissions compares the current and target permission sets of a role.
+func diffPermissions(current, next []*entities.Permission) *dto.PermissionsDiffDTO {
+	currentByID := make(map[uuid.UUID]*entities.Permission, len(current))
+	for _, p := range current {
+		currentByID[p.ID] = p
+	}
+	diff := &dto.PermissionsDiffDTO{Added: []*dto.PermissionDTO{}, Removed: []*dto.PermissionDTO{}}
+	nextByID := make(map[uuid.UUID]bool, len(next))
+	for _, p := range next {
+		if nextByID[p.ID] {
+			continue
+		}
+		nextByID[p.ID] = true
+		if _, ok := currentByID[p.ID]; ok {
+			diff.Unchanged++
+		} else {
+			diff.Added = append(diff.Added, dto.ToPermissionDTO(p))
+		}
+	}
+	for _, p := range current {
+		if !nextByID[p.ID] {
+			diff.Removed = append(diff.Removed, dto.ToPermissionDTO(p))
+		}
+	}
+	return diff
+}
+
+func permissionNames(perms []*entities.Permission) []string {
+	names := make([]string, len(perms))
+	for i, p := range perms {
+		names[i] = p.Name
+	}
+	return names
+}
+
+func permissionIDs(perms []*entities.Permission) []uuid.UUID {
+	ids := make([]uuid.UUID, len(perms))
+	for i, p := range perms {
+		ids[i] = p.ID
+	}
+	return ids
+}
+
+func permissionDTONames(perms []*dto.PermissionDTO) []string {
+	names := make([]string, len(perms))
+	for i, p := range perms {
+		names[i] = p.Name
+	}
+	return names
 }
 
 func (s *roleService) GetUserRoles(ctx context.Context, userID string) (*dto.UserRolesResponse, error) {
@@ -346,28 +628,121 @@ func (s *roleService) GetUserRoles(ctx context.Context, userID string) (*dto.Use
 
 	dtos := make([]*dto.UserRoleDTO, len(userRoles))
 	for i, ur := range userRoles {
-		d := &dto.UserRoleDTO{
-			ID:        ur.ID.String(),
-			UserID:    ur.UserID.String(),
-			RoleID:    ur.RoleID.String(),
-			IsActive:  ur.IsActive,
-			GrantedAt: ur.GrantedAt.Format(time.RFC3339),
+		roleName := ""
+		if role, exists := roleCache[ur.RoleID]; exists {
+			roleName = role.Name
+		}
+		dtos[i] = toUserRoleDTO(ur, roleName)
+	}
+
+	return &dto.UserRolesResponse{UserRoles: dtos}, nil
+}
+
+func toUserRoleDTO(ur *entities.UserRole, roleName string) *dto.UserRoleDTO {
+	return &dto.UserRoleDTO{
+		ID:             ur.ID.String(),
+		UserID:         ur.UserID.String(),
+		RoleID:         ur.RoleID.String(),
+		RoleName:       roleName,
+		SchoolID:       uuidString(ur.SchoolID),
+		AcademicUnitID: uuidString(ur.AcademicUnitID),
+		IsActive:       ur.IsActive,
+		GrantedAt:      ur.GrantedAt.Format(time.RFC3339),
+	}
+}
+
+// GetUserEffectivePermissions resolves the permissions a user holds in a context
+// and which role assignments grant each one. It follows the same resolution as
+// the RBAC context built at login: active user_roles filtered by school/unit,
+// joined with the active permissions of each role.
+func (s *roleService) GetUserEffectivePermissions(ctx context.Context, userID string, schoolID, unitID string) (*dto.EffectivePermissionsResponse, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid user ID")
+	}
+
+	resp := &dto.EffectivePermissionsResponse{UserID: userID, Permissions: []*dto.EffectivePermissionDTO{}}
+
+	var sid *uuid.UUID
+	if schoolID != "" {
+		parsed, err := uuid.Parse(schoolID)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid school_id")
 		}
-		if ur.SchoolID != nil {
-			sid := ur.SchoolID.String()
-			d.SchoolID = &sid
+		sid = &parsed
+		resp.SchoolID = &schoolID
+	}
+
+	var aid *uuid.UUID
+	if unitID != "" {
+		parsed, err := uuid.Parse(unitID)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid unit_id")
 		}
-		if ur.AcademicUnitID != nil {
-			aid := ur.AcademicUnitID.String()
-			d.AcademicUnitID = &aid
+		aid = &parsed
+		resp.AcademicUnitID = &unitID
+	}
+
+	userRoles, err := s.userRoleRepo.FindByUserInContext(ctx, uid, sid, aid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find user roles", err)
+	}
+
+	byName := make(map[string]*dto.EffectivePermissionDTO)
+	rolePerms := make(map[uuid.UUID][]*entities.Permission)
+	roleNames := make(map[uuid.UUID]string)
+	for _, ur := range userRoles {
+		perms, loaded := rolePerms[ur.RoleID]
+		if !loaded {
+			perms, err = s.permissionRepo.FindByRole(ctx, ur.RoleID)
+			if err != nil {
+				return nil, errors.NewDatabaseError("find role permissions", err)
+			}
+			rolePerms[ur.RoleID] = perms
+			if role, err := s.roleRepo.FindByID(ctx, ur.RoleID); err == nil && role != nil {
+				roleNames[ur.RoleID] = role.Name
+			}
 		}
-		if role, exists := roleCache[ur.RoleID]; exists {
-			d.RoleName = role.Name
+
+		grant := toPermissionGrantDTO(ur, roleNames[ur.RoleID])
+		for _, perm := range perms {
+			ep, exists := byName[perm.Name]
+			if !exists {
+				ep = &dto.EffectivePermissionDTO{Permission: dto.ToPermissionDTO(perm)}
+				byName[perm.Name] = ep
+				resp.Permissions = append(resp.Permissions, ep)
+			}
+			ep.GrantedBy = append(ep.GrantedBy, grant)
 		}
-		dtos[i] = d
 	}
 
-	return &dto.UserRolesResponse{UserRoles: dtos}, nil
+	sort.Slice(resp.Permissions, func(i, j int) bool {
+		return resp.Permissions[i].Permission.Name < resp.Permissions[j].Permission.Name
+	})
+	resp.Total = len(resp.Permissions)
+	return resp, nil
+}
+
+func toPermissionGrantDTO(ur *entities.UserRole, roleName string) *dto.PermissionGrantDTO {
+	g := &dto.PermissionGrantDTO{
+		UserRoleID: ur.ID.String(),
+		RoleID:     ur.RoleID.String(),
+		RoleName:   roleName,
+		GrantedAt:  ur.GrantedAt.Format(time.RFC3339),
+	}
+	if ur.SchoolID != nil {
+		sid := ur.SchoolID.String()
+		g.SchoolID = &sid
+	}
+	if ur.AcademicUnitID != nil {
+		aid := ur.AcademicUnitID.String()
+		g.AcademicUnitID = &aid
+	}
+	if ur.ExpiresAt != nil {
+		exp := ur.ExpiresAt.Format(time.RFC3339)
+		g.ExpiresAt = &exp
+	}
+	return g
 }
 
 func (s *roleService) GrantRoleToUser(ctx context.Context, userID string, req *dto.GrantRoleRequest, grantedBy string) (*dto.GrantRoleResponse, error) {
@@ -443,6 +818,27 @@ func (s *roleService) GrantRoleToUser(ctx context.Context, userID string, req *d
 		UpdatedAt:      now,
 	}
 
+	if s.sod != nil {
+		if err := s.sod.CheckGrant(ctx, userRole); err != nil {
+			return nil, err
+		}
+	}
+
+	// Roles flagged as requiring approval wait for a second admin
+	if s.approvals != nil {
+		required, err := s.approvals.RequiresApproval(ctx, roleID)
+		if err != nil {
+			return nil, err
+		}
+		if required {
+			pending, err := s.approvals.RequestGrant(ctx, userRole, role)
+			if err != nil {
+				return nil, err
+			}
+			return &dto.GrantRoleResponse{ApprovalRequest: pending}, nil
+		}
+	}
+
 	if err := s.userRoleRepo.Grant(ctx, userRole); err != nil {
 		return nil, errors.NewDatabaseError("grant role", err)
 	}
@@ -457,24 +853,7 @@ func (s *roleService) GrantRoleToUser(ctx context.Context, userID string, req *d
 	})
 	s.logger.Info("role granted", "entity_type", "user_role", "user_id", userID, "role_id", req.RoleID, "role_name", role.Name)
 
-	d := &dto.UserRoleDTO{
-		ID:        userRole.ID.String(),
-		UserID:    userRole.UserID.String(),
-		RoleID:    userRole.RoleID.String(),
-		RoleName:  role.Name,
-		IsActive:  userRole.IsActive,
-		GrantedAt: userRole.GrantedAt.Format(time.RFC3339),
-	}
-	if userRole.SchoolID != nil {
-		sid := userRole.SchoolID.String()
-		d.SchoolID = &sid
-	}
-	if userRole.AcademicUnitID != nil {
-		aid := userRole.AcademicUnitID.String()
-		d.AcademicUnitID = &aid
-	}
-
-	return &dto.GrantRoleResponse{UserRole: d}, nil
+	return &dto.GrantRoleResponse{UserRole: toUserRoleDTO(userRole, role.Name)}, nil
 }
 
 func (s *roleService) RevokeRoleFromUser(ctx context.Context, userID, roleID string) error {
@@ -499,3 +878,27 @@ func (s *roleService) RevokeRoleFromUser(ctx context.Context, userID, roleID str
 	s.logger.Info("role revoked", "entity_type", "user_role", "user_id", userID, "role_id", roleID)
 	return nil
 }
+
+// ExpireGrants deactivates every assignment past its expiry. It is run
+// periodically by the background job runner; reads already ignore expired
+// assignments, so the sweep only has to keep the table honest.
+func (s *roleService) ExpireGrants(ctx context.Context) (int, error) {
+	expired, err := s.userRoleRepo.DeactivateExpired(ctx, time.Now())
+	if err != nil {
+		return 0, errors.NewDatabaseError("expire user roles", err)
+	}
+	for _, ur := range expired {
+		_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action:       "expire",
+			ResourceType: "user_role",
+			ResourceID:   ur.ID.String(),
+			Severity:     audit.SeverityWarning,
+			Category:     audit.CategoryAdmin,
+			Metadata:     map[string]interface{}{"user_id": ur.UserID.String(), "role_id": ur.RoleID.String()},
+		})
+	}
+	if len(expired) > 0 {
+		s.logger.Info("user roles expired", "entity_type", "user_role", "count", len(expired))
+	}
+	return len(expired), nil
+}
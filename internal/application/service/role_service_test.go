@@ -14,7 +14,7 @@ import (
 )
 
 func newRoleService(roleRepo *mockRoleRepo, permRepo *mockPermissionRepo, urRepo *mockUserRoleRepo) RoleService {
-	return NewRoleService(roleRepo, permRepo, urRepo, &mockRolePermRepo{}, &mockLogger{}, &mockAuditLogger{})
+	return NewRoleService(roleRepo, permRepo, urRepo, &mockRolePermRepo{}, NewMenuService(&mockResourceRepo{}, &mockResourceScreenRepo{}, &mockTranslationRepo{}, &mockLogger{}), nil, nil, &mockLogger{}, &mockAuditLogger{})
 }
 
 // ─── GetRoles ────────────────────────────────────────────────────────────────
@@ -363,6 +363,85 @@ func TestRoleService_GetUserRoles(t *testing.T) {
 	})
 }
 
+// ─── GetUserEffectivePermissions ──────────────────────────────────────────────
+
+func TestRoleService_GetUserEffectivePermissions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("agrupa permisos por rol que los otorga", func(t *testing.T) {
+		userID := uuid.New()
+		schoolID := uuid.New()
+		teacherID, tutorID := uuid.New(), uuid.New()
+		expires := time.Now().Add(24 * time.Hour)
+		userRoles := []*entities.UserRole{
+			{ID: uuid.New(), UserID: userID, RoleID: teacherID, SchoolID: &schoolID, IsActive: true, GrantedAt: time.Now()},
+			{ID: uuid.New(), UserID: userID, RoleID: tutorID, SchoolID: &schoolID, IsActive: true, GrantedAt: time.Now(), ExpiresAt: &expires},
+		}
+		shared := &entities.Permission{ID: uuid.New(), Name: "grades:read", Action: "read"}
+		only := &entities.Permission{ID: uuid.New(), Name: "grades:update", Action: "update"}
+
+		var gotSchool *uuid.UUID
+		urRepo := &mockUserRoleRepo{
+			findByUserInContextFn: func(ctx context.Context, uid uuid.UUID, sid *uuid.UUID, unitID *uuid.UUID) ([]*entities.UserRole, error) {
+				gotSchool = sid
+				return userRoles, nil
+			},
+		}
+		permRepo := &mockPermissionRepo{
+			findByRoleFn: func(ctx context.Context, roleID uuid.UUID) ([]*entities.Permission, error) {
+				if roleID == teacherID {
+					return []*entities.Permission{shared, only}, nil
+				}
+				return []*entities.Permission{shared}, nil
+			},
+		}
+		roleRepo := &mockRoleRepo{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Role, error) {
+				if id == teacherID {
+					return &entities.Role{ID: id, Name: "teacher"}, nil
+				}
+				return &entities.Role{ID: id, Name: "tutor"}, nil
+			},
+		}
+
+		svc := newRoleService(roleRepo, permRepo, urRepo)
+		resp, err := svc.GetUserEffectivePermissions(ctx, userID.String(), schoolID.String(), "")
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if gotSchool == nil || *gotSchool != schoolID {
+			t.Error("esperaba filtro por school_id")
+		}
+		if resp.Total != 2 {
+			t.Fatalf("esperaba 2 permisos, obtuvo %d", resp.Total)
+		}
+		if resp.Permissions[0].Permission.Name != "grades:read" || len(resp.Permissions[0].GrantedBy) != 2 {
+			t.Errorf("grades:read debería estar otorgado por 2 roles")
+		}
+		tutorGrant := resp.Permissions[0].GrantedBy[1]
+		if tutorGrant.RoleName != "tutor" || tutorGrant.ExpiresAt == nil {
+			t.Errorf("esperaba grant de tutor con expiración, obtuvo %+v", tutorGrant)
+		}
+	})
+
+	t.Run("retorna error de validación con school_id inválido", func(t *testing.T) {
+		svc := newRoleService(&mockRoleRepo{}, &mockPermissionRepo{}, &mockUserRoleRepo{})
+		_, err := svc.GetUserEffectivePermissions(ctx, uuid.New().String(), "bad", "")
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	})
+
+	t.Run("propaga error de base de datos", func(t *testing.T) {
+		urRepo := &mockUserRoleRepo{
+			findByUserInContextFn: func(ctx context.Context, uid uuid.UUID, sid *uuid.UUID, unitID *uuid.UUID) ([]*entities.UserRole, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		svc := newRoleService(&mockRoleRepo{}, &mockPermissionRepo{}, urRepo)
+		_, err := svc.GetUserEffectivePermissions(ctx, uuid.New().String(), "", "")
+		assertAppError(t, err, sharedErrors.ErrorCodeDatabaseError)
+	})
+}
+
 // ─── GrantRoleToUser ──────────────────────────────────────────────────────────
 
 func TestRoleService_GrantRoleToUser(t *testing.T) {
@@ -757,7 +836,7 @@ func TestRoleService_DeleteRole(t *testing.T) {
 // ─── AssignPermission ─────────────────────────────────────────────────────────
 
 func newRoleServiceFull(roleRepo *mockRoleRepo, permRepo *mockPermissionRepo, urRepo *mockUserRoleRepo, rpRepo *mockRolePermRepo) RoleService {
-	return NewRoleService(roleRepo, permRepo, urRepo, rpRepo, &mockLogger{}, &mockAuditLogger{})
+	return NewRoleService(roleRepo, permRepo, urRepo, rpRepo, NewMenuService(&mockResourceRepo{}, &mockResourceScreenRepo{}, &mockTranslationRepo{}, &mockLogger{}), nil, nil, &mockLogger{}, &mockAuditLogger{})
 }
 
 func TestRoleService_AssignPermission(t *testing.T) {
@@ -1004,6 +1083,121 @@ func TestRoleService_BulkReplacePermissions(t *testing.T) {
 	})
 }
 
+// ─── PreviewBulkReplacePermissions ────────────────────────────────────────────
+
+func TestRoleService_PreviewBulkReplacePermissions(t *testing.T) {
+	ctx := context.Background()
+
+	roleID := uuid.New()
+	role := &entities.Role{ID: roleID, Name: "teacher", DisplayName: "Teacher", Scope: "school", IsActive: true}
+	usersRead := &entities.Permission{ID: uuid.New(), Name: "users:read", Action: "read"}
+	gradesRead := &entities.Permission{ID: uuid.New(), Name: "grades:read", Action: "read"}
+	reportsRead := &entities.Permission{ID: uuid.New(), Name: "reports:read", Action: "read"}
+	byID := map[uuid.UUID]*entities.Permission{usersRead.ID: usersRead, gradesRead.ID: gradesRead, reportsRead.ID: reportsRead}
+
+	resources := []*entities.Resource{
+		{ID: uuid.New(), Key: "users", DisplayName: "Users", IsMenuVisible: true, IsActive: true},
+		{ID: uuid.New(), Key: "grades", DisplayName: "Grades", IsMenuVisible: true, IsActive: true},
+		{ID: uuid.New(), Key: "reports", DisplayName: "Reports", IsMenuVisible: true, IsActive: true},
+	}
+
+	newSvc := func(urRepo *mockUserRoleRepo, rpRepo *mockRolePermRepo) RoleService {
+		permRepo := &mockPermissionRepo{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Permission, error) { return byID[id], nil },
+			findByRoleFn: func(ctx context.Context, rID uuid.UUID) ([]*entities.Permission, error) {
+				if rID == roleID {
+					return []*entities.Permission{usersRead, reportsRead}, nil
+				}
+				return []*entities.Permission{reportsRead}, nil
+			},
+		}
+		roleRepo := &mockRoleRepo{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Role, error) { return role, nil },
+		}
+		menuSvc := NewMenuService(&mockResourceRepo{
+			findMenuVisibleFn: func(ctx context.Context) ([]*entities.Resource, error) { return resources, nil },
+		}, &mockResourceScreenRepo{}, &mockTranslationRepo{}, &mockLogger{})
+		return NewRoleService(roleRepo, permRepo, urRepo, rpRepo, menuSvc, nil, nil, &mockLogger{}, &mockAuditLogger{})
+	}
+
+	t.Run("calcula diff, asignaciones afectadas y menú sin persistir", func(t *testing.T) {
+		userID := uuid.New()
+		otherRoleID := uuid.New()
+		schoolID, otherSchool := uuid.New(), uuid.New()
+		batches := 0
+		urRepo := &mockUserRoleRepo{
+			findActiveByRoleFn: func(ctx context.Context, rID uuid.UUID) ([]*entities.UserRole, error) {
+				return []*entities.UserRole{{ID: uuid.New(), UserID: userID, RoleID: roleID, SchoolID: &schoolID, IsActive: true}}, nil
+			},
+			findActiveByUsersFn: func(ctx context.Context, userIDs []uuid.UUID) ([]*entities.UserRole, error) {
+				batches++
+				return []*entities.UserRole{
+					{ID: uuid.New(), UserID: userID, RoleID: roleID, SchoolID: &schoolID, IsActive: true},
+					{ID: uuid.New(), UserID: userID, RoleID: otherRoleID, SchoolID: &schoolID, IsActive: true},
+					{ID: uuid.New(), UserID: userID, RoleID: uuid.New(), SchoolID: &otherSchool, IsActive: true},
+				}, nil
+			},
+		}
+		bulkCalled := false
+		rpRepo := &mockRolePermRepo{
+			bulkReplaceFn: func(ctx context.Context, rID uuid.UUID, pIDs []uuid.UUID) error {
+				bulkCalled = true
+				return nil
+			},
+		}
+
+		svc := newSvc(urRepo, rpRepo)
+		req := &dto.BulkPermissionsRequest{PermissionIDs: []string{gradesRead.ID.String()}}
+		resp, err := svc.PreviewBulkReplacePermissions(ctx, roleID.String(), req)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if bulkCalled {
+			t.Error("dry run no debe llamar a BulkReplace")
+		}
+		if len(resp.Diff.Added) != 1 || len(resp.Diff.Removed) != 2 {
+			t.Errorf("diff incorrecto: +%d -%d", len(resp.Diff.Added), len(resp.Diff.Removed))
+		}
+		if resp.AffectedUserRoles != 1 {
+			t.Errorf("esperaba 1 asignación afectada, obtuvo %d", resp.AffectedUserRoles)
+		}
+		if batches != 1 {
+			t.Errorf("esperaba una sola consulta de asignaciones, obtuvo %d", batches)
+		}
+		if len(resp.MenuItemsGained) != 1 || resp.MenuItemsGained[0].Key != "grades" {
+			t.Errorf("esperaba ganar 'grades', obtuvo %+v", resp.MenuItemsGained)
+		}
+		// reports sigue otorgado por otro rol: solo se pierde users
+		if len(resp.MenuItemsLost) != 1 || resp.MenuItemsLost[0].Key != "users" {
+			t.Errorf("esperaba perder solo 'users', obtuvo %+v", resp.MenuItemsLost)
+		}
+	})
+
+	t.Run("sin asignaciones activas no calcula menú", func(t *testing.T) {
+		svc := newSvc(&mockUserRoleRepo{}, &mockRolePermRepo{})
+		req := &dto.BulkPermissionsRequest{PermissionIDs: []string{gradesRead.ID.String()}}
+		resp, err := svc.PreviewBulkReplacePermissions(ctx, roleID.String(), req)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if resp.AffectedUserRoles != 0 || len(resp.MenuItemsGained) != 0 {
+			t.Errorf("esperaba impacto vacío, obtuvo %+v", resp)
+		}
+	})
+
+	t.Run("propaga error al buscar asignaciones", func(t *testing.T) {
+		urRepo := &mockUserRoleRepo{
+			findActiveByRoleFn: func(ctx context.Context, rID uuid.UUID) ([]*entities.UserRole, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		svc := newSvc(urRepo, &mockRolePermRepo{})
+		req := &dto.BulkPermissionsRequest{PermissionIDs: []string{gradesRead.ID.String()}}
+		_, err := svc.PreviewBulkReplacePermissions(ctx, roleID.String(), req)
+		assertAppError(t, err, sharedErrors.ErrorCodeDatabaseError)
+	})
+}
+
 // ─── Helpers ──────────────────────────────────────────────────────────────────
 
 func assertAppError(t *testing.T, err error, code sharedErrors.ErrorCode) {
@@ -1019,3 +1213,34 @@ func assertAppError(t *testing.T, err error, code sharedErrors.ErrorCode) {
 		t.Errorf("código de error incorrecto: esperaba %s, obtuvo %s", code, appErr.Code)
 	}
 }
+
+func TestRoleService_ExpireGrants(t *testing.T) {
+	t.Run("desactiva las asignaciones vencidas", func(t *testing.T) {
+		var cutoff time.Time
+		urRepo := &mockUserRoleRepo{
+			deactivateExpiredFn: func(ctx context.Context, now time.Time) ([]*entities.UserRole, error) {
+				cutoff = now
+				return []*entities.UserRole{{ID: uuid.New(), UserID: uuid.New(), RoleID: uuid.New()}}, nil
+			},
+		}
+		svc := newRoleService(&mockRoleRepo{}, &mockPermissionRepo{}, urRepo)
+		n, err := svc.ExpireGrants(context.Background())
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if n != 1 || cutoff.IsZero() {
+			t.Errorf("esperaba 1 asignación vencida, obtuvo %d", n)
+		}
+	})
+
+	t.Run("propaga errores de base de datos", func(t *testing.T) {
+		urRepo := &mockUserRoleRepo{
+			deactivateExpiredFn: func(ctx context.Context, now time.Time) ([]*entities.UserRole, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		svc := newRoleService(&mockRoleRepo{}, &mockPermissionRepo{}, urRepo)
+		_, err := svc.ExpireGrants(context.Background())
+		assertAppError(t, err, sharedErrors.ErrorCodeDatabaseError)
+	})
+}
@@ -0,0 +1,540 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+// ScreenConfigBundleVersion is the bundle format version produced by export
+const ScreenConfigBundleVersion = 1
+
+// Bundle plan change kinds and operations
+const (
+	ScreenBundleKindTemplate = "template"
+	ScreenBundleKindInstance = "instance"
+	ScreenBundleKindLink     = "link"
+
+	ScreenBundleOpCreate = "create"
+	ScreenBundleOpUpdate = "update"
+)
+
+// ScreenConfigBundle is a portable copy of the published screen configuration.
+// Entities are matched across environments by natural key: templates by
+// pattern, instances by screen_key and links by resource_key and screen_key.
+type ScreenConfigBundle struct {
+	Version   int                    `json:"version"`
+	Templates []ScreenBundleTemplate `json:"templates"`
+	Instances []ScreenBundleInstance `json:"instances"`
+	Links     []ScreenBundleLink     `json:"links"`
+}
+
+type ScreenBundleTemplate struct {
+	Pattern     string          `json:"pattern"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Definition  json.RawMessage `json:"definition"`
+}
+
+// ScreenBundleInstance references its template by pattern
+type ScreenBundleInstance struct {
+	ScreenKey          string          `json:"screen_key"`
+	Template           string          `json:"template"`
+	Name               string          `json:"name"`
+	Description        string          `json:"description,omitempty"`
+	SlotData           json.RawMessage `json:"slot_data"`
+	Scope              string          `json:"scope"`
+	RequiredPermission string          `json:"required_permission,omitempty"`
+	HandlerKey         string          `json:"handler_key,omitempty"`
+}
+
+type ScreenBundleLink struct {
+	ResourceKey string `json:"resource_key"`
+	ScreenKey   string `json:"screen_key"`
+	ScreenType  string `json:"screen_type"`
+	IsDefault   bool   `json:"is_default"`
+}
+
+// ScreenBundleChange is one planned change of an import. Diff lists the
+// definition or slot data changes of an update.
+type ScreenBundleChange struct {
+	Kind   string       `json:"kind"`
+	Key    string       `json:"key"`
+	Op     string       `json:"op"`
+	Fields []string     `json:"fields,omitempty"`
+	Diff   []JSONChange `json:"diff,omitempty"`
+}
+
+// ScreenBundleConflict is an entity of the bundle that cannot be imported
+// into this environment as it stands
+type ScreenBundleConflict struct {
+	Kind   string `json:"kind"`
+	Key    string `json:"key"`
+	Reason string `json:"reason"`
+}
+
+type ScreenBundleSummary struct {
+	Create    int `json:"create"`
+	Update    int `json:"update"`
+	Unchanged int `json:"unchanged"`
+}
+
+// ScreenBundleImportResponse is the plan (and outcome) of an import. Nothing
+// is written on a dry run or when there are conflicts.
+type ScreenBundleImportResponse struct {
+	DryRun    bool                   `json:"dry_run"`
+	Applied   bool                   `json:"applied"`
+	Summary   ScreenBundleSummary    `json:"summary"`
+	Changes   []ScreenBundleChange   `json:"changes"`
+	Conflicts []ScreenBundleConflict `json:"conflicts"`
+}
+
+// ParseScreenConfigBundle decodes a JSON bundle. Unknown fields are rejected
+// to catch typos early.
+func ParseScreenConfigBundle(data []byte) (*ScreenConfigBundle, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var b ScreenConfigBundle
+	if err := dec.Decode(&b); err != nil {
+		return nil, errors.NewValidationError("invalid JSON bundle: " + err.Error())
+	}
+	return &b, nil
+}
+
+// ExportBundle exports the published revision of the active templates,
+// instances and resource-screen links
+func (s *screenConfigService) ExportBundle(ctx context.Context) (*ScreenConfigBundle, error) {
+	snap, err := s.bundleRepo.Snapshot(ctx)
+	if err != nil {
+		return nil, errors.NewDatabaseError("load screen config", err)
+	}
+
+	b := &ScreenConfigBundle{
+		Version:   ScreenConfigBundleVersion,
+		Templates: []ScreenBundleTemplate{},
+		Instances: []ScreenBundleInstance{},
+		Links:     []ScreenBundleLink{},
+	}
+	patterns := make(map[uuid.UUID]string, len(snap.Templates))
+	exported := make(map[string]bool, len(snap.Templates))
+	for _, t := range snap.Templates {
+		if !t.IsActive {
+			continue
+		}
+		if exported[t.Pattern] {
+			return nil, errors.NewConflictError(fmt.Sprintf("several active templates share pattern %q; bundles match templates by pattern", t.Pattern))
+		}
+		exported[t.Pattern] = true
+		patterns[t.ID] = t.Pattern
+		b.Templates = append(b.Templates, ScreenBundleTemplate{
+			Pattern: t.Pattern, Name: t.Name, Description: derefString(t.Description), Definition: t.Definition,
+		})
+	}
+	screenKeys := make(map[string]bool, len(snap.Instances))
+	for _, inst := range snap.Instances {
+		pattern, ok := patterns[inst.TemplateID]
+		if !inst.IsActive || !ok {
+			continue
+		}
+		screenKeys[inst.ScreenKey] = true
+		b.Instances = append(b.Instances, ScreenBundleInstance{
+			ScreenKey: inst.ScreenKey, Template: pattern, Name: inst.Name, Description: derefString(inst.Description),
+			SlotData: inst.SlotData, Scope: inst.Scope, RequiredPermission: derefString(inst.RequiredPermission),
+			HandlerKey: derefString(inst.HandlerKey),
+		})
+	}
+	for _, rs := range snap.ResourceScreens {
+		if !rs.IsActive || !screenKeys[rs.ScreenKey] {
+			continue
+		}
+		b.Links = append(b.Links, ScreenBundleLink{
+			ResourceKey: rs.ResourceKey, ScreenKey: rs.ScreenKey, ScreenType: rs.ScreenType, IsDefault: rs.IsDefault,
+		})
+	}
+
+	slices.SortFunc(b.Templates, func(x, y ScreenBundleTemplate) int { return strings.Compare(x.Pattern, y.Pattern) })
+	slices.SortFunc(b.Instances, func(x, y ScreenBundleInstance) int { return strings.Compare(x.ScreenKey, y.ScreenKey) })
+	slices.SortFunc(b.Links, func(x, y ScreenBundleLink) int {
+		return strings.Compare(bundleLinkKey(x.ResourceKey, x.ScreenKey), bundleLinkKey(y.ResourceKey, y.ScreenKey))
+	})
+	return b, nil
+}
+
+// ImportBundle reconciles the screen configuration with a bundle in a single
+// transaction that also reads the configuration the plan is based on.
+// Imported changes are published directly and recorded in the version
+// history. The plan is returned without writing on a dry run or when any
+// entity conflicts with this environment.
+func (s *screenConfigService) ImportBundle(ctx context.Context, bundle *ScreenConfigBundle, dryRun bool) (*ScreenBundleImportResponse, error) {
+	if err := validateScreenBundle(bundle); err != nil {
+		return nil, err
+	}
+	conflicts, err := s.bundleSchemaConflicts(ctx, bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *ScreenBundleImportResponse
+	err = s.bundleRepo.Reconcile(ctx, func(snap *repository.ScreenConfigSnapshot) (*repository.ScreenConfigChangeSet, error) {
+		plan := planScreenBundle(bundle, snap, time.Now())
+		resp = &ScreenBundleImportResponse{
+			DryRun:    dryRun,
+			Summary:   ScreenBundleSummary{Unchanged: plan.unchanged},
+			Changes:   plan.changes,
+			Conflicts: append(conflicts, plan.conflicts...),
+		}
+		for _, c := range plan.changes {
+			switch c.Op {
+			case ScreenBundleOpCreate:
+				resp.Summary.Create++
+			case ScreenBundleOpUpdate:
+				resp.Summary.Update++
+			}
+		}
+		if dryRun || len(resp.Conflicts) > 0 || len(resp.Changes) == 0 {
+			return nil, nil
+		}
+		return plan.changeSet, nil
+	})
+	if err != nil {
+		return nil, errors.NewDatabaseError("import screen config bundle", err)
+	}
+	if dryRun || len(resp.Conflicts) > 0 || len(resp.Changes) == 0 {
+		return resp, nil
+	}
+	resp.Applied = true
+	s.logger.Info("screen config bundle imported", "create", resp.Summary.Create, "update", resp.Summary.Update,
+		"unchanged", resp.Summary.Unchanged)
+	return resp, nil
+}
+
+// validateScreenBundle checks the bundle is self-consistent: required fields,
+// unique natural keys and instances whose template is part of the bundle
+func validateScreenBundle(b *ScreenConfigBundle) error {
+	if b == nil {
+		return errors.NewValidationError("bundle is required")
+	}
+	if b.Version > ScreenConfigBundleVersion {
+		return errors.NewValidationError(fmt.Sprintf("unsupported bundle version %d", b.Version))
+	}
+
+	patterns := make(map[string]bool, len(b.Templates))
+	for i, t := range b.Templates {
+		if t.Pattern == "" || t.Name == "" {
+			return errors.NewValidationError(fmt.Sprintf("templates[%d]: pattern and name are required", i))
+		}
+		if patterns[t.Pattern] {
+			return errors.NewValidationError("duplicate template pattern: " + t.Pattern)
+		}
+		if nullableJSON(t.Definition) == nil || !json.Valid(t.Definition) {
+			return errors.NewValidationError(fmt.Sprintf("template %s: definition must be a JSON document", t.Pattern))
+		}
+		patterns[t.Pattern] = true
+	}
+
+	screenKeys := make(map[string]bool, len(b.Instances))
+	for i, inst := range b.Instances {
+		if inst.ScreenKey == "" || inst.Name == "" {
+			return errors.NewValidationError(fmt.Sprintf("instances[%d]: screen_key and name are required", i))
+		}
+		if !screenKeyRegex.MatchString(inst.ScreenKey) {
+			return errors.NewValidationError(fmt.Sprintf("instance %s: screen_key must be kebab-case", inst.ScreenKey))
+		}
+		if screenKeys[inst.ScreenKey] {
+			return errors.NewValidationError("duplicate instance screen_key: " + inst.ScreenKey)
+		}
+		if !patterns[inst.Template] {
+			return errors.NewValidationError(fmt.Sprintf("instance %s: unknown template %s", inst.ScreenKey, inst.Template))
+		}
+		if nullableJSON(inst.SlotData) != nil && !json.Valid(inst.SlotData) {
+			return errors.NewValidationError(fmt.Sprintf("instance %s: slot_data must be valid JSON", inst.ScreenKey))
+		}
+		screenKeys[inst.ScreenKey] = true
+	}
+
+	links := make(map[string]bool, len(b.Links))
+	for i, l := range b.Links {
+		if l.ResourceKey == "" || l.ScreenKey == "" || l.ScreenType == "" {
+			return errors.NewValidationError(fmt.Sprintf("links[%d]: resource_key, screen_key and screen_type are required", i))
+		}
+		key := bundleLinkKey(l.ResourceKey, l.ScreenKey)
+		if links[key] {
+			return errors.NewValidationError("duplicate link: " + key)
+		}
+		links[key] = true
+	}
+	return nil
+}
+
+// bundleSchemaConflicts validates the bundle's definitions and slot data
+// against the pattern schemas of this environment
+func (s *screenConfigService) bundleSchemaConflicts(ctx context.Context, b *ScreenConfigBundle) ([]ScreenBundleConflict, error) {
+	conflicts := []ScreenBundleConflict{}
+	check := func(kind, key string, err error) error {
+		if err == nil {
+			return nil
+		}
+		if appErr, ok := errors.GetAppError(err); !ok || appErr.Code != errors.ErrorCodeValidation {
+			return err
+		}
+		conflicts = append(conflicts, ScreenBundleConflict{Kind: kind, Key: key, Reason: err.Error()})
+		return nil
+	}
+	for _, t := range b.Templates {
+		if err := check(ScreenBundleKindTemplate, t.Pattern, s.validateDefinition(ctx, t.Pattern, t.Definition)); err != nil {
+			return nil, err
+		}
+	}
+	for _, inst := range b.Instances {
+		if err := check(ScreenBundleKindInstance, inst.ScreenKey, s.validateSlotData(ctx, inst.Template, bundleSlotData(inst))); err != nil {
+			return nil, err
+		}
+	}
+	return conflicts, nil
+}
+
+// screenBundlePlan is the outcome of diffing a bundle against a snapshot
+type screenBundlePlan struct {
+	changeSet *repository.ScreenConfigChangeSet
+	changes   []ScreenBundleChange
+	conflicts []ScreenBundleConflict
+	unchanged int
+}
+
+// planScreenBundle diffs a validated bundle against the current screen
+// configuration. Templates are matched among the active ones; instances and
+// links also match inactive rows, which are reactivated rather than
+// duplicated. Entities with a pending draft are conflicts: the import would
+// publish over the draft's base.
+func planScreenBundle(b *ScreenConfigBundle, snap *repository.ScreenConfigSnapshot, now time.Time) *screenBundlePlan {
+	p := &screenBundlePlan{
+		changeSet: &repository.ScreenConfigChangeSet{},
+		changes:   []ScreenBundleChange{},
+		conflicts: []ScreenBundleConflict{},
+	}
+	conflict := func(kind, key, reason string) {
+		p.conflicts = append(p.conflicts, ScreenBundleConflict{Kind: kind, Key: key, Reason: reason})
+	}
+
+	// Templates
+	activeTemplates := make(map[string][]*entities.ScreenTemplate)
+	for _, t := range snap.Templates {
+		if t.IsActive {
+			activeTemplates[t.Pattern] = append(activeTemplates[t.Pattern], t)
+		}
+	}
+	templateDrafts := draftsByTemplateID(snap.TemplateDrafts)
+	templateIDs := make(map[string]uuid.UUID, len(b.Templates))
+	for _, bt := range b.Templates {
+		matches := activeTemplates[bt.Pattern]
+		if len(matches) > 1 {
+			conflict(ScreenBundleKindTemplate, bt.Pattern, "several active templates share this pattern")
+			continue
+		}
+		if len(matches) == 0 {
+			t := &entities.ScreenTemplate{
+				ID: uuid.New(), Pattern: bt.Pattern, Name: bt.Name, Description: optionalString(bt.Description),
+				Version: 1, Definition: bt.Definition, IsActive: true, CreatedAt: now, UpdatedAt: now,
+			}
+			templateIDs[bt.Pattern] = t.ID
+			p.changeSet.UpsertTemplates = append(p.changeSet.UpsertTemplates, t)
+			p.changeSet.TemplateVersions = append(p.changeSet.TemplateVersions, importedTemplateVersion(t))
+			p.changes = append(p.changes, ScreenBundleChange{Kind: ScreenBundleKindTemplate, Key: bt.Pattern, Op: ScreenBundleOpCreate})
+			continue
+		}
+		current := matches[0]
+		templateIDs[bt.Pattern] = current.ID
+		definitionChanged := jsonChanged(current.Definition, bt.Definition)
+		var fields []string
+		fields = appendIfChanged(fields, "name", current.Name != bt.Name)
+		fields = appendIfChanged(fields, "description", derefString(current.Description) != bt.Description)
+		fields = appendIfChanged(fields, "definition", definitionChanged)
+		if len(fields) == 0 {
+			p.unchanged++
+			continue
+		}
+		if templateDrafts[current.ID] != nil {
+			conflict(ScreenBundleKindTemplate, bt.Pattern, "the template has an unpublished draft")
+			continue
+		}
+		t := *current
+		t.Name = bt.Name
+		t.Description = optionalString(bt.Description)
+		t.UpdatedAt = now
+		change := ScreenBundleChange{Kind: ScreenBundleKindTemplate, Key: bt.Pattern, Op: ScreenBundleOpUpdate, Fields: fields}
+		if definitionChanged {
+			t.Definition = bt.Definition
+			t.Version++
+			p.changeSet.TemplateVersions = append(p.changeSet.TemplateVersions, importedTemplateVersion(&t))
+			change.Diff, _ = diffJSON(current.Definition, bt.Definition)
+		}
+		p.changeSet.UpsertTemplates = append(p.changeSet.UpsertTemplates, &t)
+		p.changes = append(p.changes, change)
+	}
+
+	// Instances
+	existingInstances := make(map[string]*entities.ScreenInstance, len(snap.Instances))
+	screenKeys := make(map[string]bool, len(snap.Instances)+len(b.Instances))
+	for _, inst := range snap.Instances {
+		if prev, ok := existingInstances[inst.ScreenKey]; !ok || !prev.IsActive {
+			existingInstances[inst.ScreenKey] = inst
+		}
+		if inst.IsActive {
+			screenKeys[inst.ScreenKey] = true
+		}
+	}
+	instanceDrafts := draftsByInstanceID(snap.InstanceDrafts)
+	for _, bi := range b.Instances {
+		screenKeys[bi.ScreenKey] = true
+		templateID, ok := templateIDs[bi.Template]
+		if !ok {
+			// the template is already reported as a conflict
+			continue
+		}
+		slotData := bundleSlotData(bi)
+		scope := bi.Scope
+		if scope == "" {
+			scope = "system"
+		}
+		current, exists := existingInstances[bi.ScreenKey]
+		if !exists {
+			inst := &entities.ScreenInstance{
+				ID: uuid.New(), ScreenKey: bi.ScreenKey, TemplateID: templateID, Name: bi.Name,
+				Description: optionalString(bi.Description), SlotData: slotData, Scope: scope,
+				RequiredPermission: optionalString(bi.RequiredPermission), HandlerKey: optionalString(bi.HandlerKey),
+				IsActive: true, CreatedAt: now, UpdatedAt: now,
+			}
+			p.changeSet.UpsertInstances = append(p.changeSet.UpsertInstances, inst)
+			p.changeSet.InstanceVersions = append(p.changeSet.InstanceVersions, importedInstanceVersion(inst, 1))
+			p.changes = append(p.changes, ScreenBundleChange{Kind: ScreenBundleKindInstance, Key: bi.ScreenKey, Op: ScreenBundleOpCreate})
+			continue
+		}
+		slotDataChanged := jsonChanged(current.SlotData, slotData)
+		var fields []string
+		fields = appendIfChanged(fields, "template", current.TemplateID != templateID)
+		fields = appendIfChanged(fields, "name", current.Name != bi.Name)
+		fields = appendIfChanged(fields, "description", derefString(current.Description) != bi.Description)
+		fields = appendIfChanged(fields, "slot_data", slotDataChanged)
+		fields = appendIfChanged(fields, "scope", current.Scope != scope)
+		fields = appendIfChanged(fields, "required_permission", derefString(current.RequiredPermission) != bi.RequiredPermission)
+		fields = appendIfChanged(fields, "handler_key", derefString(current.HandlerKey) != bi.HandlerKey)
+		fields = appendIfChanged(fields, "is_active", !current.IsActive)
+		if len(fields) == 0 {
+			p.unchanged++
+			continue
+		}
+		if instanceDrafts[current.ID] != nil {
+			conflict(ScreenBundleKindInstance, bi.ScreenKey, "the instance has an unpublished draft")
+			continue
+		}
+		inst := *current
+		inst.TemplateID = templateID
+		inst.Name = bi.Name
+		inst.Description = optionalString(bi.Description)
+		inst.SlotData = slotData
+		inst.Scope = scope
+		inst.RequiredPermission = optionalString(bi.RequiredPermission)
+		inst.HandlerKey = optionalString(bi.HandlerKey)
+		inst.IsActive = true
+		inst.UpdatedAt = now
+		change := ScreenBundleChange{Kind: ScreenBundleKindInstance, Key: bi.ScreenKey, Op: ScreenBundleOpUpdate, Fields: fields}
+		if slotDataChanged {
+			version := snap.LatestInstanceVersions[current.ID] + 1
+			p.changeSet.InstanceVersions = append(p.changeSet.InstanceVersions, importedInstanceVersion(&inst, version))
+			change.Diff, _ = diffJSON(current.SlotData, slotData)
+		}
+		p.changeSet.UpsertInstances = append(p.changeSet.UpsertInstances, &inst)
+		p.changes = append(p.changes, change)
+	}
+
+	// Resource-screen links
+	resourceIDs := make(map[string]uuid.UUID, len(snap.Resources))
+	for _, r := range snap.Resources {
+		resourceIDs[r.Key] = r.ID
+	}
+	existingLinks := make(map[string]*entities.ResourceScreen, len(snap.ResourceScreens))
+	for _, rs := range snap.ResourceScreens {
+		key := bundleLinkKey(rs.ResourceKey, rs.ScreenKey)
+		if prev, ok := existingLinks[key]; !ok || !prev.IsActive {
+			existingLinks[key] = rs
+		}
+	}
+	for _, bl := range b.Links {
+		key := bundleLinkKey(bl.ResourceKey, bl.ScreenKey)
+		resourceID, ok := resourceIDs[bl.ResourceKey]
+		if !ok {
+			conflict(ScreenBundleKindLink, key, "unknown resource "+bl.ResourceKey)
+			continue
+		}
+		if !screenKeys[bl.ScreenKey] {
+			conflict(ScreenBundleKindLink, key, "unknown screen "+bl.ScreenKey)
+			continue
+		}
+		current, exists := existingLinks[key]
+		if !exists {
+			rs := &entities.ResourceScreen{
+				ID: uuid.New(), ResourceID: resourceID, ResourceKey: bl.ResourceKey, ScreenKey: bl.ScreenKey,
+				ScreenType: bl.ScreenType, IsDefault: bl.IsDefault, IsActive: true, CreatedAt: now, UpdatedAt: now,
+			}
+			p.changeSet.UpsertResourceScreens = append(p.changeSet.UpsertResourceScreens, rs)
+			p.changes = append(p.changes, ScreenBundleChange{Kind: ScreenBundleKindLink, Key: key, Op: ScreenBundleOpCreate})
+			continue
+		}
+		var fields []string
+		fields = appendIfChanged(fields, "resource", current.ResourceID != resourceID)
+		fields = appendIfChanged(fields, "screen_type", current.ScreenType != bl.ScreenType)
+		fields = appendIfChanged(fields, "is_default", current.IsDefault != bl.IsDefault)
+		fields = appendIfChanged(fields, "is_active", !current.IsActive)
+		if len(fields) == 0 {
+			p.unchanged++
+			continue
+		}
+		rs := *current
+		rs.ResourceID = resourceID
+		rs.ScreenType = bl.ScreenType
+		rs.IsDefault = bl.IsDefault
+		rs.IsActive = true
+		rs.UpdatedAt = now
+		p.changeSet.UpsertResourceScreens = append(p.changeSet.UpsertResourceScreens, &rs)
+		p.changes = append(p.changes, ScreenBundleChange{Kind: ScreenBundleKindLink, Key: key, Op: ScreenBundleOpUpdate, Fields: fields})
+	}
+	return p
+}
+
+// bundleSlotData returns the instance's slot data, {} when absent
+func bundleSlotData(inst ScreenBundleInstance) json.RawMessage {
+	if nullableJSON(inst.SlotData) == nil {
+		return json.RawMessage(`{}`)
+	}
+	return inst.SlotData
+}
+
+// bundleLinkKey is the natural key of a resource-screen link in plans and
+// conflicts
+func bundleLinkKey(resourceKey, screenKey string) string {
+	return resourceKey + ":" + screenKey
+}
+
+func importedTemplateVersion(t *entities.ScreenTemplate) *model.ScreenTemplateVersion {
+	return &model.ScreenTemplateVersion{
+		ID: uuid.New(), TemplateID: t.ID, Version: t.Version, Pattern: t.Pattern, Name: t.Name,
+		Description: t.Description, Definition: t.Definition, Source: model.ScreenVersionImport, CreatedAt: t.UpdatedAt,
+	}
+}
+
+func importedInstanceVersion(inst *entities.ScreenInstance, version int) *model.ScreenInstanceVersion {
+	return &model.ScreenInstanceVersion{
+		ID: uuid.New(), InstanceID: inst.ID, Version: version, SlotData: inst.SlotData,
+		Source: model.ScreenVersionImport, CreatedAt: inst.UpdatedAt,
+	}
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedErrors "github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+func newBundleScreenService(snap *repository.ScreenConfigSnapshot) (ScreenConfigService, *mockScreenConfigBundleRepo) {
+	bundles := &mockScreenConfigBundleRepo{snapshot: snap}
+	return NewScreenConfigService(&mockScreenTemplateRepo{}, &mockScreenInstanceRepo{}, &mockResourceScreenRepo{},
+		&mockScreenVersionRepo{}, &mockScreenDraftRepo{}, &mockScreenPatternSchemaRepo{}, &mockScreenOverrideRepo{},
+		&mockTranslationRepo{}, bundles, NewLocaleSettings("es", []string{"es", "en"}), &mockLogger{}), bundles
+}
+
+// bundleSnapshot is an environment with a "list" template, its students-list
+// instance linked to the students resource and an inactive detail template
+func bundleSnapshot() *repository.ScreenConfigSnapshot {
+	now := time.Now()
+	list := &entities.ScreenTemplate{ID: uuid.New(), Pattern: "list", Name: "Lista", Version: 3,
+		Definition: json.RawMessage(`{"title":"v1"}`), IsActive: true, CreatedAt: now, UpdatedAt: now}
+	detail := &entities.ScreenTemplate{ID: uuid.New(), Pattern: "detail", Name: "Detalle", Version: 1,
+		Definition: json.RawMessage(`{}`), CreatedAt: now, UpdatedAt: now}
+	students := &entities.ScreenInstance{ID: uuid.New(), ScreenKey: "students-list", TemplateID: list.ID, Name: "Alumnos",
+		SlotData: json.RawMessage(`{"columns":["name"]}`), Scope: "school", IsActive: true, CreatedAt: now, UpdatedAt: now}
+	resource := &entities.Resource{ID: uuid.New(), Key: "students", IsActive: true}
+	return &repository.ScreenConfigSnapshot{
+		Templates: []*entities.ScreenTemplate{list, detail},
+		Instances: []*entities.ScreenInstance{students},
+		ResourceScreens: []*entities.ResourceScreen{{
+			ID: uuid.New(), ResourceID: resource.ID, ResourceKey: "students", ScreenKey: "students-list",
+			ScreenType: "list", IsDefault: true, IsActive: true,
+		}},
+		Resources:              []*entities.Resource{resource},
+		LatestInstanceVersions: map[uuid.UUID]int{students.ID: 4},
+	}
+}
+
+func TestScreenConfigService_ExportBundle(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("exporta lo activo por clave natural", func(t *testing.T) {
+		svc, _ := newBundleScreenService(bundleSnapshot())
+		bundle, err := svc.ExportBundle(ctx)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if bundle.Version != ScreenConfigBundleVersion || len(bundle.Templates) != 1 || bundle.Templates[0].Pattern != "list" {
+			t.Fatalf("plantillas incorrectas: %+v", bundle.Templates)
+		}
+		if len(bundle.Instances) != 1 || bundle.Instances[0].Template != "list" || bundle.Instances[0].Scope != "school" {
+			t.Errorf("instancias incorrectas: %+v", bundle.Instances)
+		}
+		if len(bundle.Links) != 1 || bundle.Links[0] != (ScreenBundleLink{ResourceKey: "students", ScreenKey: "students-list", ScreenType: "list", IsDefault: true}) {
+			t.Errorf("enlaces incorrectos: %+v", bundle.Links)
+		}
+	})
+
+	t.Run("rechaza patrones ambiguos", func(t *testing.T) {
+		snap := bundleSnapshot()
+		snap.Templates[1].Pattern, snap.Templates[1].IsActive = "list", true
+		svc, _ := newBundleScreenService(snap)
+		_, err := svc.ExportBundle(ctx)
+		assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+	})
+}
+
+func TestScreenConfigService_ImportBundle(t *testing.T) {
+	ctx := context.Background()
+
+	// roundTrip exports the snapshot and edits the bundle before importing it
+	roundTrip := func(t *testing.T, snap *repository.ScreenConfigSnapshot, edit func(b *ScreenConfigBundle)) *ScreenConfigBundle {
+		t.Helper()
+		svc, _ := newBundleScreenService(snap)
+		bundle, err := svc.ExportBundle(ctx)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		edit(bundle)
+		data, _ := json.Marshal(bundle)
+		parsed, err := ParseScreenConfigBundle(data)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		return parsed
+	}
+
+	t.Run("el dry run muestra el diff sin escribir", func(t *testing.T) {
+		snap := bundleSnapshot()
+		bundle := roundTrip(t, snap, func(b *ScreenConfigBundle) {
+			b.Templates[0].Definition = json.RawMessage(`{"title":"v2"}`)
+			b.Instances = append(b.Instances, ScreenBundleInstance{ScreenKey: "teachers-list", Template: "list", Name: "Profesores"})
+			b.Links = append(b.Links, ScreenBundleLink{ResourceKey: "students", ScreenKey: "teachers-list", ScreenType: "list"})
+		})
+		svc, bundles := newBundleScreenService(snap)
+		resp, err := svc.ImportBundle(ctx, bundle, true)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if resp.Applied || bundles.applied != nil {
+			t.Error("un dry run no debería escribir")
+		}
+		if resp.Summary != (ScreenBundleSummary{Create: 2, Update: 1, Unchanged: 2}) || len(resp.Conflicts) != 0 {
+			t.Fatalf("plan incorrecto: %+v %+v", resp.Summary, resp.Conflicts)
+		}
+		update := resp.Changes[0]
+		if update.Kind != ScreenBundleKindTemplate || update.Op != ScreenBundleOpUpdate || len(update.Diff) != 1 || update.Diff[0].Path != "$.title" {
+			t.Errorf("cambio de plantilla incorrecto: %+v", update)
+		}
+	})
+
+	t.Run("aplica en una transacción y registra versiones", func(t *testing.T) {
+		snap := bundleSnapshot()
+		snap.ResourceScreens[0].IsActive = false
+		bundle := roundTrip(t, snap, func(b *ScreenConfigBundle) {
+			b.Templates[0].Definition = json.RawMessage(`{"title":"v2"}`)
+			b.Instances = []ScreenBundleInstance{{ScreenKey: "students-list", Template: "list", Name: "Alumnos",
+				SlotData: json.RawMessage(`{"columns":["name","grade"]}`), Scope: "school"}}
+			b.Links = []ScreenBundleLink{{ResourceKey: "students", ScreenKey: "students-list", ScreenType: "list", IsDefault: true}}
+		})
+		svc, bundles := newBundleScreenService(snap)
+		resp, err := svc.ImportBundle(ctx, bundle, false)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if !resp.Applied || bundles.applied == nil {
+			t.Fatalf("debería aplicar el plan: %+v", resp)
+		}
+		changes := bundles.applied
+		if len(changes.UpsertTemplates) != 1 || changes.UpsertTemplates[0].Version != 4 || changes.UpsertTemplates[0].ID != snap.Templates[0].ID {
+			t.Errorf("plantilla incorrecta: %+v", changes.UpsertTemplates)
+		}
+		if len(changes.TemplateVersions) != 1 || changes.TemplateVersions[0].Version != 4 || changes.TemplateVersions[0].Source != model.ScreenVersionImport {
+			t.Errorf("versión de plantilla incorrecta: %+v", changes.TemplateVersions)
+		}
+		if len(changes.InstanceVersions) != 1 || changes.InstanceVersions[0].Version != 5 {
+			t.Errorf("versión de instancia incorrecta: %+v", changes.InstanceVersions)
+		}
+		if len(changes.UpsertResourceScreens) != 1 || !changes.UpsertResourceScreens[0].IsActive ||
+			changes.UpsertResourceScreens[0].ID != snap.ResourceScreens[0].ID {
+			t.Errorf("el enlace inactivo debería reactivarse: %+v", changes.UpsertResourceScreens)
+		}
+	})
+
+	t.Run("los conflictos impiden escribir", func(t *testing.T) {
+		snap := bundleSnapshot()
+		snap.InstanceDrafts = []*model.ScreenInstanceDraft{{InstanceID: snap.Instances[0].ID}}
+		bundle := roundTrip(t, snap, func(b *ScreenConfigBundle) {
+			b.Instances[0].Name = "Estudiantes"
+			b.Links = append(b.Links,
+				ScreenBundleLink{ResourceKey: "courses", ScreenKey: "students-list", ScreenType: "list"},
+				ScreenBundleLink{ResourceKey: "students", ScreenKey: "grades-list", ScreenType: "list"})
+		})
+		svc, bundles := newBundleScreenService(snap)
+		resp, err := svc.ImportBundle(ctx, bundle, false)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if resp.Applied || bundles.applied != nil {
+			t.Error("no debería escribir con conflictos")
+		}
+		want := []string{"students-list", "courses:students-list", "students:grades-list"}
+		if len(resp.Conflicts) != len(want) {
+			t.Fatalf("esperaba %d conflictos, obtuvo %+v", len(want), resp.Conflicts)
+		}
+		for i, key := range want {
+			if resp.Conflicts[i].Key != key {
+				t.Errorf("conflicto %d: esperaba %s, obtuvo %+v", i, key, resp.Conflicts[i])
+			}
+		}
+	})
+
+	t.Run("valida el bundle", func(t *testing.T) {
+		svc, _ := newBundleScreenService(bundleSnapshot())
+		def := json.RawMessage(`{}`)
+		_, err := svc.ImportBundle(ctx, &ScreenConfigBundle{Templates: []ScreenBundleTemplate{
+			{Pattern: "list", Name: "A", Definition: def}, {Pattern: "list", Name: "B", Definition: def},
+		}}, true)
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+		_, err = svc.ImportBundle(ctx, &ScreenConfigBundle{Instances: []ScreenBundleInstance{
+			{ScreenKey: "students-list", Template: "form", Name: "Alumnos"},
+		}}, true)
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+		_, err = ParseScreenConfigBundle([]byte(`{"version":1,"templats":[]}`))
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	})
+}
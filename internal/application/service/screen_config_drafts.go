@@ -0,0 +1,439 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+// PermissionScreensPublish allows promoting screen drafts to the published
+// revision that clients resolve and sync
+const PermissionScreensPublish = "screens:publish"
+
+// ScreenPreviewPermissions are the permissions that may resolve unpublished
+// drafts: screen authors and publishers
+var ScreenPreviewPermissions = []string{"screen_templates:update", "screen_instances:update", PermissionScreensPublish}
+
+// Revision status of a screen template or instance DTO
+const (
+	ScreenStatusPublished = "published"
+	ScreenStatusDraft     = "draft"
+)
+
+// ResolveOptions selects which revision of the screens is resolved and for
+// which context
+type ResolveOptions struct {
+	// Draft overlays pending drafts on the published screens (preview)
+	Draft bool
+	// SchoolID and RoleID are the active context; when SchoolID is set the
+	// overrides of the school and of the role are merged onto the slot data
+	SchoolID string
+	RoleID   string
+	// Locale selects the language of names and translatable slot data text;
+	// empty means the default locale
+	Locale string
+}
+
+// PublishRequest publishes a draft now, or at PublishAt when it is in the future
+type PublishRequest struct {
+	PublishAt *time.Time `json:"publish_at"`
+}
+
+// ==================== Templates ====================
+
+// templateDraftFor returns the pending draft of a template, or a new one
+// seeded from the published revision
+func (s *screenConfigService) templateDraftFor(ctx context.Context, t *entities.ScreenTemplate) (*model.ScreenTemplateDraft, error) {
+	draft, err := s.draftRepo.GetTemplateDraft(ctx, t.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get screen template draft", err)
+	}
+	if draft != nil {
+		return draft, nil
+	}
+	return &model.ScreenTemplateDraft{
+		TemplateID: t.ID, Pattern: t.Pattern, Name: t.Name, Description: t.Description,
+		Definition: t.Definition, CreatedAt: time.Now(),
+	}, nil
+}
+
+func (s *screenConfigService) GetTemplateDraft(ctx context.Context, id string) (*ScreenTemplateDTO, error) {
+	template, err := s.findTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	draft, err := s.draftRepo.GetTemplateDraft(ctx, template.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get screen template draft", err)
+	}
+	if draft == nil {
+		return nil, errors.NewNotFoundError("screen_template_draft")
+	}
+	return toTemplateDraftDTO(template, draft), nil
+}
+
+func (s *screenConfigService) DiscardTemplateDraft(ctx context.Context, id string) error {
+	template, err := s.findTemplate(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.draftRepo.DeleteTemplateDraft(ctx, template.ID); err != nil {
+		return errors.NewDatabaseError("discard screen template draft", err)
+	}
+	s.logger.Info("screen template draft discarded", "entity_type", "screen_template", "entity_id", id)
+	return nil
+}
+
+// PublishTemplate promotes the template's draft, or schedules it when
+// req.PublishAt is in the future
+func (s *screenConfigService) PublishTemplate(ctx context.Context, id string, req *PublishRequest) (*ScreenTemplateDTO, error) {
+	template, err := s.findTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	draft, err := s.draftRepo.GetTemplateDraft(ctx, template.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get screen template draft", err)
+	}
+	if draft == nil {
+		return nil, errors.NewNotFoundError("screen_template_draft")
+	}
+	if req != nil && req.PublishAt != nil && req.PublishAt.After(time.Now()) {
+		draft.PublishAt = req.PublishAt
+		draft.UpdatedAt = time.Now()
+		if err := s.draftRepo.SaveTemplateDraft(ctx, draft); err != nil {
+			return nil, errors.NewDatabaseError("schedule screen template draft", err)
+		}
+		s.logger.Info("screen template publish scheduled", "entity_type", "screen_template", "entity_id", id,
+			"publish_at", draft.PublishAt)
+		return toTemplateDraftDTO(template, draft), nil
+	}
+	published, err := s.publishTemplateDraft(ctx, template, draft)
+	if err != nil {
+		return nil, err
+	}
+	return toTemplateDTO(published), nil
+}
+
+// publishTemplateDraft writes the draft over the published template and
+// removes it in one transaction. A definition change bumps the version and
+// records it.
+func (s *screenConfigService) publishTemplateDraft(ctx context.Context, template *entities.ScreenTemplate, draft *model.ScreenTemplateDraft) (*entities.ScreenTemplate, error) {
+	published := overlayTemplateDraft(template, draft)
+	published.UpdatedAt = time.Now()
+	changes := &repository.ScreenConfigChangeSet{
+		UpsertTemplates:      []*entities.ScreenTemplate{published},
+		DeleteTemplateDrafts: []uuid.UUID{template.ID},
+	}
+	if published.Version != template.Version {
+		changes.TemplateVersions = []*model.ScreenTemplateVersion{templateVersion(published, model.ScreenVersionPublish, nil)}
+	}
+	if err := s.bundleRepo.Apply(ctx, changes); err != nil {
+		return nil, errors.NewDatabaseError("publish screen template draft", err)
+	}
+	s.logger.Info("screen template published", "entity_type", "screen_template", "entity_id", template.ID.String(),
+		"version", published.Version)
+	return published, nil
+}
+
+// overlayTemplateDraft returns a copy of the template with the draft applied
+func overlayTemplateDraft(t *entities.ScreenTemplate, d *model.ScreenTemplateDraft) *entities.ScreenTemplate {
+	merged := *t
+	merged.Pattern = d.Pattern
+	merged.Name = d.Name
+	merged.Description = d.Description
+	if jsonChanged(t.Definition, d.Definition) {
+		merged.Definition = d.Definition
+		merged.Version++
+	}
+	merged.UpdatedAt = d.UpdatedAt
+	return &merged
+}
+
+// ==================== Instances ====================
+
+// instanceDraftFor returns the pending draft of an instance, or a new one
+// seeded from the published revision
+func (s *screenConfigService) instanceDraftFor(ctx context.Context, inst *entities.ScreenInstance) (*model.ScreenInstanceDraft, error) {
+	draft, err := s.draftRepo.GetInstanceDraft(ctx, inst.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get screen instance draft", err)
+	}
+	if draft != nil {
+		return draft, nil
+	}
+	return &model.ScreenInstanceDraft{
+		InstanceID: inst.ID, ScreenKey: inst.ScreenKey, TemplateID: inst.TemplateID, Name: inst.Name,
+		Description: inst.Description, SlotData: inst.SlotData, Scope: inst.Scope,
+		RequiredPermission: inst.RequiredPermission, HandlerKey: inst.HandlerKey, CreatedAt: time.Now(),
+	}, nil
+}
+
+func (s *screenConfigService) GetInstanceDraft(ctx context.Context, id string) (*ScreenInstanceDTO, error) {
+	instance, err := s.findInstance(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	draft, err := s.draftRepo.GetInstanceDraft(ctx, instance.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get screen instance draft", err)
+	}
+	if draft == nil {
+		return nil, errors.NewNotFoundError("screen_instance_draft")
+	}
+	return toInstanceDraftDTO(instance, draft), nil
+}
+
+func (s *screenConfigService) DiscardInstanceDraft(ctx context.Context, id string) error {
+	instance, err := s.findInstance(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.draftRepo.DeleteInstanceDraft(ctx, instance.ID); err != nil {
+		return errors.NewDatabaseError("discard screen instance draft", err)
+	}
+	s.logger.Info("screen instance draft discarded", "entity_type", "screen_instance", "entity_id", id)
+	return nil
+}
+
+// PublishInstance promotes the instance's draft, or schedules it when
+// req.PublishAt is in the future
+func (s *screenConfigService) PublishInstance(ctx context.Context, id string, req *PublishRequest) (*ScreenInstanceDTO, error) {
+	instance, err := s.findInstance(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	draft, err := s.draftRepo.GetInstanceDraft(ctx, instance.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get screen instance draft", err)
+	}
+	if draft == nil {
+		return nil, errors.NewNotFoundError("screen_instance_draft")
+	}
+	if req != nil && req.PublishAt != nil && req.PublishAt.After(time.Now()) {
+		draft.PublishAt = req.PublishAt
+		draft.UpdatedAt = time.Now()
+		if err := s.draftRepo.SaveInstanceDraft(ctx, draft); err != nil {
+			return nil, errors.NewDatabaseError("schedule screen instance draft", err)
+		}
+		s.logger.Info("screen instance publish scheduled", "entity_type", "screen_instance", "entity_id", id,
+			"publish_at", draft.PublishAt)
+		return toInstanceDraftDTO(instance, draft), nil
+	}
+	published, err := s.publishInstanceDraft(ctx, instance, draft)
+	if err != nil {
+		return nil, err
+	}
+	return toInstanceDTO(published), nil
+}
+
+// publishInstanceDraft writes the draft over the published instance and
+// removes it in one transaction. A slot data change is recorded in the
+// instance history; a new screen key must be free and takes the resource
+// links of the old one along.
+func (s *screenConfigService) publishInstanceDraft(ctx context.Context, instance *entities.ScreenInstance, draft *model.ScreenInstanceDraft) (*entities.ScreenInstance, error) {
+	published := overlayInstanceDraft(instance, draft)
+	published.UpdatedAt = time.Now()
+	changes := &repository.ScreenConfigChangeSet{
+		UpsertInstances:      []*entities.ScreenInstance{published},
+		DeleteInstanceDrafts: []uuid.UUID{instance.ID},
+	}
+	if published.ScreenKey != instance.ScreenKey {
+		taken, err := s.instanceRepo.GetByScreenKey(ctx, published.ScreenKey)
+		if err != nil {
+			return nil, errors.NewDatabaseError("get screen instance by key", err)
+		}
+		if taken != nil && taken.ID != instance.ID {
+			return nil, errors.NewConflictError("screen_key " + published.ScreenKey + " is already used by another instance")
+		}
+		links, err := s.resourceScreenRepo.GetByScreenKeys(ctx, []string{instance.ScreenKey})
+		if err != nil {
+			return nil, errors.NewDatabaseError("get resource screens", err)
+		}
+		for _, link := range links {
+			moved := *link
+			moved.ScreenKey = published.ScreenKey
+			moved.UpdatedAt = published.UpdatedAt
+			changes.UpsertResourceScreens = append(changes.UpsertResourceScreens, &moved)
+		}
+	}
+	if jsonChanged(instance.SlotData, published.SlotData) {
+		v, err := s.nextInstanceVersion(ctx, published, model.ScreenVersionPublish, nil)
+		if err != nil {
+			return nil, err
+		}
+		changes.InstanceVersions = []*model.ScreenInstanceVersion{v}
+	}
+	if err := s.bundleRepo.Apply(ctx, changes); err != nil {
+		return nil, errors.NewDatabaseError("publish screen instance draft", err)
+	}
+	s.logger.Info("screen instance published", "entity_type", "screen_instance", "entity_id", instance.ID.String())
+	return published, nil
+}
+
+// overlayInstanceDraft returns a copy of the instance with the draft applied
+func overlayInstanceDraft(inst *entities.ScreenInstance, d *model.ScreenInstanceDraft) *entities.ScreenInstance {
+	merged := *inst
+	merged.ScreenKey = d.ScreenKey
+	merged.TemplateID = d.TemplateID
+	merged.Name = d.Name
+	merged.Description = d.Description
+	merged.SlotData = d.SlotData
+	merged.Scope = d.Scope
+	merged.RequiredPermission = d.RequiredPermission
+	merged.HandlerKey = d.HandlerKey
+	merged.UpdatedAt = d.UpdatedAt
+	return &merged
+}
+
+// ==================== Scheduled publishing ====================
+
+// PublishDue publishes every draft whose scheduled time has passed. A draft
+// that fails to publish is logged and retried on the next run without holding
+// back the others; the count covers the drafts published.
+func (s *screenConfigService) PublishDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	published := 0
+	var firstErr error
+
+	templateDrafts, err := s.draftRepo.FindDueTemplateDrafts(ctx, now)
+	if err != nil {
+		return 0, errors.NewDatabaseError("find due screen template drafts", err)
+	}
+	for _, d := range templateDrafts {
+		if err := s.publishDueTemplateDraft(ctx, d); err != nil {
+			s.logger.Error("error publishing scheduled screen template draft", "template_id", d.TemplateID, "error", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		published++
+	}
+
+	instanceDrafts, err := s.draftRepo.FindDueInstanceDrafts(ctx, now)
+	if err != nil {
+		return published, errors.NewDatabaseError("find due screen instance drafts", err)
+	}
+	for _, d := range instanceDrafts {
+		if err := s.publishDueInstanceDraft(ctx, d); err != nil {
+			s.logger.Error("error publishing scheduled screen instance draft", "instance_id", d.InstanceID, "error", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		published++
+	}
+	return published, firstErr
+}
+
+func (s *screenConfigService) publishDueTemplateDraft(ctx context.Context, d *model.ScreenTemplateDraft) error {
+	template, err := s.templateRepo.GetByID(ctx, d.TemplateID)
+	if err != nil {
+		return errors.NewDatabaseError("get screen template", err)
+	}
+	if template == nil {
+		return errors.NewNotFoundError("screen_template")
+	}
+	_, err = s.publishTemplateDraft(ctx, template, d)
+	return err
+}
+
+func (s *screenConfigService) publishDueInstanceDraft(ctx context.Context, d *model.ScreenInstanceDraft) error {
+	instance, err := s.instanceRepo.GetByID(ctx, d.InstanceID)
+	if err != nil {
+		return errors.NewDatabaseError("get screen instance", err)
+	}
+	if instance == nil {
+		return errors.NewNotFoundError("screen_instance")
+	}
+	_, err = s.publishInstanceDraft(ctx, instance, d)
+	return err
+}
+
+// ==================== Preview ====================
+
+// previewInstanceByKey resolves the draft revision of the instance that
+// carries key: a draft may rename a published instance or take over its key.
+func (s *screenConfigService) previewInstanceByKey(ctx context.Context, key string, published *entities.ScreenInstance) (*entities.ScreenInstance, bool, error) {
+	drafts, err := s.draftRepo.ListInstanceDrafts(ctx)
+	if err != nil {
+		return nil, false, errors.NewDatabaseError("list screen instance drafts", err)
+	}
+	for _, d := range drafts {
+		if d.ScreenKey != key {
+			continue
+		}
+		instance := published
+		if instance == nil || instance.ID != d.InstanceID {
+			if instance, err = s.instanceRepo.GetByID(ctx, d.InstanceID); err != nil {
+				return nil, false, err
+			}
+			if instance == nil || !instance.IsActive {
+				continue
+			}
+		}
+		return overlayInstanceDraft(instance, d), true, nil
+	}
+	if published != nil {
+		for _, d := range drafts {
+			if d.InstanceID == published.ID {
+				// the draft moved this instance to another key
+				return nil, false, nil
+			}
+		}
+	}
+	return published, false, nil
+}
+
+// previewTemplate overlays the template's draft, if any
+func (s *screenConfigService) previewTemplate(ctx context.Context, t *entities.ScreenTemplate) (*entities.ScreenTemplate, bool, error) {
+	draft, err := s.draftRepo.GetTemplateDraft(ctx, t.ID)
+	if err != nil {
+		return nil, false, errors.NewDatabaseError("get screen template draft", err)
+	}
+	if draft == nil {
+		return t, false, nil
+	}
+	return overlayTemplateDraft(t, draft), true, nil
+}
+
+// Conversion helpers
+
+func toTemplateDraftDTO(t *entities.ScreenTemplate, d *model.ScreenTemplateDraft) *ScreenTemplateDTO {
+	result := toTemplateDTO(overlayTemplateDraft(t, d))
+	result.Status = ScreenStatusDraft
+	result.HasDraft = true
+	result.PublishAt = d.PublishAt
+	return result
+}
+
+func toInstanceDraftDTO(inst *entities.ScreenInstance, d *model.ScreenInstanceDraft) *ScreenInstanceDTO {
+	result := toInstanceDTO(overlayInstanceDraft(inst, d))
+	result.Status = ScreenStatusDraft
+	result.HasDraft = true
+	result.PublishAt = d.PublishAt
+	return result
+}
+
+func draftsByTemplateID(drafts []*model.ScreenTemplateDraft) map[uuid.UUID]*model.ScreenTemplateDraft {
+	byID := make(map[uuid.UUID]*model.ScreenTemplateDraft, len(drafts))
+	for _, d := range drafts {
+		byID[d.TemplateID] = d
+	}
+	return byID
+}
+
+func draftsByInstanceID(drafts []*model.ScreenInstanceDraft) map[uuid.UUID]*model.ScreenInstanceDraft {
+	byID := make(map[uuid.UUID]*model.ScreenInstanceDraft, len(drafts))
+	for _, d := range drafts {
+		byID[d.InstanceID] = d
+	}
+	return byID
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedErrors "github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+// newPublishedScreen creates a template and an instance on key with the given
+// slot data, both published
+func newPublishedScreen(t *testing.T, svc ScreenConfigService, key, slot string) (*ScreenTemplateDTO, *ScreenInstanceDTO) {
+	t.Helper()
+	ctx := context.Background()
+	tpl, err := svc.CreateTemplate(ctx, &CreateTemplateRequest{Pattern: "list", Name: "Lista", Definition: json.RawMessage(`{"title":"v1"}`)})
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	inst, err := svc.CreateInstance(ctx, &CreateInstanceRequest{
+		ScreenKey: key, TemplateID: tpl.ID, Name: "Alumnos", SlotData: json.RawMessage(slot),
+	})
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	return tpl, inst
+}
+
+func TestScreenConfigService_Drafts(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("la edición no cambia la versión publicada", func(t *testing.T) {
+		svc, _, _ := newVersionedScreenService()
+		_, inst := newPublishedScreen(t, svc, "students-list", `{"columns":["name"]}`)
+
+		slot := json.RawMessage(`{"columns":["name","age"]}`)
+		draft, err := svc.UpdateInstance(ctx, inst.ID, &UpdateInstanceRequest{SlotData: &slot})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if draft.Status != ScreenStatusDraft || string(draft.SlotData) != string(slot) {
+			t.Errorf("borrador incorrecto: %+v", draft)
+		}
+
+		published, err := svc.ResolveScreenByKey(ctx, "students-list", ResolveOptions{})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if string(published.SlotData) != `{"columns":["name"]}` || published.Draft {
+			t.Errorf("los clientes no deberían ver el borrador: %s", published.SlotData)
+		}
+		all, err := svc.ResolveAllScreens(ctx, ResolveOptions{})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if len(all) != 1 || string(all[0].SlotData) != `{"columns":["name"]}` {
+			t.Errorf("el bundle no debería incluir el borrador: %+v", all)
+		}
+
+		got, err := svc.GetInstance(ctx, inst.ID)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if got.Status != ScreenStatusPublished || !got.HasDraft {
+			t.Errorf("estado incorrecto: status=%s has_draft=%v", got.Status, got.HasDraft)
+		}
+	})
+
+	t.Run("preview muestra borradores de instancia y template", func(t *testing.T) {
+		svc, _, _ := newVersionedScreenService()
+		tpl, inst := newPublishedScreen(t, svc, "students-list", `{"columns":["name"]}`)
+
+		def := json.RawMessage(`{"title":"v2"}`)
+		if _, err := svc.UpdateTemplate(ctx, tpl.ID, &UpdateTemplateRequest{Definition: &def}); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		key := "students-grid"
+		if _, err := svc.UpdateInstance(ctx, inst.ID, &UpdateInstanceRequest{ScreenKey: &key}); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+
+		preview, err := svc.ResolveScreenByKey(ctx, "students-grid", ResolveOptions{Draft: true})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if !preview.Draft || preview.Version != 2 || string(preview.Template) != string(def) {
+			t.Errorf("preview incorrecto: %+v", preview)
+		}
+		_, err = svc.ResolveScreenByKey(ctx, "students-list", ResolveOptions{Draft: true})
+		assertAppError(t, err, sharedErrors.ErrorCodeNotFound)
+
+		all, err := svc.ResolveAllScreens(ctx, ResolveOptions{Draft: true})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if len(all) != 1 || all[0].ScreenKey != "students-grid" || !all[0].Draft {
+			t.Errorf("preview del bundle incorrecto: %+v", all)
+		}
+	})
+
+	t.Run("publicar aplica el borrador y lo elimina", func(t *testing.T) {
+		svc, versions, drafts := newVersionedScreenService()
+		_, inst := newPublishedScreen(t, svc, "students-list", `{"columns":["name"]}`)
+
+		slot := json.RawMessage(`{"columns":["name","age"]}`)
+		if _, err := svc.UpdateInstance(ctx, inst.ID, &UpdateInstanceRequest{SlotData: &slot}); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		published, err := svc.PublishInstance(ctx, inst.ID, &PublishRequest{})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if published.Status != ScreenStatusPublished || string(published.SlotData) != string(slot) {
+			t.Errorf("publicación incorrecta: %+v", published)
+		}
+		if len(drafts.instanceDrafts) != 0 {
+			t.Error("el borrador debería eliminarse al publicar")
+		}
+		resolved, _ := svc.ResolveScreenByKey(ctx, "students-list", ResolveOptions{})
+		if string(resolved.SlotData) != string(slot) {
+			t.Errorf("los clientes deberían ver lo publicado: %s", resolved.SlotData)
+		}
+		if last := versions.instanceVersions[len(versions.instanceVersions)-1]; last.Source != model.ScreenVersionPublish {
+			t.Errorf("la publicación debería quedar en el historial: %+v", last)
+		}
+
+		_, err = svc.PublishInstance(ctx, inst.ID, nil)
+		assertAppError(t, err, sharedErrors.ErrorCodeNotFound)
+	})
+
+	t.Run("publicación programada espera a su hora", func(t *testing.T) {
+		svc, _, drafts := newVersionedScreenService()
+		tpl, _ := newPublishedScreen(t, svc, "students-list", `{}`)
+
+		name := "Lista nueva"
+		if _, err := svc.UpdateTemplate(ctx, tpl.ID, &UpdateTemplateRequest{Name: &name}); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		at := time.Now().Add(time.Hour)
+		scheduled, err := svc.PublishTemplate(ctx, tpl.ID, &PublishRequest{PublishAt: &at})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if scheduled.Status != ScreenStatusDraft || scheduled.PublishAt == nil {
+			t.Errorf("debería quedar programado: %+v", scheduled)
+		}
+		if n, err := svc.PublishDue(ctx); err != nil || n != 0 {
+			t.Fatalf("no debería publicar antes de tiempo: n=%d err=%v", n, err)
+		}
+
+		past := time.Now().Add(-time.Minute)
+		drafts.templateDrafts[uuid.MustParse(tpl.ID)].PublishAt = &past
+		n, err := svc.PublishDue(ctx)
+		if err != nil || n != 1 {
+			t.Fatalf("esperaba 1 publicación: n=%d err=%v", n, err)
+		}
+		got, _ := svc.GetTemplate(ctx, tpl.ID)
+		if got.Name != name || got.HasDraft {
+			t.Errorf("publicación programada incorrecta: %+v", got)
+		}
+	})
+
+	t.Run("editar cancela la programación", func(t *testing.T) {
+		svc, _, _ := newVersionedScreenService()
+		tpl, _ := newPublishedScreen(t, svc, "students-list", `{}`)
+
+		name := "A"
+		if _, err := svc.UpdateTemplate(ctx, tpl.ID, &UpdateTemplateRequest{Name: &name}); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		at := time.Now().Add(time.Hour)
+		if _, err := svc.PublishTemplate(ctx, tpl.ID, &PublishRequest{PublishAt: &at}); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		name = "B"
+		draft, err := svc.UpdateTemplate(ctx, tpl.ID, &UpdateTemplateRequest{Name: &name})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if draft.PublishAt != nil {
+			t.Error("una edición debería cancelar la publicación programada")
+		}
+	})
+
+	t.Run("descartar el borrador", func(t *testing.T) {
+		svc, _, _ := newVersionedScreenService()
+		tpl, _ := newPublishedScreen(t, svc, "students-list", `{}`)
+
+		name := "Otro"
+		if _, err := svc.UpdateTemplate(ctx, tpl.ID, &UpdateTemplateRequest{Name: &name}); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if err := svc.DiscardTemplateDraft(ctx, tpl.ID); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		_, err := svc.GetTemplateDraft(ctx, tpl.ID)
+		assertAppError(t, err, sharedErrors.ErrorCodeNotFound)
+	})
+
+	t.Run("publicar un cambio de clave mueve los enlaces de recursos", func(t *testing.T) {
+		inst := &entities.ScreenInstance{ID: uuid.New(), ScreenKey: "students-list", TemplateID: uuid.New(), SlotData: json.RawMessage(`{}`), IsActive: true}
+		link := &entities.ResourceScreen{ID: uuid.New(), ResourceKey: "students", ScreenKey: "students-list", IsActive: true}
+		drafts := &mockScreenDraftRepo{instanceDrafts: map[uuid.UUID]*model.ScreenInstanceDraft{inst.ID: {
+			InstanceID: inst.ID, ScreenKey: "students-grid", TemplateID: inst.TemplateID, SlotData: inst.SlotData, Scope: "system",
+		}}}
+		bundles := &mockScreenConfigBundleRepo{}
+		svc := NewScreenConfigService(&mockScreenTemplateRepo{}, &mockScreenInstanceRepo{
+			getByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.ScreenInstance, error) {
+				cp := *inst
+				return &cp, nil
+			},
+		}, &mockResourceScreenRepo{getByScreenKeysFn: func(ctx context.Context, keys []string) ([]*entities.ResourceScreen, error) {
+			return []*entities.ResourceScreen{link}, nil
+		}}, &mockScreenVersionRepo{}, drafts, &mockScreenPatternSchemaRepo{}, &mockScreenOverrideRepo{}, &mockTranslationRepo{}, bundles, NewLocaleSettings("es", []string{"es", "en"}), &mockLogger{})
+
+		if _, err := svc.PublishInstance(ctx, inst.ID.String(), nil); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		applied := bundles.applied
+		if applied == nil || len(applied.UpsertInstances) != 1 || len(applied.DeleteInstanceDrafts) != 1 {
+			t.Fatalf("la publicación debería escribirse en una sola transacción: %+v", applied)
+		}
+		if len(applied.UpsertResourceScreens) != 1 || applied.UpsertResourceScreens[0].ScreenKey != "students-grid" {
+			t.Errorf("los enlaces deberían apuntar a la nueva clave: %+v", applied.UpsertResourceScreens)
+		}
+	})
+
+	t.Run("no publica una clave usada por otra instancia", func(t *testing.T) {
+		inst := &entities.ScreenInstance{ID: uuid.New(), ScreenKey: "students-list", TemplateID: uuid.New(), SlotData: json.RawMessage(`{}`), IsActive: true}
+		drafts := &mockScreenDraftRepo{instanceDrafts: map[uuid.UUID]*model.ScreenInstanceDraft{inst.ID: {
+			InstanceID: inst.ID, ScreenKey: "grades-list", TemplateID: inst.TemplateID, SlotData: inst.SlotData, Scope: "system",
+		}}}
+		bundles := &mockScreenConfigBundleRepo{}
+		svc := NewScreenConfigService(&mockScreenTemplateRepo{}, &mockScreenInstanceRepo{
+			getByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.ScreenInstance, error) {
+				cp := *inst
+				return &cp, nil
+			},
+			getByScreenKeyFn: func(ctx context.Context, key string) (*entities.ScreenInstance, error) {
+				return &entities.ScreenInstance{ID: uuid.New(), ScreenKey: key, IsActive: true}, nil
+			},
+		}, &mockResourceScreenRepo{}, &mockScreenVersionRepo{}, drafts, &mockScreenPatternSchemaRepo{}, &mockScreenOverrideRepo{}, &mockTranslationRepo{}, bundles, NewLocaleSettings("es", []string{"es", "en"}), &mockLogger{})
+
+		_, err := svc.PublishInstance(ctx, inst.ID.String(), nil)
+		assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+		if bundles.applied != nil {
+			t.Error("no debería escribirse nada")
+		}
+	})
+
+	t.Run("un borrador que falla no detiene la publicación programada", func(t *testing.T) {
+		past := time.Now().Add(-time.Minute)
+		broken, ok := uuid.New(), uuid.New()
+		drafts := &mockScreenDraftRepo{templateDrafts: map[uuid.UUID]*model.ScreenTemplateDraft{
+			broken: {TemplateID: broken, Pattern: "list", Name: "Rota", Definition: json.RawMessage(`{}`), PublishAt: &past},
+			ok:     {TemplateID: ok, Pattern: "list", Name: "Nueva", Definition: json.RawMessage(`{}`), PublishAt: &past},
+		}}
+		svc := NewScreenConfigService(&mockScreenTemplateRepo{
+			getByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.ScreenTemplate, error) {
+				if id == broken {
+					return nil, context.DeadlineExceeded
+				}
+				return &entities.ScreenTemplate{ID: id, Pattern: "list", Name: "Vieja", Definition: json.RawMessage(`{}`), IsActive: true}, nil
+			},
+		}, &mockScreenInstanceRepo{}, &mockResourceScreenRepo{}, &mockScreenVersionRepo{}, drafts, &mockScreenPatternSchemaRepo{}, &mockScreenOverrideRepo{}, &mockTranslationRepo{}, &mockScreenConfigBundleRepo{}, NewLocaleSettings("es", []string{"es", "en"}), &mockLogger{})
+
+		n, err := svc.PublishDue(ctx)
+		if n != 1 {
+			t.Errorf("el borrador sano debería publicarse: n=%d", n)
+		}
+		assertAppError(t, err, sharedErrors.ErrorCodeDatabaseError)
+	})
+}
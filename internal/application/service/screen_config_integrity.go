@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+// Reasons reported by the consistency check
+const (
+	ConsistencyTemplateMissing  = "template_missing"
+	ConsistencyTemplateInactive = "template_inactive"
+	ConsistencyScreenMissing    = "screen_missing"
+	ConsistencyResourceMissing  = "resource_missing"
+)
+
+// OrphanedInstanceDTO is an active instance whose template is gone
+type OrphanedInstanceDTO struct {
+	ID         string `json:"id"`
+	ScreenKey  string `json:"screen_key"`
+	TemplateID string `json:"template_id"`
+	Reason     string `json:"reason"`
+}
+
+// DanglingResourceScreenDTO is an active link whose screen or resource is gone
+type DanglingResourceScreenDTO struct {
+	ID          string `json:"id"`
+	ResourceKey string `json:"resource_key"`
+	ScreenKey   string `json:"screen_key"`
+	Reason      string `json:"reason"`
+}
+
+// UnknownPermissionDTO is an active instance requiring a permission that is
+// not an active permission name
+type UnknownPermissionDTO struct {
+	InstanceID         string `json:"instance_id"`
+	ScreenKey          string `json:"screen_key"`
+	RequiredPermission string `json:"required_permission"`
+}
+
+// ScreenConsistencyReport lists the broken references of the screen config
+type ScreenConsistencyReport struct {
+	Consistent              bool                         `json:"consistent"`
+	OrphanedInstances       []*OrphanedInstanceDTO       `json:"orphaned_instances"`
+	DanglingResourceScreens []*DanglingResourceScreenDTO `json:"dangling_resource_screens"`
+	UnknownPermissions      []*UnknownPermissionDTO      `json:"unknown_required_permissions"`
+}
+
+// CheckConsistency reports orphaned instances, dangling resource-screen links
+// and unknown required permissions. Inactive rows are ignored.
+func (s *screenConfigService) CheckConsistency(ctx context.Context) (*ScreenConsistencyReport, error) {
+	snap, err := s.bundleRepo.Snapshot(ctx)
+	if err != nil {
+		return nil, errors.NewDatabaseError("load screen config", err)
+	}
+	return checkScreenConsistency(snap), nil
+}
+
+func checkScreenConsistency(snap *repository.ScreenConfigSnapshot) *ScreenConsistencyReport {
+	report := &ScreenConsistencyReport{
+		OrphanedInstances:       []*OrphanedInstanceDTO{},
+		DanglingResourceScreens: []*DanglingResourceScreenDTO{},
+		UnknownPermissions:      []*UnknownPermissionDTO{},
+	}
+	templates := make(map[uuid.UUID]*entities.ScreenTemplate, len(snap.Templates))
+	for _, t := range snap.Templates {
+		templates[t.ID] = t
+	}
+	permissions := make(map[string]bool, len(snap.Permissions))
+	for _, p := range snap.Permissions {
+		permissions[p.Name] = true
+	}
+	screens := map[string]bool{}
+	for _, inst := range snap.Instances {
+		if !inst.IsActive {
+			continue
+		}
+		screens[inst.ScreenKey] = true
+		if t, ok := templates[inst.TemplateID]; !ok || !t.IsActive {
+			reason := ConsistencyTemplateMissing
+			if ok {
+				reason = ConsistencyTemplateInactive
+			}
+			report.OrphanedInstances = append(report.OrphanedInstances, &OrphanedInstanceDTO{
+				ID: inst.ID.String(), ScreenKey: inst.ScreenKey, TemplateID: inst.TemplateID.String(), Reason: reason,
+			})
+		}
+		if perm := derefString(inst.RequiredPermission); perm != "" && !permissions[perm] {
+			report.UnknownPermissions = append(report.UnknownPermissions, &UnknownPermissionDTO{
+				InstanceID: inst.ID.String(), ScreenKey: inst.ScreenKey, RequiredPermission: perm,
+			})
+		}
+	}
+	resources := activeResourcesByID(snap)
+	for _, rs := range snap.ResourceScreens {
+		if !rs.IsActive {
+			continue
+		}
+		reason := ""
+		switch {
+		case !screens[rs.ScreenKey]:
+			reason = ConsistencyScreenMissing
+		case resources[rs.ResourceID] == nil:
+			reason = ConsistencyResourceMissing
+		default:
+			continue
+		}
+		report.DanglingResourceScreens = append(report.DanglingResourceScreens, &DanglingResourceScreenDTO{
+			ID: rs.ID.String(), ResourceKey: rs.ResourceKey, ScreenKey: rs.ScreenKey, Reason: reason,
+		})
+	}
+	report.Consistent = len(report.OrphanedInstances) == 0 && len(report.DanglingResourceScreens) == 0 &&
+		len(report.UnknownPermissions) == 0
+	return report
+}
+
+func activeResourcesByID(snap *repository.ScreenConfigSnapshot) map[uuid.UUID]*entities.Resource {
+	resources := make(map[uuid.UUID]*entities.Resource, len(snap.Resources))
+	for _, r := range snap.Resources {
+		if r.IsActive {
+			resources[r.ID] = r
+		}
+	}
+	return resources
+}
+
+// activeLinksOfResources returns the active links of any of the resources
+func activeLinksOfResources(snap *repository.ScreenConfigSnapshot, ids map[uuid.UUID]bool) []*entities.ResourceScreen {
+	var links []*entities.ResourceScreen
+	for _, rs := range snap.ResourceScreens {
+		if rs.IsActive && ids[rs.ResourceID] {
+			links = append(links, rs)
+		}
+	}
+	return links
+}
+
+// activeDescendants returns the active resources below id in the menu tree
+func activeDescendants(snap *repository.ScreenConfigSnapshot, id uuid.UUID) []*entities.Resource {
+	var result []*entities.Resource
+	queue := []uuid.UUID{id}
+	seen := map[uuid.UUID]bool{id: true}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		for _, r := range snap.Resources {
+			if r.IsActive && r.ParentID != nil && *r.ParentID == parent && !seen[r.ID] {
+				seen[r.ID] = true
+				result = append(result, r)
+				queue = append(queue, r.ID)
+			}
+		}
+	}
+	return result
+}
+
+func resourceScreenIDs(links []*entities.ResourceScreen) []uuid.UUID {
+	ids := make([]uuid.UUID, len(links))
+	for i, rs := range links {
+		ids[i] = rs.ID
+	}
+	return ids
+}
+
+// dependentsList renders up to five keys for conflict messages
+func dependentsList(keys []string) string {
+	sort.Strings(keys)
+	if len(keys) > 5 {
+		return strings.Join(keys[:5], ", ") + fmt.Sprintf(" and %d more", len(keys)-5)
+	}
+	return strings.Join(keys, ", ")
+}
+
+func linkKeys(links []*entities.ResourceScreen) []string {
+	keys := make([]string, len(links))
+	for i, rs := range links {
+		keys[i] = bundleLinkKey(rs.ResourceKey, rs.ScreenKey)
+	}
+	return keys
+}
+
+// deleteTemplateCascade deactivates the template, its active instances and
+// their links in one change set. It refuses while instances exist unless
+// cascade is set; it reports false when nothing depends on the template.
+func (s *screenConfigService) deleteTemplateCascade(ctx context.Context, t *entities.ScreenTemplate, cascade bool) (bool, error) {
+	instances, err := s.instanceRepo.ListByTemplate(ctx, t.ID)
+	if err != nil {
+		return false, errors.NewDatabaseError("list screen instances", err)
+	}
+	if len(instances) == 0 {
+		return false, nil
+	}
+	changes := &repository.ScreenConfigChangeSet{DeactivateTemplates: []uuid.UUID{t.ID}}
+	screenKeys := make([]string, len(instances))
+	for i, inst := range instances {
+		screenKeys[i] = inst.ScreenKey
+		changes.DeactivateInstances = append(changes.DeactivateInstances, inst.ID)
+	}
+	if !cascade {
+		return false, errors.NewConflictError(fmt.Sprintf(
+			"screen template is used by %d active instances (%s); delete them first or retry with cascade=true",
+			len(screenKeys), dependentsList(screenKeys)))
+	}
+	links, err := s.resourceScreenRepo.GetByScreenKeys(ctx, screenKeys)
+	if err != nil {
+		return false, errors.NewDatabaseError("list resource screens", err)
+	}
+	changes.DeleteResourceScreens = resourceScreenIDs(links)
+	if err := s.bundleRepo.Apply(ctx, changes); err != nil {
+		return false, errors.NewDatabaseError("delete screen template", err)
+	}
+	s.logger.Info("entity deleted", "entity_type", "screen_template", "entity_id", t.ID.String(),
+		"cascade_instances", len(changes.DeactivateInstances), "cascade_links", len(changes.DeleteResourceScreens))
+	return true, nil
+}
+
+// deleteInstanceCascade deactivates the instance and removes the links that
+// point at its screen key. It refuses while links exist unless cascade is
+// set; it reports false when nothing depends on the instance.
+func (s *screenConfigService) deleteInstanceCascade(ctx context.Context, inst *entities.ScreenInstance, cascade bool) (bool, error) {
+	links, err := s.resourceScreenRepo.GetByScreenKeys(ctx, []string{inst.ScreenKey})
+	if err != nil {
+		return false, errors.NewDatabaseError("list resource screens", err)
+	}
+	if len(links) == 0 {
+		return false, nil
+	}
+	if !cascade {
+		return false, errors.NewConflictError(fmt.Sprintf(
+			"screen instance is linked to %d resources (%s); unlink it first or retry with cascade=true",
+			len(links), dependentsList(linkKeys(links))))
+	}
+	changes := &repository.ScreenConfigChangeSet{
+		DeactivateInstances:   []uuid.UUID{inst.ID},
+		DeleteResourceScreens: resourceScreenIDs(links),
+	}
+	if err := s.bundleRepo.Apply(ctx, changes); err != nil {
+		return false, errors.NewDatabaseError("delete screen instance", err)
+	}
+	s.logger.Info("entity deleted", "entity_type", "screen_instance", "entity_id", inst.ID.String(),
+		"cascade_links", len(links))
+	return true, nil
+}
+
+// unlinkScreenCascade guards the last screen of a menu item: removing it
+// would leave a visible leaf that opens nothing. With cascade the resource is
+// hidden from the menu in the same transaction. It reports false when the
+// link is not the last one of a visible leaf.
+func (s *screenConfigService) unlinkScreenCascade(ctx context.Context, id uuid.UUID, cascade bool) (bool, error) {
+	link, err := s.resourceScreenRepo.GetByID(ctx, id)
+	if err != nil {
+		return false, errors.NewDatabaseError("get resource screen", err)
+	}
+	if link == nil || !link.IsActive {
+		return false, nil
+	}
+	resource, err := s.bundleRepo.VisibleLeafResource(ctx, link.ResourceID)
+	if err != nil {
+		return false, errors.NewDatabaseError("get resource", err)
+	}
+	if resource == nil {
+		return false, nil
+	}
+	links, err := s.resourceScreenRepo.GetByResourceID(ctx, resource.ID)
+	if err != nil {
+		return false, errors.NewDatabaseError("list resource screens", err)
+	}
+	if len(links) > 1 {
+		return false, nil
+	}
+	if !cascade {
+		return false, errors.NewConflictError(fmt.Sprintf(
+			"%s is the last screen of menu item %s; retry with cascade=true to hide the item from the menu",
+			link.ScreenKey, resource.Key))
+	}
+	changes := &repository.ScreenConfigChangeSet{
+		DeleteResourceScreens: []uuid.UUID{link.ID},
+		HideResources:         []uuid.UUID{resource.ID},
+	}
+	if err := s.bundleRepo.Apply(ctx, changes); err != nil {
+		return false, errors.NewDatabaseError("unlink screen", err)
+	}
+	s.logger.Info("screen unlinked", "resource_screen_id", id.String(), "hidden_resource", resource.Key)
+	return true, nil
+}
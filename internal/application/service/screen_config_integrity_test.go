@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedErrors "github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+// newIntegrityScreenService serves the snapshot entities from the template,
+// instance and link repositories too, and records plain deletes
+func newIntegrityScreenService(snap *repository.ScreenConfigSnapshot, deleted *[]uuid.UUID) (ScreenConfigService, *mockScreenConfigBundleRepo) {
+	bundles := &mockScreenConfigBundleRepo{snapshot: snap}
+	record := func(ctx context.Context, id uuid.UUID) error { *deleted = append(*deleted, id); return nil }
+	templates := &mockScreenTemplateRepo{
+		getByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.ScreenTemplate, error) {
+			for _, t := range snap.Templates {
+				if t.ID == id {
+					return t, nil
+				}
+			}
+			return nil, nil
+		},
+		deleteFn: record,
+	}
+	instances := &mockScreenInstanceRepo{
+		getByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.ScreenInstance, error) {
+			for _, inst := range snap.Instances {
+				if inst.ID == id {
+					return inst, nil
+				}
+			}
+			return nil, nil
+		},
+		listByTemplateFn: func(ctx context.Context, templateID uuid.UUID) ([]*entities.ScreenInstance, error) {
+			var result []*entities.ScreenInstance
+			for _, inst := range snap.Instances {
+				if inst.IsActive && inst.TemplateID == templateID {
+					result = append(result, inst)
+				}
+			}
+			return result, nil
+		},
+		deleteFn: record,
+	}
+	activeLinks := func(match func(rs *entities.ResourceScreen) bool) []*entities.ResourceScreen {
+		var result []*entities.ResourceScreen
+		for _, rs := range snap.ResourceScreens {
+			if rs.IsActive && match(rs) {
+				result = append(result, rs)
+			}
+		}
+		return result
+	}
+	links := &mockResourceScreenRepo{
+		getByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.ResourceScreen, error) {
+			for _, rs := range snap.ResourceScreens {
+				if rs.ID == id {
+					return rs, nil
+				}
+			}
+			return nil, nil
+		},
+		getByResourceIDFn: func(ctx context.Context, resourceID uuid.UUID) ([]*entities.ResourceScreen, error) {
+			return activeLinks(func(rs *entities.ResourceScreen) bool { return rs.ResourceID == resourceID }), nil
+		},
+		getByScreenKeysFn: func(ctx context.Context, keys []string) ([]*entities.ResourceScreen, error) {
+			return activeLinks(func(rs *entities.ResourceScreen) bool { return slices.Contains(keys, rs.ScreenKey) }), nil
+		},
+		deleteFn: record,
+	}
+	return NewScreenConfigService(templates, instances, links, &mockScreenVersionRepo{}, &mockScreenDraftRepo{},
+		&mockScreenPatternSchemaRepo{}, &mockScreenOverrideRepo{}, &mockTranslationRepo{}, bundles,
+		NewLocaleSettings("es", []string{"es", "en"}), &mockLogger{}), bundles
+}
+
+func TestScreenConfigService_DeleteTemplateIntegrity(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rechaza borrar una plantilla con instancias activas", func(t *testing.T) {
+		snap := bundleSnapshot()
+		var deleted []uuid.UUID
+		svc, bundles := newIntegrityScreenService(snap, &deleted)
+		err := svc.DeleteTemplate(ctx, snap.Templates[0].ID.String(), false)
+		assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+		if bundles.applied != nil || len(deleted) != 0 {
+			t.Error("no debería escribir")
+		}
+	})
+
+	t.Run("cascade desactiva instancias y enlaces", func(t *testing.T) {
+		snap := bundleSnapshot()
+		var deleted []uuid.UUID
+		svc, bundles := newIntegrityScreenService(snap, &deleted)
+		if err := svc.DeleteTemplate(ctx, snap.Templates[0].ID.String(), true); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		changes := bundles.applied
+		if changes == nil || len(changes.DeactivateTemplates) != 1 || changes.DeactivateTemplates[0] != snap.Templates[0].ID {
+			t.Fatalf("plantilla no desactivada: %+v", changes)
+		}
+		if bundles.snapshots != 0 {
+			t.Error("no debería cargar la configuración completa")
+		}
+		if len(changes.DeactivateInstances) != 1 || changes.DeactivateInstances[0] != snap.Instances[0].ID {
+			t.Errorf("instancias incorrectas: %+v", changes.DeactivateInstances)
+		}
+		if len(changes.DeleteResourceScreens) != 1 || changes.DeleteResourceScreens[0] != snap.ResourceScreens[0].ID {
+			t.Errorf("enlaces incorrectos: %+v", changes.DeleteResourceScreens)
+		}
+	})
+
+	t.Run("sin dependientes usa el borrado normal", func(t *testing.T) {
+		snap := bundleSnapshot()
+		var deleted []uuid.UUID
+		svc, bundles := newIntegrityScreenService(snap, &deleted)
+		if err := svc.DeleteTemplate(ctx, snap.Templates[1].ID.String(), false); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if bundles.applied != nil || len(deleted) != 1 || deleted[0] != snap.Templates[1].ID {
+			t.Errorf("borrado incorrecto: %v", deleted)
+		}
+	})
+}
+
+func TestScreenConfigService_DeleteInstanceIntegrity(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rechaza borrar una instancia enlazada", func(t *testing.T) {
+		snap := bundleSnapshot()
+		var deleted []uuid.UUID
+		svc, _ := newIntegrityScreenService(snap, &deleted)
+		err := svc.DeleteInstance(ctx, snap.Instances[0].ID.String(), false)
+		assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+	})
+
+	t.Run("cascade elimina los enlaces", func(t *testing.T) {
+		snap := bundleSnapshot()
+		var deleted []uuid.UUID
+		svc, bundles := newIntegrityScreenService(snap, &deleted)
+		if err := svc.DeleteInstance(ctx, snap.Instances[0].ID.String(), true); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		changes := bundles.applied
+		if changes == nil || len(changes.DeactivateInstances) != 1 || len(changes.DeleteResourceScreens) != 1 {
+			t.Errorf("cascade incorrecto: %+v", changes)
+		}
+		if bundles.snapshots != 0 {
+			t.Error("no debería cargar la configuración completa")
+		}
+	})
+}
+
+func TestScreenConfigService_UnlinkScreenIntegrity(t *testing.T) {
+	ctx := context.Background()
+
+	// menuSnapshot makes students a visible leaf whose only screen is the link
+	menuSnapshot := func() *repository.ScreenConfigSnapshot {
+		snap := bundleSnapshot()
+		snap.Resources[0].IsMenuVisible = true
+		return snap
+	}
+
+	t.Run("rechaza quitar la última pantalla de un ítem del menú", func(t *testing.T) {
+		snap := menuSnapshot()
+		var deleted []uuid.UUID
+		svc, _ := newIntegrityScreenService(snap, &deleted)
+		err := svc.UnlinkScreen(ctx, snap.ResourceScreens[0].ID.String(), false)
+		assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+		if len(deleted) != 0 {
+			t.Error("no debería borrar el enlace")
+		}
+	})
+
+	t.Run("cascade oculta el ítem del menú", func(t *testing.T) {
+		snap := menuSnapshot()
+		var deleted []uuid.UUID
+		svc, bundles := newIntegrityScreenService(snap, &deleted)
+		if err := svc.UnlinkScreen(ctx, snap.ResourceScreens[0].ID.String(), true); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		changes := bundles.applied
+		if changes == nil || len(changes.HideResources) != 1 || changes.HideResources[0] != snap.Resources[0].ID ||
+			len(changes.DeleteResourceScreens) != 1 {
+			t.Errorf("cascade incorrecto: %+v", changes)
+		}
+		if bundles.snapshots != 0 {
+			t.Error("no debería cargar la configuración completa")
+		}
+	})
+
+	t.Run("permite quitar una pantalla si quedan otras", func(t *testing.T) {
+		snap := menuSnapshot()
+		snap.ResourceScreens = append(snap.ResourceScreens, &entities.ResourceScreen{
+			ID: uuid.New(), ResourceID: snap.Resources[0].ID, ResourceKey: "students", ScreenKey: "students-form",
+			ScreenType: "form", IsActive: true,
+		})
+		var deleted []uuid.UUID
+		svc, bundles := newIntegrityScreenService(snap, &deleted)
+		if err := svc.UnlinkScreen(ctx, snap.ResourceScreens[0].ID.String(), false); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if bundles.applied != nil || len(deleted) != 1 {
+			t.Errorf("debería usar el borrado normal: %v", deleted)
+		}
+	})
+}
+
+func TestScreenConfigService_CheckConsistency(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("un entorno sano es consistente", func(t *testing.T) {
+		var deleted []uuid.UUID
+		svc, _ := newIntegrityScreenService(bundleSnapshot(), &deleted)
+		report, err := svc.CheckConsistency(ctx)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if !report.Consistent {
+			t.Errorf("esperaba consistente: %+v", report)
+		}
+	})
+
+	t.Run("reporta huérfanos, enlaces colgantes y permisos desconocidos", func(t *testing.T) {
+		snap := bundleSnapshot()
+		perm := "students:read"
+		snap.Templates[0].IsActive = false
+		snap.Instances[0].RequiredPermission = &perm
+		snap.ResourceScreens = append(snap.ResourceScreens, &entities.ResourceScreen{
+			ID: uuid.New(), ResourceID: snap.Resources[0].ID, ResourceKey: "students", ScreenKey: "grades-list", IsActive: true,
+		}, &entities.ResourceScreen{
+			ID: uuid.New(), ResourceID: uuid.New(), ResourceKey: "courses", ScreenKey: "students-list", IsActive: true,
+		})
+		var deleted []uuid.UUID
+		svc, _ := newIntegrityScreenService(snap, &deleted)
+		report, err := svc.CheckConsistency(ctx)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if report.Consistent {
+			t.Fatal("no debería ser consistente")
+		}
+		if len(report.OrphanedInstances) != 1 || report.OrphanedInstances[0].Reason != ConsistencyTemplateInactive {
+			t.Errorf("huérfanos incorrectos: %+v", report.OrphanedInstances)
+		}
+		if len(report.DanglingResourceScreens) != 2 || report.DanglingResourceScreens[0].Reason != ConsistencyScreenMissing ||
+			report.DanglingResourceScreens[1].Reason != ConsistencyResourceMissing {
+			t.Errorf("enlaces colgantes incorrectos: %+v", report.DanglingResourceScreens)
+		}
+		if len(report.UnknownPermissions) != 1 || report.UnknownPermissions[0].RequiredPermission != perm {
+			t.Errorf("permisos desconocidos incorrectos: %+v", report.UnknownPermissions)
+		}
+	})
+}
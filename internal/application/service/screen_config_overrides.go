@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+// PermissionScreensOverride allows a school to manage the overrides of its
+// own screens. screen_instances:update manages those of every school.
+const PermissionScreensOverride = "screens:override"
+
+// ScreenOverrideAdminPermissions may manage the overrides of any school
+var ScreenOverrideAdminPermissions = []string{"screen_instances:update"}
+
+// CreateScreenOverrideRequest adds an override to an instance. SlotData is a
+// JSON merge patch (RFC 7386) over the instance's slot data: objects merge
+// key by key, null removes a key and any other value replaces it.
+type CreateScreenOverrideRequest struct {
+	SchoolID string          `json:"school_id" binding:"required"`
+	RoleID   *string         `json:"role_id"`
+	SlotData json.RawMessage `json:"slot_data" binding:"required"`
+}
+
+type UpdateScreenOverrideRequest struct {
+	SlotData json.RawMessage `json:"slot_data" binding:"required"`
+}
+
+type ScreenOverrideDTO struct {
+	ID         string          `json:"id"`
+	InstanceID string          `json:"instance_id"`
+	SchoolID   string          `json:"school_id"`
+	RoleID     *string         `json:"role_id,omitempty"`
+	SlotData   json.RawMessage `json:"slot_data"`
+	CreatedBy  *string         `json:"created_by,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+// ListScreenOverrides lists the overrides of an instance, of every school or
+// only of schoolID
+func (s *screenConfigService) ListScreenOverrides(ctx context.Context, instanceID, schoolID string) ([]*ScreenOverrideDTO, error) {
+	instance, err := s.findInstance(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	var school *uuid.UUID
+	if schoolID != "" {
+		sid, err := uuid.Parse(schoolID)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid school_id")
+		}
+		school = &sid
+	}
+	overrides, err := s.overrideRepo.ListByInstance(ctx, instance.ID, school)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list screen overrides", err)
+	}
+	dtos := make([]*ScreenOverrideDTO, len(overrides))
+	for i, o := range overrides {
+		dtos[i] = toScreenOverrideDTO(o)
+	}
+	return dtos, nil
+}
+
+func (s *screenConfigService) CreateScreenOverride(ctx context.Context, instanceID string, req *CreateScreenOverrideRequest, createdBy string) (*ScreenOverrideDTO, error) {
+	instance, err := s.findInstance(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	schoolID, err := uuid.Parse(req.SchoolID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school_id")
+	}
+	var roleID *uuid.UUID
+	if req.RoleID != nil && *req.RoleID != "" {
+		rid, err := uuid.Parse(*req.RoleID)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid role_id")
+		}
+		roleID = &rid
+	}
+	if err := s.validateOverride(ctx, instance, req.SlotData); err != nil {
+		return nil, err
+	}
+	existing, err := s.overrideRepo.GetByContext(ctx, instance.ID, schoolID, roleID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get screen override", err)
+	}
+	if existing != nil {
+		return nil, errors.NewAlreadyExistsError("screen_override")
+	}
+
+	now := time.Now()
+	override := &model.ScreenOverride{
+		ID: uuid.New(), InstanceID: instance.ID, SchoolID: schoolID, RoleID: roleID,
+		SlotData: req.SlotData, CreatedAt: now, UpdatedAt: now,
+	}
+	if uid, err := uuid.Parse(createdBy); err == nil {
+		override.CreatedBy = &uid
+	}
+	if err := s.overrideRepo.Create(ctx, override); err != nil {
+		return nil, errors.NewDatabaseError("create screen override", err)
+	}
+	s.logger.Info("entity created", "entity_type", "screen_override", "entity_id", override.ID.String(),
+		"screen_key", instance.ScreenKey, "school_id", schoolID.String())
+	return toScreenOverrideDTO(override), nil
+}
+
+// UpdateScreenOverride replaces the patch of an override. A non-empty
+// schoolScope restricts the call to the overrides of that school.
+func (s *screenConfigService) UpdateScreenOverride(ctx context.Context, instanceID, overrideID, schoolScope string, req *UpdateScreenOverrideRequest) (*ScreenOverrideDTO, error) {
+	instance, err := s.findInstance(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	override, err := s.findOverride(ctx, instance, overrideID, schoolScope)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateOverride(ctx, instance, req.SlotData); err != nil {
+		return nil, err
+	}
+	override.SlotData = req.SlotData
+	override.UpdatedAt = time.Now()
+	if err := s.overrideRepo.Update(ctx, override); err != nil {
+		return nil, errors.NewDatabaseError("update screen override", err)
+	}
+	s.logger.Info("entity updated", "entity_type", "screen_override", "entity_id", overrideID)
+	return toScreenOverrideDTO(override), nil
+}
+
+// DeleteScreenOverride removes an override. A non-empty schoolScope
+// restricts the call to the overrides of that school.
+func (s *screenConfigService) DeleteScreenOverride(ctx context.Context, instanceID, overrideID, schoolScope string) error {
+	instance, err := s.findInstance(ctx, instanceID)
+	if err != nil {
+		return err
+	}
+	override, err := s.findOverride(ctx, instance, overrideID, schoolScope)
+	if err != nil {
+		return err
+	}
+	if err := s.overrideRepo.Delete(ctx, override.ID); err != nil {
+		return errors.NewDatabaseError("delete screen override", err)
+	}
+	s.logger.Info("entity deleted", "entity_type", "screen_override", "entity_id", overrideID)
+	return nil
+}
+
+func (s *screenConfigService) findOverride(ctx context.Context, instance *entities.ScreenInstance, overrideID, schoolScope string) (*model.ScreenOverride, error) {
+	oid, err := uuid.Parse(overrideID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid override ID")
+	}
+	override, err := s.overrideRepo.GetByID(ctx, oid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get screen override", err)
+	}
+	if override == nil || override.InstanceID != instance.ID ||
+		(schoolScope != "" && override.SchoolID.String() != schoolScope) {
+		return nil, errors.NewNotFoundError("screen_override")
+	}
+	return override, nil
+}
+
+// validateOverride checks that patch is a JSON object and that the slot data
+// it produces over the published instance still matches the pattern schema
+func (s *screenConfigService) validateOverride(ctx context.Context, instance *entities.ScreenInstance, patch json.RawMessage) error {
+	v, err := decodeJSONValue(patch)
+	if _, ok := v.(map[string]any); err != nil || !ok {
+		return errors.NewValidationError("slot_data must be a JSON object")
+	}
+	merged, err := mergePatchJSON(instance.SlotData, patch)
+	if err != nil {
+		return errors.NewValidationError("instance slot_data is not valid JSON")
+	}
+	tmpl, err := s.templateRepo.GetByID(ctx, instance.TemplateID)
+	if err != nil || tmpl == nil {
+		return errors.NewValidationError("template not found")
+	}
+	return s.validateSlotData(ctx, tmpl.Pattern, merged)
+}
+
+// overrideContext returns the school and role whose overrides apply to a
+// resolution; ok is false when no school is set
+func (o ResolveOptions) overrideContext() (schoolID uuid.UUID, roleID *uuid.UUID, ok bool) {
+	schoolID, err := uuid.Parse(o.SchoolID)
+	if err != nil {
+		return uuid.Nil, nil, false
+	}
+	if rid, err := uuid.Parse(o.RoleID); err == nil {
+		roleID = &rid
+	}
+	return schoolID, roleID, true
+}
+
+// findOverridesForContext returns the overrides that apply to opts, by
+// instance. instanceID narrows the lookup to one instance.
+func (s *screenConfigService) findOverridesForContext(ctx context.Context, opts ResolveOptions, instanceID *uuid.UUID) (map[uuid.UUID][]*model.ScreenOverride, error) {
+	schoolID, roleID, ok := opts.overrideContext()
+	if !ok {
+		return nil, nil
+	}
+	overrides, err := s.overrideRepo.FindForContext(ctx, schoolID, roleID, instanceID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find screen overrides", err)
+	}
+	byInstance := make(map[uuid.UUID][]*model.ScreenOverride)
+	for _, o := range overrides {
+		byInstance[o.InstanceID] = append(byInstance[o.InstanceID], o)
+	}
+	return byInstance, nil
+}
+
+// applyOverrides merges overrides onto the slot data of a resolved screen,
+// the school-wide one before the role's. UpdatedAt becomes the latest change.
+func (s *screenConfigService) applyOverrides(combined *CombinedScreenDTO, overrides []*model.ScreenOverride) {
+	ordered := slices.Clone(overrides)
+	slices.SortStableFunc(ordered, func(a, b *model.ScreenOverride) int {
+		switch {
+		case a.RoleID == nil && b.RoleID != nil:
+			return -1
+		case a.RoleID != nil && b.RoleID == nil:
+			return 1
+		}
+		return 0
+	})
+	for _, o := range ordered {
+		merged, err := mergePatchJSON(combined.SlotData, o.SlotData)
+		if err != nil {
+			s.logger.Warn("screen override skipped", "override_id", o.ID.String(), "error", err)
+			continue
+		}
+		combined.SlotData = merged
+		combined.Overridden = true
+		if o.UpdatedAt.After(combined.UpdatedAt) {
+			combined.UpdatedAt = o.UpdatedAt
+		}
+	}
+}
+
+// mergePatchJSON applies patch to doc as a JSON merge patch (RFC 7386).
+// Arrays are replaced as a whole.
+func mergePatchJSON(doc, patch json.RawMessage) (json.RawMessage, error) {
+	target, err := decodeJSONValue(doc)
+	if err != nil {
+		return nil, err
+	}
+	p, err := decodeJSONValue(patch)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergePatchValue(target, p))
+}
+
+func mergePatchValue(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = make(map[string]any, len(p))
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = mergePatchValue(t[k], v)
+	}
+	return t
+}
+
+func toScreenOverrideDTO(o *model.ScreenOverride) *ScreenOverrideDTO {
+	d := &ScreenOverrideDTO{
+		ID: o.ID.String(), InstanceID: o.InstanceID.String(), SchoolID: o.SchoolID.String(),
+		SlotData: o.SlotData, CreatedAt: o.CreatedAt, UpdatedAt: o.UpdatedAt,
+	}
+	if o.RoleID != nil {
+		rid := o.RoleID.String()
+		d.RoleID = &rid
+	}
+	if o.CreatedBy != nil {
+		uid := o.CreatedBy.String()
+		d.CreatedBy = &uid
+	}
+	return d
+}
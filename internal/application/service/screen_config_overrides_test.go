@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	sharedErrors "github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+func TestMergePatchJSON(t *testing.T) {
+	cases := []struct{ name, doc, patch, want string }{
+		{"reemplaza un valor", `{"title":"Alumnos"}`, `{"title":"Estudiantes"}`, `{"title":"Estudiantes"}`},
+		{"fusiona objetos anidados", `{"labels":{"name":"Nombre","age":"Edad"}}`, `{"labels":{"age":"Años"}}`, `{"labels":{"age":"Años","name":"Nombre"}}`},
+		{"null elimina la clave", `{"title":"A","subtitle":"B"}`, `{"subtitle":null}`, `{"title":"A"}`},
+		{"los arrays se reemplazan", `{"columns":["name","age"]}`, `{"columns":["name"]}`, `{"columns":["name"]}`},
+		{"crea objetos faltantes", `{}`, `{"labels":{"name":"Nombre"}}`, `{"labels":{"name":"Nombre"}}`},
+		{"conserva los números", `{"page_size":20}`, `{"title":"A"}`, `{"page_size":20,"title":"A"}`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := mergePatchJSON(json.RawMessage(tc.doc), json.RawMessage(tc.patch))
+			if err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("esperaba %s, obtuvo %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestScreenConfigService_Overrides(t *testing.T) {
+	ctx := context.Background()
+	schoolID, roleID := uuid.NewString(), uuid.NewString()
+	base := `{"title":"Alumnos","labels":{"name":"Nombre","age":"Edad"}}`
+
+	t.Run("fusiona la escuela y luego el rol", func(t *testing.T) {
+		svc, _, _ := newVersionedScreenService()
+		_, inst := newPublishedScreen(t, svc, "students-list", base)
+
+		if _, err := svc.CreateScreenOverride(ctx, inst.ID, &CreateScreenOverrideRequest{
+			SchoolID: schoolID, RoleID: &roleID, SlotData: json.RawMessage(`{"labels":{"name":"Alumno"}}`),
+		}, ""); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if _, err := svc.CreateScreenOverride(ctx, inst.ID, &CreateScreenOverrideRequest{
+			SchoolID: schoolID, SlotData: json.RawMessage(`{"title":"Estudiantes","labels":{"name":"Estudiante","age":null}}`),
+		}, ""); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+
+		want := `{"labels":{"name":"Alumno"},"title":"Estudiantes"}`
+		resolved, err := svc.ResolveScreenByKey(ctx, "students-list", ResolveOptions{SchoolID: schoolID, RoleID: roleID})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if string(resolved.SlotData) != want || !resolved.Overridden {
+			t.Errorf("esperaba %s, obtuvo %s", want, resolved.SlotData)
+		}
+
+		all, err := svc.ResolveAllScreens(ctx, ResolveOptions{SchoolID: schoolID, RoleID: roleID})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if len(all) != 1 || string(all[0].SlotData) != want {
+			t.Errorf("el bundle debería incluir los overrides: %+v", all)
+		}
+
+		otherRole, _ := svc.ResolveScreenByKey(ctx, "students-list", ResolveOptions{SchoolID: schoolID, RoleID: uuid.NewString()})
+		if string(otherRole.SlotData) != `{"labels":{"name":"Estudiante"},"title":"Estudiantes"}` {
+			t.Errorf("otro rol solo debería ver el override de la escuela: %s", otherRole.SlotData)
+		}
+	})
+
+	t.Run("otras escuelas ven la pantalla base", func(t *testing.T) {
+		svc, _, _ := newVersionedScreenService()
+		_, inst := newPublishedScreen(t, svc, "students-list", base)
+		if _, err := svc.CreateScreenOverride(ctx, inst.ID, &CreateScreenOverrideRequest{
+			SchoolID: schoolID, SlotData: json.RawMessage(`{"title":"Estudiantes"}`),
+		}, ""); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		for _, opts := range []ResolveOptions{{}, {SchoolID: uuid.NewString()}} {
+			resolved, err := svc.ResolveScreenByKey(ctx, "students-list", opts)
+			if err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+			if string(resolved.SlotData) != base || resolved.Overridden {
+				t.Errorf("no debería aplicar overrides: %s", resolved.SlotData)
+			}
+		}
+	})
+
+	t.Run("valida el override", func(t *testing.T) {
+		svc, _, _ := newVersionedScreenService()
+		_, inst := newPublishedScreen(t, svc, "students-list", base)
+
+		_, err := svc.CreateScreenOverride(ctx, inst.ID, &CreateScreenOverrideRequest{SchoolID: schoolID, SlotData: json.RawMessage(`["x"]`)}, "")
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+		_, err = svc.CreateScreenOverride(ctx, inst.ID, &CreateScreenOverrideRequest{SchoolID: "x", SlotData: json.RawMessage(`{}`)}, "")
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+
+		req := &CreateScreenOverrideRequest{SchoolID: schoolID, SlotData: json.RawMessage(`{"title":"A"}`)}
+		if _, err := svc.CreateScreenOverride(ctx, inst.ID, req, ""); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		_, err = svc.CreateScreenOverride(ctx, inst.ID, req, "")
+		assertAppError(t, err, sharedErrors.ErrorCodeAlreadyExists)
+	})
+
+	t.Run("una escuela solo gestiona sus overrides", func(t *testing.T) {
+		svc, _, _ := newVersionedScreenService()
+		_, inst := newPublishedScreen(t, svc, "students-list", base)
+		created, err := svc.CreateScreenOverride(ctx, inst.ID, &CreateScreenOverrideRequest{
+			SchoolID: schoolID, SlotData: json.RawMessage(`{"title":"A"}`),
+		}, "")
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+
+		err = svc.DeleteScreenOverride(ctx, inst.ID, created.ID, uuid.NewString())
+		assertAppError(t, err, sharedErrors.ErrorCodeNotFound)
+
+		updated, err := svc.UpdateScreenOverride(ctx, inst.ID, created.ID, schoolID, &UpdateScreenOverrideRequest{SlotData: json.RawMessage(`{"title":"B"}`)})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if string(updated.SlotData) != `{"title":"B"}` {
+			t.Errorf("override no actualizado: %s", updated.SlotData)
+		}
+		if err := svc.DeleteScreenOverride(ctx, inst.ID, created.ID, schoolID); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		list, _ := svc.ListScreenOverrides(ctx, inst.ID, "")
+		if len(list) != 0 {
+			t.Errorf("esperaba 0 overrides, obtuvo %d", len(list))
+		}
+	})
+}
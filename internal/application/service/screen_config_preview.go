@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+// Preview warning codes
+const (
+	PreviewWarningTemplateInactive  = "template_inactive"
+	PreviewWarningInvalidDefinition = "invalid_definition"
+	PreviewWarningInvalidSlotData   = "invalid_slot_data"
+	PreviewWarningInvalidMerged     = "invalid_merged_slot_data"
+	PreviewWarningUnknownScreen     = "unknown_screen"
+	PreviewWarningUnsupportedLocale = "unsupported_locale"
+	PreviewWarningPermissionMissing = "permission_missing"
+)
+
+// ScreenPreviewContext is the simulated user a preview is resolved for
+type ScreenPreviewContext struct {
+	RoleID      string   `json:"role_id"`
+	SchoolID    string   `json:"school_id"`
+	Permissions []string `json:"permissions"`
+	Locale      string   `json:"locale"`
+}
+
+// PreviewScreenRequest is a candidate screen. Definition replaces the
+// template's published definition when set. ScreenKey ties the preview to an
+// existing instance, whose name translations and overrides then apply and
+// whose fields fill in the ones left empty.
+type PreviewScreenRequest struct {
+	TemplateID         string               `json:"template_id" binding:"required"`
+	Definition         json.RawMessage      `json:"definition" swaggertype:"object"`
+	ScreenKey          string               `json:"screen_key"`
+	Name               string               `json:"name"`
+	SlotData           json.RawMessage      `json:"slot_data" swaggertype:"object"`
+	HandlerKey         string               `json:"handler_key"`
+	RequiredPermission string               `json:"required_permission"`
+	Context            ScreenPreviewContext `json:"context"`
+}
+
+type ScreenPreviewWarning struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ScreenPreviewResponse is the screen as the sync bundle would deliver it to
+// the simulated user, with the hash the client would receive
+type ScreenPreviewResponse struct {
+	Screen     *dto.ScreenBundle      `json:"screen"`
+	Hash       string                 `json:"hash"`
+	Locale     string                 `json:"locale"`
+	Overridden bool                   `json:"overridden"`
+	Warnings   []ScreenPreviewWarning `json:"warnings"`
+}
+
+// PreviewScreen resolves a candidate screen without persisting anything.
+// Problems the author can fix are returned as warnings; only malformed
+// requests and lookup failures are errors.
+func (s *screenConfigService) PreviewScreen(ctx context.Context, req *PreviewScreenRequest) (*ScreenPreviewResponse, error) {
+	templateID, err := uuid.Parse(req.TemplateID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid template_id")
+	}
+	opts := ResolveOptions{SchoolID: req.Context.SchoolID, RoleID: req.Context.RoleID}
+	if _, err := uuid.Parse(opts.SchoolID); opts.SchoolID != "" && err != nil {
+		return nil, errors.NewValidationError("invalid context.school_id")
+	}
+	if _, err := uuid.Parse(opts.RoleID); opts.RoleID != "" && err != nil {
+		return nil, errors.NewValidationError("invalid context.role_id")
+	}
+	if req.ScreenKey != "" && !screenKeyRegex.MatchString(req.ScreenKey) {
+		return nil, errors.NewValidationError("screen_key must be kebab-case")
+	}
+
+	template, err := s.templateRepo.GetByID(ctx, templateID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get screen template", err)
+	}
+	if template == nil {
+		return nil, errors.NewNotFoundError("screen_template")
+	}
+
+	resp := &ScreenPreviewResponse{Warnings: []ScreenPreviewWarning{}}
+	warn := func(code, message string) {
+		resp.Warnings = append(resp.Warnings, ScreenPreviewWarning{Code: code, Message: message})
+	}
+	// schemaWarning turns schema violations into warnings and passes any
+	// other failure through
+	schemaWarning := func(code string, err error) error {
+		if err == nil {
+			return nil
+		}
+		if appErr, ok := errors.GetAppError(err); !ok || appErr.Code != errors.ErrorCodeValidation {
+			return err
+		}
+		warn(code, err.Error())
+		return nil
+	}
+
+	if !template.IsActive {
+		warn(PreviewWarningTemplateInactive, "the template is inactive; the sync bundle does not deliver its screens")
+	}
+	candidate := *template
+	if nullableJSON(req.Definition) != nil {
+		candidate.Definition = req.Definition
+	}
+	if err := schemaWarning(PreviewWarningInvalidDefinition, s.validateDefinition(ctx, candidate.Pattern, candidate.Definition)); err != nil {
+		return nil, err
+	}
+
+	inst := &entities.ScreenInstance{ScreenKey: req.ScreenKey, TemplateID: template.ID, UpdatedAt: time.Now()}
+	var existing *entities.ScreenInstance
+	if req.ScreenKey != "" {
+		if existing, err = s.instanceRepo.GetByScreenKey(ctx, req.ScreenKey); err != nil {
+			return nil, errors.NewDatabaseError("get screen instance", err)
+		}
+		if existing == nil {
+			warn(PreviewWarningUnknownScreen, fmt.Sprintf("no instance has screen_key %q; overrides and name translations are not applied", req.ScreenKey))
+		} else {
+			copied := *existing
+			inst = &copied
+			inst.TemplateID = template.ID
+		}
+	}
+	if req.Name != "" {
+		inst.Name = req.Name
+	}
+	if inst.Name == "" {
+		inst.Name = template.Name
+	}
+	if nullableJSON(req.SlotData) != nil {
+		inst.SlotData = req.SlotData
+	}
+	if req.HandlerKey != "" {
+		inst.HandlerKey = &req.HandlerKey
+	}
+	if req.RequiredPermission != "" {
+		inst.RequiredPermission = &req.RequiredPermission
+	}
+	warnings := len(resp.Warnings)
+	if err := schemaWarning(PreviewWarningInvalidSlotData, s.validateSlotData(ctx, candidate.Pattern, inst.SlotData)); err != nil {
+		return nil, err
+	}
+	slotDataValid := len(resp.Warnings) == warnings
+
+	combined := toCombinedScreenDTO(inst, &candidate)
+	if existing == nil {
+		combined.ScreenID = ""
+	} else {
+		overrides, err := s.findOverridesForContext(ctx, opts, &existing.ID)
+		if err != nil {
+			return nil, err
+		}
+		s.applyOverrides(combined, overrides[existing.ID])
+		// valid candidate slot data can still break once overrides merge in
+		if combined.Overridden && slotDataValid {
+			if err := schemaWarning(PreviewWarningInvalidMerged, s.validateSlotData(ctx, candidate.Pattern, combined.SlotData)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	locale := normalizeLocale(req.Context.Locale)
+	if locale == "" {
+		locale = s.locales.Default
+	} else if !slices.Contains(s.locales.Supported, locale) {
+		warn(PreviewWarningUnsupportedLocale, fmt.Sprintf("locale %q is not supported; clients receive %q", locale, s.locales.Default))
+		locale = s.locales.Default
+	}
+	s.localizeScreens(ctx, []*CombinedScreenDTO{combined}, locale)
+
+	if perm := derefString(inst.RequiredPermission); perm != "" && !slices.Contains(req.Context.Permissions, perm) {
+		warn(PreviewWarningPermissionMissing, fmt.Sprintf("the simulated user lacks %q and cannot open this screen", perm))
+	}
+
+	resp.Screen = toScreenBundle(combined)
+	resp.Hash = hashLocalized(locale, resp.Screen)
+	resp.Locale = locale
+	resp.Overridden = combined.Overridden
+	return resp, nil
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedErrors "github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+func previewWarningCodes(resp *ScreenPreviewResponse) []string {
+	codes := make([]string, len(resp.Warnings))
+	for i, w := range resp.Warnings {
+		codes[i] = w.Code
+	}
+	return codes
+}
+
+func TestScreenConfigService_PreviewScreen(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("resuelve como el bundle de sync sin persistir", func(t *testing.T) {
+		tpl := &entities.ScreenTemplate{ID: uuid.New(), Pattern: "list", Name: "Lista", Version: 2, IsActive: true,
+			Definition: json.RawMessage(`{"title":"Lista"}`)}
+		instRepo := &mockScreenInstanceRepo{
+			createFn: func(ctx context.Context, instance *entities.ScreenInstance) error {
+				t.Fatal("la vista previa no debería persistir")
+				return nil
+			},
+		}
+		svc := newSchemaScreenService(tpl, instRepo)
+		resp, err := svc.PreviewScreen(ctx, &PreviewScreenRequest{
+			TemplateID: tpl.ID.String(), Name: "Alumnos", SlotData: json.RawMessage(`{"columns":["name"],"title":{"$i18n":{"es":"Alumnos","en":"Students"}}}`),
+			RequiredPermission: "students:read",
+			Context:            ScreenPreviewContext{Permissions: []string{"students:read"}, Locale: "en"},
+		})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if len(resp.Warnings) != 0 {
+			t.Errorf("no esperaba advertencias: %+v", resp.Warnings)
+		}
+		if resp.Locale != "en" || string(resp.Screen.SlotData) != `{"columns":["name"],"title":"Students"}` || resp.Screen.Version != 2 {
+			t.Errorf("pantalla incorrecta: %+v", resp.Screen)
+		}
+		if resp.Hash != hashLocalized("en", resp.Screen) {
+			t.Error("el hash debería coincidir con el del bundle de sync")
+		}
+	})
+
+	t.Run("advierte en vez de fallar", func(t *testing.T) {
+		tpl := &entities.ScreenTemplate{ID: uuid.New(), Pattern: "list", Name: "Lista", Version: 1,
+			Definition: json.RawMessage(`{"title":"Lista"}`)}
+		svc := newSchemaScreenService(tpl, &mockScreenInstanceRepo{})
+		resp, err := svc.PreviewScreen(ctx, &PreviewScreenRequest{
+			TemplateID: tpl.ID.String(), Definition: json.RawMessage(`{}`), ScreenKey: "grades-list",
+			SlotData: json.RawMessage(`{"columns":[]}`), RequiredPermission: "grades:read",
+			Context: ScreenPreviewContext{Locale: "fr"},
+		})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		want := []string{
+			PreviewWarningTemplateInactive, PreviewWarningInvalidDefinition, PreviewWarningUnknownScreen,
+			PreviewWarningInvalidSlotData, PreviewWarningUnsupportedLocale, PreviewWarningPermissionMissing,
+		}
+		codes := previewWarningCodes(resp)
+		if len(codes) != len(want) {
+			t.Fatalf("esperaba %v, obtuvo %v", want, codes)
+		}
+		for i := range want {
+			if codes[i] != want[i] {
+				t.Errorf("advertencia %d: esperaba %s, obtuvo %s", i, want[i], codes[i])
+			}
+		}
+		if resp.Locale != "es" || resp.Screen.ScreenName != "Lista" {
+			t.Errorf("respuesta incorrecta: %+v", resp)
+		}
+	})
+
+	t.Run("aplica los overrides de la pantalla existente", func(t *testing.T) {
+		svc, _, _ := newVersionedScreenService()
+		tpl, inst := newPublishedScreen(t, svc, "students-list", `{"title":"Alumnos"}`)
+		schoolID := uuid.NewString()
+		if _, err := svc.CreateScreenOverride(ctx, inst.ID, &CreateScreenOverrideRequest{
+			SchoolID: schoolID, SlotData: json.RawMessage(`{"subtitle":"Escuela"}`),
+		}, ""); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		resp, err := svc.PreviewScreen(ctx, &PreviewScreenRequest{
+			TemplateID: tpl.ID, ScreenKey: "students-list", SlotData: json.RawMessage(`{"title":"Estudiantes"}`),
+			Context: ScreenPreviewContext{SchoolID: schoolID},
+		})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if !resp.Overridden || string(resp.Screen.SlotData) != `{"subtitle":"Escuela","title":"Estudiantes"}` {
+			t.Errorf("esperaba los overrides sobre el candidato: %s", resp.Screen.SlotData)
+		}
+		stored, _ := svc.GetInstance(ctx, inst.ID)
+		if string(stored.SlotData) != `{"title":"Alumnos"}` {
+			t.Errorf("la instancia no debería cambiar: %s", stored.SlotData)
+		}
+	})
+
+	t.Run("valida la petición", func(t *testing.T) {
+		svc := newSchemaScreenService(nil, &mockScreenInstanceRepo{})
+		_, err := svc.PreviewScreen(ctx, &PreviewScreenRequest{TemplateID: "bad-uuid"})
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+		_, err = svc.PreviewScreen(ctx, &PreviewScreenRequest{TemplateID: uuid.NewString(), Context: ScreenPreviewContext{SchoolID: "x"}})
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+		_, err = svc.PreviewScreen(ctx, &PreviewScreenRequest{TemplateID: uuid.NewString()})
+		assertAppError(t, err, sharedErrors.ErrorCodeNotFound)
+	})
+}
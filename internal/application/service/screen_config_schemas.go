@@ -0,0 +1,171 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+// SavePatternSchemaRequest declares the schemas of a template pattern. A
+// missing or null schema disables that validation.
+type SavePatternSchemaRequest struct {
+	DefinitionSchema json.RawMessage `json:"definition_schema"`
+	SlotDataSchema   json.RawMessage `json:"slot_data_schema"`
+}
+
+type PatternSchemaDTO struct {
+	Pattern          string          `json:"pattern"`
+	DefinitionSchema json.RawMessage `json:"definition_schema,omitempty"`
+	SlotDataSchema   json.RawMessage `json:"slot_data_schema,omitempty"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+}
+
+func (s *screenConfigService) ListPatternSchemas(ctx context.Context) ([]*PatternSchemaDTO, error) {
+	schemas, err := s.schemaRepo.List(ctx)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list screen pattern schemas", err)
+	}
+	dtos := make([]*PatternSchemaDTO, len(schemas))
+	for i, schema := range schemas {
+		dtos[i] = toPatternSchemaDTO(schema)
+	}
+	return dtos, nil
+}
+
+func (s *screenConfigService) GetPatternSchema(ctx context.Context, pattern string) (*PatternSchemaDTO, error) {
+	schema, err := s.schemaRepo.Get(ctx, pattern)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get screen pattern schema", err)
+	}
+	if schema == nil {
+		return nil, errors.NewNotFoundError("screen_pattern_schema")
+	}
+	return toPatternSchemaDTO(schema), nil
+}
+
+// SavePatternSchema sets the schemas of a pattern. Existing templates and
+// instances are not revalidated; the schemas apply from their next edit.
+func (s *screenConfigService) SavePatternSchema(ctx context.Context, pattern string, req *SavePatternSchemaRequest) (*PatternSchemaDTO, error) {
+	if strings.TrimSpace(pattern) == "" {
+		return nil, errors.NewValidationError("pattern is required")
+	}
+	definitionSchema, slotDataSchema := nullableJSON(req.DefinitionSchema), nullableJSON(req.SlotDataSchema)
+	if definitionSchema == nil && slotDataSchema == nil {
+		return nil, errors.NewValidationError("definition_schema or slot_data_schema is required")
+	}
+	if definitionSchema != nil {
+		if _, err := compileJSONSchema(definitionSchema); err != nil {
+			return nil, errors.NewValidationError("definition_schema is invalid: " + err.Error())
+		}
+	}
+	if slotDataSchema != nil {
+		if _, err := compileJSONSchema(slotDataSchema); err != nil {
+			return nil, errors.NewValidationError("slot_data_schema is invalid: " + err.Error())
+		}
+	}
+
+	existing, err := s.schemaRepo.Get(ctx, pattern)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get screen pattern schema", err)
+	}
+	now := time.Now()
+	schema := &model.ScreenPatternSchema{
+		Pattern: pattern, DefinitionSchema: definitionSchema, SlotDataSchema: slotDataSchema,
+		CreatedAt: now, UpdatedAt: now,
+	}
+	if existing != nil {
+		schema.CreatedAt = existing.CreatedAt
+	}
+	if err := s.schemaRepo.Save(ctx, schema); err != nil {
+		return nil, errors.NewDatabaseError("save screen pattern schema", err)
+	}
+	s.logger.Info("entity updated", "entity_type", "screen_pattern_schema", "entity_id", pattern)
+	return toPatternSchemaDTO(schema), nil
+}
+
+func (s *screenConfigService) DeletePatternSchema(ctx context.Context, pattern string) error {
+	schema, err := s.schemaRepo.Get(ctx, pattern)
+	if err != nil {
+		return errors.NewDatabaseError("get screen pattern schema", err)
+	}
+	if schema == nil {
+		return errors.NewNotFoundError("screen_pattern_schema")
+	}
+	if err := s.schemaRepo.Delete(ctx, pattern); err != nil {
+		return errors.NewDatabaseError("delete screen pattern schema", err)
+	}
+	s.logger.Info("entity deleted", "entity_type", "screen_pattern_schema", "entity_id", pattern)
+	return nil
+}
+
+// validateDefinition checks a template definition against the definition
+// schema of its pattern, if any
+func (s *screenConfigService) validateDefinition(ctx context.Context, pattern string, definition json.RawMessage) error {
+	return s.validateAgainstPattern(ctx, pattern, "definition", definition, func(p *model.ScreenPatternSchema) json.RawMessage {
+		return p.DefinitionSchema
+	})
+}
+
+// validateSlotData checks instance slot data against the slot data schema of
+// its template's pattern, if any. Translatable values are checked in the
+// default locale.
+func (s *screenConfigService) validateSlotData(ctx context.Context, pattern string, slotData json.RawMessage) error {
+	if localized, err := localizeJSON(slotData, s.locales.Default, s.locales.Default); err == nil {
+		slotData = localized
+	}
+	return s.validateAgainstPattern(ctx, pattern, "slot_data", slotData, func(p *model.ScreenPatternSchema) json.RawMessage {
+		return p.SlotDataSchema
+	})
+}
+
+func (s *screenConfigService) validateAgainstPattern(
+	ctx context.Context, pattern, field string, doc json.RawMessage, pick func(*model.ScreenPatternSchema) json.RawMessage,
+) error {
+	patternSchema, err := s.schemaRepo.Get(ctx, pattern)
+	if err != nil {
+		return errors.NewDatabaseError("get screen pattern schema", err)
+	}
+	if patternSchema == nil || nullableJSON(pick(patternSchema)) == nil {
+		return nil
+	}
+	schema, err := compileJSONSchema(pick(patternSchema))
+	if err != nil {
+		return errors.NewValidationError(fmt.Sprintf("%s schema of pattern %q is invalid: %v", field, pattern, err))
+	}
+	violations, err := schema.validate(doc)
+	if err != nil {
+		return errors.NewValidationError(field + " must be valid JSON")
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	details := make([]string, len(violations))
+	for i, v := range violations {
+		details[i] = v.String()
+	}
+	return errors.NewValidationError(fmt.Sprintf("%s does not match the schema of pattern %q: %s",
+		field, pattern, strings.Join(details, "; ")))
+}
+
+// nullableJSON maps an absent or null document to nil
+func nullableJSON(raw json.RawMessage) json.RawMessage {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return nil
+	}
+	return raw
+}
+
+func toPatternSchemaDTO(p *model.ScreenPatternSchema) *PatternSchemaDTO {
+	return &PatternSchemaDTO{
+		Pattern: p.Pattern, DefinitionSchema: p.DefinitionSchema, SlotDataSchema: p.SlotDataSchema,
+		CreatedAt: p.CreatedAt, UpdatedAt: p.UpdatedAt,
+	}
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedErrors "github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+// newSchemaScreenService returns a service whose "list" pattern requires a
+// title in the definition and a non-empty columns array in the slot data
+func newSchemaScreenService(tpl *entities.ScreenTemplate, instRepo *mockScreenInstanceRepo) ScreenConfigService {
+	schemas := &mockScreenPatternSchemaRepo{schemas: map[string]*model.ScreenPatternSchema{
+		"list": {
+			Pattern:          "list",
+			DefinitionSchema: json.RawMessage(`{"type":"object","required":["title"],"properties":{"title":{"type":"string"}}}`),
+			SlotDataSchema:   json.RawMessage(`{"type":"object","properties":{"columns":{"type":"array","minItems":1,"items":{"type":"string"}}}}`),
+		},
+	}}
+	tplRepo := &mockScreenTemplateRepo{
+		getByIDFn: func(_ context.Context, _ uuid.UUID) (*entities.ScreenTemplate, error) { return tpl, nil },
+	}
+	return NewScreenConfigService(tplRepo, instRepo, &mockResourceScreenRepo{},
+		&mockScreenVersionRepo{}, &mockScreenDraftRepo{}, schemas, &mockScreenOverrideRepo{}, &mockTranslationRepo{}, &mockScreenConfigBundleRepo{}, NewLocaleSettings("es", []string{"es", "en"}), &mockLogger{})
+}
+
+func assertValidationMentions(t *testing.T, err error, paths ...string) {
+	t.Helper()
+	assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	for _, p := range paths {
+		if !strings.Contains(err.Error(), p) {
+			t.Errorf("el error debería mencionar %s: %v", p, err)
+		}
+	}
+}
+
+func TestScreenConfigService_SchemaValidation(t *testing.T) {
+	ctx := context.Background()
+	tpl := &entities.ScreenTemplate{ID: uuid.New(), Pattern: "list", Name: "Lista", Version: 1, IsActive: true,
+		Definition: json.RawMessage(`{"title":"Lista"}`)}
+
+	t.Run("rechaza una definición que no cumple el esquema del patrón", func(t *testing.T) {
+		svc := newSchemaScreenService(tpl, &mockScreenInstanceRepo{})
+		_, err := svc.CreateTemplate(ctx, &CreateTemplateRequest{Pattern: "list", Name: "Lista", Definition: json.RawMessage(`{"title":3}`)})
+		assertValidationMentions(t, err, "$.title", "expected string")
+	})
+
+	t.Run("patrones sin esquema no se validan", func(t *testing.T) {
+		svc := newSchemaScreenService(tpl, &mockScreenInstanceRepo{})
+		if _, err := svc.CreateTemplate(ctx, &CreateTemplateRequest{Pattern: "form", Name: "Form", Definition: json.RawMessage(`[1]`)}); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+	})
+
+	t.Run("valida la definición al cambiar el patrón", func(t *testing.T) {
+		svc := newSchemaScreenService(&entities.ScreenTemplate{ID: uuid.New(), Pattern: "form", Name: "F", Version: 1,
+			Definition: json.RawMessage(`{}`)}, &mockScreenInstanceRepo{})
+		pattern := "list"
+		_, err := svc.UpdateTemplate(ctx, uuid.NewString(), &UpdateTemplateRequest{Pattern: &pattern})
+		assertValidationMentions(t, err, "$.title", "is required")
+	})
+
+	t.Run("rechaza slot data inválido al crear la instancia", func(t *testing.T) {
+		svc := newSchemaScreenService(tpl, &mockScreenInstanceRepo{})
+		_, err := svc.CreateInstance(ctx, &CreateInstanceRequest{
+			ScreenKey: "students-list", TemplateID: tpl.ID.String(), Name: "Alumnos",
+			SlotData: json.RawMessage(`{"columns":["name",7]}`),
+		})
+		assertValidationMentions(t, err, "slot_data", "$.columns[1]", "expected string, got integer")
+	})
+
+	t.Run("rechaza slot data inválido al editar la instancia", func(t *testing.T) {
+		svc := newSchemaScreenService(tpl, &mockScreenInstanceRepo{getByIDFn: func(_ context.Context, id uuid.UUID) (*entities.ScreenInstance, error) {
+			return &entities.ScreenInstance{ID: id, ScreenKey: "students-list", TemplateID: tpl.ID, SlotData: json.RawMessage(`{"columns":["name"]}`)}, nil
+		}})
+		slot := json.RawMessage(`{"columns":[]}`)
+		_, err := svc.UpdateInstance(ctx, uuid.NewString(), &UpdateInstanceRequest{SlotData: &slot})
+		assertValidationMentions(t, err, "$.columns", "at least 1 items")
+	})
+}
+
+func TestScreenConfigService_SavePatternSchema(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("guarda y devuelve los esquemas", func(t *testing.T) {
+		svc := newSchemaScreenService(nil, &mockScreenInstanceRepo{})
+		saved, err := svc.SavePatternSchema(ctx, "detail", &SavePatternSchemaRequest{SlotDataSchema: json.RawMessage(`{"type":"object"}`)})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if saved.Pattern != "detail" || saved.DefinitionSchema != nil {
+			t.Errorf("esquema incorrecto: %+v", saved)
+		}
+		got, err := svc.GetPatternSchema(ctx, "detail")
+		if err != nil || string(got.SlotDataSchema) != `{"type":"object"}` {
+			t.Errorf("esquema no guardado: %+v %v", got, err)
+		}
+	})
+
+	t.Run("rechaza un esquema inválido indicando la ruta", func(t *testing.T) {
+		svc := newSchemaScreenService(nil, &mockScreenInstanceRepo{})
+		_, err := svc.SavePatternSchema(ctx, "detail", &SavePatternSchemaRequest{
+			DefinitionSchema: json.RawMessage(`{"properties":{"title":{"type":"text"}}}`),
+		})
+		assertValidationMentions(t, err, "definition_schema", "$.properties.title.type")
+	})
+
+	t.Run("requiere al menos un esquema", func(t *testing.T) {
+		svc := newSchemaScreenService(nil, &mockScreenInstanceRepo{})
+		_, err := svc.SavePatternSchema(ctx, "detail", &SavePatternSchemaRequest{DefinitionSchema: json.RawMessage(`null`)})
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	})
+
+	t.Run("eliminar un esquema inexistente", func(t *testing.T) {
+		svc := newSchemaScreenService(nil, &mockScreenInstanceRepo{})
+		assertAppError(t, svc.DeletePatternSchema(ctx, "detail"), sharedErrors.ErrorCodeNotFound)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"time"
 
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
 	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	"github.com/EduGoGroup/edugo-shared/common/errors"
@@ -22,19 +23,49 @@ type ScreenConfigService interface {
 	GetTemplate(ctx context.Context, id string) (*ScreenTemplateDTO, error)
 	ListTemplates(ctx context.Context, filter TemplateFilter) ([]*ScreenTemplateDTO, int, error)
 	UpdateTemplate(ctx context.Context, id string, req *UpdateTemplateRequest) (*ScreenTemplateDTO, error)
-	DeleteTemplate(ctx context.Context, id string) error
+	DeleteTemplate(ctx context.Context, id string, cascade bool) error
+	ListTemplateVersions(ctx context.Context, id string, filter VersionFilter) ([]*ScreenTemplateVersionDTO, int, error)
+	GetTemplateVersion(ctx context.Context, id string, version int) (*ScreenTemplateVersionDTO, error)
+	DiffTemplateVersions(ctx context.Context, id string, from, to int) (*ScreenVersionDiffDTO, error)
+	RollbackTemplate(ctx context.Context, id string, version int) (*ScreenTemplateDTO, error)
+	GetTemplateDraft(ctx context.Context, id string) (*ScreenTemplateDTO, error)
+	DiscardTemplateDraft(ctx context.Context, id string) error
+	PublishTemplate(ctx context.Context, id string, req *PublishRequest) (*ScreenTemplateDTO, error)
 	CreateInstance(ctx context.Context, req *CreateInstanceRequest) (*ScreenInstanceDTO, error)
 	GetInstance(ctx context.Context, id string) (*ScreenInstanceDTO, error)
 	GetInstanceByKey(ctx context.Context, key string) (*ScreenInstanceDTO, error)
 	ListInstances(ctx context.Context, filter InstanceFilter) ([]*ScreenInstanceDTO, int, error)
 	UpdateInstance(ctx context.Context, id string, req *UpdateInstanceRequest) (*ScreenInstanceDTO, error)
-	DeleteInstance(ctx context.Context, id string) error
-	ResolveScreenByKey(ctx context.Context, key string) (*CombinedScreenDTO, error)
-	ResolveAllScreens(ctx context.Context) ([]*CombinedScreenDTO, error)
+	DeleteInstance(ctx context.Context, id string, cascade bool) error
+	ListInstanceVersions(ctx context.Context, id string, filter VersionFilter) ([]*ScreenInstanceVersionDTO, int, error)
+	GetInstanceVersion(ctx context.Context, id string, version int) (*ScreenInstanceVersionDTO, error)
+	DiffInstanceVersions(ctx context.Context, id string, from, to int) (*ScreenVersionDiffDTO, error)
+	RollbackInstance(ctx context.Context, id string, version int) (*ScreenInstanceDTO, error)
+	GetInstanceDraft(ctx context.Context, id string) (*ScreenInstanceDTO, error)
+	DiscardInstanceDraft(ctx context.Context, id string) error
+	PublishInstance(ctx context.Context, id string, req *PublishRequest) (*ScreenInstanceDTO, error)
+	PublishDue(ctx context.Context) (int, error)
+	ListPatternSchemas(ctx context.Context) ([]*PatternSchemaDTO, error)
+	GetPatternSchema(ctx context.Context, pattern string) (*PatternSchemaDTO, error)
+	SavePatternSchema(ctx context.Context, pattern string, req *SavePatternSchemaRequest) (*PatternSchemaDTO, error)
+	DeletePatternSchema(ctx context.Context, pattern string) error
+
+	// Overrides
+	ListScreenOverrides(ctx context.Context, instanceID, schoolID string) ([]*ScreenOverrideDTO, error)
+	CreateScreenOverride(ctx context.Context, instanceID string, req *CreateScreenOverrideRequest, createdBy string) (*ScreenOverrideDTO, error)
+	UpdateScreenOverride(ctx context.Context, instanceID, overrideID, schoolScope string, req *UpdateScreenOverrideRequest) (*ScreenOverrideDTO, error)
+	DeleteScreenOverride(ctx context.Context, instanceID, overrideID, schoolScope string) error
+	ResolveScreenByKey(ctx context.Context, key string, opts ResolveOptions) (*CombinedScreenDTO, error)
+	ResolveAllScreens(ctx context.Context, opts ResolveOptions) ([]*CombinedScreenDTO, error)
+	EachResolvedScreen(ctx context.Context, opts ResolveOptions, fn func(*CombinedScreenDTO) error) error
 	GetScreenVersion(ctx context.Context, key string) (*ScreenVersionDTO, error)
 	LinkScreenToResource(ctx context.Context, req *LinkScreenRequest) (*ResourceScreenDTO, error)
 	GetScreensForResource(ctx context.Context, resourceID string) ([]*ResourceScreenDTO, error)
-	UnlinkScreen(ctx context.Context, id string) error
+	UnlinkScreen(ctx context.Context, id string, cascade bool) error
+	ExportBundle(ctx context.Context) (*ScreenConfigBundle, error)
+	ImportBundle(ctx context.Context, bundle *ScreenConfigBundle, dryRun bool) (*ScreenBundleImportResponse, error)
+	CheckConsistency(ctx context.Context) (*ScreenConsistencyReport, error)
+	PreviewScreen(ctx context.Context, req *PreviewScreenRequest) (*ScreenPreviewResponse, error)
 }
 
 // Request/Response types for screen config
@@ -109,6 +140,9 @@ type ScreenTemplateDTO struct {
 	Version     int             `json:"version"`
 	Definition  json.RawMessage `json:"definition"`
 	IsActive    bool            `json:"is_active"`
+	Status      string          `json:"status"`
+	HasDraft    bool            `json:"has_draft"`
+	PublishAt   *time.Time      `json:"publish_at,omitempty"`
 	CreatedAt   time.Time       `json:"created_at"`
 	UpdatedAt   time.Time       `json:"updated_at"`
 }
@@ -124,6 +158,9 @@ type ScreenInstanceDTO struct {
 	RequiredPermission string          `json:"required_permission,omitempty"`
 	HandlerKey         *string         `json:"handler_key,omitempty"`
 	IsActive           bool            `json:"is_active"`
+	Status             string          `json:"status"`
+	HasDraft           bool            `json:"has_draft"`
+	PublishAt          *time.Time      `json:"publish_at,omitempty"`
 	CreatedAt          time.Time       `json:"created_at"`
 	UpdatedAt          time.Time       `json:"updated_at"`
 }
@@ -138,6 +175,8 @@ type CombinedScreenDTO struct {
 	SlotData   json.RawMessage `json:"slot_data"`
 	HandlerKey *string         `json:"handler_key,omitempty"`
 	UpdatedAt  time.Time       `json:"updated_at"`
+	Draft      bool            `json:"draft,omitempty"`
+	Overridden bool            `json:"overridden,omitempty"`
 }
 
 type ResourceScreenDTO struct {
@@ -159,6 +198,13 @@ type screenConfigService struct {
 	templateRepo       repository.ScreenTemplateRepository
 	instanceRepo       repository.ScreenInstanceRepository
 	resourceScreenRepo repository.ResourceScreenRepository
+	versionRepo        repository.ScreenVersionRepository
+	draftRepo          repository.ScreenDraftRepository
+	schemaRepo         repository.ScreenPatternSchemaRepository
+	overrideRepo       repository.ScreenOverrideRepository
+	translationRepo    repository.TranslationRepository
+	bundleRepo         repository.ScreenConfigBundleRepository
+	locales            LocaleSettings
 	logger             logger.Logger
 }
 
@@ -166,12 +212,27 @@ func NewScreenConfigService(
 	templateRepo repository.ScreenTemplateRepository,
 	instanceRepo repository.ScreenInstanceRepository,
 	resourceScreenRepo repository.ResourceScreenRepository,
+	versionRepo repository.ScreenVersionRepository,
+	draftRepo repository.ScreenDraftRepository,
+	schemaRepo repository.ScreenPatternSchemaRepository,
+	overrideRepo repository.ScreenOverrideRepository,
+	translationRepo repository.TranslationRepository,
+	bundleRepo repository.ScreenConfigBundleRepository,
+	locales LocaleSettings,
 	logger logger.Logger,
 ) ScreenConfigService {
-	return &screenConfigService{templateRepo: templateRepo, instanceRepo: instanceRepo, resourceScreenRepo: resourceScreenRepo, logger: logger}
+	return &screenConfigService{
+		templateRepo: templateRepo, instanceRepo: instanceRepo, resourceScreenRepo: resourceScreenRepo,
+		versionRepo: versionRepo, draftRepo: draftRepo, schemaRepo: schemaRepo,
+		overrideRepo: overrideRepo, translationRepo: translationRepo, bundleRepo: bundleRepo, locales: locales,
+		logger: logger,
+	}
 }
 
 func (s *screenConfigService) CreateTemplate(ctx context.Context, req *CreateTemplateRequest) (*ScreenTemplateDTO, error) {
+	if err := s.validateDefinition(ctx, req.Pattern, req.Definition); err != nil {
+		return nil, err
+	}
 	now := time.Now()
 	template := &entities.ScreenTemplate{
 		ID: uuid.New(), Pattern: req.Pattern, Name: req.Name, Version: 1,
@@ -183,6 +244,9 @@ func (s *screenConfigService) CreateTemplate(ctx context.Context, req *CreateTem
 	if err := s.templateRepo.Create(ctx, template); err != nil {
 		return nil, errors.NewDatabaseError("create screen template", err)
 	}
+	if err := s.recordTemplateVersion(ctx, template, model.ScreenVersionCreate, nil); err != nil {
+		return nil, err
+	}
 	s.logger.Info("entity created", "entity_type", "screen_template", "entity_id", template.ID.String())
 	return toTemplateDTO(template), nil
 }
@@ -199,7 +263,13 @@ func (s *screenConfigService) GetTemplate(ctx context.Context, id string) (*Scre
 	if template == nil {
 		return nil, errors.NewNotFoundError("screen_template")
 	}
-	return toTemplateDTO(template), nil
+	draft, err := s.draftRepo.GetTemplateDraft(ctx, template.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get screen template draft", err)
+	}
+	result := toTemplateDTO(template)
+	result.HasDraft = draft != nil
+	return result, nil
 }
 
 func (s *screenConfigService) ListTemplates(ctx context.Context, filter TemplateFilter) ([]*ScreenTemplateDTO, int, error) {
@@ -223,47 +293,60 @@ func (s *screenConfigService) ListTemplates(ctx context.Context, filter Template
 	if err != nil {
 		return nil, 0, errors.NewDatabaseError("list screen templates", err)
 	}
+	drafts, err := s.draftRepo.ListTemplateDrafts(ctx)
+	if err != nil {
+		return nil, 0, errors.NewDatabaseError("list screen template drafts", err)
+	}
+	draftByID := draftsByTemplateID(drafts)
 	dtos := make([]*ScreenTemplateDTO, len(templates))
 	for i, t := range templates {
 		dtos[i] = toTemplateDTO(t)
+		dtos[i].HasDraft = draftByID[t.ID] != nil
 	}
 	return dtos, total, nil
 }
 
+// UpdateTemplate edits the template's draft; clients keep resolving the
+// published revision until the draft is published
 func (s *screenConfigService) UpdateTemplate(ctx context.Context, id string, req *UpdateTemplateRequest) (*ScreenTemplateDTO, error) {
-	tid, err := uuid.Parse(id)
+	template, err := s.findTemplate(ctx, id)
 	if err != nil {
-		return nil, errors.NewValidationError("invalid template ID")
+		return nil, err
 	}
-	template, err := s.templateRepo.GetByID(ctx, tid)
+	draft, err := s.templateDraftFor(ctx, template)
 	if err != nil {
 		return nil, err
 	}
-	if template == nil {
-		return nil, errors.NewNotFoundError("screen_template")
-	}
 	if req.Pattern != nil {
-		template.Pattern = *req.Pattern
+		draft.Pattern = *req.Pattern
 	}
 	if req.Name != nil {
-		template.Name = *req.Name
+		draft.Name = *req.Name
 	}
 	if req.Description != nil {
-		template.Description = req.Description
+		draft.Description = req.Description
 	}
 	if req.Definition != nil {
-		template.Definition = *req.Definition
-		template.Version++
+		draft.Definition = *req.Definition
 	}
-	template.UpdatedAt = time.Now()
-	if err := s.templateRepo.Update(ctx, template); err != nil {
-		return nil, errors.NewDatabaseError("update screen template", err)
+	if req.Pattern != nil || req.Definition != nil {
+		if err := s.validateDefinition(ctx, draft.Pattern, draft.Definition); err != nil {
+			return nil, err
+		}
 	}
-	s.logger.Info("entity updated", "entity_type", "screen_template", "entity_id", id)
-	return toTemplateDTO(template), nil
+	// an edit invalidates any scheduled publication
+	draft.PublishAt = nil
+	draft.UpdatedAt = time.Now()
+	if err := s.draftRepo.SaveTemplateDraft(ctx, draft); err != nil {
+		return nil, errors.NewDatabaseError("save screen template draft", err)
+	}
+	s.logger.Info("entity updated", "entity_type", "screen_template", "entity_id", id, "status", ScreenStatusDraft)
+	return toTemplateDraftDTO(template, draft), nil
 }
 
-func (s *screenConfigService) DeleteTemplate(ctx context.Context, id string) error {
+// DeleteTemplate deactivates a template. While active instances use it the
+// delete is refused unless cascade is set, which also deactivates them.
+func (s *screenConfigService) DeleteTemplate(ctx context.Context, id string, cascade bool) error {
 	tid, err := uuid.Parse(id)
 	if err != nil {
 		return errors.NewValidationError("invalid template ID")
@@ -275,6 +358,9 @@ func (s *screenConfigService) DeleteTemplate(ctx context.Context, id string) err
 	if t == nil {
 		return errors.NewNotFoundError("screen_template")
 	}
+	if done, err := s.deleteTemplateCascade(ctx, t, cascade); err != nil || done {
+		return err
+	}
 	if err := s.templateRepo.Delete(ctx, tid); err != nil {
 		return errors.NewDatabaseError("delete screen template", err)
 	}
@@ -317,10 +403,16 @@ func (s *screenConfigService) CreateInstance(ctx context.Context, req *CreateIns
 	if instance.SlotData == nil {
 		instance.SlotData = json.RawMessage(`{}`)
 	}
+	if err := s.validateSlotData(ctx, tmpl.Pattern, instance.SlotData); err != nil {
+		return nil, err
+	}
 
 	if err := s.instanceRepo.Create(ctx, instance); err != nil {
 		return nil, errors.NewDatabaseError("create screen instance", err)
 	}
+	if _, err := s.recordInstanceVersion(ctx, instance, model.ScreenVersionCreate, nil); err != nil {
+		return nil, err
+	}
 	s.logger.Info("entity created", "entity_type", "screen_instance", "entity_id", instance.ID.String())
 	return toInstanceDTO(instance), nil
 }
@@ -337,7 +429,13 @@ func (s *screenConfigService) GetInstance(ctx context.Context, id string) (*Scre
 	if instance == nil {
 		return nil, errors.NewNotFoundError("screen_instance")
 	}
-	return toInstanceDTO(instance), nil
+	draft, err := s.draftRepo.GetInstanceDraft(ctx, instance.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get screen instance draft", err)
+	}
+	result := toInstanceDTO(instance)
+	result.HasDraft = draft != nil
+	return result, nil
 }
 
 func (s *screenConfigService) GetInstanceByKey(ctx context.Context, key string) (*ScreenInstanceDTO, error) {
@@ -372,65 +470,83 @@ func (s *screenConfigService) ListInstances(ctx context.Context, filter Instance
 	if err != nil {
 		return nil, 0, errors.NewDatabaseError("list screen instances", err)
 	}
+	drafts, err := s.draftRepo.ListInstanceDrafts(ctx)
+	if err != nil {
+		return nil, 0, errors.NewDatabaseError("list screen instance drafts", err)
+	}
+	draftByID := draftsByInstanceID(drafts)
 	dtos := make([]*ScreenInstanceDTO, len(instances))
 	for i, inst := range instances {
 		dtos[i] = toInstanceDTO(inst)
+		dtos[i].HasDraft = draftByID[inst.ID] != nil
 	}
 	return dtos, total, nil
 }
 
+// UpdateInstance edits the instance's draft; clients keep resolving the
+// published revision until the draft is published
 func (s *screenConfigService) UpdateInstance(ctx context.Context, id string, req *UpdateInstanceRequest) (*ScreenInstanceDTO, error) {
-	iid, err := uuid.Parse(id)
+	instance, err := s.findInstance(ctx, id)
 	if err != nil {
-		return nil, errors.NewValidationError("invalid instance ID")
+		return nil, err
 	}
-	instance, err := s.instanceRepo.GetByID(ctx, iid)
+	draft, err := s.instanceDraftFor(ctx, instance)
 	if err != nil {
 		return nil, err
 	}
-	if instance == nil {
-		return nil, errors.NewNotFoundError("screen_instance")
-	}
 	if req.ScreenKey != nil {
 		if !screenKeyRegex.MatchString(*req.ScreenKey) {
 			return nil, errors.NewValidationError("screen_key must be kebab-case (e.g., 'my-screen-list')")
 		}
-		instance.ScreenKey = *req.ScreenKey
+		draft.ScreenKey = *req.ScreenKey
 	}
 	if req.TemplateID != nil {
 		tid, err := uuid.Parse(*req.TemplateID)
 		if err != nil {
 			return nil, errors.NewValidationError("invalid template_id")
 		}
-		instance.TemplateID = tid
+		draft.TemplateID = tid
 	}
 	if req.Name != nil {
-		instance.Name = *req.Name
+		draft.Name = *req.Name
 	}
 	if req.Description != nil {
-		instance.Description = req.Description
+		draft.Description = req.Description
 	}
 	if req.SlotData != nil {
-		instance.SlotData = *req.SlotData
+		draft.SlotData = *req.SlotData
 	}
 	if req.Scope != nil {
-		instance.Scope = *req.Scope
+		draft.Scope = *req.Scope
 	}
 	if req.RequiredPermission != nil {
-		instance.RequiredPermission = req.RequiredPermission
+		draft.RequiredPermission = req.RequiredPermission
 	}
 	if req.HandlerKey != nil {
-		instance.HandlerKey = req.HandlerKey
+		draft.HandlerKey = req.HandlerKey
 	}
-	instance.UpdatedAt = time.Now()
-	if err := s.instanceRepo.Update(ctx, instance); err != nil {
-		return nil, errors.NewDatabaseError("update screen instance", err)
+	if req.SlotData != nil || req.TemplateID != nil {
+		tmpl, err := s.templateRepo.GetByID(ctx, draft.TemplateID)
+		if err != nil || tmpl == nil {
+			return nil, errors.NewValidationError("template not found")
+		}
+		if err := s.validateSlotData(ctx, tmpl.Pattern, draft.SlotData); err != nil {
+			return nil, err
+		}
 	}
-	s.logger.Info("entity updated", "entity_type", "screen_instance", "entity_id", id)
-	return toInstanceDTO(instance), nil
+	// an edit invalidates any scheduled publication
+	draft.PublishAt = nil
+	draft.UpdatedAt = time.Now()
+	if err := s.draftRepo.SaveInstanceDraft(ctx, draft); err != nil {
+		return nil, errors.NewDatabaseError("save screen instance draft", err)
+	}
+	s.logger.Info("entity updated", "entity_type", "screen_instance", "entity_id", id, "status", ScreenStatusDraft)
+	return toInstanceDraftDTO(instance, draft), nil
 }
 
-func (s *screenConfigService) DeleteInstance(ctx context.Context, id string) error {
+// DeleteInstance deactivates an instance. While resources link to its screen
+// key the delete is refused unless cascade is set, which also unlinks them.
+func (s *screenConfigService) DeleteInstance(ctx context.Context, id string, cascade bool) error {
 	iid, err := uuid.Parse(id)
 	if err != nil {
 		return errors.NewValidationError("invalid instance ID")
@@ -442,6 +558,9 @@ func (s *screenConfigService) DeleteInstance(ctx context.Context, id string) err
 	if inst == nil {
 		return errors.NewNotFoundError("screen_instance")
 	}
+	if done, err := s.deleteInstanceCascade(ctx, inst, cascade); err != nil || done {
+		return err
+	}
 	if err := s.instanceRepo.Delete(ctx, iid); err != nil {
 		return errors.NewDatabaseError("delete screen instance", err)
 	}
@@ -449,11 +568,19 @@ func (s *screenConfigService) DeleteInstance(ctx context.Context, id string) err
 	return nil
 }
 
-func (s *screenConfigService) ResolveScreenByKey(ctx context.Context, key string) (*CombinedScreenDTO, error) {
+// ResolveScreenByKey combines the instance with key and its template. With
+// opts.Draft pending drafts are overlaid on the published revision.
+func (s *screenConfigService) ResolveScreenByKey(ctx context.Context, key string, opts ResolveOptions) (*CombinedScreenDTO, error) {
 	instance, err := s.instanceRepo.GetByScreenKey(ctx, key)
 	if err != nil {
 		return nil, err
 	}
+	instanceDraft := false
+	if opts.Draft {
+		if instance, instanceDraft, err = s.previewInstanceByKey(ctx, key, instance); err != nil {
+			return nil, err
+		}
+	}
 	if instance == nil {
 		return nil, errors.NewNotFoundError("screen_instance")
 	}
@@ -464,48 +591,146 @@ func (s *screenConfigService) ResolveScreenByKey(ctx context.Context, key string
 	if template == nil {
 		return nil, errors.NewNotFoundError("screen_template")
 	}
-	combined := &CombinedScreenDTO{
-		ScreenID: instance.ID.String(), ScreenKey: instance.ScreenKey, ScreenName: instance.Name,
-		Pattern: template.Pattern, Version: template.Version, Template: template.Definition,
-		SlotData: instance.SlotData, UpdatedAt: instance.UpdatedAt,
+	templateDraft := false
+	if opts.Draft {
+		if template, templateDraft, err = s.previewTemplate(ctx, template); err != nil {
+			return nil, err
+		}
 	}
-	if instance.HandlerKey != nil {
-		combined.HandlerKey = instance.HandlerKey
+	combined := toCombinedScreenDTO(instance, template)
+	combined.Draft = instanceDraft || templateDraft
+	overrides, err := s.findOverridesForContext(ctx, opts, &instance.ID)
+	if err != nil {
+		return nil, err
 	}
+	s.applyOverrides(combined, overrides[instance.ID])
+	s.localizeScreens(ctx, []*CombinedScreenDTO{combined}, opts.Locale)
 	return combined, nil
 }
 
-// ResolveAllScreens fetches all screen instances and their templates in 2 queries (no N+1).
-func (s *screenConfigService) ResolveAllScreens(ctx context.Context) ([]*CombinedScreenDTO, error) {
-	instances, _, err := s.instanceRepo.List(ctx, sharedrepo.ListFilters{Limit: 1000})
+// screenPageSize is the number of instances or templates read per query
+// while resolving all screens
+const screenPageSize = 500
+
+// ResolveAllScreens resolves every active screen; see EachResolvedScreen.
+// With opts.Draft pending drafts are overlaid on the published revision.
+func (s *screenConfigService) ResolveAllScreens(ctx context.Context, opts ResolveOptions) ([]*CombinedScreenDTO, error) {
+	result := []*CombinedScreenDTO{}
+	err := s.EachResolvedScreen(ctx, opts, func(screen *CombinedScreenDTO) error {
+		result = append(result, screen)
+		return nil
+	})
 	if err != nil {
-		return nil, errors.NewDatabaseError("list screen instances", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// EachResolvedScreen combines every active instance with its template and
+// hands each screen to fn as soon as it is resolved, so callers can stream
+// them. Templates are loaded up front; instances are read in pages of
+// screenPageSize ordered by id, so the cost does not depend on how many
+// screens exist (no N+1, no row limit). An error from fn stops the iteration
+// and is returned as is.
+func (s *screenConfigService) EachResolvedScreen(ctx context.Context, opts ResolveOptions, fn func(*CombinedScreenDTO) error) error {
+	templateDrafts := map[uuid.UUID]*model.ScreenTemplateDraft{}
+	instanceDrafts := map[uuid.UUID]*model.ScreenInstanceDraft{}
+	if opts.Draft {
+		tDrafts, err := s.draftRepo.ListTemplateDrafts(ctx)
+		if err != nil {
+			return errors.NewDatabaseError("list screen template drafts", err)
+		}
+		iDrafts, err := s.draftRepo.ListInstanceDrafts(ctx)
+		if err != nil {
+			return errors.NewDatabaseError("list screen instance drafts", err)
+		}
+		templateDrafts = draftsByTemplateID(tDrafts)
+		instanceDrafts = draftsByInstanceID(iDrafts)
+	}
+	templateMap := map[uuid.UUID]*entities.ScreenTemplate{}
+	for after := uuid.Nil; ; {
+		templates, err := s.templateRepo.ListAfter(ctx, after, screenPageSize)
+		if err != nil {
+			return errors.NewDatabaseError("list screen templates", err)
+		}
+		for _, t := range templates {
+			if d, ok := templateDrafts[t.ID]; ok {
+				t = overlayTemplateDraft(t, d)
+			}
+			templateMap[t.ID] = t
+		}
+		if len(templates) < screenPageSize {
+			break
+		}
+		after = templates[len(templates)-1].ID
 	}
-	templates, _, err := s.templateRepo.List(ctx, sharedrepo.ListFilters{Limit: 1000})
+	overrides, err := s.findOverridesForContext(ctx, opts, nil)
 	if err != nil {
-		return nil, errors.NewDatabaseError("list screen templates", err)
+		return err
+	}
+	localize := s.screenLocalizer(ctx, opts.Locale)
+
+	for after := uuid.Nil; ; {
+		instances, err := s.instanceRepo.ListAfter(ctx, after, screenPageSize)
+		if err != nil {
+			return errors.NewDatabaseError("list screen instances", err)
+		}
+		for _, inst := range instances {
+			d, instanceDraft := instanceDrafts[inst.ID]
+			if instanceDraft {
+				inst = overlayInstanceDraft(inst, d)
+			}
+			t, ok := templateMap[inst.TemplateID]
+			if !ok {
+				s.logger.Warn("screen instance skipped: template not found", "screen_key", inst.ScreenKey, "template_id", inst.TemplateID.String())
+				continue
+			}
+			combined := toCombinedScreenDTO(inst, t)
+			_, templateDraft := templateDrafts[t.ID]
+			combined.Draft = instanceDraft || templateDraft
+			s.applyOverrides(combined, overrides[inst.ID])
+			localize(combined)
+			if err := fn(combined); err != nil {
+				return err
+			}
+		}
+		if len(instances) < screenPageSize {
+			return nil
+		}
+		after = instances[len(instances)-1].ID
+	}
+}
+
+// localizeScreens translates resolved screens to locale: instance names from
+// the translations table and {"$i18n": ...} values of the template and slot
+// data
+func (s *screenConfigService) localizeScreens(ctx context.Context, screens []*CombinedScreenDTO, locale string) {
+	localize := s.screenLocalizer(ctx, locale)
+	for _, screen := range screens {
+		localize(screen)
 	}
-	templateMap := make(map[uuid.UUID]*entities.ScreenTemplate, len(templates))
-	for _, t := range templates {
-		templateMap[t.ID] = t
+}
+
+// screenLocalizer loads the name translations of locale once and returns a
+// function that localizes one resolved screen
+func (s *screenConfigService) screenLocalizer(ctx context.Context, locale string) func(*CombinedScreenDTO) {
+	if locale == "" {
+		locale = s.locales.Default
 	}
-	result := make([]*CombinedScreenDTO, 0, len(instances))
-	for _, inst := range instances {
-		t, ok := templateMap[inst.TemplateID]
-		if !ok {
-			continue
+	names := translatedValues(ctx, s.translationRepo, s.logger, model.TranslationEntityScreenInstance, model.TranslationFieldName, locale)
+	return func(screen *CombinedScreenDTO) {
+		if id, err := uuid.Parse(screen.ScreenID); err == nil {
+			if name, ok := names[id]; ok {
+				screen.ScreenName = name
+			}
 		}
-		combined := &CombinedScreenDTO{
-			ScreenID: inst.ID.String(), ScreenKey: inst.ScreenKey, ScreenName: inst.Name,
-			Pattern: t.Pattern, Version: t.Version, Template: t.Definition,
-			SlotData: inst.SlotData, UpdatedAt: inst.UpdatedAt,
+		if localized, err := localizeJSON(screen.Template, locale, s.locales.Default); err == nil {
+			screen.Template = localized
 		}
-		if inst.HandlerKey != nil {
-			combined.HandlerKey = inst.HandlerKey
+		if localized, err := localizeJSON(screen.SlotData, locale, s.locales.Default); err == nil {
+			screen.SlotData = localized
 		}
-		result = append(result, combined)
 	}
-	return result, nil
 }
 
 func (s *screenConfigService) GetScreenVersion(ctx context.Context, key string) (*ScreenVersionDTO, error) {
@@ -563,11 +788,16 @@ func (s *screenConfigService) GetScreensForResource(ctx context.Context, resourc
 	return dtos, nil
 }
 
-func (s *screenConfigService) UnlinkScreen(ctx context.Context, id string) error {
+// UnlinkScreen removes a resource-screen link. Removing the last screen of a
+// visible menu item is refused unless cascade is set, which hides the item.
+func (s *screenConfigService) UnlinkScreen(ctx context.Context, id string, cascade bool) error {
 	rsID, err := uuid.Parse(id)
 	if err != nil {
 		return errors.NewValidationError("invalid resource_screen ID")
 	}
+	if done, err := s.unlinkScreenCascade(ctx, rsID, cascade); err != nil || done {
+		return err
+	}
 	if err := s.resourceScreenRepo.Delete(ctx, rsID); err != nil {
 		return err
 	}
@@ -580,7 +810,8 @@ func (s *screenConfigService) UnlinkScreen(ctx context.Context, id string) error
 func toTemplateDTO(t *entities.ScreenTemplate) *ScreenTemplateDTO {
 	d := &ScreenTemplateDTO{
 		ID: t.ID.String(), Pattern: t.Pattern, Name: t.Name, Version: t.Version,
-		Definition: t.Definition, IsActive: t.IsActive, CreatedAt: t.CreatedAt, UpdatedAt: t.UpdatedAt,
+		Definition: t.Definition, IsActive: t.IsActive, Status: ScreenStatusPublished,
+		CreatedAt: t.CreatedAt, UpdatedAt: t.UpdatedAt,
 	}
 	if t.Description != nil {
 		d.Description = *t.Description
@@ -592,7 +823,8 @@ func toInstanceDTO(inst *entities.ScreenInstance) *ScreenInstanceDTO {
 	d := &ScreenInstanceDTO{
 		ID: inst.ID.String(), ScreenKey: inst.ScreenKey, TemplateID: inst.TemplateID.String(),
 		Name: inst.Name, SlotData: inst.SlotData,
-		Scope: inst.Scope, IsActive: inst.IsActive, CreatedAt: inst.CreatedAt, UpdatedAt: inst.UpdatedAt,
+		Scope: inst.Scope, IsActive: inst.IsActive, Status: ScreenStatusPublished,
+		CreatedAt: inst.CreatedAt, UpdatedAt: inst.UpdatedAt,
 	}
 	if inst.Description != nil {
 		d.Description = *inst.Description
@@ -606,6 +838,18 @@ func toInstanceDTO(inst *entities.ScreenInstance) *ScreenInstanceDTO {
 	return d
 }
 
+func toCombinedScreenDTO(inst *entities.ScreenInstance, t *entities.ScreenTemplate) *CombinedScreenDTO {
+	combined := &CombinedScreenDTO{
+		ScreenID: inst.ID.String(), ScreenKey: inst.ScreenKey, ScreenName: inst.Name,
+		Pattern: t.Pattern, Version: t.Version, Template: t.Definition,
+		SlotData: inst.SlotData, UpdatedAt: inst.UpdatedAt,
+	}
+	if inst.HandlerKey != nil {
+		combined.HandlerKey = inst.HandlerKey
+	}
+	return combined
+}
+
 func toResourceScreenDTO(rs *entities.ResourceScreen) *ResourceScreenDTO {
 	return &ResourceScreenDTO{
 		ResourceID: rs.ResourceID.String(), ResourceKey: rs.ResourceKey,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -18,7 +19,7 @@ func newScreenConfigService(
 	instRepo *mockScreenInstanceRepo,
 	rsRepo *mockResourceScreenRepo,
 ) ScreenConfigService {
-	return NewScreenConfigService(tplRepo, instRepo, rsRepo, &mockLogger{})
+	return NewScreenConfigService(tplRepo, instRepo, rsRepo, &mockScreenVersionRepo{}, &mockScreenDraftRepo{}, &mockScreenPatternSchemaRepo{}, &mockScreenOverrideRepo{}, &mockTranslationRepo{}, &mockScreenConfigBundleRepo{}, NewLocaleSettings("es", []string{"es", "en"}), &mockLogger{})
 }
 
 func sampleDefinition() json.RawMessage {
@@ -259,7 +260,7 @@ func TestScreenConfigService_DeleteTemplate(t *testing.T) {
 		}
 
 		svc := newScreenConfigService(tplRepo, &mockScreenInstanceRepo{}, &mockResourceScreenRepo{})
-		err := svc.DeleteTemplate(ctx, id.String())
+		err := svc.DeleteTemplate(ctx, id.String(), false)
 		if err != nil {
 			t.Fatalf("error inesperado: %v", err)
 		}
@@ -270,7 +271,7 @@ func TestScreenConfigService_DeleteTemplate(t *testing.T) {
 
 	t.Run("retorna error de validación con UUID inválido", func(t *testing.T) {
 		svc := newScreenConfigService(&mockScreenTemplateRepo{}, &mockScreenInstanceRepo{}, &mockResourceScreenRepo{})
-		err := svc.DeleteTemplate(ctx, "bad-uuid")
+		err := svc.DeleteTemplate(ctx, "bad-uuid", false)
 		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
 	})
 }
@@ -417,7 +418,7 @@ func TestScreenConfigService_ResolveScreenByKey(t *testing.T) {
 		}
 
 		svc := newScreenConfigService(tplRepo, instRepo, &mockResourceScreenRepo{})
-		resp, err := svc.ResolveScreenByKey(ctx, "my-screen")
+		resp, err := svc.ResolveScreenByKey(ctx, "my-screen", ResolveOptions{})
 		if err != nil {
 			t.Fatalf("error inesperado: %v", err)
 		}
@@ -531,7 +532,7 @@ func TestScreenConfigService_UnlinkScreen(t *testing.T) {
 		}
 
 		svc := newScreenConfigService(&mockScreenTemplateRepo{}, &mockScreenInstanceRepo{}, rsRepo)
-		err := svc.UnlinkScreen(ctx, id.String())
+		err := svc.UnlinkScreen(ctx, id.String(), false)
 		if err != nil {
 			t.Fatalf("error inesperado: %v", err)
 		}
@@ -542,7 +543,62 @@ func TestScreenConfigService_UnlinkScreen(t *testing.T) {
 
 	t.Run("retorna error con UUID inválido", func(t *testing.T) {
 		svc := newScreenConfigService(&mockScreenTemplateRepo{}, &mockScreenInstanceRepo{}, &mockResourceScreenRepo{})
-		err := svc.UnlinkScreen(ctx, "bad-uuid")
+		err := svc.UnlinkScreen(ctx, "bad-uuid", false)
 		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
 	})
 }
+
+// ─── ResolveAllScreens ────────────────────────────────────────────────────────
+
+func TestScreenConfigService_EachResolvedScreen(t *testing.T) {
+	ctx := context.Background()
+
+	// 2.5 pages of instances over a single template
+	tpl := &entities.ScreenTemplate{ID: uuid.New(), Pattern: "list", Name: "Lista", Version: 1, IsActive: true, Definition: sampleDefinition()}
+	var instances []*entities.ScreenInstance
+	for i := 0; i < screenPageSize*5/2; i++ {
+		instances = append(instances, &entities.ScreenInstance{ID: uuid.New(), ScreenKey: fmt.Sprintf("screen-%d", i),
+			TemplateID: tpl.ID, Name: "Pantalla", SlotData: json.RawMessage(`{}`), IsActive: true})
+	}
+	tplRepo := &mockScreenTemplateRepo{
+		listFn: func(ctx context.Context, filter sharedrepo.ListFilters) ([]*entities.ScreenTemplate, int, error) {
+			return []*entities.ScreenTemplate{tpl}, 1, nil
+		},
+	}
+	instRepo := &mockScreenInstanceRepo{
+		listFn: func(ctx context.Context, filter sharedrepo.ListFilters) ([]*entities.ScreenInstance, int, error) {
+			return instances, len(instances), nil
+		},
+	}
+
+	t.Run("recorre todas las páginas sin límite de filas", func(t *testing.T) {
+		svc := newScreenConfigService(tplRepo, instRepo, &mockResourceScreenRepo{})
+		all, err := svc.ResolveAllScreens(ctx, ResolveOptions{})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		seen := map[string]bool{}
+		for _, screen := range all {
+			seen[screen.ScreenKey] = true
+		}
+		if len(all) != len(instances) || len(seen) != len(instances) {
+			t.Errorf("esperaba %d pantallas distintas, obtuvo %d (%d distintas)", len(instances), len(all), len(seen))
+		}
+	})
+
+	t.Run("un error del callback detiene la iteración", func(t *testing.T) {
+		svc := newScreenConfigService(tplRepo, instRepo, &mockResourceScreenRepo{})
+		stop := errors.New("stop")
+		count := 0
+		err := svc.EachResolvedScreen(ctx, ResolveOptions{}, func(*CombinedScreenDTO) error {
+			count++
+			if count == 3 {
+				return stop
+			}
+			return nil
+		})
+		if !errors.Is(err, stop) || count != 3 {
+			t.Errorf("esperaba detenerse en 3, obtuvo %d (%v)", count, err)
+		}
+	})
+}
@@ -0,0 +1,342 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+type VersionFilter struct {
+	Page    int `form:"page"`
+	PerPage int `form:"per_page"`
+}
+
+// ScreenTemplateVersionDTO is one entry of a template's history. Lists leave
+// Definition out; fetch a single version to get it.
+type ScreenTemplateVersionDTO struct {
+	TemplateID   string          `json:"template_id"`
+	Version      int             `json:"version"`
+	Pattern      string          `json:"pattern"`
+	Name         string          `json:"name"`
+	Description  string          `json:"description,omitempty"`
+	Definition   json.RawMessage `json:"definition,omitempty"`
+	Source       string          `json:"source"`
+	RestoredFrom *int            `json:"restored_from,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+// ScreenInstanceVersionDTO is one entry of an instance's slot data history.
+// Lists leave SlotData out; fetch a single version to get it.
+type ScreenInstanceVersionDTO struct {
+	InstanceID   string          `json:"instance_id"`
+	Version      int             `json:"version"`
+	SlotData     json.RawMessage `json:"slot_data,omitempty"`
+	Source       string          `json:"source"`
+	RestoredFrom *int            `json:"restored_from,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+type ScreenVersionDiffDTO struct {
+	From    int          `json:"from"`
+	To      int          `json:"to"`
+	Changes []JSONChange `json:"changes"`
+}
+
+func (f VersionFilter) listFilters() sharedrepo.ListFilters {
+	if f.PerPage <= 0 {
+		f.PerPage = 20
+	}
+	if f.Page <= 0 {
+		f.Page = 1
+	}
+	return sharedrepo.ListFilters{Page: f.Page, Limit: f.PerPage}
+}
+
+// ==================== Templates ====================
+
+// recordTemplateVersion snapshots the template as it is now
+func (s *screenConfigService) recordTemplateVersion(ctx context.Context, t *entities.ScreenTemplate, source string, restoredFrom *int) error {
+	if err := s.versionRepo.CreateTemplateVersion(ctx, templateVersion(t, source, restoredFrom)); err != nil {
+		return errors.NewDatabaseError("record screen template version", err)
+	}
+	return nil
+}
+
+// templateVersion builds the history entry of the template as it is now
+func templateVersion(t *entities.ScreenTemplate, source string, restoredFrom *int) *model.ScreenTemplateVersion {
+	return &model.ScreenTemplateVersion{
+		ID: uuid.New(), TemplateID: t.ID, Version: t.Version, Pattern: t.Pattern, Name: t.Name,
+		Description: t.Description, Definition: t.Definition, Source: source, RestoredFrom: restoredFrom,
+		CreatedAt: t.UpdatedAt,
+	}
+}
+
+func (s *screenConfigService) ListTemplateVersions(ctx context.Context, id string, filter VersionFilter) ([]*ScreenTemplateVersionDTO, int, error) {
+	template, err := s.findTemplate(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+	versions, total, err := s.versionRepo.ListTemplateVersions(ctx, template.ID, filter.listFilters())
+	if err != nil {
+		return nil, 0, errors.NewDatabaseError("list screen template versions", err)
+	}
+	dtos := make([]*ScreenTemplateVersionDTO, len(versions))
+	for i, v := range versions {
+		dtos[i] = toTemplateVersionDTO(v)
+		dtos[i].Definition = nil
+	}
+	return dtos, total, nil
+}
+
+func (s *screenConfigService) GetTemplateVersion(ctx context.Context, id string, version int) (*ScreenTemplateVersionDTO, error) {
+	template, err := s.findTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	v, err := s.findTemplateVersion(ctx, template.ID, version)
+	if err != nil {
+		return nil, err
+	}
+	return toTemplateVersionDTO(v), nil
+}
+
+// DiffTemplateVersions compares two versions of a template. Paths are rooted
+// at the snapshot, e.g. $.name or $.definition.columns[0].label.
+func (s *screenConfigService) DiffTemplateVersions(ctx context.Context, id string, from, to int) (*ScreenVersionDiffDTO, error) {
+	template, err := s.findTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	a, err := s.findTemplateVersion(ctx, template.ID, from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.findTemplateVersion(ctx, template.ID, to)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := diffJSON(templateSnapshot(a), templateSnapshot(b))
+	if err != nil {
+		return nil, errors.NewValidationError("stored template definition is not valid JSON")
+	}
+	return &ScreenVersionDiffDTO{From: from, To: to, Changes: changes}, nil
+}
+
+// RollbackTemplate restores an earlier version as a new one, so the history
+// keeps the change being undone
+func (s *screenConfigService) RollbackTemplate(ctx context.Context, id string, version int) (*ScreenTemplateDTO, error) {
+	template, err := s.findTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if version == template.Version {
+		return nil, errors.NewValidationError("template is already at that version")
+	}
+	v, err := s.findTemplateVersion(ctx, template.ID, version)
+	if err != nil {
+		return nil, err
+	}
+	template.Pattern = v.Pattern
+	template.Name = v.Name
+	template.Description = v.Description
+	template.Definition = v.Definition
+	template.Version++
+	template.UpdatedAt = time.Now()
+	if err := s.templateRepo.Update(ctx, template); err != nil {
+		return nil, errors.NewDatabaseError("update screen template", err)
+	}
+	if err := s.recordTemplateVersion(ctx, template, model.ScreenVersionRollback, &version); err != nil {
+		return nil, err
+	}
+	s.logger.Info("screen template rolled back", "entity_type", "screen_template", "entity_id", id,
+		"restored_version", version, "new_version", template.Version)
+	return toTemplateDTO(template), nil
+}
+
+func (s *screenConfigService) findTemplate(ctx context.Context, id string) (*entities.ScreenTemplate, error) {
+	tid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid template ID")
+	}
+	template, err := s.templateRepo.GetByID(ctx, tid)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return nil, errors.NewNotFoundError("screen_template")
+	}
+	return template, nil
+}
+
+func (s *screenConfigService) findTemplateVersion(ctx context.Context, templateID uuid.UUID, version int) (*model.ScreenTemplateVersion, error) {
+	v, err := s.versionRepo.GetTemplateVersion(ctx, templateID, version)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get screen template version", err)
+	}
+	if v == nil {
+		return nil, errors.NewNotFoundError("screen_template_version")
+	}
+	return v, nil
+}
+
+func templateSnapshot(v *model.ScreenTemplateVersion) json.RawMessage {
+	snapshot := map[string]any{"pattern": v.Pattern, "name": v.Name, "definition": v.Definition}
+	if v.Description != nil {
+		snapshot["description"] = *v.Description
+	}
+	return rawJSON(snapshot)
+}
+
+// ==================== Instances ====================
+
+// recordInstanceVersion snapshots the instance's slot data as the next version
+func (s *screenConfigService) recordInstanceVersion(ctx context.Context, inst *entities.ScreenInstance, source string, restoredFrom *int) (int, error) {
+	v, err := s.nextInstanceVersion(ctx, inst, source, restoredFrom)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.versionRepo.CreateInstanceVersion(ctx, v); err != nil {
+		return 0, errors.NewDatabaseError("record screen instance version", err)
+	}
+	return v.Version, nil
+}
+
+// nextInstanceVersion builds the history entry that follows the latest
+// recorded version of the instance
+func (s *screenConfigService) nextInstanceVersion(ctx context.Context, inst *entities.ScreenInstance, source string, restoredFrom *int) (*model.ScreenInstanceVersion, error) {
+	latest, err := s.versionRepo.LatestInstanceVersion(ctx, inst.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get latest screen instance version", err)
+	}
+	return &model.ScreenInstanceVersion{
+		ID: uuid.New(), InstanceID: inst.ID, Version: latest + 1, SlotData: inst.SlotData,
+		Source: source, RestoredFrom: restoredFrom, CreatedAt: inst.UpdatedAt,
+	}, nil
+}
+
+func (s *screenConfigService) ListInstanceVersions(ctx context.Context, id string, filter VersionFilter) ([]*ScreenInstanceVersionDTO, int, error) {
+	instance, err := s.findInstance(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+	versions, total, err := s.versionRepo.ListInstanceVersions(ctx, instance.ID, filter.listFilters())
+	if err != nil {
+		return nil, 0, errors.NewDatabaseError("list screen instance versions", err)
+	}
+	dtos := make([]*ScreenInstanceVersionDTO, len(versions))
+	for i, v := range versions {
+		dtos[i] = toInstanceVersionDTO(v)
+		dtos[i].SlotData = nil
+	}
+	return dtos, total, nil
+}
+
+func (s *screenConfigService) GetInstanceVersion(ctx context.Context, id string, version int) (*ScreenInstanceVersionDTO, error) {
+	instance, err := s.findInstance(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	v, err := s.findInstanceVersion(ctx, instance.ID, version)
+	if err != nil {
+		return nil, err
+	}
+	return toInstanceVersionDTO(v), nil
+}
+
+// DiffInstanceVersions compares the slot data of two versions of an instance.
+// Paths are rooted at the slot data, e.g. $.columns[0].label.
+func (s *screenConfigService) DiffInstanceVersions(ctx context.Context, id string, from, to int) (*ScreenVersionDiffDTO, error) {
+	instance, err := s.findInstance(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	a, err := s.findInstanceVersion(ctx, instance.ID, from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.findInstanceVersion(ctx, instance.ID, to)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := diffJSON(a.SlotData, b.SlotData)
+	if err != nil {
+		return nil, errors.NewValidationError("stored slot data is not valid JSON")
+	}
+	return &ScreenVersionDiffDTO{From: from, To: to, Changes: changes}, nil
+}
+
+// RollbackInstance restores the slot data of an earlier version as a new one
+func (s *screenConfigService) RollbackInstance(ctx context.Context, id string, version int) (*ScreenInstanceDTO, error) {
+	instance, err := s.findInstance(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	v, err := s.findInstanceVersion(ctx, instance.ID, version)
+	if err != nil {
+		return nil, err
+	}
+	instance.SlotData = v.SlotData
+	instance.UpdatedAt = time.Now()
+	if err := s.instanceRepo.Update(ctx, instance); err != nil {
+		return nil, errors.NewDatabaseError("update screen instance", err)
+	}
+	newVersion, err := s.recordInstanceVersion(ctx, instance, model.ScreenVersionRollback, &version)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("screen instance rolled back", "entity_type", "screen_instance", "entity_id", id,
+		"restored_version", version, "new_version", newVersion)
+	return toInstanceDTO(instance), nil
+}
+
+func (s *screenConfigService) findInstance(ctx context.Context, id string) (*entities.ScreenInstance, error) {
+	iid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid instance ID")
+	}
+	instance, err := s.instanceRepo.GetByID(ctx, iid)
+	if err != nil {
+		return nil, err
+	}
+	if instance == nil {
+		return nil, errors.NewNotFoundError("screen_instance")
+	}
+	return instance, nil
+}
+
+func (s *screenConfigService) findInstanceVersion(ctx context.Context, instanceID uuid.UUID, version int) (*model.ScreenInstanceVersion, error) {
+	v, err := s.versionRepo.GetInstanceVersion(ctx, instanceID, version)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get screen instance version", err)
+	}
+	if v == nil {
+		return nil, errors.NewNotFoundError("screen_instance_version")
+	}
+	return v, nil
+}
+
+// Conversion helpers
+
+func toTemplateVersionDTO(v *model.ScreenTemplateVersion) *ScreenTemplateVersionDTO {
+	d := &ScreenTemplateVersionDTO{
+		TemplateID: v.TemplateID.String(), Version: v.Version, Pattern: v.Pattern, Name: v.Name,
+		Definition: v.Definition, Source: v.Source, RestoredFrom: v.RestoredFrom, CreatedAt: v.CreatedAt,
+	}
+	if v.Description != nil {
+		d.Description = *v.Description
+	}
+	return d
+}
+
+func toInstanceVersionDTO(v *model.ScreenInstanceVersion) *ScreenInstanceVersionDTO {
+	return &ScreenInstanceVersionDTO{
+		InstanceID: v.InstanceID.String(), Version: v.Version, SlotData: v.SlotData,
+		Source: v.Source, RestoredFrom: v.RestoredFrom, CreatedAt: v.CreatedAt,
+	}
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedErrors "github.com/EduGoGroup/edugo-shared/common/errors"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+// newVersionedScreenService wires the service to in-memory template and
+// instance stores so version history and drafts can be exercised end to end
+func newVersionedScreenService() (ScreenConfigService, *mockScreenVersionRepo, *mockScreenDraftRepo) {
+	templates := map[uuid.UUID]*entities.ScreenTemplate{}
+	instances := map[uuid.UUID]*entities.ScreenInstance{}
+	tplRepo := &mockScreenTemplateRepo{
+		createFn: func(_ context.Context, t *entities.ScreenTemplate) error { templates[t.ID] = t; return nil },
+		updateFn: func(_ context.Context, t *entities.ScreenTemplate) error { templates[t.ID] = t; return nil },
+		getByIDFn: func(_ context.Context, id uuid.UUID) (*entities.ScreenTemplate, error) {
+			if t, ok := templates[id]; ok {
+				cp := *t
+				return &cp, nil
+			}
+			return nil, nil
+		},
+		listFn: func(_ context.Context, _ sharedrepo.ListFilters) ([]*entities.ScreenTemplate, int, error) {
+			var result []*entities.ScreenTemplate
+			for _, t := range templates {
+				cp := *t
+				result = append(result, &cp)
+			}
+			return result, len(result), nil
+		},
+	}
+	instRepo := &mockScreenInstanceRepo{
+		createFn: func(_ context.Context, i *entities.ScreenInstance) error { instances[i.ID] = i; return nil },
+		updateFn: func(_ context.Context, i *entities.ScreenInstance) error { instances[i.ID] = i; return nil },
+		getByIDFn: func(_ context.Context, id uuid.UUID) (*entities.ScreenInstance, error) {
+			if i, ok := instances[id]; ok {
+				cp := *i
+				return &cp, nil
+			}
+			return nil, nil
+		},
+		getByScreenKeyFn: func(_ context.Context, key string) (*entities.ScreenInstance, error) {
+			for _, i := range instances {
+				if i.ScreenKey == key && i.IsActive {
+					cp := *i
+					return &cp, nil
+				}
+			}
+			return nil, nil
+		},
+		listFn: func(_ context.Context, _ sharedrepo.ListFilters) ([]*entities.ScreenInstance, int, error) {
+			var result []*entities.ScreenInstance
+			for _, i := range instances {
+				cp := *i
+				result = append(result, &cp)
+			}
+			return result, len(result), nil
+		},
+	}
+	versions := &mockScreenVersionRepo{}
+	drafts := &mockScreenDraftRepo{}
+	// publications are written through the change set, like the transaction does
+	bundles := &mockScreenConfigBundleRepo{applyFn: func(_ context.Context, c *repository.ScreenConfigChangeSet) error {
+		for _, t := range c.UpsertTemplates {
+			templates[t.ID] = t
+		}
+		for _, i := range c.UpsertInstances {
+			instances[i.ID] = i
+		}
+		versions.templateVersions = append(versions.templateVersions, c.TemplateVersions...)
+		versions.instanceVersions = append(versions.instanceVersions, c.InstanceVersions...)
+		for _, id := range c.DeleteTemplateDrafts {
+			delete(drafts.templateDrafts, id)
+		}
+		for _, id := range c.DeleteInstanceDrafts {
+			delete(drafts.instanceDrafts, id)
+		}
+		return nil
+	}}
+	return NewScreenConfigService(tplRepo, instRepo, &mockResourceScreenRepo{}, versions, drafts, &mockScreenPatternSchemaRepo{}, &mockScreenOverrideRepo{}, &mockTranslationRepo{}, bundles, NewLocaleSettings("es", []string{"es", "en"}), &mockLogger{}), versions, drafts
+}
+
+func TestScreenConfigService_TemplateVersions(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (ScreenConfigService, *ScreenTemplateDTO) {
+		svc, _, _ := newVersionedScreenService()
+		tpl, err := svc.CreateTemplate(ctx, &CreateTemplateRequest{Pattern: "list", Name: "Lista", Definition: json.RawMessage(`{"title":"v1"}`)})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		for _, def := range []string{`{"title":"v2"}`, `{"title":"v3","extra":true}`} {
+			raw := json.RawMessage(def)
+			if _, err := svc.UpdateTemplate(ctx, tpl.ID, &UpdateTemplateRequest{Definition: &raw}); err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+			if _, err := svc.PublishTemplate(ctx, tpl.ID, nil); err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+		}
+		return svc, tpl
+	}
+
+	t.Run("cada definición publicada queda en el historial", func(t *testing.T) {
+		svc, tpl := setup(t)
+		versions, total, err := svc.ListTemplateVersions(ctx, tpl.ID, VersionFilter{})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if total != 3 || versions[0].Version != 3 || versions[2].Version != 1 {
+			t.Fatalf("historial incorrecto: total=%d %+v", total, versions)
+		}
+		if versions[0].Definition != nil {
+			t.Error("el listado no debería incluir la definición")
+		}
+		v1, err := svc.GetTemplateVersion(ctx, tpl.ID, 1)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if string(v1.Definition) != `{"title":"v1"}` || v1.Source != model.ScreenVersionCreate {
+			t.Errorf("versión 1 incorrecta: %+v", v1)
+		}
+	})
+
+	t.Run("cambios de nombre sin definición no crean versión", func(t *testing.T) {
+		svc, tpl := setup(t)
+		name := "Otro"
+		if _, err := svc.UpdateTemplate(ctx, tpl.ID, &UpdateTemplateRequest{Name: &name}); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if _, err := svc.PublishTemplate(ctx, tpl.ID, nil); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		_, total, _ := svc.ListTemplateVersions(ctx, tpl.ID, VersionFilter{})
+		if total != 3 {
+			t.Errorf("esperaba 3 versiones, obtuvo %d", total)
+		}
+	})
+
+	t.Run("diff entre versiones apunta a la ruta", func(t *testing.T) {
+		svc, tpl := setup(t)
+		diff, err := svc.DiffTemplateVersions(ctx, tpl.ID, 1, 3)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if len(diff.Changes) != 2 || diff.Changes[0].Path != "$.definition.extra" || diff.Changes[1].Path != "$.definition.title" {
+			t.Errorf("diff incorrecto: %+v", diff.Changes)
+		}
+	})
+
+	t.Run("rollback crea una versión nueva", func(t *testing.T) {
+		svc, tpl := setup(t)
+		restored, err := svc.RollbackTemplate(ctx, tpl.ID, 1)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if restored.Version != 4 || string(restored.Definition) != `{"title":"v1"}` {
+			t.Errorf("rollback incorrecto: versión %d, definición %s", restored.Version, restored.Definition)
+		}
+		v4, err := svc.GetTemplateVersion(ctx, tpl.ID, 4)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if v4.Source != model.ScreenVersionRollback || v4.RestoredFrom == nil || *v4.RestoredFrom != 1 {
+			t.Errorf("versión de rollback incorrecta: %+v", v4)
+		}
+	})
+
+	t.Run("rollback a la versión actual falla", func(t *testing.T) {
+		svc, tpl := setup(t)
+		_, err := svc.RollbackTemplate(ctx, tpl.ID, 3)
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	})
+
+	t.Run("versión inexistente", func(t *testing.T) {
+		svc, tpl := setup(t)
+		_, err := svc.GetTemplateVersion(ctx, tpl.ID, 9)
+		assertAppError(t, err, sharedErrors.ErrorCodeNotFound)
+	})
+}
+
+func TestScreenConfigService_InstanceVersions(t *testing.T) {
+	ctx := context.Background()
+	svc, versions, _ := newVersionedScreenService()
+	tpl, err := svc.CreateTemplate(ctx, &CreateTemplateRequest{Pattern: "list", Name: "Lista", Definition: sampleDefinition()})
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	inst, err := svc.CreateInstance(ctx, &CreateInstanceRequest{
+		ScreenKey: "students-list", TemplateID: tpl.ID, Name: "Alumnos", SlotData: json.RawMessage(`{"columns":["name"]}`),
+	})
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	slot := json.RawMessage(`{"columns":["name","age"]}`)
+	if _, err := svc.UpdateInstance(ctx, inst.ID, &UpdateInstanceRequest{SlotData: &slot}); err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if _, err := svc.PublishInstance(ctx, inst.ID, nil); err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+
+	diff, err := svc.DiffInstanceVersions(ctx, inst.ID, 1, 2)
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if len(diff.Changes) != 1 || diff.Changes[0].Path != "$.columns[1]" || diff.Changes[0].Op != JSONChangeAdded {
+		t.Errorf("diff incorrecto: %+v", diff.Changes)
+	}
+
+	restored, err := svc.RollbackInstance(ctx, inst.ID, 1)
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if string(restored.SlotData) != `{"columns":["name"]}` {
+		t.Errorf("slot data no restaurado: %s", restored.SlotData)
+	}
+	if len(versions.instanceVersions) != 3 {
+		t.Fatalf("esperaba 3 versiones, obtuvo %d", len(versions.instanceVersions))
+	}
+	last := versions.instanceVersions[2]
+	if last.Version != 3 || last.Source != model.ScreenVersionRollback || *last.RestoredFrom != 1 {
+		t.Errorf("versión de rollback incorrecta: %+v", last)
+	}
+}
@@ -0,0 +1,508 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+// SoDService manages separation-of-duties constraints: pairs of roles or
+// permissions that a single user must not hold in the same context. Two
+// assignments share a context when they are in the same school or when either
+// of them is not bound to a school.
+type SoDService interface {
+	ListConstraints(ctx context.Context) (*dto.SoDConstraintsResponse, error)
+	CreateConstraint(ctx context.Context, req *dto.CreateSoDConstraintRequest, createdBy string) (*dto.SoDConstraintDTO, error)
+	DeleteConstraint(ctx context.Context, id string) error
+	Report(ctx context.Context, schoolID string) (*dto.SoDReportResponse, error)
+	// CheckGrant rejects a new assignment that would violate a constraint
+	// together with the user's current assignments and the pending ones, which
+	// are about to be granted to the same user in the same batch.
+	CheckGrant(ctx context.Context, userRole *entities.UserRole, pending ...*entities.UserRole) error
+	// CheckRolePermissions rejects replacing a role's permissions with a set
+	// that violates a permission constraint, on its own or for any holder.
+	CheckRolePermissions(ctx context.Context, roleID uuid.UUID, permissionIDs []uuid.UUID) error
+}
+
+type sodService struct {
+	constraintRepo repository.SoDConstraintRepository
+	roleRepo       repository.RoleRepository
+	permissionRepo repository.PermissionRepository
+	userRoleRepo   repository.UserRoleRepository
+	logger         logger.Logger
+	auditLogger    audit.AuditLogger
+}
+
+// NewSoDService creates a new separation-of-duties service
+func NewSoDService(constraintRepo repository.SoDConstraintRepository, roleRepo repository.RoleRepository, permissionRepo repository.PermissionRepository, userRoleRepo repository.UserRoleRepository, logger logger.Logger, auditLogger audit.AuditLogger) SoDService {
+	return &sodService{constraintRepo: constraintRepo, roleRepo: roleRepo, permissionRepo: permissionRepo, userRoleRepo: userRoleRepo, logger: logger, auditLogger: auditLogger}
+}
+
+func (s *sodService) ListConstraints(ctx context.Context) (*dto.SoDConstraintsResponse, error) {
+	constraints, err := s.constraintRepo.FindActive(ctx)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list sod constraints", err)
+	}
+	names := make(map[uuid.UUID]string)
+	result := make([]*dto.SoDConstraintDTO, 0, len(constraints))
+	for _, c := range constraints {
+		left, err := s.sideName(ctx, c.Kind, c.LeftID, names)
+		if err != nil {
+			return nil, err
+		}
+		right, err := s.sideName(ctx, c.Kind, c.RightID, names)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, toSoDConstraintDTO(c, left, right))
+	}
+	return &dto.SoDConstraintsResponse{Constraints: result}, nil
+}
+
+// CreateConstraint only declares the rule; assignments that already break it
+// are not touched and show up in the violations report.
+func (s *sodService) CreateConstraint(ctx context.Context, req *dto.CreateSoDConstraintRequest, createdBy string) (*dto.SoDConstraintDTO, error) {
+	leftID, err := uuid.Parse(req.LeftID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid left_id")
+	}
+	rightID, err := uuid.Parse(req.RightID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid right_id")
+	}
+	if leftID == rightID {
+		return nil, errors.NewValidationError("left_id and right_id must differ")
+	}
+	if req.Kind != model.SoDKindRole && req.Kind != model.SoDKindPermission {
+		return nil, errors.NewValidationError("kind must be role or permission")
+	}
+	schoolID, err := parseOptionalUUID(req.SchoolID, "school_id")
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[uuid.UUID]string)
+	left, err := s.sideName(ctx, req.Kind, leftID, names)
+	if err != nil {
+		return nil, err
+	}
+	right, err := s.sideName(ctx, req.Kind, rightID, names)
+	if err != nil {
+		return nil, err
+	}
+	if left == "" || right == "" {
+		return nil, errors.NewNotFoundError(req.Kind)
+	}
+
+	exists, err := s.constraintRepo.Exists(ctx, req.Kind, leftID, rightID, schoolID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("check sod constraint", err)
+	}
+	if exists {
+		return nil, errors.NewAlreadyExistsError("sod_constraint")
+	}
+
+	now := time.Now()
+	constraint := &model.SoDConstraint{
+		ID:          uuid.New(),
+		Name:        req.Name,
+		Description: req.Description,
+		Kind:        req.Kind,
+		LeftID:      leftID,
+		RightID:     rightID,
+		SchoolID:    schoolID,
+		IsActive:    true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if cid, err := uuid.Parse(createdBy); err == nil {
+		constraint.CreatedBy = &cid
+	}
+	if err := s.constraintRepo.Create(ctx, constraint); err != nil {
+		return nil, errors.NewDatabaseError("create sod constraint", err)
+	}
+
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action:       "create",
+		ResourceType: "sod_constraint",
+		ResourceID:   constraint.ID.String(),
+		Severity:     audit.SeverityCritical,
+		Category:     audit.CategoryAdmin,
+		Metadata: map[string]interface{}{
+			"name":      constraint.Name,
+			"kind":      constraint.Kind,
+			"left":      left,
+			"right":     right,
+			"school_id": uuidString(schoolID),
+		},
+	})
+	s.logger.Info("sod constraint created", "entity_type", "sod_constraint", "constraint_id", constraint.ID.String(), "kind", constraint.Kind, "left", left, "right", right)
+
+	return toSoDConstraintDTO(constraint, left, right), nil
+}
+
+func (s *sodService) DeleteConstraint(ctx context.Context, id string) error {
+	cid, err := uuid.Parse(id)
+	if err != nil {
+		return errors.NewValidationError("invalid constraint ID")
+	}
+	constraint, err := s.constraintRepo.FindByID(ctx, cid)
+	if err != nil {
+		return errors.NewDatabaseError("find sod constraint", err)
+	}
+	if constraint == nil {
+		return errors.NewNotFoundError("sod_constraint")
+	}
+	if err := s.constraintRepo.Deactivate(ctx, cid); err != nil {
+		return errors.NewDatabaseError("delete sod constraint", err)
+	}
+
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action:       "delete",
+		ResourceType: "sod_constraint",
+		ResourceID:   id,
+		Severity:     audit.SeverityCritical,
+		Category:     audit.CategoryAdmin,
+		Metadata:     map[string]interface{}{"name": constraint.Name, "kind": constraint.Kind},
+	})
+	s.logger.Info("sod constraint deleted", "entity_type", "sod_constraint", "constraint_id", id)
+	return nil
+}
+
+func (s *sodService) CheckGrant(ctx context.Context, userRole *entities.UserRole, pending ...*entities.UserRole) error {
+	constraints, err := s.constraintRepo.FindActive(ctx)
+	if err != nil {
+		return errors.NewDatabaseError("list sod constraints", err)
+	}
+	if len(constraints) == 0 {
+		return nil
+	}
+
+	current, err := s.userRoleRepo.FindByUser(ctx, userRole.UserID)
+	if err != nil {
+		return errors.NewDatabaseError("find user roles", err)
+	}
+	assignments := append(activeAssignments(current, time.Now()), pending...)
+	assignments = append(assignments, userRole)
+
+	perms := make(map[uuid.UUID]map[uuid.UUID]bool)
+	if hasPermissionConstraint(constraints) {
+		if err := s.loadRolePermissions(ctx, assignments, perms); err != nil {
+			return err
+		}
+	}
+
+	for _, c := range constraints {
+		for _, other := range assignments {
+			if _, ok := sodConflict(c, userRole, other, perms); ok {
+				return errors.NewConflictError("role assignment violates separation-of-duties constraint: " + c.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *sodService) CheckRolePermissions(ctx context.Context, roleID uuid.UUID, permissionIDs []uuid.UUID) error {
+	constraints, err := s.constraintRepo.FindActive(ctx)
+	if err != nil {
+		return errors.NewDatabaseError("list sod constraints", err)
+	}
+	if !hasPermissionConstraint(constraints) {
+		return nil
+	}
+
+	next := make(map[uuid.UUID]bool, len(permissionIDs))
+	for _, pid := range permissionIDs {
+		next[pid] = true
+	}
+	// A global constraint is broken by the role definition itself
+	for _, c := range constraints {
+		if c.Kind == model.SoDKindPermission && c.SchoolID == nil && next[c.LeftID] && next[c.RightID] {
+			return errors.NewConflictError("role permissions violate separation-of-duties constraint: " + c.Name)
+		}
+	}
+
+	holders, err := s.userRoleRepo.FindActiveByRole(ctx, roleID)
+	if err != nil {
+		return errors.NewDatabaseError("find role assignments", err)
+	}
+	now := time.Now()
+	perms := map[uuid.UUID]map[uuid.UUID]bool{roleID: next}
+	checked := make(map[uuid.UUID]bool)
+	for _, holder := range activeAssignments(holders, now) {
+		if checked[holder.UserID] {
+			continue
+		}
+		checked[holder.UserID] = true
+
+		userRoles, err := s.userRoleRepo.FindByUser(ctx, holder.UserID)
+		if err != nil {
+			return errors.NewDatabaseError("find user roles", err)
+		}
+		assignments := activeAssignments(userRoles, now)
+		if err := s.loadRolePermissions(ctx, assignments, perms); err != nil {
+			return err
+		}
+		for _, c := range constraints {
+			if c.Kind != model.SoDKindPermission {
+				continue
+			}
+			for _, a := range assignments {
+				if a.RoleID != roleID {
+					continue
+				}
+				for _, b := range assignments {
+					if _, ok := sodConflict(c, a, b, perms); ok {
+						return errors.NewConflictError("role permissions violate separation-of-duties constraint " + c.Name + " for user " + a.UserID.String())
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Report evaluates every active constraint against the current assignments.
+// With a school filter only violations effective in that school are listed;
+// violations between platform-wide assignments apply to every school.
+func (s *sodService) Report(ctx context.Context, schoolID string) (*dto.SoDReportResponse, error) {
+	var schoolFilter *uuid.UUID
+	if schoolID != "" {
+		sid, err := uuid.Parse(schoolID)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid school_id")
+		}
+		schoolFilter = &sid
+	}
+
+	constraints, err := s.constraintRepo.FindActive(ctx)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list sod constraints", err)
+	}
+	resp := &dto.SoDReportResponse{Violations: []*dto.SoDViolationDTO{}, GeneratedAt: time.Now().UTC().Format(time.RFC3339)}
+	if len(constraints) == 0 {
+		return resp, nil
+	}
+
+	perms := make(map[uuid.UUID]map[uuid.UUID]bool)
+	involved := make(map[uuid.UUID]bool)
+	for _, c := range constraints {
+		if c.Kind == model.SoDKindRole {
+			involved[c.LeftID] = true
+			involved[c.RightID] = true
+		}
+	}
+	if hasPermissionConstraint(constraints) {
+		roles, _, err := s.roleRepo.FindAll(ctx, sharedrepo.ListFilters{})
+		if err != nil {
+			return nil, errors.NewDatabaseError("list roles", err)
+		}
+		for _, role := range roles {
+			rolePerms, err := s.permissionRepo.FindByRole(ctx, role.ID)
+			if err != nil {
+				return nil, errors.NewDatabaseError("find role permissions", err)
+			}
+			set := make(map[uuid.UUID]bool, len(rolePerms))
+			for _, p := range rolePerms {
+				set[p.ID] = true
+			}
+			perms[role.ID] = set
+			for _, c := range constraints {
+				if c.Kind == model.SoDKindPermission && (set[c.LeftID] || set[c.RightID]) {
+					involved[role.ID] = true
+				}
+			}
+		}
+	}
+
+	now := time.Now()
+	byUser := make(map[uuid.UUID][]*entities.UserRole)
+	var users []uuid.UUID
+	for roleID := range involved {
+		holders, err := s.userRoleRepo.FindActiveByRole(ctx, roleID)
+		if err != nil {
+			return nil, errors.NewDatabaseError("find role assignments", err)
+		}
+		for _, ur := range activeAssignments(holders, now) {
+			if _, ok := byUser[ur.UserID]; !ok {
+				users = append(users, ur.UserID)
+			}
+			byUser[ur.UserID] = append(byUser[ur.UserID], ur)
+		}
+	}
+
+	for _, userID := range users {
+		assignments := byUser[userID]
+		for i, a := range assignments {
+			for _, b := range assignments[i:] {
+				for _, c := range constraints {
+					school, ok := sodConflict(c, a, b, perms)
+					if !ok || (schoolFilter != nil && school != nil && *school != *schoolFilter) {
+						continue
+					}
+					resp.Violations = append(resp.Violations, toSoDViolationDTO(c, a, b, school, perms))
+				}
+			}
+		}
+	}
+	resp.Total = len(resp.Violations)
+	return resp, nil
+}
+
+// sideName resolves the role or permission name of a constraint side, caching
+// lookups in names. It returns an empty name when the entity does not exist.
+func (s *sodService) sideName(ctx context.Context, kind string, id uuid.UUID, names map[uuid.UUID]string) (string, error) {
+	if name, ok := names[id]; ok {
+		return name, nil
+	}
+	var name string
+	if kind == model.SoDKindRole {
+		role, err := s.roleRepo.FindByID(ctx, id)
+		if err != nil {
+			return "", errors.NewDatabaseError("find role", err)
+		}
+		if role != nil {
+			name = role.Name
+		}
+	} else {
+		perm, err := s.permissionRepo.FindByID(ctx, id)
+		if err != nil {
+			return "", errors.NewDatabaseError("find permission", err)
+		}
+		if perm != nil {
+			name = perm.Name
+		}
+	}
+	names[id] = name
+	return name, nil
+}
+
+// loadRolePermissions fills perms with the permission set of every role in
+// assignments that is not already loaded.
+func (s *sodService) loadRolePermissions(ctx context.Context, assignments []*entities.UserRole, perms map[uuid.UUID]map[uuid.UUID]bool) error {
+	for _, ur := range assignments {
+		if _, ok := perms[ur.RoleID]; ok {
+			continue
+		}
+		rolePerms, err := s.permissionRepo.FindByRole(ctx, ur.RoleID)
+		if err != nil {
+			return errors.NewDatabaseError("find role permissions", err)
+		}
+		set := make(map[uuid.UUID]bool, len(rolePerms))
+		for _, p := range rolePerms {
+			set[p.ID] = true
+		}
+		perms[ur.RoleID] = set
+	}
+	return nil
+}
+
+// sodConflict reports whether the pair of assignments violates the constraint
+// and, if so, the school where the violation takes effect (nil when both
+// assignments are platform-wide). a and b may be the same assignment, which
+// catches a single role carrying both excluded permissions.
+func sodConflict(c *model.SoDConstraint, a, b *entities.UserRole, perms map[uuid.UUID]map[uuid.UUID]bool) (*uuid.UUID, bool) {
+	school, ok := sodSharedSchool(a.SchoolID, b.SchoolID)
+	if !ok {
+		return nil, false
+	}
+	if c.SchoolID != nil && school != nil && *school != *c.SchoolID {
+		return nil, false
+	}
+	switch c.Kind {
+	case model.SoDKindRole:
+		if (a.RoleID == c.LeftID && b.RoleID == c.RightID) || (a.RoleID == c.RightID && b.RoleID == c.LeftID) {
+			return school, true
+		}
+	case model.SoDKindPermission:
+		pa, pb := perms[a.RoleID], perms[b.RoleID]
+		if (pa[c.LeftID] && pb[c.RightID]) || (pa[c.RightID] && pb[c.LeftID]) {
+			return school, true
+		}
+	}
+	return nil, false
+}
+
+// sodSharedSchool returns the school two assignments overlap in. Assignments
+// without a school apply everywhere and overlap with any other.
+func sodSharedSchool(a, b *uuid.UUID) (*uuid.UUID, bool) {
+	switch {
+	case a == nil:
+		return b, true
+	case b == nil:
+		return a, true
+	case *a == *b:
+		return a, true
+	}
+	return nil, false
+}
+
+func hasPermissionConstraint(constraints []*model.SoDConstraint) bool {
+	for _, c := range constraints {
+		if c.Kind == model.SoDKindPermission {
+			return true
+		}
+	}
+	return false
+}
+
+// activeAssignments drops assignments whose expiry already passed but that the
+// sweeper has not deactivated yet.
+func activeAssignments(userRoles []*entities.UserRole, now time.Time) []*entities.UserRole {
+	active := make([]*entities.UserRole, 0, len(userRoles))
+	for _, ur := range userRoles {
+		if ur.ExpiresAt != nil && ur.ExpiresAt.Before(now) {
+			continue
+		}
+		active = append(active, ur)
+	}
+	return active
+}
+
+func toSoDConstraintDTO(c *model.SoDConstraint, leftName, rightName string) *dto.SoDConstraintDTO {
+	return &dto.SoDConstraintDTO{
+		ID:          c.ID.String(),
+		Name:        c.Name,
+		Description: c.Description,
+		Kind:        c.Kind,
+		LeftID:      c.LeftID.String(),
+		LeftName:    leftName,
+		RightID:     c.RightID.String(),
+		RightName:   rightName,
+		SchoolID:    uuidString(c.SchoolID),
+		CreatedBy:   uuidString(c.CreatedBy),
+		CreatedAt:   c.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// toSoDViolationDTO orders the pair so the left assignment matches the
+// constraint's left side.
+func toSoDViolationDTO(c *model.SoDConstraint, a, b *entities.UserRole, school *uuid.UUID, perms map[uuid.UUID]map[uuid.UUID]bool) *dto.SoDViolationDTO {
+	swap := a.RoleID == c.RightID
+	if c.Kind == model.SoDKindPermission {
+		swap = !(perms[a.RoleID][c.LeftID] && perms[b.RoleID][c.RightID])
+	}
+	if swap {
+		a, b = b, a
+	}
+	return &dto.SoDViolationDTO{
+		ConstraintID:    c.ID.String(),
+		ConstraintName:  c.Name,
+		Kind:            c.Kind,
+		UserID:          a.UserID.String(),
+		SchoolID:        uuidString(school),
+		LeftUserRoleID:  a.ID.String(),
+		LeftRoleID:      a.RoleID.String(),
+		RightUserRoleID: b.ID.String(),
+		RightRoleID:     b.RoleID.String(),
+	}
+}
@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedErrors "github.com/EduGoGroup/edugo-shared/common/errors"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+func newSoDService(constraints []*model.SoDConstraint, roleRepo *mockRoleRepo, permRepo *mockPermissionRepo, urRepo *mockUserRoleRepo) SoDService {
+	repo := &mockSoDConstraintRepo{findActiveFn: func(ctx context.Context) ([]*model.SoDConstraint, error) { return constraints, nil }}
+	return NewSoDService(repo, roleRepo, permRepo, urRepo, &mockLogger{}, &mockAuditLogger{})
+}
+
+func TestSoDService_CheckGrant(t *testing.T) {
+	ctx := context.Background()
+	userID, cashier, auditor := uuid.New(), uuid.New(), uuid.New()
+	schoolA, schoolB := uuid.New(), uuid.New()
+	roleConstraint := &model.SoDConstraint{ID: uuid.New(), Name: "cajero-auditor", Kind: model.SoDKindRole, LeftID: cashier, RightID: auditor}
+	holding := func(schoolID *uuid.UUID) *mockUserRoleRepo {
+		return &mockUserRoleRepo{findByUserFn: func(ctx context.Context, id uuid.UUID) ([]*entities.UserRole, error) {
+			return []*entities.UserRole{{ID: uuid.New(), UserID: userID, RoleID: cashier, SchoolID: schoolID, IsActive: true}}, nil
+		}}
+	}
+
+	t.Run("rechaza un rol excluyente en la misma escuela", func(t *testing.T) {
+		svc := newSoDService([]*model.SoDConstraint{roleConstraint}, &mockRoleRepo{}, &mockPermissionRepo{}, holding(&schoolA))
+		err := svc.CheckGrant(ctx, &entities.UserRole{ID: uuid.New(), UserID: userID, RoleID: auditor, SchoolID: &schoolA})
+		assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+	})
+
+	t.Run("permite el rol excluyente en otra escuela", func(t *testing.T) {
+		svc := newSoDService([]*model.SoDConstraint{roleConstraint}, &mockRoleRepo{}, &mockPermissionRepo{}, holding(&schoolA))
+		if err := svc.CheckGrant(ctx, &entities.UserRole{ID: uuid.New(), UserID: userID, RoleID: auditor, SchoolID: &schoolB}); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+	})
+
+	t.Run("una asignación de plataforma entra en conflicto con cualquier escuela", func(t *testing.T) {
+		svc := newSoDService([]*model.SoDConstraint{roleConstraint}, &mockRoleRepo{}, &mockPermissionRepo{}, holding(nil))
+		err := svc.CheckGrant(ctx, &entities.UserRole{ID: uuid.New(), UserID: userID, RoleID: auditor, SchoolID: &schoolB})
+		assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+	})
+
+	t.Run("una restricción por escuela no aplica en otras escuelas", func(t *testing.T) {
+		scoped := *roleConstraint
+		scoped.SchoolID = &schoolA
+		svc := newSoDService([]*model.SoDConstraint{&scoped}, &mockRoleRepo{}, &mockPermissionRepo{}, holding(&schoolB))
+		if err := svc.CheckGrant(ctx, &entities.UserRole{ID: uuid.New(), UserID: userID, RoleID: auditor, SchoolID: &schoolB}); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+	})
+
+	t.Run("rechaza permisos excluyentes repartidos entre roles", func(t *testing.T) {
+		approve, pay := uuid.New(), uuid.New()
+		permConstraint := &model.SoDConstraint{ID: uuid.New(), Name: "aprobar-pagar", Kind: model.SoDKindPermission, LeftID: approve, RightID: pay}
+		permRepo := &mockPermissionRepo{findByRoleFn: func(ctx context.Context, roleID uuid.UUID) ([]*entities.Permission, error) {
+			if roleID == cashier {
+				return []*entities.Permission{{ID: pay}}, nil
+			}
+			return []*entities.Permission{{ID: approve}}, nil
+		}}
+		svc := newSoDService([]*model.SoDConstraint{permConstraint}, &mockRoleRepo{}, permRepo, holding(&schoolA))
+		err := svc.CheckGrant(ctx, &entities.UserRole{ID: uuid.New(), UserID: userID, RoleID: auditor, SchoolID: &schoolA})
+		assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+	})
+}
+
+func TestSoDService_CheckRolePermissions(t *testing.T) {
+	ctx := context.Background()
+	roleID, approve, pay := uuid.New(), uuid.New(), uuid.New()
+	constraint := &model.SoDConstraint{ID: uuid.New(), Name: "aprobar-pagar", Kind: model.SoDKindPermission, LeftID: approve, RightID: pay}
+
+	t.Run("rechaza un rol con ambos permisos de una restricción global", func(t *testing.T) {
+		svc := newSoDService([]*model.SoDConstraint{constraint}, &mockRoleRepo{}, &mockPermissionRepo{}, &mockUserRoleRepo{})
+		err := svc.CheckRolePermissions(ctx, roleID, []uuid.UUID{approve, pay})
+		assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+	})
+
+	t.Run("rechaza si un titular del rol ya tiene el permiso opuesto", func(t *testing.T) {
+		userID, otherRole, school := uuid.New(), uuid.New(), uuid.New()
+		assignments := []*entities.UserRole{
+			{ID: uuid.New(), UserID: userID, RoleID: roleID, SchoolID: &school},
+			{ID: uuid.New(), UserID: userID, RoleID: otherRole, SchoolID: &school},
+		}
+		urRepo := &mockUserRoleRepo{
+			findActiveByRoleFn: func(ctx context.Context, id uuid.UUID) ([]*entities.UserRole, error) { return assignments[:1], nil },
+			findByUserFn:       func(ctx context.Context, id uuid.UUID) ([]*entities.UserRole, error) { return assignments, nil },
+		}
+		permRepo := &mockPermissionRepo{findByRoleFn: func(ctx context.Context, id uuid.UUID) ([]*entities.Permission, error) {
+			return []*entities.Permission{{ID: pay}}, nil
+		}}
+		svc := newSoDService([]*model.SoDConstraint{constraint}, &mockRoleRepo{}, permRepo, urRepo)
+		err := svc.CheckRolePermissions(ctx, roleID, []uuid.UUID{approve})
+		assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+	})
+
+	t.Run("sin restricciones de permisos no consulta asignaciones", func(t *testing.T) {
+		urRepo := &mockUserRoleRepo{findActiveByRoleFn: func(ctx context.Context, id uuid.UUID) ([]*entities.UserRole, error) {
+			t.Error("no debe consultar titulares sin restricciones de permisos")
+			return nil, nil
+		}}
+		svc := newSoDService(nil, &mockRoleRepo{}, &mockPermissionRepo{}, urRepo)
+		if err := svc.CheckRolePermissions(ctx, roleID, []uuid.UUID{approve, pay}); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+	})
+}
+
+func TestRoleService_GrantRoleToUser_SoDViolation(t *testing.T) {
+	ctx := context.Background()
+	userID, cashier, auditor := uuid.New(), uuid.New(), uuid.New()
+	roleRepo := &mockRoleRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Role, error) {
+		return &entities.Role{ID: id, Name: "auditor", Scope: "platform", IsActive: true}, nil
+	}}
+	urRepo := &mockUserRoleRepo{
+		findByUserFn: func(ctx context.Context, id uuid.UUID) ([]*entities.UserRole, error) {
+			return []*entities.UserRole{{ID: uuid.New(), UserID: userID, RoleID: cashier, IsActive: true}}, nil
+		},
+		grantFn: func(ctx context.Context, ur *entities.UserRole) error {
+			t.Error("no debe otorgar un rol que viola una restricción SoD")
+			return nil
+		},
+	}
+	sod := newSoDService([]*model.SoDConstraint{{ID: uuid.New(), Name: "cajero-auditor", Kind: model.SoDKindRole, LeftID: cashier, RightID: auditor}}, roleRepo, &mockPermissionRepo{}, urRepo)
+	svc := NewRoleService(roleRepo, &mockPermissionRepo{}, urRepo, &mockRolePermRepo{}, NewMenuService(&mockResourceRepo{}, &mockResourceScreenRepo{}, &mockTranslationRepo{}, &mockLogger{}), nil, sod, &mockLogger{}, &mockAuditLogger{})
+
+	_, err := svc.GrantRoleToUser(ctx, userID.String(), &dto.GrantRoleRequest{RoleID: auditor.String()}, "")
+	assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+}
+
+func TestSoDService_Report(t *testing.T) {
+	ctx := context.Background()
+	cashier, auditor, approve, pay := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	userA, userB, school := uuid.New(), uuid.New(), uuid.New()
+	constraints := []*model.SoDConstraint{
+		{ID: uuid.New(), Name: "cajero-auditor", Kind: model.SoDKindRole, LeftID: cashier, RightID: auditor},
+		{ID: uuid.New(), Name: "aprobar-pagar", Kind: model.SoDKindPermission, LeftID: approve, RightID: pay},
+	}
+	byRole := map[uuid.UUID][]*entities.UserRole{
+		// userA holds both roles in the same school; userB only one
+		cashier: {
+			{ID: uuid.New(), UserID: userA, RoleID: cashier, SchoolID: &school},
+			{ID: uuid.New(), UserID: userB, RoleID: cashier, SchoolID: &school},
+		},
+		auditor: {{ID: uuid.New(), UserID: userA, RoleID: auditor, SchoolID: &school}},
+	}
+	roleRepo := &mockRoleRepo{findAllFn: func(ctx context.Context, filters sharedrepo.ListFilters) ([]*entities.Role, int, error) {
+		return []*entities.Role{{ID: cashier}, {ID: auditor}}, 2, nil
+	}}
+	permRepo := &mockPermissionRepo{findByRoleFn: func(ctx context.Context, id uuid.UUID) ([]*entities.Permission, error) {
+		if id == auditor {
+			// a single role carrying both excluded permissions
+			return []*entities.Permission{{ID: approve}, {ID: pay}}, nil
+		}
+		return nil, nil
+	}}
+	urRepo := &mockUserRoleRepo{findActiveByRoleFn: func(ctx context.Context, id uuid.UUID) ([]*entities.UserRole, error) {
+		return byRole[id], nil
+	}}
+	svc := newSoDService(constraints, roleRepo, permRepo, urRepo)
+
+	t.Run("lista violaciones de roles y de permisos", func(t *testing.T) {
+		resp, err := svc.Report(ctx, "")
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if resp.Total != 2 {
+			t.Fatalf("esperaba 2 violaciones, obtuvo %d: %+v", resp.Total, resp.Violations)
+		}
+		for _, v := range resp.Violations {
+			if v.UserID != userA.String() {
+				t.Errorf("violación para usuario inesperado: %+v", v)
+			}
+			if v.Kind == model.SoDKindRole && v.LeftRoleID != cashier.String() {
+				t.Errorf("el lado izquierdo debe corresponder a la restricción: %+v", v)
+			}
+		}
+	})
+
+	t.Run("filtra por escuela", func(t *testing.T) {
+		resp, err := svc.Report(ctx, uuid.New().String())
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if resp.Total != 0 {
+			t.Errorf("no esperaba violaciones en otra escuela, obtuvo %+v", resp.Violations)
+		}
+	})
+}
+
+func TestSoDService_CreateConstraint(t *testing.T) {
+	ctx := context.Background()
+	left, right := uuid.New(), uuid.New()
+	roleRepo := &mockRoleRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Role, error) {
+		if id == left || id == right {
+			return &entities.Role{ID: id, Name: "rol-" + id.String()[:4]}, nil
+		}
+		return nil, nil
+	}}
+
+	t.Run("crea la restricción", func(t *testing.T) {
+		var created *model.SoDConstraint
+		repo := &mockSoDConstraintRepo{createFn: func(ctx context.Context, c *model.SoDConstraint) error {
+			created = c
+			return nil
+		}}
+		svc := NewSoDService(repo, roleRepo, &mockPermissionRepo{}, &mockUserRoleRepo{}, &mockLogger{}, &mockAuditLogger{})
+		resp, err := svc.CreateConstraint(ctx, &dto.CreateSoDConstraintRequest{Name: "x", Kind: model.SoDKindRole, LeftID: left.String(), RightID: right.String()}, uuid.New().String())
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if created == nil || !created.IsActive || resp.LeftName == "" || resp.RightName == "" {
+			t.Errorf("restricción incorrecta: %+v", resp)
+		}
+	})
+
+	t.Run("rechaza el mismo elemento en ambos lados", func(t *testing.T) {
+		svc := NewSoDService(&mockSoDConstraintRepo{}, roleRepo, &mockPermissionRepo{}, &mockUserRoleRepo{}, &mockLogger{}, &mockAuditLogger{})
+		_, err := svc.CreateConstraint(ctx, &dto.CreateSoDConstraintRequest{Name: "x", Kind: model.SoDKindRole, LeftID: left.String(), RightID: left.String()}, "")
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	})
+
+	t.Run("retorna not found si el rol no existe", func(t *testing.T) {
+		svc := NewSoDService(&mockSoDConstraintRepo{}, roleRepo, &mockPermissionRepo{}, &mockUserRoleRepo{}, &mockLogger{}, &mockAuditLogger{})
+		_, err := svc.CreateConstraint(ctx, &dto.CreateSoDConstraintRequest{Name: "x", Kind: model.SoDKindRole, LeftID: left.String(), RightID: uuid.New().String()}, "")
+		assertAppError(t, err, sharedErrors.ErrorCodeNotFound)
+	})
+
+	t.Run("rechaza duplicados", func(t *testing.T) {
+		repo := &mockSoDConstraintRepo{existsFn: func(ctx context.Context, kind string, l, r uuid.UUID, s *uuid.UUID) (bool, error) { return true, nil }}
+		svc := NewSoDService(repo, roleRepo, &mockPermissionRepo{}, &mockUserRoleRepo{}, &mockLogger{}, &mockAuditLogger{})
+		_, err := svc.CreateConstraint(ctx, &dto.CreateSoDConstraintRequest{Name: "x", Kind: model.SoDKindRole, LeftID: left.String(), RightID: right.String()}, "")
+		assertAppError(t, err, sharedErrors.ErrorCodeAlreadyExists)
+	})
+}
+
+func TestRoleService_AssignPermission_SoDViolation(t *testing.T) {
+	ctx := context.Background()
+	roleID, approve, pay := uuid.New(), uuid.New(), uuid.New()
+	roleRepo := &mockRoleRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Role, error) {
+		return &entities.Role{ID: id, Name: "tesorero", Scope: "school", IsActive: true}, nil
+	}}
+	permRepo := &mockPermissionRepo{
+		findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Permission, error) {
+			return &entities.Permission{ID: id, Name: "payments:pay", IsActive: true}, nil
+		},
+		findByRoleFn: func(ctx context.Context, id uuid.UUID) ([]*entities.Permission, error) {
+			return []*entities.Permission{{ID: approve}}, nil
+		},
+	}
+	rpRepo := &mockRolePermRepo{assignFn: func(ctx context.Context, rp *entities.RolePermission) error {
+		t.Error("no debe asignar un permiso que viola una restricción SoD")
+		return nil
+	}}
+	sod := newSoDService([]*model.SoDConstraint{{ID: uuid.New(), Name: "aprobar-pagar", Kind: model.SoDKindPermission, LeftID: approve, RightID: pay}},
+		roleRepo, permRepo, &mockUserRoleRepo{})
+	svc := NewRoleService(roleRepo, permRepo, &mockUserRoleRepo{}, rpRepo, NewMenuService(&mockResourceRepo{}, &mockResourceScreenRepo{}, &mockTranslationRepo{}, &mockLogger{}), nil, sod, &mockLogger{}, &mockAuditLogger{})
+
+	_, err := svc.AssignPermission(ctx, roleID.String(), &dto.AssignPermissionRequest{PermissionID: pay.String()})
+	assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+}
+
+func TestRoleGrantApprovalService_Approve_SoDViolation(t *testing.T) {
+	ctx := context.Background()
+	userID, cashier, auditor, requester, approver := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	roleRepo := &mockRoleRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Role, error) {
+		return &entities.Role{ID: id, Name: "auditor", Scope: "platform", IsActive: true}, nil
+	}}
+	// the user was granted the excluded role after requesting this one
+	urRepo := &mockUserRoleRepo{findByUserFn: func(ctx context.Context, id uuid.UUID) ([]*entities.UserRole, error) {
+		return []*entities.UserRole{{ID: uuid.New(), UserID: userID, RoleID: cashier, IsActive: true}}, nil
+	}}
+	req := &model.RoleGrantRequest{
+		ID: uuid.New(), UserID: userID, RoleID: auditor, RequestedBy: &requester,
+		Status: model.GrantRequestPending, ExpiresAt: time.Now().Add(time.Hour),
+	}
+	sod := newSoDService([]*model.SoDConstraint{{ID: uuid.New(), Name: "cajero-auditor", Kind: model.SoDKindRole, LeftID: cashier, RightID: auditor}}, roleRepo, &mockPermissionRepo{}, urRepo)
+	svc := NewRoleGrantApprovalService(&mockRoleGrantPolicyRepo{},
+		&mockRoleGrantRequestRepo{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*model.RoleGrantRequest, error) { return req, nil },
+			decideFn: func(ctx context.Context, r *model.RoleGrantRequest, grant *entities.UserRole) (bool, error) {
+				t.Error("no debe aprobar un rol que viola una restricción SoD")
+				return true, nil
+			},
+		},
+		roleRepo, urRepo, sod, nil, &mockLogger{}, &mockAuditLogger{}, 72*time.Hour)
+
+	_, err := svc.Approve(ctx, req.ID.String(), &dto.DecideRoleGrantRequest{}, approver.String())
+	assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+}
+
+func TestIAMCatalogService_Apply_SoDViolation(t *testing.T) {
+	m, _ := ParseIAMManifest([]byte(testManifestYAML), "yaml")
+	m.Permissions = append(m.Permissions, dto.IAMManifestPermission{Name: "users:write", DisplayName: "Editar usuarios", Resource: "users", Action: "write", Scope: "platform"})
+	m.Roles[0].Permissions = append(m.Roles[0].Permissions, "users:write")
+	usersID, readID, writeID := uuid.New(), uuid.New(), uuid.New()
+	snap := &repository.IAMCatalogSnapshot{
+		Resources: []*entities.Resource{{ID: usersID, Key: "users", DisplayName: "Usuarios", Scope: "platform", IsActive: true}},
+		Permissions: []*entities.Permission{
+			{ID: readID, Name: "users:read", DisplayName: "Ver usuarios", ResourceID: usersID, Action: "read", Scope: "platform", IsActive: true},
+			{ID: writeID, Name: "users:write", DisplayName: "Editar usuarios", ResourceID: usersID, Action: "write", Scope: "platform", IsActive: true},
+		},
+	}
+	sod := newSoDService([]*model.SoDConstraint{{ID: uuid.New(), Name: "leer-escribir", Kind: model.SoDKindPermission, LeftID: readID, RightID: writeID}},
+		&mockRoleRepo{}, &mockPermissionRepo{}, &mockUserRoleRepo{})
+	svc := NewIAMCatalogService(&mockIAMCatalogRepo{
+		snapshotFn: func(ctx context.Context) (*repository.IAMCatalogSnapshot, error) { return snap, nil },
+		applyFn: func(ctx context.Context, changes *repository.IAMCatalogChangeSet) error {
+			t.Error("no debe aplicar un manifiesto que viola una restricción SoD")
+			return nil
+		},
+	}, sod, &mockLogger{}, &mockAuditLogger{})
+
+	_, err := svc.Apply(context.Background(), m, false, false)
+	assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+}
@@ -1,10 +1,13 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"io"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -26,8 +29,9 @@ var syncMetrics = metrics.New("edugo-api-iam-platform")
 
 // SyncService defines the sync service interface
 type SyncService interface {
-	GetFullBundle(ctx context.Context, userID string, activeContext *auth.UserContext, buckets []string) (*dto.SyncBundleResponse, error)
-	GetDeltaSync(ctx context.Context, userID string, activeContext *auth.UserContext, clientHashes map[string]string) (*dto.DeltaSyncResponse, error)
+	GetFullBundle(ctx context.Context, userID string, activeContext *auth.UserContext, buckets []string, locale string) (*dto.SyncBundleResponse, error)
+	WriteFullBundle(ctx context.Context, w io.Writer, userID string, activeContext *auth.UserContext, buckets []string, locale string) error
+	GetDeltaSync(ctx context.Context, userID string, activeContext *auth.UserContext, clientHashes map[string]string, locale string) (*dto.DeltaSyncResponse, error)
 }
 
 type syncService struct {
@@ -35,7 +39,7 @@ type syncService struct {
 	screenConfigService ScreenConfigService
 	authService         authService.AuthService
 	screenInstanceRepo  repository.ScreenInstanceRepository
-	schoolConceptRepo   repository.SchoolConceptRepository
+	glossaryService     GlossaryService
 	logger              logger.Logger
 }
 
@@ -45,7 +49,7 @@ func NewSyncService(
 	screenConfigService ScreenConfigService,
 	authSvc authService.AuthService,
 	screenInstanceRepo repository.ScreenInstanceRepository,
-	schoolConceptRepo repository.SchoolConceptRepository,
+	glossaryService GlossaryService,
 	logger logger.Logger,
 ) SyncService {
 	return &syncService{
@@ -53,7 +57,7 @@ func NewSyncService(
 		screenConfigService: screenConfigService,
 		authService:         authSvc,
 		screenInstanceRepo:  screenInstanceRepo,
-		schoolConceptRepo:   schoolConceptRepo,
+		glossaryService:     glossaryService,
 		logger:              logger,
 	}
 }
@@ -61,9 +65,17 @@ func NewSyncService(
 // GetFullBundle builds the sync bundle for a user.
 // If buckets is non-empty, only the specified buckets are loaded (e.g. ["menu","permissions","available_contexts","screens"]).
 // If buckets is empty, all buckets are loaded (backward compatible).
-func (s *syncService) GetFullBundle(ctx context.Context, userID string, activeContext *auth.UserContext, buckets []string) (*dto.SyncBundleResponse, error) {
+// Menu and screens are localized to locale and their hashes are per locale.
+func (s *syncService) GetFullBundle(ctx context.Context, userID string, activeContext *auth.UserContext, buckets []string, locale string) (*dto.SyncBundleResponse, error) {
 	start := time.Now()
+	bundle, err := s.buildBundle(ctx, userID, activeContext, buckets, locale, true)
+	syncMetrics.RecordBusinessOperation("sync", "full_bundle", time.Since(start), err)
+	return bundle, err
+}
 
+// buildBundle loads the requested buckets concurrently. The screens bucket is
+// only loaded when withScreens is set; WriteFullBundle streams it instead.
+func (s *syncService) buildBundle(ctx context.Context, userID string, activeContext *auth.UserContext, buckets []string, locale string, withScreens bool) (*dto.SyncBundleResponse, error) {
 	var (
 		mu      sync.Mutex
 		bundle  dto.SyncBundleResponse
@@ -73,6 +85,7 @@ func (s *syncService) GetFullBundle(ctx context.Context, userID string, activeCo
 
 	bundle.Hashes = hashes
 	bundle.Screens = screens
+	bundle.Locale = locale
 
 	// Build a set of requested buckets for fast lookup
 	bucketSet := make(map[string]bool, len(buckets))
@@ -86,18 +99,18 @@ func (s *syncService) GetFullBundle(ctx context.Context, userID string, activeCo
 	// 1. Menu
 	if loadAll || bucketSet["menu"] {
 		g.Go(func() error {
-			menu, err := s.menuService.GetMenuForUser(gCtx, activeContext.Permissions)
+			menu, err := s.menuService.GetMenuForUser(gCtx, activeContext.Permissions, locale)
 			if err != nil {
 				s.logger.Warn("sync: error fetching menu", "user_id", userID, "error", err)
 				mu.Lock()
 				bundle.Menu = []dto.MenuItemDTO{}
-				hashes["menu"] = hashJSON([]dto.MenuItemDTO{})
+				hashes["menu"] = hashLocalized(locale, []dto.MenuItemDTO{})
 				mu.Unlock()
 				return nil
 			}
 			mu.Lock()
 			bundle.Menu = menu.Items
-			hashes["menu"] = hashJSON(menu.Items)
+			hashes["menu"] = hashLocalized(locale, menu.Items)
 			mu.Unlock()
 			return nil
 		})
@@ -147,69 +160,34 @@ func (s *syncService) GetFullBundle(ctx context.Context, userID string, activeCo
 		})
 	}
 
-	// 4. Screens — uses ResolveAllScreens to avoid N+1 (2 queries total)
-	if loadAll || bucketSet["screens"] {
+	// 4. Screens — resolved page by page with the overrides of the active
+	// school and role merged in, and added to the bucket as they arrive
+	if withScreens && (loadAll || bucketSet["screens"]) {
 		g.Go(func() error {
-			allScreens, err := s.screenConfigService.ResolveAllScreens(gCtx)
+			err := s.eachScreen(gCtx, activeContext, locale, func(screenBundle *dto.ScreenBundle, hash string) error {
+				mu.Lock()
+				screens[screenBundle.ScreenKey] = screenBundle
+				hashes["screen:"+screenBundle.ScreenKey] = hash
+				mu.Unlock()
+				return nil
+			})
 			if err != nil {
 				s.logger.Warn("sync: error resolving screens", "user_id", userID, "error", err)
-				return nil
-			}
-
-			for _, resolved := range allScreens {
-				screenBundle := &dto.ScreenBundle{
-					ScreenKey:  resolved.ScreenKey,
-					ScreenName: resolved.ScreenName,
-					Pattern:    resolved.Pattern,
-					Version:    resolved.Version,
-					Template:   resolved.Template,
-					SlotData:   resolved.SlotData,
-					HandlerKey: resolved.HandlerKey,
-				}
-
-				hashKey := "screen:" + resolved.ScreenKey
-				hashVal := hashScreen(resolved.Version, resolved.UpdatedAt.UTC().Format(time.RFC3339Nano))
-
 				mu.Lock()
-				screens[resolved.ScreenKey] = screenBundle
-				hashes[hashKey] = hashVal
+				bundle.Complete = new(bool)
 				mu.Unlock()
 			}
 			return nil
 		})
 	}
 
-	// 5. Glossary
+	// 5. Glossary — platform defaults with the active school's terms on top
 	if loadAll || bucketSet["glossary"] {
 		g.Go(func() error {
-			if activeContext.SchoolID == "" {
-				mu.Lock()
-				bundle.Glossary = map[string]string{}
-				hashes["glossary"] = hashJSON(map[string]string{})
-				mu.Unlock()
-				return nil
-			}
-			schoolUUID, err := uuid.Parse(activeContext.SchoolID)
+			glossary, err := s.glossaryService.EffectiveGlossary(gCtx, activeContext.SchoolID)
 			if err != nil {
-				s.logger.Warn("sync: invalid school_id for glossary", "school_id", activeContext.SchoolID, "error", err)
-				mu.Lock()
-				bundle.Glossary = map[string]string{}
-				hashes["glossary"] = hashJSON(map[string]string{})
-				mu.Unlock()
-				return nil
-			}
-			concepts, err := s.schoolConceptRepo.FindBySchoolID(gCtx, schoolUUID)
-			if err != nil {
-				s.logger.Warn("sync: failed to load glossary", "error", err)
-				mu.Lock()
-				bundle.Glossary = map[string]string{}
-				hashes["glossary"] = hashJSON(map[string]string{})
-				mu.Unlock()
-				return nil
-			}
-			glossary := make(map[string]string, len(concepts))
-			for _, c := range concepts {
-				glossary[c.TermKey] = c.TermValue
+				s.logger.Warn("sync: failed to load glossary", "school_id", activeContext.SchoolID, "error", err)
+				glossary = map[string]string{}
 			}
 			mu.Lock()
 			bundle.Glossary = glossary
@@ -220,7 +198,6 @@ func (s *syncService) GetFullBundle(ctx context.Context, userID string, activeCo
 	}
 
 	if err := g.Wait(); err != nil {
-		syncMetrics.RecordBusinessOperation("sync", "full_bundle", time.Since(start), err)
 		return nil, fmt.Errorf("error building sync bundle: %w", err)
 	}
 
@@ -236,14 +213,112 @@ func (s *syncService) GetFullBundle(ctx context.Context, userID string, activeCo
 	if bundle.Glossary == nil {
 		bundle.Glossary = map[string]string{}
 	}
-
-	syncMetrics.RecordBusinessOperation("sync", "full_bundle", time.Since(start), nil)
 	return &bundle, nil
 }
 
+// eachScreen resolves the screens of the active context one at a time and
+// hands fn their bundle form and hash. The hash covers the effective screen,
+// so it differs per context when overrides apply, and per locale.
+func (s *syncService) eachScreen(ctx context.Context, activeContext *auth.UserContext, locale string, fn func(*dto.ScreenBundle, string) error) error {
+	opts := ResolveOptions{SchoolID: activeContext.SchoolID, RoleID: activeContext.RoleID, Locale: locale}
+	return s.screenConfigService.EachResolvedScreen(ctx, opts, func(resolved *CombinedScreenDTO) error {
+		screenBundle := toScreenBundle(resolved)
+		return fn(screenBundle, hashLocalized(locale, screenBundle))
+	})
+}
+
+// syncBundleHead encodes a dto.SyncBundleResponse without the screens and
+// hashes, which WriteFullBundle streams after it. The shadowing fields are
+// never set, so the encoder leaves both keys out.
+type syncBundleHead struct {
+	*dto.SyncBundleResponse
+	Screens *struct{} `json:"screens,omitempty"`
+	Hashes  *struct{} `json:"hashes,omitempty"`
+}
+
+// WriteFullBundle writes the same document as GetFullBundle to w, but
+// streams the screens bucket: each screen is encoded and written as soon as
+// it is resolved instead of holding the whole bucket in memory. Errors
+// loading the other buckets are returned before anything is written; after
+// that only write errors are returned, and a failure resolving screens ends
+// the bucket early and marks the document "complete": false like in
+// GetFullBundle.
+func (s *syncService) WriteFullBundle(ctx context.Context, w io.Writer, userID string, activeContext *auth.UserContext, buckets []string, locale string) error {
+	start := time.Now()
+	err := s.writeFullBundle(ctx, w, userID, activeContext, buckets, locale)
+	syncMetrics.RecordBusinessOperation("sync", "full_bundle_stream", time.Since(start), err)
+	return err
+}
+
+func (s *syncService) writeFullBundle(ctx context.Context, w io.Writer, userID string, activeContext *auth.UserContext, buckets []string, locale string) error {
+	bundle, err := s.buildBundle(ctx, userID, activeContext, buckets, locale, false)
+	if err != nil {
+		return err
+	}
+	head, err := json.Marshal(syncBundleHead{SyncBundleResponse: bundle})
+	if err != nil {
+		return fmt.Errorf("error encoding sync bundle: %w", err)
+	}
+	// leave the head object open; the streamed buckets close it
+	if _, err := w.Write(bytes.TrimSuffix(head, []byte("}"))); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, `,"screens":{`); err != nil {
+		return err
+	}
+
+	hashes := bundle.Hashes
+	complete := true
+	if len(buckets) == 0 || slices.Contains(buckets, "screens") {
+		first := true
+		var writeErr error
+		err := s.eachScreen(ctx, activeContext, locale, func(screenBundle *dto.ScreenBundle, hash string) error {
+			key, _ := json.Marshal(screenBundle.ScreenKey)
+			data, err := json.Marshal(screenBundle)
+			if err != nil {
+				return err
+			}
+			if !first {
+				key = append([]byte{','}, key...)
+			}
+			first = false
+			if _, writeErr = w.Write(append(append(key, ':'), data...)); writeErr != nil {
+				return writeErr
+			}
+			hashes["screen:"+screenBundle.ScreenKey] = hash
+			return nil
+		})
+		if writeErr != nil {
+			return writeErr
+		}
+		if err != nil {
+			s.logger.Warn("sync: error resolving screens", "user_id", userID, "error", err)
+			complete = false
+		}
+	}
+
+	encodedHashes, err := json.Marshal(hashes)
+	if err != nil {
+		return fmt.Errorf("error encoding sync bundle: %w", err)
+	}
+	if _, err := io.WriteString(w, `},"hashes":`); err != nil {
+		return err
+	}
+	if _, err := w.Write(encodedHashes); err != nil {
+		return err
+	}
+	if !complete {
+		if _, err := io.WriteString(w, `,"complete":false`); err != nil {
+			return err
+		}
+	}
+	_, err = io.WriteString(w, "}")
+	return err
+}
+
 // GetDeltaSync compares client hashes and returns only changed buckets
-func (s *syncService) GetDeltaSync(ctx context.Context, userID string, activeContext *auth.UserContext, clientHashes map[string]string) (*dto.DeltaSyncResponse, error) {
-	fullBundle, err := s.GetFullBundle(ctx, userID, activeContext, nil)
+func (s *syncService) GetDeltaSync(ctx context.Context, userID string, activeContext *auth.UserContext, clientHashes map[string]string, locale string) (*dto.DeltaSyncResponse, error) {
+	fullBundle, err := s.GetFullBundle(ctx, userID, activeContext, nil, locale)
 	if err != nil {
 		return nil, err
 	}
@@ -278,6 +353,7 @@ func (s *syncService) GetDeltaSync(ctx context.Context, userID string, activeCon
 	return &dto.DeltaSyncResponse{
 		Changed:   changed,
 		Unchanged: unchanged,
+		Locale:    locale,
 	}, nil
 }
 
@@ -306,6 +382,19 @@ func (s *syncService) extractBucketData(bundle *dto.SyncBundleResponse, key stri
 
 // Hash helpers
 
+// toScreenBundle is the sync bundle form of a resolved screen
+func toScreenBundle(resolved *CombinedScreenDTO) *dto.ScreenBundle {
+	return &dto.ScreenBundle{
+		ScreenKey:  resolved.ScreenKey,
+		ScreenName: resolved.ScreenName,
+		Pattern:    resolved.Pattern,
+		Version:    resolved.Version,
+		Template:   resolved.Template,
+		SlotData:   resolved.SlotData,
+		HandlerKey: resolved.HandlerKey,
+	}
+}
+
 func hashJSON(v interface{}) string {
 	data, err := json.Marshal(v)
 	if err != nil {
@@ -314,13 +403,19 @@ func hashJSON(v interface{}) string {
 	return fmt.Sprintf("%x", sha256.Sum256(data))
 }
 
+// hashLocalized hashes a localized bucket. The locale is part of the hash so
+// a client that switches language refetches the bucket even when no text of
+// it is translated.
+func hashLocalized(locale string, v interface{}) string {
+	return hashJSON(struct {
+		Locale string      `json:"locale"`
+		Data   interface{} `json:"data"`
+	}{locale, v})
+}
+
 func hashPermissions(permissions []string) string {
 	sorted := make([]string, len(permissions))
 	copy(sorted, permissions)
 	sort.Strings(sorted)
 	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(sorted, ","))))
 }
-
-func hashScreen(version int, updatedAt string) string {
-	return fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprintf("%d:%s", version, updatedAt))))
-}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	authDto "github.com/EduGoGroup/edugo-api-iam-platform/internal/auth/dto"
+	authService "github.com/EduGoGroup/edugo-api-iam-platform/internal/auth/service"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/auth"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+// stubContextsAuthService only answers GetAvailableContexts
+type stubContextsAuthService struct {
+	authService.AuthService
+	contexts []*authDto.UserContextDTO
+}
+
+func (s *stubContextsAuthService) GetAvailableContexts(ctx context.Context, userID string, currentContext *auth.UserContext) (*authDto.AvailableContextsResponse, error) {
+	return &authDto.AvailableContextsResponse{Available: s.contexts}, nil
+}
+
+var syncTestTemplateID = uuid.MustParse("00000000-0000-0000-0000-0000000000aa")
+
+// newSyncTestService serves one menu item, two screens, one context and one
+// glossary term. The instance ids sort like their screen keys, so the
+// screens are resolved in the order the encoder writes map keys.
+func newSyncTestService() SyncService {
+	return newSyncTestServiceWithInstances(nil)
+}
+
+// newSyncTestServiceWithInstances is newSyncTestService with the screen
+// instances listed by listInstances instead, when it is set.
+func newSyncTestServiceWithInstances(listInstances func(ctx context.Context, filter sharedrepo.ListFilters) ([]*entities.ScreenInstance, int, error)) SyncService {
+	resource := &entities.Resource{ID: uuid.New(), Key: "students", DisplayName: "Alumnos", IsMenuVisible: true, IsActive: true}
+	menu := NewMenuService(&mockResourceRepo{
+		findMenuVisibleFn: func(ctx context.Context) ([]*entities.Resource, error) {
+			return []*entities.Resource{resource}, nil
+		},
+	}, &mockResourceScreenRepo{}, &mockTranslationRepo{}, &mockLogger{})
+
+	tpl := &entities.ScreenTemplate{ID: syncTestTemplateID, Pattern: "list", Name: "Lista", Version: 2, IsActive: true, Definition: sampleDefinition()}
+	instances := []*entities.ScreenInstance{
+		{ID: uuid.MustParse("00000000-0000-0000-0000-000000000001"), ScreenKey: "grades-list", TemplateID: tpl.ID,
+			Name: "Notas & medias", SlotData: json.RawMessage(`{"columns":["grade"]}`), IsActive: true},
+		{ID: uuid.MustParse("00000000-0000-0000-0000-000000000002"), ScreenKey: "students-list", TemplateID: tpl.ID,
+			Name: "Alumnos", SlotData: json.RawMessage(`{"columns":["name"]}`), IsActive: true},
+	}
+	if listInstances == nil {
+		listInstances = func(ctx context.Context, filter sharedrepo.ListFilters) ([]*entities.ScreenInstance, int, error) {
+			return instances, len(instances), nil
+		}
+	}
+	screens := newScreenConfigService(&mockScreenTemplateRepo{
+		listFn: func(ctx context.Context, filter sharedrepo.ListFilters) ([]*entities.ScreenTemplate, int, error) {
+			return []*entities.ScreenTemplate{tpl}, 1, nil
+		},
+	}, &mockScreenInstanceRepo{listFn: listInstances}, &mockResourceScreenRepo{})
+
+	contexts := &stubContextsAuthService{contexts: []*authDto.UserContextDTO{
+		{RoleID: "role-1", RoleName: "Docente", Permissions: []string{"students:read"}},
+	}}
+	glossary := NewGlossaryService(&mockGlossaryDefaultRepo{terms: map[string]*model.GlossaryDefault{
+		"student": {TermKey: "student", TermValue: "Alumno"},
+	}}, &mockSchoolConceptRepo{}, &mockLogger{}, &mockAuditLogger{})
+
+	return NewSyncService(menu, screens, contexts, &mockScreenInstanceRepo{}, glossary, &mockLogger{})
+}
+
+func TestSyncService_WriteFullBundle(t *testing.T) {
+	ctx := context.Background()
+	activeContext := &auth.UserContext{RoleID: "role-1", Permissions: []string{"students:read"}}
+
+	cases := []struct {
+		name    string
+		buckets []string
+	}{
+		{"todos los buckets", nil},
+		{"solo pantallas", []string{"screens"}},
+		{"sin pantallas", []string{"menu", "glossary"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name+" coincide byte a byte con GetFullBundle", func(t *testing.T) {
+			svc := newSyncTestService()
+			bundle, err := svc.GetFullBundle(ctx, "user-1", activeContext, tc.buckets, "es")
+			if err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+			want, err := json.Marshal(bundle)
+			if err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+			var got bytes.Buffer
+			if err := svc.WriteFullBundle(ctx, &got, "user-1", activeContext, tc.buckets, "es"); err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+			if !bytes.Equal(got.Bytes(), want) {
+				t.Errorf("documentos distintos\nstream: %s\nbundle: %s", got.Bytes(), want)
+			}
+		})
+	}
+
+	t.Run("incluye las pantallas y sus hashes", func(t *testing.T) {
+		var got bytes.Buffer
+		if err := newSyncTestService().WriteFullBundle(ctx, &got, "user-1", activeContext, nil, "es"); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		var bundle struct {
+			Screens map[string]json.RawMessage `json:"screens"`
+			Hashes  map[string]string          `json:"hashes"`
+		}
+		if err := json.Unmarshal(got.Bytes(), &bundle); err != nil {
+			t.Fatalf("JSON inválido: %v", err)
+		}
+		if len(bundle.Screens) != 2 || bundle.Hashes["screen:grades-list"] == "" || bundle.Hashes["screen:students-list"] == "" {
+			t.Errorf("pantallas incorrectas: %s", got.Bytes())
+		}
+	})
+
+	t.Run("marca el documento incompleto si las pantallas fallan a mitad", func(t *testing.T) {
+		svc := newSyncTestServiceWithInstances(failingInstancePages(t))
+		var got bytes.Buffer
+		if err := svc.WriteFullBundle(ctx, &got, "user-1", activeContext, nil, "es"); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		var bundle struct {
+			Screens  map[string]json.RawMessage `json:"screens"`
+			Complete *bool                      `json:"complete"`
+		}
+		if err := json.Unmarshal(got.Bytes(), &bundle); err != nil {
+			t.Fatalf("JSON inválido: %v", err)
+		}
+		if bundle.Complete == nil || *bundle.Complete || len(bundle.Screens) != screenPageSize {
+			t.Errorf("esperaba la primera página y complete=false, obtuvo %d pantallas y complete=%v", len(bundle.Screens), bundle.Complete)
+		}
+
+		full, err := newSyncTestServiceWithInstances(failingInstancePages(t)).GetFullBundle(ctx, "user-1", activeContext, nil, "es")
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if full.Complete == nil || *full.Complete {
+			t.Errorf("GetFullBundle también debe marcar el bundle incompleto")
+		}
+	})
+
+	t.Run("omite complete cuando el documento está completo", func(t *testing.T) {
+		var got bytes.Buffer
+		if err := newSyncTestService().WriteFullBundle(ctx, &got, "user-1", activeContext, nil, "es"); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if bytes.Contains(got.Bytes(), []byte(`"complete"`)) {
+			t.Errorf("no esperaba la marca complete: %s", got.Bytes())
+		}
+	})
+}
+
+// failingInstancePages lists one full page of instances and then fails, so
+// the screens bucket breaks after part of it was resolved.
+func failingInstancePages(t *testing.T) func(ctx context.Context, filter sharedrepo.ListFilters) ([]*entities.ScreenInstance, int, error) {
+	t.Helper()
+	calls := 0
+	return func(ctx context.Context, filter sharedrepo.ListFilters) ([]*entities.ScreenInstance, int, error) {
+		calls++
+		if calls > 1 {
+			return nil, 0, errors.New("connection reset")
+		}
+		instances := make([]*entities.ScreenInstance, screenPageSize+1)
+		for i := range instances {
+			instances[i] = &entities.ScreenInstance{ID: uuid.New(), ScreenKey: fmt.Sprintf("screen-%d", i),
+				TemplateID: syncTestTemplateID, Name: "Pantalla", SlotData: json.RawMessage(`{}`), IsActive: true}
+		}
+		return instances, len(instances), nil
+	}
+}
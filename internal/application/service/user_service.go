@@ -0,0 +1,348 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	"github.com/EduGoGroup/edugo-shared/auth"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+// anonymizedEmailDomain marks accounts scrubbed by Anonymize. The domain is
+// reserved (RFC 2606), so it can never collide with a real address.
+const anonymizedEmailDomain = "@anonymized.invalid"
+
+// SessionRevoker invalidates every token issued to a user so far
+type SessionRevoker interface {
+	RevokeUserSessions(userID string)
+}
+
+// UserService manages the lifecycle of user accounts. Deactivation and
+// anonymization revoke the user's role assignments and live sessions; every
+// lifecycle event is audited.
+type UserService interface {
+	ListUsers(ctx context.Context, filters sharedrepo.ListFilters) (*dto.UsersResponse, error)
+	GetUser(ctx context.Context, id string) (*dto.UserDTO, error)
+	CreateUser(ctx context.Context, req *dto.CreateUserRequest) (*dto.UserDTO, error)
+	UpdateUser(ctx context.Context, id string, req *dto.UpdateUserRequest) (*dto.UserDTO, error)
+	DeactivateUser(ctx context.Context, id, actorID string, req *dto.UserLifecycleRequest) (*dto.UserLifecycleResponse, error)
+	ReactivateUser(ctx context.Context, id, actorID string, req *dto.UserLifecycleRequest) (*dto.UserLifecycleResponse, error)
+	// AnonymizeUser scrubs personal data (GDPR erasure) but keeps the row and
+	// its ID, so audit events and historical records stay resolvable.
+	AnonymizeUser(ctx context.Context, id, actorID string, req *dto.UserLifecycleRequest) (*dto.UserLifecycleResponse, error)
+}
+
+type userService struct {
+	userRepo     sharedrepo.UserRepository
+	erasureRepo  repository.UserErasureRepository
+	userRoleRepo repository.UserRoleRepository
+	sessions     SessionRevoker
+	logger       logger.Logger
+	auditLogger  audit.AuditLogger
+}
+
+// NewUserService creates a new user service
+func NewUserService(userRepo sharedrepo.UserRepository, erasureRepo repository.UserErasureRepository, userRoleRepo repository.UserRoleRepository, sessions SessionRevoker, logger logger.Logger, auditLogger audit.AuditLogger) UserService {
+	return &userService{
+		userRepo:     userRepo,
+		erasureRepo:  erasureRepo,
+		userRoleRepo: userRoleRepo,
+		sessions:     sessions,
+		logger:       logger,
+		auditLogger:  auditLogger,
+	}
+}
+
+func (s *userService) ListUsers(ctx context.Context, filters sharedrepo.ListFilters) (*dto.UsersResponse, error) {
+	users, total, err := s.userRepo.List(ctx, filters)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list users", err)
+	}
+	dtos := make([]*dto.UserDTO, len(users))
+	for i, u := range users {
+		dtos[i] = toUserDTO(u)
+	}
+
+	page, limit := pageAndLimit(filters, int(total))
+	return &dto.UsersResponse{Users: dtos, Total: int(total), Page: page, Limit: limit}, nil
+}
+
+func (s *userService) GetUser(ctx context.Context, id string) (*dto.UserDTO, error) {
+	user, err := s.findUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toUserDTO(user), nil
+}
+
+func (s *userService) CreateUser(ctx context.Context, req *dto.CreateUserRequest) (*dto.UserDTO, error) {
+	email := normalizeEmail(req.Email)
+	if strings.HasSuffix(email, anonymizedEmailDomain) {
+		return nil, errors.NewValidationError("email domain is reserved")
+	}
+	exists, err := s.userRepo.ExistsByEmail(ctx, email)
+	if err != nil {
+		return nil, errors.NewDatabaseError("check user email", err)
+	}
+	if exists {
+		return nil, errors.NewAlreadyExistsError("user")
+	}
+	hash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid password: " + err.Error())
+	}
+
+	now := time.Now()
+	user := &entities.User{
+		ID:           uuid.New(),
+		Email:        email,
+		PasswordHash: hash,
+		FirstName:    strings.TrimSpace(req.FirstName),
+		LastName:     strings.TrimSpace(req.LastName),
+		IsActive:     true,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, errors.NewDatabaseError("create user", err)
+	}
+
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action:       "create",
+		ResourceType: "user",
+		ResourceID:   user.ID.String(),
+		Severity:     audit.SeverityInfo,
+		Category:     audit.CategoryAdmin,
+	})
+	s.logger.Info("user created", "entity_type", "user", "user_id", user.ID)
+	return toUserDTO(user), nil
+}
+
+func (s *userService) UpdateUser(ctx context.Context, id string, req *dto.UpdateUserRequest) (*dto.UserDTO, error) {
+	user, err := s.findUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if isAnonymized(user) {
+		return nil, errors.NewConflictError("anonymized users cannot be modified")
+	}
+
+	changes := map[string]interface{}{}
+	if req.Email != nil {
+		email := normalizeEmail(*req.Email)
+		if email != user.Email {
+			if strings.HasSuffix(email, anonymizedEmailDomain) {
+				return nil, errors.NewValidationError("email domain is reserved")
+			}
+			exists, err := s.userRepo.ExistsByEmail(ctx, email)
+			if err != nil {
+				return nil, errors.NewDatabaseError("check user email", err)
+			}
+			if exists {
+				return nil, errors.NewAlreadyExistsError("user")
+			}
+			changes["email"] = true
+			user.Email = email
+		}
+	}
+	if req.FirstName != nil {
+		user.FirstName = strings.TrimSpace(*req.FirstName)
+		changes["first_name"] = true
+	}
+	if req.LastName != nil {
+		user.LastName = strings.TrimSpace(*req.LastName)
+		changes["last_name"] = true
+	}
+	if len(changes) == 0 {
+		return toUserDTO(user), nil
+	}
+
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, errors.NewDatabaseError("update user", err)
+	}
+
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action:       "update",
+		ResourceType: "user",
+		ResourceID:   user.ID.String(),
+		Severity:     audit.SeverityInfo,
+		Category:     audit.CategoryAdmin,
+		Metadata:     changes,
+	})
+	s.logger.Info("user updated", "entity_type", "user", "user_id", user.ID)
+	return toUserDTO(user), nil
+}
+
+func (s *userService) DeactivateUser(ctx context.Context, id, actorID string, req *dto.UserLifecycleRequest) (*dto.UserLifecycleResponse, error) {
+	user, err := s.findUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.ID.String() == actorID {
+		return nil, errors.NewValidationError("you cannot deactivate your own account")
+	}
+	if !user.IsActive {
+		return nil, errors.NewConflictError("user is already inactive")
+	}
+
+	user.IsActive = false
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, errors.NewDatabaseError("deactivate user", err)
+	}
+	revoked := s.revokeAccess(ctx, user.ID)
+
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action:       "deactivate",
+		ResourceType: "user",
+		ResourceID:   user.ID.String(),
+		Severity:     audit.SeverityWarning,
+		Category:     audit.CategoryAdmin,
+		Metadata:     map[string]interface{}{"reason": req.Reason, "revoked_assignments": revoked},
+	})
+	s.logger.Warn("user deactivated", "entity_type", "user", "user_id", user.ID, "revoked_assignments", revoked)
+	return &dto.UserLifecycleResponse{User: toUserDTO(user), RevokedAssignments: revoked, SessionsInvalidated: true}, nil
+}
+
+// ReactivateUser lets the user log in again. Role assignments revoked on
+// deactivation are not restored; they have to be granted anew.
+func (s *userService) ReactivateUser(ctx context.Context, id, actorID string, req *dto.UserLifecycleRequest) (*dto.UserLifecycleResponse, error) {
+	user, err := s.findUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if isAnonymized(user) {
+		return nil, errors.NewConflictError("anonymized users cannot be reactivated")
+	}
+	if user.IsActive {
+		return nil, errors.NewConflictError("user is already active")
+	}
+
+	user.IsActive = true
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, errors.NewDatabaseError("reactivate user", err)
+	}
+
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action:       "reactivate",
+		ResourceType: "user",
+		ResourceID:   user.ID.String(),
+		Severity:     audit.SeverityWarning,
+		Category:     audit.CategoryAdmin,
+		Metadata:     map[string]interface{}{"reason": req.Reason},
+	})
+	s.logger.Info("user reactivated", "entity_type", "user", "user_id", user.ID, "actor_id", actorID)
+	return &dto.UserLifecycleResponse{User: toUserDTO(user)}, nil
+}
+
+func (s *userService) AnonymizeUser(ctx context.Context, id, actorID string, req *dto.UserLifecycleRequest) (*dto.UserLifecycleResponse, error) {
+	user, err := s.findUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.ID.String() == actorID {
+		return nil, errors.NewValidationError("you cannot anonymize your own account")
+	}
+	if isAnonymized(user) {
+		return nil, errors.NewConflictError("user is already anonymized")
+	}
+
+	// The ID is kept on purpose: audit events, grants and reviews reference it.
+	// Login attempts are keyed by the email, so they are erased along with it.
+	wasActive := user.IsActive
+	loginIdentifier := normalizeEmail(user.Email)
+	user.Email = "anonymized+" + user.ID.String() + anonymizedEmailDomain
+	user.FirstName = "Anonymized"
+	user.LastName = ""
+	user.PasswordHash = ""
+	user.IsActive = false
+	user.UpdatedAt = time.Now()
+	if err := s.erasureRepo.Anonymize(ctx, user, loginIdentifier); err != nil {
+		return nil, errors.NewDatabaseError("anonymize user", err)
+	}
+	revoked := s.revokeAccess(ctx, user.ID)
+
+	// Metadata deliberately carries no personal data
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action:       "anonymize",
+		ResourceType: "user",
+		ResourceID:   user.ID.String(),
+		Severity:     audit.SeverityCritical,
+		Category:     audit.CategoryAdmin,
+		Metadata:     map[string]interface{}{"reason": req.Reason, "was_active": wasActive, "revoked_assignments": revoked},
+	})
+	s.logger.Warn("user anonymized", "entity_type", "user", "user_id", user.ID)
+	return &dto.UserLifecycleResponse{User: toUserDTO(user), RevokedAssignments: revoked, SessionsInvalidated: true}, nil
+}
+
+func (s *userService) findUser(ctx context.Context, id string) (*entities.User, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid user ID")
+	}
+	user, err := s.userRepo.FindByID(ctx, uid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find user", err)
+	}
+	if user == nil {
+		return nil, errors.NewNotFoundError("user")
+	}
+	return user, nil
+}
+
+// revokeAccess revokes every active role assignment of the user and
+// invalidates the tokens issued so far. Failures are logged and skipped: the
+// account is already inactive, so login and refresh reject it regardless.
+func (s *userService) revokeAccess(ctx context.Context, userID uuid.UUID) int {
+	s.sessions.RevokeUserSessions(userID.String())
+
+	userRoles, err := s.userRoleRepo.FindByUser(ctx, userID)
+	if err != nil {
+		s.logger.Error("error loading role assignments to revoke", "user_id", userID, "error", err)
+		return 0
+	}
+	revoked := 0
+	for _, ur := range userRoles {
+		if !ur.IsActive {
+			continue
+		}
+		if err := s.userRoleRepo.Revoke(ctx, ur.ID); err != nil {
+			s.logger.Error("error revoking role assignment", "user_role_id", ur.ID, "error", err)
+			continue
+		}
+		revoked++
+	}
+	return revoked
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func isAnonymized(user *entities.User) bool {
+	return strings.HasSuffix(user.Email, anonymizedEmailDomain)
+}
+
+func toUserDTO(u *entities.User) *dto.UserDTO {
+	return &dto.UserDTO{
+		ID:         u.ID.String(),
+		Email:      u.Email,
+		FirstName:  u.FirstName,
+		LastName:   u.LastName,
+		FullName:   strings.TrimSpace(u.FirstName + " " + u.LastName),
+		IsActive:   u.IsActive,
+		Anonymized: isAnonymized(u),
+		CreatedAt:  u.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  u.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	sharedErrors "github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+func newLifecycleUser() *entities.User {
+	return &entities.User{ID: uuid.New(), Email: "ana@edugo.test", PasswordHash: "hash", FirstName: "Ana", LastName: "Pérez", IsActive: true}
+}
+
+func TestUserService_CreateUser(t *testing.T) {
+	ctx := context.Background()
+	req := &dto.CreateUserRequest{Email: "  Ana@EduGo.test ", Password: "secreto-seguro", FirstName: "Ana", LastName: "Pérez"}
+
+	t.Run("crea el usuario activo con el email normalizado y lo audita", func(t *testing.T) {
+		var created *entities.User
+		auditLog := &recordingAuditLogger{}
+		svc := NewUserService(&mockUserRepo{createFn: func(ctx context.Context, u *entities.User) error {
+			created = u
+			return nil
+		}}, &mockUserErasureRepo{}, &mockUserRoleRepo{}, &mockSessionRevoker{}, &mockLogger{}, auditLog)
+
+		d, err := svc.CreateUser(ctx, req)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if created == nil || created.Email != "ana@edugo.test" || !created.IsActive || created.PasswordHash == "" || created.PasswordHash == req.Password {
+			t.Fatalf("usuario incorrecto: %+v", created)
+		}
+		if d.FullName != "Ana Pérez" {
+			t.Errorf("nombre completo incorrecto: %s", d.FullName)
+		}
+		if len(auditLog.events) != 1 || auditLog.events[0].Action != "create" {
+			t.Errorf("se esperaba un evento de auditoría: %+v", auditLog.events)
+		}
+		if _, ok := auditLog.events[0].Metadata["email"]; ok {
+			t.Errorf("la auditoría no debe guardar el email: %+v", auditLog.events[0].Metadata)
+		}
+	})
+
+	t.Run("rechaza emails duplicados", func(t *testing.T) {
+		svc := NewUserService(&mockUserRepo{existsByEmailFn: func(ctx context.Context, email string) (bool, error) { return true, nil }},
+			&mockUserErasureRepo{}, &mockUserRoleRepo{}, &mockSessionRevoker{}, &mockLogger{}, &mockAuditLogger{})
+		_, err := svc.CreateUser(ctx, req)
+		assertAppError(t, err, sharedErrors.ErrorCodeAlreadyExists)
+	})
+}
+
+func TestUserService_DeactivateUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("revoca asignaciones y sesiones", func(t *testing.T) {
+		user := newLifecycleUser()
+		activeRole, inactiveRole := uuid.New(), uuid.New()
+		var revoked []uuid.UUID
+		sessions := &mockSessionRevoker{}
+		auditLog := &recordingAuditLogger{}
+		svc := NewUserService(&mockUserRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.User, error) { return user, nil }},
+			&mockUserErasureRepo{}, &mockUserRoleRepo{
+				findByUserFn: func(ctx context.Context, userID uuid.UUID) ([]*entities.UserRole, error) {
+					return []*entities.UserRole{{ID: activeRole, IsActive: true}, {ID: inactiveRole}}, nil
+				},
+				revokeFn: func(ctx context.Context, id uuid.UUID) error {
+					revoked = append(revoked, id)
+					return nil
+				},
+			}, sessions, &mockLogger{}, auditLog)
+
+		res, err := svc.DeactivateUser(ctx, user.ID.String(), uuid.New().String(), &dto.UserLifecycleRequest{Reason: "baja"})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if user.IsActive || res.RevokedAssignments != 1 || len(revoked) != 1 || revoked[0] != activeRole {
+			t.Errorf("desactivación incorrecta: %+v revoked=%v", res, revoked)
+		}
+		if len(sessions.revoked) != 1 || sessions.revoked[0] != user.ID.String() {
+			t.Errorf("se esperaba invalidar las sesiones: %v", sessions.revoked)
+		}
+		if len(auditLog.events) != 1 || auditLog.events[0].Action != "deactivate" || auditLog.events[0].Severity != audit.SeverityWarning {
+			t.Errorf("se esperaba un evento de auditoría: %+v", auditLog.events)
+		}
+	})
+
+	t.Run("no permite desactivarse a sí mismo", func(t *testing.T) {
+		user := newLifecycleUser()
+		svc := NewUserService(&mockUserRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.User, error) { return user, nil }},
+			&mockUserErasureRepo{}, &mockUserRoleRepo{}, &mockSessionRevoker{}, &mockLogger{}, &mockAuditLogger{})
+		_, err := svc.DeactivateUser(ctx, user.ID.String(), user.ID.String(), &dto.UserLifecycleRequest{})
+		assertAppError(t, err, sharedErrors.ErrorCodeValidation)
+	})
+
+	t.Run("usuario inexistente", func(t *testing.T) {
+		svc := NewUserService(&mockUserRepo{}, &mockUserErasureRepo{}, &mockUserRoleRepo{}, &mockSessionRevoker{}, &mockLogger{}, &mockAuditLogger{})
+		_, err := svc.DeactivateUser(ctx, uuid.New().String(), uuid.New().String(), &dto.UserLifecycleRequest{})
+		assertAppError(t, err, sharedErrors.ErrorCodeNotFound)
+	})
+}
+
+func TestUserService_AnonymizeUser(t *testing.T) {
+	ctx := context.Background()
+	user := newLifecycleUser()
+	originalID := user.ID
+	auditLog := &recordingAuditLogger{}
+	sessions := &mockSessionRevoker{}
+	var erasedIdentifier string
+	erasure := &mockUserErasureRepo{anonymizeFn: func(ctx context.Context, u *entities.User, loginIdentifier string) error {
+		erasedIdentifier = loginIdentifier
+		return nil
+	}}
+	svc := NewUserService(&mockUserRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.User, error) { return user, nil }},
+		erasure, &mockUserRoleRepo{}, sessions, &mockLogger{}, auditLog)
+
+	res, err := svc.AnonymizeUser(ctx, user.ID.String(), uuid.New().String(), &dto.UserLifecycleRequest{Reason: "solicitud GDPR"})
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if user.ID != originalID || user.IsActive || user.PasswordHash != "" || strings.Contains(user.Email, "ana") || user.LastName != "" {
+		t.Errorf("los datos personales deben borrarse conservando el ID: %+v", user)
+	}
+	if !res.User.Anonymized || len(sessions.revoked) != 1 {
+		t.Errorf("anonimización incorrecta: %+v", res)
+	}
+	if erasedIdentifier != "ana@edugo.test" {
+		t.Errorf("debe borrar los intentos de login del email anterior, obtuvo %q", erasedIdentifier)
+	}
+	if len(auditLog.events) != 1 || auditLog.events[0].Severity != audit.SeverityCritical || auditLog.events[0].ResourceID != originalID.String() {
+		t.Errorf("se esperaba un evento crítico sobre el mismo ID: %+v", auditLog.events)
+	}
+
+	t.Run("no se puede reactivar ni volver a anonimizar", func(t *testing.T) {
+		_, err := svc.ReactivateUser(ctx, user.ID.String(), uuid.New().String(), &dto.UserLifecycleRequest{})
+		assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+		_, err = svc.AnonymizeUser(ctx, user.ID.String(), uuid.New().String(), &dto.UserLifecycleRequest{})
+		assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+	})
+
+	t.Run("propaga error de base de datos al anonimizar", func(t *testing.T) {
+		other := newLifecycleUser()
+		svc := NewUserService(&mockUserRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.User, error) { return other, nil }},
+			&mockUserErasureRepo{anonymizeFn: func(ctx context.Context, u *entities.User, loginIdentifier string) error {
+				return errors.New("db error")
+			}}, &mockUserRoleRepo{}, &mockSessionRevoker{}, &mockLogger{}, &mockAuditLogger{})
+		_, err := svc.AnonymizeUser(ctx, other.ID.String(), uuid.New().String(), &dto.UserLifecycleRequest{})
+		assertAppError(t, err, sharedErrors.ErrorCodeDatabaseError)
+	})
+}
+
+func TestUserService_ReactivateUser(t *testing.T) {
+	user := newLifecycleUser()
+	user.IsActive = false
+	svc := NewUserService(&mockUserRepo{findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.User, error) { return user, nil }},
+		&mockUserErasureRepo{}, &mockUserRoleRepo{}, &mockSessionRevoker{}, &mockLogger{}, &mockAuditLogger{})
+
+	res, err := svc.ReactivateUser(context.Background(), user.ID.String(), uuid.New().String(), &dto.UserLifecycleRequest{})
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if !res.User.IsActive {
+		t.Errorf("el usuario debe quedar activo")
+	}
+	_, err = svc.ReactivateUser(context.Background(), user.ID.String(), uuid.New().String(), &dto.UserLifecycleRequest{})
+	assertAppError(t, err, sharedErrors.ErrorCodeConflict)
+}
@@ -73,6 +73,13 @@ type UserContextDTO struct {
 	AcademicUnitID   string   `json:"academic_unit_id,omitempty"`
 	AcademicUnitName string   `json:"academic_unit_name,omitempty"`
 	Permissions      []string `json:"permissions"`
+	// DelegatedBy and DelegationEndsAt are set on contexts held through a
+	// temporary role delegation
+	DelegatedBy      string `json:"delegated_by,omitempty"`
+	DelegationEndsAt string `json:"delegation_ends_at,omitempty"`
+	// InheritedFromUnitID is set on unit contexts reached through a
+	// membership or role held on an ancestor unit
+	InheritedFromUnitID string `json:"inherited_from_unit_id,omitempty"`
 }
 
 // SwitchContextRequest represents the request to switch school context
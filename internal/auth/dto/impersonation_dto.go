@@ -0,0 +1,39 @@
+package dto
+
+import "time"
+
+// ImpersonateRequest represents a request to act as another user
+type ImpersonateRequest struct {
+	UserID   string `json:"user_id" binding:"required,uuid"`
+	SchoolID string `json:"school_id,omitempty" binding:"omitempty,uuid"`
+	Reason   string `json:"reason" binding:"required,min=10,max=500"`
+}
+
+// ImpersonateResponse carries the read-only access token for the target user.
+// No refresh token is issued: the session ends when the token expires.
+type ImpersonateResponse struct {
+	AccessToken   string          `json:"access_token"`
+	ExpiresIn     int64           `json:"expires_in"`
+	TokenType     string          `json:"token_type"`
+	SessionID     string          `json:"session_id"`
+	Impersonator  *UserInfo       `json:"impersonator"`
+	Target        *UserInfo       `json:"target"`
+	ActiveContext *UserContextDTO `json:"active_context"`
+}
+
+// ImpersonationSessionDTO describes an impersonation session
+type ImpersonationSessionDTO struct {
+	ID             string     `json:"id"`
+	ImpersonatorID string     `json:"impersonator_id"`
+	TargetUserID   string     `json:"target_user_id"`
+	SchoolID       string     `json:"school_id,omitempty"`
+	Reason         string     `json:"reason"`
+	StartedAt      time.Time  `json:"started_at"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	EndedAt        *time.Time `json:"ended_at,omitempty"`
+}
+
+// ImpersonationSessionsResponse lists impersonation sessions
+type ImpersonationSessionsResponse struct {
+	Sessions []*ImpersonationSessionDTO `json:"sessions"`
+}
@@ -93,6 +93,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // @Success 200 {object} dto.LoginResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /auth/refresh [post]
 func (h *AuthHandler) Refresh(c *gin.Context) {
@@ -115,6 +116,12 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 				Message: "Invalid or expired refresh token",
 				Code:    "INVALID_REFRESH_TOKEN",
 			})
+		case errors.Is(err, service.ErrImpersonationNotAllowed):
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:   "forbidden",
+				Message: "Impersonation tokens cannot be refreshed",
+				Code:    "IMPERSONATION_NOT_ALLOWED",
+			})
 		case errors.Is(err, service.ErrUserInactive):
 			c.JSON(http.StatusForbidden, dto.ErrorResponse{
 				Error:   "forbidden",
@@ -214,6 +221,12 @@ func (h *AuthHandler) SwitchContext(c *gin.Context) {
 				Message: "No active membership in target school",
 				Code:    "NO_MEMBERSHIP",
 			})
+		case errors.Is(err, service.ErrImpersonationNotAllowed):
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:   "forbidden",
+				Message: "Impersonation tokens cannot switch context",
+				Code:    "IMPERSONATION_NOT_ALLOWED",
+			})
 		case errors.Is(err, service.ErrUnauthorizedUnit):
 			c.JSON(http.StatusForbidden, dto.ErrorResponse{
 				Error:   "forbidden",
@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/auth/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/auth/service"
+	"github.com/EduGoGroup/edugo-shared/auth"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	ginmiddleware "github.com/EduGoGroup/edugo-shared/middleware/gin"
+)
+
+// impersonationAllowedWrites lists the non-GET routes an impersonation token may call
+var impersonationAllowedWrites = map[string]bool{
+	"/api/v1/auth/logout":          true,
+	"/api/v1/auth/impersonate/end": true,
+}
+
+// ImpersonationHandler handles "act as user" endpoints
+type ImpersonationHandler struct {
+	impersonationService service.ImpersonationService
+	logger               logger.Logger
+}
+
+// NewImpersonationHandler creates a new ImpersonationHandler
+func NewImpersonationHandler(impersonationService service.ImpersonationService, log logger.Logger) *ImpersonationHandler {
+	return &ImpersonationHandler{impersonationService: impersonationService, logger: log}
+}
+
+// ImpersonationGuard recognises impersonation tokens from their claims, attaches
+// the real actor to the request context for auditing and rejects anything other
+// than reads, logout and ending the session. It must run after JWT authentication.
+func ImpersonationGuard(tokenService *service.TokenService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := strings.TrimSpace(strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer "))
+		if token == "" {
+			c.Next()
+			return
+		}
+		imp, err := tokenService.ReadImpersonation(token)
+		if err != nil || imp == nil {
+			c.Next()
+			return
+		}
+
+		c.Request = c.Request.WithContext(service.WithImpersonation(c.Request.Context(), imp))
+		c.Header("X-Impersonated-By", imp.ImpersonatorID.String())
+
+		method := c.Request.Method
+		if method != http.MethodGet && method != http.MethodHead && method != http.MethodOptions && !impersonationAllowedWrites[c.FullPath()] {
+			c.AbortWithStatusJSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:   "forbidden",
+				Message: "Impersonation tokens are read-only",
+				Code:    "IMPERSONATION_READ_ONLY",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// Impersonate starts an impersonation session
+// @Summary Impersonate a user
+// @Description Issue a short-lived, read-only access token acting as another user. Requires the users:impersonate permission. Every audit event written with the token records both the impersonator and the impersonated user.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.ImpersonateRequest true "Target user and reason"
+// @Success 200 {object} dto.ImpersonateResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /auth/impersonate [post]
+func (h *ImpersonationHandler) Impersonate(c *gin.Context) {
+	userID, err := ginmiddleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+			Code:    "NOT_AUTHENTICATED",
+		})
+		return
+	}
+
+	var req dto.ImpersonateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "bad_request",
+			Message: "user_id and a reason of at least 10 characters are required",
+			Code:    "INVALID_REQUEST",
+		})
+		return
+	}
+
+	var activeContext *auth.UserContext
+	if claims, _ := ginmiddleware.GetClaims(c); claims != nil {
+		activeContext = claims.ActiveContext
+	}
+
+	response, err := h.impersonationService.Start(c.Request.Context(), userID, activeContext, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrImpersonationForbidden):
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:   "forbidden",
+				Message: "Missing permission to impersonate users",
+				Code:    "IMPERSONATION_FORBIDDEN",
+			})
+		case errors.Is(err, service.ErrImpersonateSelf), errors.Is(err, service.ErrImpersonationTargetAdmin):
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:   "forbidden",
+				Message: err.Error(),
+				Code:    "IMPERSONATION_NOT_ALLOWED",
+			})
+		case errors.Is(err, service.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   "not_found",
+				Message: "User not found",
+				Code:    "USER_NOT_FOUND",
+			})
+		case errors.Is(err, service.ErrUserInactive):
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:   "forbidden",
+				Message: "User inactive",
+				Code:    "USER_INACTIVE",
+			})
+		case errors.Is(err, service.ErrInvalidSchoolID):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "bad_request",
+				Message: "Invalid school_id",
+				Code:    "INVALID_SCHOOL_ID",
+			})
+		default:
+			h.logger.Error("error starting impersonation", "user_id", userID, "target_user_id", req.UserID, "error", err)
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Error:   "internal_error",
+				Message: "Error starting impersonation",
+				Code:    "IMPERSONATION_ERROR",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// EndImpersonation ends the current impersonation session
+// @Summary End impersonation
+// @Description End the impersonation session of the calling token and revoke it
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /auth/impersonate/end [post]
+func (h *ImpersonationHandler) EndImpersonation(c *gin.Context) {
+	if err := h.impersonationService.End(c.Request.Context()); err != nil {
+		if errors.Is(err, service.ErrNotImpersonating) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "bad_request",
+				Message: "Token is not an impersonation token",
+				Code:    "NOT_IMPERSONATING",
+			})
+			return
+		}
+		h.logger.Error("error ending impersonation", "error", err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: "Error ending impersonation",
+			Code:    "IMPERSONATION_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Impersonation ended"})
+}
+
+// ListMyImpersonations lists the sessions in which the caller was impersonated
+// @Summary List impersonations of my account
+// @Description Return the most recent sessions in which support staff acted as the authenticated user
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.ImpersonationSessionsResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /auth/impersonations [get]
+func (h *ImpersonationHandler) ListMyImpersonations(c *gin.Context) {
+	userID, err := ginmiddleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+			Code:    "NOT_AUTHENTICATED",
+		})
+		return
+	}
+
+	response, err := h.impersonationService.ListForTarget(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("error listing impersonations", "user_id", userID, "error", err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: "Error listing impersonations",
+			Code:    "IMPERSONATIONS_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
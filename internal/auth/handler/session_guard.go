@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/auth/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/auth/service"
+	ginmiddleware "github.com/EduGoGroup/edugo-shared/middleware/gin"
+)
+
+// SessionRevocationGuard rejects tokens issued before the user's sessions were
+// revoked (deactivation or anonymization). It must run after JWT authentication.
+func SessionRevocationGuard(registry *service.UserSessionRegistry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := ginmiddleware.GetClaims(c)
+		if err != nil || claims == nil {
+			c.Next()
+			return
+		}
+		var issuedAt *time.Time
+		if claims.IssuedAt != nil {
+			issuedAt = &claims.IssuedAt.Time
+		}
+		if registry.IsRevoked(claims.UserID, issuedAt) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ErrorResponse{
+				Error:   "unauthorized",
+				Message: "Session has been revoked",
+				Code:    "SESSION_REVOKED",
+			})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImpersonationSession maps to auth.impersonation_sessions: a support user
+// acting as another user through a short-lived, read-only access token.
+type ImpersonationSession struct {
+	ID             uuid.UUID  `gorm:"column:id;type:uuid;primaryKey"`
+	ImpersonatorID uuid.UUID  `gorm:"column:impersonator_id;type:uuid;not null"`
+	TargetUserID   uuid.UUID  `gorm:"column:target_user_id;type:uuid;not null"`
+	SchoolID       *uuid.UUID `gorm:"column:school_id;type:uuid"`
+	Reason         string     `gorm:"column:reason;not null"`
+	TokenJTI       string     `gorm:"column:token_jti;not null"`
+	StartedAt      time.Time  `gorm:"column:started_at;not null;default:now()"`
+	ExpiresAt      time.Time  `gorm:"column:expires_at;not null"`
+	EndedAt        *time.Time `gorm:"column:ended_at"`
+}
+
+func (ImpersonationSession) TableName() string {
+	return "auth.impersonation_sessions"
+}
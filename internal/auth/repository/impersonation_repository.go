@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/auth/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ImpersonationRepository handles impersonation session persistence
+type ImpersonationRepository interface {
+	Create(ctx context.Context, session *model.ImpersonationSession) error
+	FindByJTI(ctx context.Context, jti string) (*model.ImpersonationSession, error)
+	ListByTarget(ctx context.Context, targetUserID uuid.UUID, limit int) ([]*model.ImpersonationSession, error)
+	End(ctx context.Context, id uuid.UUID, endedAt time.Time) error
+}
+
+type postgresImpersonationRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresImpersonationRepository creates a new impersonation session repository
+func NewPostgresImpersonationRepository(db *gorm.DB) ImpersonationRepository {
+	return &postgresImpersonationRepository{db: db}
+}
+
+func (r *postgresImpersonationRepository) Create(ctx context.Context, session *model.ImpersonationSession) error {
+	return r.db.WithContext(ctx).Create(session).Error
+}
+
+func (r *postgresImpersonationRepository) FindByJTI(ctx context.Context, jti string) (*model.ImpersonationSession, error) {
+	var session model.ImpersonationSession
+	if err := r.db.WithContext(ctx).Where("token_jti = ?", jti).First(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *postgresImpersonationRepository) ListByTarget(ctx context.Context, targetUserID uuid.UUID, limit int) ([]*model.ImpersonationSession, error) {
+	var sessions []*model.ImpersonationSession
+	err := r.db.WithContext(ctx).
+		Where("target_user_id = ?", targetUserID).
+		Order("started_at DESC").
+		Limit(limit).
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *postgresImpersonationRepository) End(ctx context.Context, id uuid.UUID, endedAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&model.ImpersonationSession{}).
+		Where("id = ? AND ended_at IS NULL", id).
+		Update("ended_at", endedAt).Error
+}
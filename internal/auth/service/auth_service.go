@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -47,6 +48,7 @@ type AuthService interface {
 	SwitchContext(ctx context.Context, userID, targetSchoolID, academicUnitID string) (*dto.SwitchContextResponse, error)
 	GetAvailableContexts(ctx context.Context, userID string, currentContext *auth.UserContext) (*dto.AvailableContextsResponse, error)
 	GetSchoolUnits(ctx context.Context, schoolID string) (*dto.SchoolUnitsResponse, error)
+	ResolveUserContext(ctx context.Context, userID uuid.UUID, schoolID *uuid.UUID) (*auth.UserContext, error)
 }
 
 type authService struct {
@@ -362,6 +364,12 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*d
 	log := logger.FromContext(ctx)
 	start := time.Now()
 
+	// Impersonation sessions are short-lived by design and must never be extended
+	if s.tokenService.IsImpersonationToken(refreshToken) {
+		authMetrics.RecordTokenRefresh(false, time.Since(start))
+		return nil, ErrImpersonationNotAllowed
+	}
+
 	// 1. Validate refresh token JWT
 	userID, _, schoolIDFromToken, err := s.tokenService.ValidateRefreshJWT(refreshToken)
 	if err != nil {
@@ -556,6 +564,9 @@ func (s *authService) getUserSchools(ctx context.Context, userID uuid.UUID) ([]d
 // SwitchContext switches the active school context for the user
 func (s *authService) SwitchContext(ctx context.Context, userID, targetSchoolID, academicUnitID string) (*dto.SwitchContextResponse, error) {
 	log := logger.FromContext(ctx)
+	if _, ok := ImpersonationFromContext(ctx); ok {
+		return nil, ErrImpersonationNotAllowed
+	}
 	userUUID, err := uuid.Parse(userID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid user_id: %w", err)
@@ -585,9 +596,26 @@ func (s *authService) SwitchContext(ctx context.Context, userID, targetSchoolID,
 		membership = nil
 	}
 
+	// Assignments scoped to the requested unit or to any unit above it (e.g. a
+	// role delegation, a grade-level coordinator) give access to it even
+	// without a membership in the school
+	unitScoped := false
+	var ancestors []uuid.UUID
+	if academicUnitID != "" {
+		if unitUUID, err := uuid.Parse(academicUnitID); err == nil {
+			ancestors = s.unitAncestors(ctx, unitUUID)
+			for _, scope := range append([]uuid.UUID{unitUUID}, ancestors...) {
+				if s.hasUnitRoles(ctx, userUUID, schoolUUID, scope) {
+					unitScoped = true
+					break
+				}
+			}
+		}
+	}
+
 	var activeContext *auth.UserContext
 
-	if membership == nil {
+	if membership == nil && !unitScoped {
 		// No school-specific membership: check for global role (e.g. super_admin)
 		globalContext := s.buildUserContext(ctx, userUUID, nil)
 		if globalContext == nil {
@@ -622,7 +650,7 @@ func (s *authService) SwitchContext(ctx context.Context, userID, targetSchoolID,
 	// Set academic unit if provided in request, validating:
 	// 1. UUID is valid (already enforced by DTO binding:"omitempty,uuid")
 	// 2. Unit exists and belongs to the target school
-	// 3. User has an active membership in that unit
+	// 3. User has an active membership in that unit or in one of its ancestors
 	if academicUnitID != "" {
 		unitUUID, err := uuid.Parse(academicUnitID)
 		if err != nil {
@@ -642,7 +670,7 @@ func (s *authService) SwitchContext(ctx context.Context, userID, targetSchoolID,
 		if err != nil {
 			return nil, fmt.Errorf("error verifying unit membership: %w", err)
 		}
-		hasUnitAccess := false
+		hasUnitAccess := unitScoped
 		for _, m := range userMemberships {
 			if m.SchoolID == schoolUUID && m.IsActive {
 				if m.AcademicUnitID == nil {
@@ -650,7 +678,8 @@ func (s *authService) SwitchContext(ctx context.Context, userID, targetSchoolID,
 					hasUnitAccess = true
 					break
 				}
-				if *m.AcademicUnitID == unitUUID {
+				// A membership in a parent unit (grade level) covers its children (sections)
+				if *m.AcademicUnitID == unitUUID || slices.Contains(ancestors, *m.AcademicUnitID) {
 					hasUnitAccess = true
 					break
 				}
@@ -672,8 +701,9 @@ func (s *authService) SwitchContext(ctx context.Context, userID, targetSchoolID,
 		// Recompute permissions with unit context only for membership-based users.
 		// Global roles (e.g. super_admin) have no membership — their permissions
 		// were already resolved without school/unit filter and must not be overwritten.
-		if membership != nil {
-			updatedPerms, err := s.userRoleRepo.GetUserPermissions(ctx, userUUID, &schoolUUID, &unitUUID)
+		// Roles held on ancestor units are inherited down the tree.
+		if membership != nil || unitScoped {
+			updatedPerms, err := s.inheritedPermissions(ctx, userUUID, schoolUUID, unitUUID, ancestors)
 			if err == nil {
 				activeContext.Permissions = updatedPerms
 			}
@@ -754,6 +784,7 @@ func (s *authService) GetAvailableContexts(ctx context.Context, userID string, c
 	if userRolesErr != nil {
 		return nil, fmt.Errorf("error fetching roles: %w", userRolesErr)
 	}
+	userRoles = unexpiredRoles(userRoles, time.Now())
 	if membershipsErr != nil {
 		s.logger.Warn("error fetching memberships for available contexts", "user_id", userID, "error", membershipsErr)
 		// Continue without memberships — roles are still available
@@ -959,6 +990,10 @@ func (s *authService) GetAvailableContexts(ctx context.Context, userID string, c
 		})
 	}
 
+	// Phase 4: Unit contexts are inherited down the academic unit tree, so a
+	// coordinator of a grade level can also act in each of its sections
+	available = s.appendInheritedUnitContexts(ctx, userUUID, available)
+
 	var current *dto.UserContextDTO
 	if currentContext != nil {
 		current = &dto.UserContextDTO{
@@ -1078,6 +1113,30 @@ func (s *authService) buildUserContext(ctx context.Context, userID uuid.UUID, sc
 	return uc
 }
 
+// ResolveUserContext builds the RBAC context the user would get on login: a
+// global role wins and is pinned to the school, otherwise the school-scoped
+// context is used. When schoolID is nil the user's first school applies.
+func (s *authService) ResolveUserContext(ctx context.Context, userID uuid.UUID, schoolID *uuid.UUID) (*auth.UserContext, error) {
+	if schoolID == nil {
+		_, schoolID = s.getUserSchools(ctx, userID)
+	}
+
+	uc := s.buildUserContext(ctx, userID, nil)
+	if uc != nil && schoolID != nil {
+		uc.SchoolID = schoolID.String()
+		if school, err := s.schoolRepo.FindByID(ctx, *schoolID); err == nil && school != nil {
+			uc.SchoolName = school.Name
+		}
+		s.autoPopulateUnit(ctx, userID, schoolID, uc)
+	} else if uc == nil && schoolID != nil {
+		uc = s.buildUserContext(ctx, userID, schoolID)
+	}
+	if uc == nil {
+		return nil, fmt.Errorf("user has no assigned roles")
+	}
+	return uc, nil
+}
+
 // GetSchoolUnits returns all active academic units for a given school.
 // Used by users with context:browse_units permission to select a unit.
 func (s *authService) GetSchoolUnits(ctx context.Context, schoolID string) (*dto.SchoolUnitsResponse, error) {
@@ -1113,7 +1172,35 @@ func (s *authService) GetSchoolUnits(ctx context.Context, schoolID string) (*dto
 	}, nil
 }
 
+// hasUnitRoles reports whether the user holds an unexpired assignment scoped
+// to the given academic unit.
+func (s *authService) hasUnitRoles(ctx context.Context, userID, schoolID, unitID uuid.UUID) bool {
+	userRoles, err := s.userRoleRepo.FindByUserInContext(ctx, userID, &schoolID, &unitID)
+	if err != nil {
+		s.logger.Warn("error fetching unit roles for switch-context",
+			"user_id", userID.String(), "academic_unit_id", unitID.String(), "error", err)
+		return false
+	}
+	return len(unexpiredRoles(userRoles, time.Now())) > 0
+}
+
+// unexpiredRoles drops assignments whose expiry has passed but that the
+// background sweep has not deactivated yet.
+func unexpiredRoles(userRoles []*entities.UserRole, now time.Time) []*entities.UserRole {
+	result := make([]*entities.UserRole, 0, len(userRoles))
+	for _, ur := range userRoles {
+		if ur.ExpiresAt != nil && !ur.ExpiresAt.After(now) {
+			continue
+		}
+		result = append(result, ur)
+	}
+	return result
+}
+
 // autoPopulateUnit sets AcademicUnitID on the context if the user has exactly 1 active unit in the school.
+// Units nested under another unit the user belongs to do not count: a
+// coordinator of a grade level who also belongs to one of its sections lands
+// on the grade level, from which every section is reachable.
 func (s *authService) autoPopulateUnit(ctx context.Context, userID uuid.UUID, schoolID *uuid.UUID, uc *auth.UserContext) {
 	if schoolID == nil || uc == nil {
 		return
@@ -1135,6 +1222,22 @@ func (s *authService) autoPopulateUnit(ctx context.Context, userID uuid.UUID, sc
 			}
 		}
 	}
+	if len(unitIDs) > 1 {
+		var topLevel []uuid.UUID
+		for _, uid := range unitIDs {
+			covered := false
+			for _, ancestor := range s.unitAncestors(ctx, uid) {
+				if _, ok := seen[ancestor]; ok {
+					covered = true
+					break
+				}
+			}
+			if !covered {
+				topLevel = append(topLevel, uid)
+			}
+		}
+		unitIDs = topLevel
+	}
 	if len(unitIDs) == 1 {
 		uc.AcademicUnitID = unitIDs[0].String()
 		if unit, err := s.academicUnitRepo.FindByID(ctx, unitIDs[0]); err == nil && unit != nil {
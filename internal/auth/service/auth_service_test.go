@@ -98,10 +98,15 @@ func (m *mockUserRepo) List(ctx context.Context, filters sharedrepo.ListFilters)
 type mockUserRoleRepo struct {
 	findByUserFn          func(ctx context.Context, userID uuid.UUID) ([]*entities.UserRole, error)
 	findByUserInContextFn func(ctx context.Context, userID uuid.UUID, schoolID *uuid.UUID, unitID *uuid.UUID) ([]*entities.UserRole, error)
+	findActiveByRoleFn    func(ctx context.Context, roleID uuid.UUID) ([]*entities.UserRole, error)
+	findActiveInScopeFn   func(ctx context.Context, schoolID, unitID, roleID *uuid.UUID) ([]*entities.UserRole, error)
+	findActiveByUsersFn   func(ctx context.Context, userIDs []uuid.UUID) ([]*entities.UserRole, error)
 	getUserPermissionsFn  func(ctx context.Context, userID uuid.UUID, schoolID, unitID *uuid.UUID) ([]string, error)
+	getContextPermsFn     func(ctx context.Context, userID uuid.UUID, schoolID, unitID *uuid.UUID) ([]string, error)
 	grantFn               func(ctx context.Context, userRole *entities.UserRole) error
 	revokeFn              func(ctx context.Context, id uuid.UUID) error
 	revokeByUserAndRoleFn func(ctx context.Context, userID, roleID uuid.UUID, schoolID, unitID *uuid.UUID) error
+	deactivateExpiredFn   func(ctx context.Context, now time.Time) ([]*entities.UserRole, error)
 	userHasRoleFn         func(ctx context.Context, userID, roleID uuid.UUID, schoolID, unitID *uuid.UUID) (bool, error)
 }
 
@@ -111,6 +116,24 @@ func (m *mockUserRoleRepo) FindByUser(ctx context.Context, userID uuid.UUID) ([]
 	}
 	return nil, nil
 }
+func (m *mockUserRoleRepo) FindActiveByRole(ctx context.Context, roleID uuid.UUID) ([]*entities.UserRole, error) {
+	if m.findActiveByRoleFn != nil {
+		return m.findActiveByRoleFn(ctx, roleID)
+	}
+	return nil, nil
+}
+func (m *mockUserRoleRepo) FindActiveInScope(ctx context.Context, schoolID, unitID, roleID *uuid.UUID) ([]*entities.UserRole, error) {
+	if m.findActiveInScopeFn != nil {
+		return m.findActiveInScopeFn(ctx, schoolID, unitID, roleID)
+	}
+	return nil, nil
+}
+func (m *mockUserRoleRepo) FindActiveByUsers(ctx context.Context, userIDs []uuid.UUID) ([]*entities.UserRole, error) {
+	if m.findActiveByUsersFn != nil {
+		return m.findActiveByUsersFn(ctx, userIDs)
+	}
+	return nil, nil
+}
 func (m *mockUserRoleRepo) FindByUserInContext(ctx context.Context, userID uuid.UUID, schoolID *uuid.UUID, unitID *uuid.UUID) ([]*entities.UserRole, error) {
 	if m.findByUserInContextFn != nil {
 		return m.findByUserInContextFn(ctx, userID, schoolID, unitID)
@@ -123,6 +146,12 @@ func (m *mockUserRoleRepo) GetUserPermissions(ctx context.Context, userID uuid.U
 	}
 	return []string{}, nil
 }
+func (m *mockUserRoleRepo) GetContextPermissions(ctx context.Context, userID uuid.UUID, schoolID, unitID *uuid.UUID) ([]string, error) {
+	if m.getContextPermsFn != nil {
+		return m.getContextPermsFn(ctx, userID, schoolID, unitID)
+	}
+	return []string{}, nil
+}
 func (m *mockUserRoleRepo) Grant(ctx context.Context, userRole *entities.UserRole) error {
 	if m.grantFn != nil {
 		return m.grantFn(ctx, userRole)
@@ -141,6 +170,12 @@ func (m *mockUserRoleRepo) RevokeByUserAndRole(ctx context.Context, userID, role
 	}
 	return nil
 }
+func (m *mockUserRoleRepo) DeactivateExpired(ctx context.Context, now time.Time) ([]*entities.UserRole, error) {
+	if m.deactivateExpiredFn != nil {
+		return m.deactivateExpiredFn(ctx, now)
+	}
+	return nil, nil
+}
 func (m *mockUserRoleRepo) UserHasRole(ctx context.Context, userID, roleID uuid.UUID, schoolID, unitID *uuid.UUID) (bool, error) {
 	if m.userHasRoleFn != nil {
 		return m.userHasRoleFn(ctx, userID, roleID, schoolID, unitID)
@@ -265,9 +300,11 @@ func (m *mockLoginAttemptRepo) CountFailedSince(ctx context.Context, identifier
 var _ repository.UserRoleRepository = (*mockUserRoleRepo)(nil)
 var _ repository.RoleRepository = (*mockRoleRepository)(nil)
 
+const testJWTSecret = "test-secret-key-for-unit-tests-only"
+
 func newTestTokenService() *TokenService {
-	jwtManager := auth.NewJWTManager("test-secret-key-for-unit-tests-only", "test-issuer")
-	return NewTokenService(jwtManager, 15*time.Minute, 7*24*time.Hour)
+	jwtManager := auth.NewJWTManager(testJWTSecret, "test-issuer")
+	return NewTokenService(jwtManager, testJWTSecret, 15*time.Minute, 7*24*time.Hour)
 }
 
 func newTestUser() *entities.User {
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/auth/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/auth"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// delegationAwareAuthService marks the available contexts a user holds through
+// an active role delegation, so clients can show who delegated them and until
+// when. Delegated assignments are regular user_roles, so everything else is
+// delegated to the wrapped service unchanged.
+type delegationAwareAuthService struct {
+	AuthService
+	delegationRepo repository.RoleDelegationRepository
+	logger         logger.Logger
+}
+
+// NewDelegationAwareAuthService wraps next so GetAvailableContexts reports
+// delegated contexts.
+func NewDelegationAwareAuthService(next AuthService, delegationRepo repository.RoleDelegationRepository, logger logger.Logger) AuthService {
+	return &delegationAwareAuthService{AuthService: next, delegationRepo: delegationRepo, logger: logger}
+}
+
+func (s *delegationAwareAuthService) GetAvailableContexts(ctx context.Context, userID string, currentContext *auth.UserContext) (*dto.AvailableContextsResponse, error) {
+	resp, err := s.AuthService.GetAvailableContexts(ctx, userID, currentContext)
+	if err != nil || resp == nil || len(resp.Available) == 0 {
+		return resp, err
+	}
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return resp, nil
+	}
+
+	delegations, err := s.delegationRepo.List(ctx, repository.RoleDelegationFilter{DelegateID: &uid, Statuses: []string{model.DelegationActive}})
+	if err != nil {
+		// Contexts are still usable without the delegation markers
+		s.logger.Warn("error fetching delegations for available contexts", "user_id", userID, "error", err)
+		return resp, nil
+	}
+	for _, c := range resp.Available {
+		for _, d := range delegations {
+			if c.RoleID == d.RoleID.String() && c.SchoolID == d.SchoolID.String() && c.AcademicUnitID == d.AcademicUnitID.String() {
+				c.DelegatedBy = d.DelegatorID.String()
+				c.DelegationEndsAt = d.EndsAt.Format(time.RFC3339)
+				break
+			}
+		}
+	}
+	return resp, nil
+}
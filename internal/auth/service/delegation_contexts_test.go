@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/auth/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/auth"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockDelegationRepo struct {
+	repository.RoleDelegationRepository
+	listFn func(ctx context.Context, filter repository.RoleDelegationFilter) ([]*model.RoleDelegation, error)
+}
+
+func (m *mockDelegationRepo) List(ctx context.Context, filter repository.RoleDelegationFilter) ([]*model.RoleDelegation, error) {
+	return m.listFn(ctx, filter)
+}
+
+type stubContextsAuthService struct {
+	AuthService
+	resp *dto.AvailableContextsResponse
+}
+
+func (s *stubContextsAuthService) GetAvailableContexts(_ context.Context, _ string, _ *auth.UserContext) (*dto.AvailableContextsResponse, error) {
+	return s.resp, nil
+}
+
+func TestDelegationAwareAuthService_MarksDelegatedContexts(t *testing.T) {
+	delegateID, delegatorID, roleID, schoolID, unitID := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	endsAt := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+
+	delegated := &dto.UserContextDTO{RoleID: roleID.String(), SchoolID: schoolID.String(), AcademicUnitID: unitID.String()}
+	own := &dto.UserContextDTO{RoleID: roleID.String(), SchoolID: schoolID.String(), AcademicUnitID: uuid.New().String()}
+	next := &stubContextsAuthService{resp: &dto.AvailableContextsResponse{Available: []*dto.UserContextDTO{own, delegated}}}
+
+	var filter repository.RoleDelegationFilter
+	repo := &mockDelegationRepo{listFn: func(_ context.Context, f repository.RoleDelegationFilter) ([]*model.RoleDelegation, error) {
+		filter = f
+		return []*model.RoleDelegation{{DelegatorID: delegatorID, DelegateID: delegateID, RoleID: roleID, SchoolID: schoolID, AcademicUnitID: unitID, EndsAt: endsAt}}, nil
+	}}
+
+	resp, err := NewDelegationAwareAuthService(next, repo, &mockLog{}).GetAvailableContexts(context.Background(), delegateID.String(), nil)
+	require.NoError(t, err)
+	require.NotNil(t, filter.DelegateID)
+	assert.Equal(t, delegateID, *filter.DelegateID)
+	assert.Equal(t, []string{model.DelegationActive}, filter.Statuses)
+
+	assert.Equal(t, delegatorID.String(), resp.Available[1].DelegatedBy)
+	assert.Equal(t, endsAt.Format(time.RFC3339), resp.Available[1].DelegationEndsAt)
+	assert.Empty(t, resp.Available[0].DelegatedBy, "las asignaciones propias no se marcan")
+}
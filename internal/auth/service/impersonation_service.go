@@ -0,0 +1,368 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/auth/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/auth/model"
+	authrepo "github.com/EduGoGroup/edugo-api-iam-platform/internal/auth/repository"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	"github.com/EduGoGroup/edugo-shared/auth"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+// PermissionImpersonate allows starting an impersonation session
+const PermissionImpersonate = "users:impersonate"
+
+// impersonationHistoryLimit caps the sessions returned to the impersonated user
+const impersonationHistoryLimit = 50
+
+// Sentinel errors for impersonation
+var (
+	ErrImpersonationForbidden   = errors.New("missing permission to impersonate users")
+	ErrImpersonateSelf          = errors.New("cannot impersonate yourself")
+	ErrImpersonationTargetAdmin = errors.New("cannot impersonate a user who can impersonate others")
+	ErrNotImpersonating         = errors.New("token is not an impersonation token")
+	ErrImpersonationNotAllowed  = errors.New("operation not allowed with an impersonation token")
+)
+
+// Impersonation identifies the real actor behind an impersonation token
+type Impersonation struct {
+	SessionID      uuid.UUID
+	ImpersonatorID uuid.UUID
+	TargetUserID   uuid.UUID
+	JTI            string
+	ExpiresAt      time.Time
+}
+
+type impersonationCtxKey struct{}
+
+// WithImpersonation returns a context carrying the impersonation details
+func WithImpersonation(ctx context.Context, imp *Impersonation) context.Context {
+	return context.WithValue(ctx, impersonationCtxKey{}, imp)
+}
+
+// ImpersonationFromContext returns the impersonation details of the request, if any
+func ImpersonationFromContext(ctx context.Context) (*Impersonation, bool) {
+	imp, ok := ctx.Value(impersonationCtxKey{}).(*Impersonation)
+	return imp, ok && imp != nil
+}
+
+// ImpersonationService lets support staff act as another user with a
+// short-lived, read-only token. Sessions are persisted for the user's history;
+// the token itself carries the impersonator and session ID, so requests are
+// recognised from its claims without a database round-trip.
+type ImpersonationService interface {
+	Start(ctx context.Context, impersonatorID string, impersonatorContext *auth.UserContext, req *dto.ImpersonateRequest) (*dto.ImpersonateResponse, error)
+	End(ctx context.Context) error
+	ListForTarget(ctx context.Context, userID string) (*dto.ImpersonationSessionsResponse, error)
+}
+
+type impersonationService struct {
+	repo         authrepo.ImpersonationRepository
+	userRepo     sharedrepo.UserRepository
+	userRoleRepo repository.UserRoleRepository
+	authService  AuthService
+	tokenService *TokenService
+	blacklist    auth.TokenBlacklist
+	logger       logger.Logger
+	auditLogger  audit.AuditLogger
+	tokenTTL     time.Duration
+}
+
+// NewImpersonationService creates a new impersonation service
+func NewImpersonationService(
+	repo authrepo.ImpersonationRepository,
+	userRepo sharedrepo.UserRepository,
+	userRoleRepo repository.UserRoleRepository,
+	authService AuthService,
+	tokenService *TokenService,
+	blacklist auth.TokenBlacklist,
+	logger logger.Logger,
+	auditLogger audit.AuditLogger,
+	tokenTTL time.Duration,
+) ImpersonationService {
+	if tokenTTL == 0 {
+		tokenTTL = 15 * time.Minute
+	}
+	return &impersonationService{
+		repo:         repo,
+		userRepo:     userRepo,
+		userRoleRepo: userRoleRepo,
+		authService:  authService,
+		tokenService: tokenService,
+		blacklist:    blacklist,
+		logger:       logger,
+		auditLogger:  auditLogger,
+		tokenTTL:     tokenTTL,
+	}
+}
+
+// Start issues a token for the target user. The token only carries the
+// target's read permissions, so it cannot be used for privileged operations
+// in any service that checks permissions from the token.
+func (s *impersonationService) Start(ctx context.Context, impersonatorID string, impersonatorContext *auth.UserContext, req *dto.ImpersonateRequest) (*dto.ImpersonateResponse, error) {
+	if impersonatorContext == nil || !slices.Contains(impersonatorContext.Permissions, PermissionImpersonate) {
+		return nil, ErrImpersonationForbidden
+	}
+	impersonatorUUID, err := uuid.Parse(impersonatorID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid impersonator id: %w", err)
+	}
+	if err := s.checkImpersonatePermission(ctx, impersonatorUUID, impersonatorContext); err != nil {
+		return nil, err
+	}
+	targetUUID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if targetUUID == impersonatorUUID {
+		return nil, ErrImpersonateSelf
+	}
+	var schoolID *uuid.UUID
+	if req.SchoolID != "" {
+		sid, err := uuid.Parse(req.SchoolID)
+		if err != nil {
+			return nil, ErrInvalidSchoolID
+		}
+		schoolID = &sid
+	}
+
+	impersonator, err := s.userRepo.FindByID(ctx, impersonatorUUID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding impersonator: %w", err)
+	}
+	if impersonator == nil {
+		return nil, ErrUserNotFound
+	}
+	target, err := s.userRepo.FindByID(ctx, targetUUID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding target user: %w", err)
+	}
+	if target == nil {
+		return nil, ErrUserNotFound
+	}
+	if !target.IsActive {
+		return nil, ErrUserInactive
+	}
+
+	activeContext, err := s.authService.ResolveUserContext(ctx, targetUUID, schoolID)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(activeContext.Permissions, PermissionImpersonate) {
+		return nil, ErrImpersonationTargetAdmin
+	}
+	activeContext.Permissions = readOnlyPermissions(activeContext.Permissions)
+
+	sessionID := uuid.New()
+	token, jti, expiresAt, err := s.tokenService.GenerateImpersonationToken(target.ID.String(), target.Email, activeContext, s.tokenTTL,
+		ImpersonationClaim{ImpersonatorID: impersonator.ID.String(), SessionID: sessionID.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	session := &model.ImpersonationSession{
+		ID:             sessionID,
+		ImpersonatorID: impersonator.ID,
+		TargetUserID:   target.ID,
+		Reason:         req.Reason,
+		TokenJTI:       jti,
+		StartedAt:      time.Now(),
+		ExpiresAt:      expiresAt,
+	}
+	if activeContext.SchoolID != "" {
+		if sid, err := uuid.Parse(activeContext.SchoolID); err == nil {
+			session.SchoolID = &sid
+		}
+	}
+	if err := s.repo.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("error saving impersonation session: %w", err)
+	}
+
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		ActorID:      impersonator.ID.String(),
+		ActorEmail:   impersonator.Email,
+		Action:       "impersonation_start",
+		ResourceType: "user",
+		ResourceID:   target.ID.String(),
+		Severity:     audit.SeverityCritical,
+		Category:     audit.CategoryAuth,
+		Metadata: map[string]interface{}{
+			"impersonation_session_id": session.ID.String(),
+			"school_id":                activeContext.SchoolID,
+			"reason":                   req.Reason,
+			"expires_at":               expiresAt.Format(time.RFC3339),
+		},
+	})
+	s.logger.Info("impersonation started",
+		"entity_type", "impersonation_session",
+		"session_id", session.ID.String(),
+		"impersonator_id", impersonator.ID.String(),
+		"target_user_id", target.ID.String(),
+	)
+
+	return &dto.ImpersonateResponse{
+		AccessToken:   token,
+		ExpiresIn:     int64(time.Until(expiresAt).Seconds()),
+		TokenType:     "Bearer",
+		SessionID:     session.ID.String(),
+		Impersonator:  toUserInfo(impersonator.ID.String(), impersonator.Email, impersonator.FirstName, impersonator.LastName),
+		Target:        toUserInfo(target.ID.String(), target.Email, target.FirstName, target.LastName),
+		ActiveContext: toUserContextDTO(activeContext),
+	}, nil
+}
+
+// End closes the impersonation session of the current request and revokes its token.
+func (s *impersonationService) End(ctx context.Context) error {
+	imp, ok := ImpersonationFromContext(ctx)
+	if !ok {
+		return ErrNotImpersonating
+	}
+	if err := s.repo.End(ctx, imp.SessionID, time.Now()); err != nil {
+		return fmt.Errorf("error ending impersonation session: %w", err)
+	}
+	s.blacklist.Revoke(imp.JTI, imp.ExpiresAt)
+
+	_ = s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action:       "impersonation_end",
+		ResourceType: "user",
+		ResourceID:   imp.TargetUserID.String(),
+		Severity:     audit.SeverityInfo,
+		Category:     audit.CategoryAuth,
+	})
+	s.logger.Info("impersonation ended", "entity_type", "impersonation_session", "session_id", imp.SessionID.String())
+	return nil
+}
+
+// ListForTarget returns the most recent sessions in which the user was impersonated
+func (s *impersonationService) ListForTarget(ctx context.Context, userID string) (*dto.ImpersonationSessionsResponse, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	sessions, err := s.repo.ListByTarget(ctx, uid, impersonationHistoryLimit)
+	if err != nil {
+		return nil, fmt.Errorf("error listing impersonation sessions: %w", err)
+	}
+	result := make([]*dto.ImpersonationSessionDTO, len(sessions))
+	for i, session := range sessions {
+		result[i] = toImpersonationSessionDTO(session)
+	}
+	return &dto.ImpersonationSessionsResponse{Sessions: result}, nil
+}
+
+// checkImpersonatePermission re-reads the impersonator's permissions in the
+// token's context: the claims are only as fresh as the token, so a revoked
+// grant would otherwise keep working until it expires.
+func (s *impersonationService) checkImpersonatePermission(ctx context.Context, impersonatorID uuid.UUID, activeContext *auth.UserContext) error {
+	var schoolID, unitID *uuid.UUID
+	if activeContext.SchoolID != "" {
+		sid, err := uuid.Parse(activeContext.SchoolID)
+		if err != nil {
+			return ErrImpersonationForbidden
+		}
+		schoolID = &sid
+	}
+	if activeContext.AcademicUnitID != "" {
+		uid, err := uuid.Parse(activeContext.AcademicUnitID)
+		if err != nil {
+			return ErrImpersonationForbidden
+		}
+		unitID = &uid
+	}
+	perms, err := s.userRoleRepo.GetContextPermissions(ctx, impersonatorID, schoolID, unitID)
+	if err != nil {
+		return fmt.Errorf("error loading impersonator permissions: %w", err)
+	}
+	if !slices.Contains(perms, PermissionImpersonate) {
+		return ErrImpersonationForbidden
+	}
+	return nil
+}
+
+// readOnlyPermissions keeps only the permissions whose action is "read"
+func readOnlyPermissions(permissions []string) []string {
+	result := make([]string, 0, len(permissions))
+	for _, p := range permissions {
+		if strings.HasSuffix(p, ":read") {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+func toUserInfo(id, email, firstName, lastName string) *dto.UserInfo {
+	return &dto.UserInfo{
+		ID:        id,
+		Email:     email,
+		FirstName: firstName,
+		LastName:  lastName,
+		FullName:  firstName + " " + lastName,
+	}
+}
+
+func toUserContextDTO(uc *auth.UserContext) *dto.UserContextDTO {
+	return &dto.UserContextDTO{
+		RoleID:           uc.RoleID,
+		RoleName:         uc.RoleName,
+		SchoolID:         uc.SchoolID,
+		SchoolName:       uc.SchoolName,
+		AcademicUnitID:   uc.AcademicUnitID,
+		AcademicUnitName: uc.AcademicUnitName,
+		Permissions:      uc.Permissions,
+	}
+}
+
+func toImpersonationSessionDTO(session *model.ImpersonationSession) *dto.ImpersonationSessionDTO {
+	d := &dto.ImpersonationSessionDTO{
+		ID:             session.ID.String(),
+		ImpersonatorID: session.ImpersonatorID.String(),
+		TargetUserID:   session.TargetUserID.String(),
+		Reason:         session.Reason,
+		StartedAt:      session.StartedAt,
+		ExpiresAt:      session.ExpiresAt,
+		EndedAt:        session.EndedAt,
+	}
+	if session.SchoolID != nil {
+		d.SchoolID = session.SchoolID.String()
+	}
+	return d
+}
+
+// impersonationAuditLogger records the impersonator on every audit event
+// written while serving an impersonation token.
+type impersonationAuditLogger struct {
+	next audit.AuditLogger
+}
+
+// NewImpersonationAuditLogger wraps an audit logger so events logged under an
+// impersonation token record both the impersonated user (the actor taken from
+// the token) and the real impersonator in the metadata.
+func NewImpersonationAuditLogger(next audit.AuditLogger) audit.AuditLogger {
+	return &impersonationAuditLogger{next: next}
+}
+
+func (l *impersonationAuditLogger) Log(ctx context.Context, event audit.AuditEvent) error {
+	if imp, ok := ImpersonationFromContext(ctx); ok {
+		metadata := make(map[string]interface{}, len(event.Metadata)+2)
+		for k, v := range event.Metadata {
+			metadata[k] = v
+		}
+		metadata["impersonator_id"] = imp.ImpersonatorID.String()
+		metadata["impersonation_session_id"] = imp.SessionID.String()
+		event.Metadata = metadata
+		if event.ActorID == "" {
+			event.ActorID = imp.TargetUserID.String()
+		}
+	}
+	return l.next.Log(ctx, event)
+}
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/auth/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/auth/model"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	"github.com/EduGoGroup/edugo-shared/auth"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockImpersonationRepo struct {
+	createFn       func(ctx context.Context, session *model.ImpersonationSession) error
+	listByTargetFn func(ctx context.Context, targetUserID uuid.UUID, limit int) ([]*model.ImpersonationSession, error)
+	endFn          func(ctx context.Context, id uuid.UUID, endedAt time.Time) error
+}
+
+func (m *mockImpersonationRepo) Create(ctx context.Context, session *model.ImpersonationSession) error {
+	if m.createFn != nil {
+		return m.createFn(ctx, session)
+	}
+	return nil
+}
+
+func (m *mockImpersonationRepo) FindByJTI(_ context.Context, _ string) (*model.ImpersonationSession, error) {
+	return nil, nil
+}
+
+func (m *mockImpersonationRepo) ListByTarget(ctx context.Context, targetUserID uuid.UUID, limit int) ([]*model.ImpersonationSession, error) {
+	if m.listByTargetFn != nil {
+		return m.listByTargetFn(ctx, targetUserID, limit)
+	}
+	return nil, nil
+}
+
+func (m *mockImpersonationRepo) End(ctx context.Context, id uuid.UUID, endedAt time.Time) error {
+	if m.endFn != nil {
+		return m.endFn(ctx, id, endedAt)
+	}
+	return nil
+}
+
+type impersonationFixture struct {
+	support            *entities.User
+	teacher            *entities.User
+	supportPermissions []string
+	repo               *mockImpersonationRepo
+	audits             []audit.AuditEvent
+	revoked            []string
+	svc                ImpersonationService
+}
+
+// newImpersonationFixture wires a support user who currently holds
+// users:impersonate and a teacher whose global role grants the given
+// permissions.
+func newImpersonationFixture(teacherPermissions []string) *impersonationFixture {
+	f := &impersonationFixture{
+		support:            &entities.User{ID: uuid.New(), Email: "soporte@edugo.test", FirstName: "Sop", LastName: "Orte", IsActive: true},
+		teacher:            &entities.User{ID: uuid.New(), Email: "docente@edugo.test", FirstName: "Do", LastName: "Cente", IsActive: true},
+		supportPermissions: []string{PermissionImpersonate},
+		repo:               &mockImpersonationRepo{},
+	}
+	role := newTestRole("teacher")
+	users := &mockUserRepo{findByIDFn: func(_ context.Context, id uuid.UUID) (*entities.User, error) {
+		switch id {
+		case f.support.ID:
+			return f.support, nil
+		case f.teacher.ID:
+			return f.teacher, nil
+		}
+		return nil, nil
+	}}
+	userRoles := &mockUserRoleRepo{
+		findByUserInContextFn: func(_ context.Context, userID uuid.UUID, schoolID *uuid.UUID, _ *uuid.UUID) ([]*entities.UserRole, error) {
+			if userID == f.teacher.ID && schoolID == nil {
+				return []*entities.UserRole{{RoleID: role.ID, UserID: userID}}, nil
+			}
+			return nil, nil
+		},
+		getUserPermissionsFn: func(_ context.Context, _ uuid.UUID, _ *uuid.UUID, _ *uuid.UUID) ([]string, error) {
+			return teacherPermissions, nil
+		},
+		getContextPermsFn: func(_ context.Context, userID uuid.UUID, _ *uuid.UUID, _ *uuid.UUID) ([]string, error) {
+			if userID == f.support.ID {
+				return f.supportPermissions, nil
+			}
+			return nil, nil
+		},
+	}
+	auditLog := &mockAuditLog{logFn: func(_ context.Context, event audit.AuditEvent) error {
+		f.audits = append(f.audits, event)
+		return nil
+	}}
+	blacklist := &mockBlacklist{revokeFn: func(jti string, _ time.Time) { f.revoked = append(f.revoked, jti) }}
+	tokens := newTestTokenService()
+	authSvc := NewAuthService(users, userRoles,
+		&mockRoleRepository{findByIDFn: func(_ context.Context, _ uuid.UUID) (*entities.Role, error) { return role, nil }},
+		&mockMembershipRepo{}, &mockSchoolRepo{}, &mockAcademicUnitRepo{}, tokens, &mockLog{}, auditLog, &mockLoginAttemptRepo{}, blacklist)
+	f.svc = NewImpersonationService(f.repo, users, userRoles, authSvc, tokens, blacklist, &mockLog{}, NewImpersonationAuditLogger(auditLog), 10*time.Minute)
+	return f
+}
+
+func TestImpersonation_Start_Success(t *testing.T) {
+	f := newImpersonationFixture([]string{"screens:read", "users:update"})
+	var saved *model.ImpersonationSession
+	f.repo.createFn = func(_ context.Context, session *model.ImpersonationSession) error {
+		saved = session
+		return nil
+	}
+
+	resp, err := f.svc.Start(context.Background(), f.support.ID.String(), &auth.UserContext{Permissions: []string{PermissionImpersonate}},
+		&dto.ImpersonateRequest{UserID: f.teacher.ID.String(), Reason: "el docente no ve su menú"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.AccessToken)
+	assert.Equal(t, f.teacher.ID.String(), resp.Target.ID)
+	assert.Equal(t, f.support.ID.String(), resp.Impersonator.ID)
+	assert.Equal(t, []string{"screens:read"}, resp.ActiveContext.Permissions, "solo permisos de lectura")
+	assert.LessOrEqual(t, resp.ExpiresIn, int64(600))
+
+	require.NotNil(t, saved)
+	claims, err := newTestTokenService().ValidateAccessToken(resp.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, f.teacher.ID.String(), claims.UserID)
+	assert.Equal(t, claims.ID, saved.TokenJTI)
+
+	imp, err := newTestTokenService().ReadImpersonation(resp.AccessToken)
+	require.NoError(t, err)
+	require.NotNil(t, imp, "el token debe llevar la suplantación en sus claims")
+	assert.Equal(t, f.support.ID, imp.ImpersonatorID)
+	assert.Equal(t, saved.ID, imp.SessionID)
+	assert.Equal(t, f.teacher.ID, imp.TargetUserID)
+	assert.Equal(t, claims.ID, imp.JTI)
+
+	require.Len(t, f.audits, 1)
+	assert.Equal(t, "impersonation_start", f.audits[0].Action)
+	assert.Equal(t, audit.SeverityCritical, f.audits[0].Severity)
+	assert.Equal(t, f.support.ID.String(), f.audits[0].ActorID)
+}
+
+func TestImpersonation_Start_Rejections(t *testing.T) {
+	f := newImpersonationFixture([]string{"screens:read"})
+	ctx := context.Background()
+	req := &dto.ImpersonateRequest{UserID: f.teacher.ID.String(), Reason: "revisión de soporte"}
+
+	_, err := f.svc.Start(ctx, f.support.ID.String(), &auth.UserContext{Permissions: []string{"users:read"}}, req)
+	assert.ErrorIs(t, err, ErrImpersonationForbidden)
+
+	_, err = f.svc.Start(ctx, f.support.ID.String(), &auth.UserContext{Permissions: []string{PermissionImpersonate}},
+		&dto.ImpersonateRequest{UserID: f.support.ID.String(), Reason: "revisión de soporte"})
+	assert.ErrorIs(t, err, ErrImpersonateSelf)
+
+	_, err = f.svc.Start(ctx, f.support.ID.String(), &auth.UserContext{Permissions: []string{PermissionImpersonate}},
+		&dto.ImpersonateRequest{UserID: uuid.New().String(), Reason: "revisión de soporte"})
+	assert.ErrorIs(t, err, ErrUserNotFound)
+
+	// the claim alone is not enough once the grant was revoked
+	f.supportPermissions = []string{"users:read"}
+	_, err = f.svc.Start(ctx, f.support.ID.String(), &auth.UserContext{Permissions: []string{PermissionImpersonate}}, req)
+	assert.ErrorIs(t, err, ErrImpersonationForbidden)
+
+	privileged := newImpersonationFixture([]string{PermissionImpersonate})
+	_, err = privileged.svc.Start(ctx, privileged.support.ID.String(), &auth.UserContext{Permissions: []string{PermissionImpersonate}},
+		&dto.ImpersonateRequest{UserID: privileged.teacher.ID.String(), Reason: "revisión de soporte"})
+	assert.ErrorIs(t, err, ErrImpersonationTargetAdmin)
+}
+
+func TestImpersonation_End(t *testing.T) {
+	f := newImpersonationFixture([]string{"screens:read"})
+	resp, err := f.svc.Start(context.Background(), f.support.ID.String(), &auth.UserContext{Permissions: []string{PermissionImpersonate}},
+		&dto.ImpersonateRequest{UserID: f.teacher.ID.String(), Reason: "revisión de soporte"})
+	require.NoError(t, err)
+	claims, err := newTestTokenService().ValidateAccessToken(resp.AccessToken)
+	require.NoError(t, err)
+	imp, err := newTestTokenService().ReadImpersonation(resp.AccessToken)
+	require.NoError(t, err)
+	require.NotNil(t, imp)
+
+	var ended uuid.UUID
+	f.repo.endFn = func(_ context.Context, id uuid.UUID, _ time.Time) error {
+		ended = id
+		return nil
+	}
+	require.NoError(t, f.svc.End(WithImpersonation(context.Background(), imp)))
+	assert.Equal(t, imp.SessionID, ended)
+	assert.Equal(t, []string{claims.ID}, f.revoked)
+
+	assert.ErrorIs(t, f.svc.End(context.Background()), ErrNotImpersonating)
+}
+
+func TestImpersonationAuditLogger_RecordsBothActors(t *testing.T) {
+	var logged audit.AuditEvent
+	inner := &mockAuditLog{logFn: func(_ context.Context, event audit.AuditEvent) error {
+		logged = event
+		return nil
+	}}
+	imp := &Impersonation{SessionID: uuid.New(), ImpersonatorID: uuid.New(), TargetUserID: uuid.New()}
+	ctx := WithImpersonation(context.Background(), imp)
+
+	err := NewImpersonationAuditLogger(inner).Log(ctx, audit.AuditEvent{Action: "read", Metadata: map[string]interface{}{"k": "v"}})
+	require.NoError(t, err)
+	assert.Equal(t, imp.TargetUserID.String(), logged.ActorID)
+	assert.Equal(t, imp.ImpersonatorID.String(), logged.Metadata["impersonator_id"])
+	assert.Equal(t, imp.SessionID.String(), logged.Metadata["impersonation_session_id"])
+	assert.Equal(t, "v", logged.Metadata["k"])
+}
+
+func TestTokenService_ReadImpersonation(t *testing.T) {
+	tokens := newTestTokenService()
+	activeContext := &auth.UserContext{RoleID: uuid.New().String(), RoleName: "teacher", Permissions: []string{"screens:read"}}
+
+	t.Run("un token normal no lleva suplantación", func(t *testing.T) {
+		pair, err := tokens.GenerateTokenPairWithContext(uuid.New().String(), "docente@edugo.test", activeContext)
+		require.NoError(t, err)
+		imp, err := tokens.ReadImpersonation(pair.AccessToken)
+		require.NoError(t, err)
+		assert.Nil(t, imp)
+		assert.False(t, tokens.IsImpersonationToken(pair.AccessToken))
+	})
+
+	t.Run("el token reescrito sigue siendo válido y conserva sus claims", func(t *testing.T) {
+		claim := ImpersonationClaim{ImpersonatorID: uuid.New().String(), SessionID: uuid.New().String()}
+		token, jti, _, err := tokens.GenerateImpersonationToken(uuid.New().String(), "docente@edugo.test", activeContext, time.Minute, claim)
+		require.NoError(t, err)
+		claims, err := tokens.ValidateAccessToken(token)
+		require.NoError(t, err)
+		assert.Equal(t, jti, claims.ID)
+		require.NotNil(t, claims.ActiveContext)
+		assert.Equal(t, []string{"screens:read"}, claims.ActiveContext.Permissions)
+		assert.True(t, tokens.IsImpersonationToken(token))
+	})
+
+	t.Run("rechaza un token firmado con otra clave", func(t *testing.T) {
+		other := NewTokenService(auth.NewJWTManager("another-secret-key-for-unit-tests", "test-issuer"), "another-secret-key-for-unit-tests", time.Minute, time.Hour)
+		claim := ImpersonationClaim{ImpersonatorID: uuid.New().String(), SessionID: uuid.New().String()}
+		token, _, _, err := other.GenerateImpersonationToken(uuid.New().String(), "docente@edugo.test", activeContext, time.Minute, claim)
+		require.NoError(t, err)
+		_, err = tokens.ReadImpersonation(token)
+		assert.Error(t, err)
+	})
+}
+
+func TestImpersonation_TokenCannotBeExtended(t *testing.T) {
+	f := newImpersonationFixture([]string{"screens:read"})
+	resp, err := f.svc.Start(context.Background(), f.support.ID.String(), &auth.UserContext{Permissions: []string{PermissionImpersonate}},
+		&dto.ImpersonateRequest{UserID: f.teacher.ID.String(), Reason: "revisión de soporte"})
+	require.NoError(t, err)
+	imp, err := newTestTokenService().ReadImpersonation(resp.AccessToken)
+	require.NoError(t, err)
+	require.NotNil(t, imp)
+
+	authSvc := NewAuthService(&mockUserRepo{findByIDFn: func(_ context.Context, _ uuid.UUID) (*entities.User, error) {
+		return f.teacher, nil
+	}}, &mockUserRoleRepo{}, &mockRoleRepository{}, &mockMembershipRepo{}, &mockSchoolRepo{}, &mockAcademicUnitRepo{},
+		newTestTokenService(), &mockLog{}, &mockAuditLog{}, &mockLoginAttemptRepo{}, &mockBlacklist{})
+
+	t.Run("no se puede usar como refresh token", func(t *testing.T) {
+		_, err := authSvc.RefreshToken(context.Background(), resp.AccessToken)
+		assert.ErrorIs(t, err, ErrImpersonationNotAllowed)
+	})
+
+	t.Run("no puede cambiar de contexto", func(t *testing.T) {
+		ctx := WithImpersonation(context.Background(), imp)
+		_, err := authSvc.SwitchContext(ctx, f.teacher.ID.String(), uuid.New().String(), "")
+		assert.ErrorIs(t, err, ErrImpersonationNotAllowed)
+	})
+}
+
+var _ auth.TokenBlacklist = (*mockBlacklist)(nil)
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+// UserSessionRegistry remembers per-user revocation times for as long as an
+// access token issued before them could still be valid. Like the token
+// blacklist it is kept in memory per instance: with several replicas, the
+// others keep accepting the user's access tokens until they expire.
+type UserSessionRegistry struct {
+	mu        sync.RWMutex
+	revokedAt map[string]time.Time
+	ttl       time.Duration
+}
+
+// NewUserSessionRegistry creates a registry; ttl is the access token lifetime
+func NewUserSessionRegistry(ttl time.Duration) *UserSessionRegistry {
+	return &UserSessionRegistry{revokedAt: make(map[string]time.Time), ttl: ttl}
+}
+
+// RevokeUserSessions invalidates every token issued to the user up to now.
+// Refresh tokens of inactive users are already rejected by RefreshToken, so
+// only access tokens still within their lifetime need tracking.
+func (r *UserSessionRegistry) RevokeUserSessions(userID string) {
+	now := time.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.revokedAt[userID] = now
+	// Drop entries no token can outlive any more
+	for id, at := range r.revokedAt {
+		if now.Sub(at) > r.ttl {
+			delete(r.revokedAt, id)
+		}
+	}
+}
+
+// IsRevoked reports whether a token issued to userID at issuedAt was revoked.
+// Tokens without an issue time are treated as revoked while an entry exists.
+func (r *UserSessionRegistry) IsRevoked(userID string, issuedAt *time.Time) bool {
+	r.mu.RLock()
+	at, ok := r.revokedAt[userID]
+	r.mu.RUnlock()
+	if !ok || time.Since(at) > r.ttl {
+		return false
+	}
+	return issuedAt == nil || !issuedAt.After(at)
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserSessionRegistry(t *testing.T) {
+	registry := NewUserSessionRegistry(time.Hour)
+	before := time.Now().Add(-time.Minute)
+
+	assert.False(t, registry.IsRevoked("user-1", &before), "sin revocación los tokens siguen válidos")
+
+	registry.RevokeUserSessions("user-1")
+	after := time.Now().Add(time.Second)
+
+	assert.True(t, registry.IsRevoked("user-1", &before), "tokens emitidos antes de la revocación")
+	assert.True(t, registry.IsRevoked("user-1", nil), "tokens sin fecha de emisión")
+	assert.False(t, registry.IsRevoked("user-1", &after), "tokens emitidos después (reactivación y nuevo login)")
+	assert.False(t, registry.IsRevoked("user-2", &before), "otros usuarios no se ven afectados")
+}
+
+func TestUserSessionRegistry_ExpiresWithTokenLifetime(t *testing.T) {
+	registry := NewUserSessionRegistry(time.Millisecond)
+	registry.RevokeUserSessions("user-1")
+	time.Sleep(5 * time.Millisecond)
+
+	assert.False(t, registry.IsRevoked("user-1", nil), "ningún token emitido antes puede seguir vigente")
+}
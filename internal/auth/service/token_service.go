@@ -7,17 +7,37 @@ import (
 
 	"github.com/EduGoGroup/edugo-api-iam-platform/internal/auth/dto"
 	"github.com/EduGoGroup/edugo-shared/auth"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
+// impersonationClaimKey is the JWT claim that marks an impersonation token
+const impersonationClaimKey = "imp"
+
+// ImpersonationClaim is embedded in impersonation tokens so the real actor can
+// be recognised from the token alone, on any instance and after restarts.
+type ImpersonationClaim struct {
+	ImpersonatorID string `json:"impersonator_id"`
+	SessionID      string `json:"session_id"`
+}
+
+// impersonationClaims decodes only the impersonation claim of a token
+type impersonationClaims struct {
+	Impersonation *ImpersonationClaim `json:"imp,omitempty"`
+	jwt.RegisteredClaims
+}
+
 // TokenService manages JWT token operations
 type TokenService struct {
 	jwtManager      *auth.JWTManager
+	signingKey      []byte
 	accessDuration  time.Duration
 	refreshDuration time.Duration
 }
 
-// NewTokenService creates a new TokenService
-func NewTokenService(jwtManager *auth.JWTManager, accessDuration, refreshDuration time.Duration) *TokenService {
+// NewTokenService creates a new TokenService. signingKey must be the secret the
+// JWT manager signs with; it is used to add and verify the impersonation claim.
+func NewTokenService(jwtManager *auth.JWTManager, signingKey string, accessDuration, refreshDuration time.Duration) *TokenService {
 	if accessDuration == 0 {
 		accessDuration = 15 * time.Minute
 	}
@@ -26,6 +46,7 @@ func NewTokenService(jwtManager *auth.JWTManager, accessDuration, refreshDuratio
 	}
 	return &TokenService{
 		jwtManager:      jwtManager,
+		signingKey:      []byte(signingKey),
 		accessDuration:  accessDuration,
 		refreshDuration: refreshDuration,
 	}
@@ -88,6 +109,88 @@ func (s *TokenService) GenerateAccessTokenWithContext(userID, email string, acti
 	}, nil
 }
 
+// GenerateImpersonationToken issues an access token for the target user with a
+// custom lifetime, no refresh token and the impersonation claim. It returns the
+// token JTI so the caller can record and revoke the impersonation session.
+func (s *TokenService) GenerateImpersonationToken(userID, email string, activeContext *auth.UserContext, ttl time.Duration, claim ImpersonationClaim) (string, string, time.Time, error) {
+	token, expiresAt, err := s.jwtManager.GenerateTokenWithContext(userID, email, activeContext, ttl)
+	if err != nil {
+		return "", "", time.Time{}, fmt.Errorf("error generating impersonation token: %w", err)
+	}
+
+	// The shared manager cannot add custom claims, so re-sign its token with
+	// the impersonation claim added and every other claim left untouched.
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	if err != nil {
+		return "", "", time.Time{}, fmt.Errorf("error reading impersonation token: %w", err)
+	}
+	claims := parsed.Claims.(jwt.MapClaims)
+	claims[impersonationClaimKey] = claim
+	resigned := jwt.NewWithClaims(parsed.Method, claims)
+	resigned.Header = parsed.Header
+	signed, err := resigned.SignedString(s.signingKey)
+	if err != nil {
+		return "", "", time.Time{}, fmt.Errorf("error signing impersonation token: %w", err)
+	}
+	jti, _ := claims["jti"].(string)
+	return signed, jti, expiresAt, nil
+}
+
+// ReadImpersonation returns the impersonation carried by a valid access token,
+// or nil when the token was not issued by an impersonation.
+func (s *TokenService) ReadImpersonation(token string) (*Impersonation, error) {
+	var extra impersonationClaims
+	_, err := jwt.ParseWithClaims(token, &extra, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+		return s.signingKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if extra.Impersonation == nil {
+		return nil, nil
+	}
+
+	claims, err := s.jwtManager.ValidateToken(token)
+	if err != nil {
+		return nil, err
+	}
+	impersonatorID, err := uuid.Parse(extra.Impersonation.ImpersonatorID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid impersonator id in token: %w", err)
+	}
+	sessionID, err := uuid.Parse(extra.Impersonation.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid impersonation session id in token: %w", err)
+	}
+	targetID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user id in token: %w", err)
+	}
+	imp := &Impersonation{
+		SessionID:      sessionID,
+		ImpersonatorID: impersonatorID,
+		TargetUserID:   targetID,
+		JTI:            claims.ID,
+	}
+	if claims.ExpiresAt != nil {
+		imp.ExpiresAt = claims.ExpiresAt.Time
+	}
+	return imp, nil
+}
+
+// IsImpersonationToken reports whether a token carries the impersonation claim.
+// The signature is not checked; callers validate the token separately.
+func (s *TokenService) IsImpersonationToken(token string) bool {
+	var extra impersonationClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &extra); err != nil {
+		return false
+	}
+	return extra.Impersonation != nil
+}
+
 // ValidateAccessToken validates an access token and returns the raw claims.
 // Used internally (e.g., for extracting JTI during logout).
 func (s *TokenService) ValidateAccessToken(token string) (*auth.Claims, error) {
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/auth/dto"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+// maxUnitDepth bounds parent-link walks so a misconfigured cycle in
+// academic.academic_units cannot loop forever.
+const maxUnitDepth = 10
+
+// parentUnitID reads the parent link of a unit; nil for top-level units
+func parentUnitID(unit *entities.AcademicUnit) *uuid.UUID {
+	return unit.ParentUnitID
+}
+
+// unitAncestors returns the chain of parent units of unitID, nearest first,
+// following AcademicUnitRepository parent links within the unit's school.
+func (s *authService) unitAncestors(ctx context.Context, unitID uuid.UUID) []uuid.UUID {
+	var ancestors []uuid.UUID
+	current := unitID
+	for range maxUnitDepth {
+		unit, err := s.academicUnitRepo.FindByID(ctx, current)
+		if err != nil || unit == nil {
+			if err != nil {
+				s.logger.Warn("error walking academic unit hierarchy", "academic_unit_id", current.String(), "error", err)
+			}
+			break
+		}
+		parent := parentUnitID(unit)
+		if parent == nil || *parent == unitID || slices.Contains(ancestors, *parent) {
+			break
+		}
+		ancestors = append(ancestors, *parent)
+		current = *parent
+	}
+	return ancestors
+}
+
+// unitTree indexes the active units of one school by parent
+type unitTree struct {
+	children map[uuid.UUID][]*entities.AcademicUnit
+}
+
+func (s *authService) loadUnitTree(ctx context.Context, schoolID uuid.UUID) *unitTree {
+	active := true
+	units, _, err := s.academicUnitRepo.FindBySchoolID(ctx, schoolID, sharedrepo.ListFilters{IsActive: &active})
+	if err != nil {
+		s.logger.Warn("error loading academic unit hierarchy", "school_id", schoolID.String(), "error", err)
+	}
+	tree := &unitTree{children: make(map[uuid.UUID][]*entities.AcademicUnit)}
+	for _, u := range units {
+		if !u.IsActive {
+			continue
+		}
+		if parent := parentUnitID(u); parent != nil {
+			tree.children[*parent] = append(tree.children[*parent], u)
+		}
+	}
+	return tree
+}
+
+// descendants returns every unit below rootID, parents before children, with
+// the path from rootID down to (excluding) each unit.
+func (t *unitTree) descendants(rootID uuid.UUID) []unitWithPath {
+	var result []unitWithPath
+	visited := map[uuid.UUID]bool{rootID: true}
+	queue := []unitWithPath{{path: []uuid.UUID{rootID}}}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		parentID := next.path[len(next.path)-1]
+		if len(next.path) > maxUnitDepth {
+			continue
+		}
+		for _, child := range t.children[parentID] {
+			if visited[child.ID] {
+				continue
+			}
+			visited[child.ID] = true
+			result = append(result, unitWithPath{unit: child, path: next.path})
+			queue = append(queue, unitWithPath{path: append(slices.Clone(next.path), child.ID)})
+		}
+	}
+	return result
+}
+
+type unitWithPath struct {
+	unit *entities.AcademicUnit
+	// path holds the ancestors the unit inherits from, root first
+	path []uuid.UUID
+}
+
+// inheritedPermissions merges the user's permissions in unitID with those of
+// the units it inherits from: roles granted on a grade level apply to its
+// sections.
+func (s *authService) inheritedPermissions(ctx context.Context, userID, schoolID, unitID uuid.UUID, ancestors []uuid.UUID) ([]string, error) {
+	perms, err := s.userRoleRepo.GetUserPermissions(ctx, userID, &schoolID, &unitID)
+	if err != nil {
+		return nil, err
+	}
+	for _, ancestor := range ancestors {
+		inherited, err := s.userRoleRepo.GetUserPermissions(ctx, userID, &schoolID, &ancestor)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range inherited {
+			if !slices.Contains(perms, p) {
+				perms = append(perms, p)
+			}
+		}
+	}
+	return perms, nil
+}
+
+// appendInheritedUnitContexts adds a context for every active unit below a
+// unit context the user already has, carrying the same role. Units the user
+// already reaches directly keep their own entry.
+func (s *authService) appendInheritedUnitContexts(ctx context.Context, userID uuid.UUID, available []*dto.UserContextDTO) []*dto.UserContextDTO {
+	type schoolUnitKey struct{ schoolID, unitID string }
+	present := make(map[schoolUnitKey]bool, len(available))
+	for _, c := range available {
+		present[schoolUnitKey{c.SchoolID, c.AcademicUnitID}] = true
+	}
+
+	trees := make(map[uuid.UUID]*unitTree)
+	roots := len(available)
+	for i := 0; i < roots; i++ {
+		root := available[i]
+		if root.SchoolID == "" || root.AcademicUnitID == "" {
+			continue
+		}
+		schoolID, err := uuid.Parse(root.SchoolID)
+		if err != nil {
+			continue
+		}
+		rootUnitID, err := uuid.Parse(root.AcademicUnitID)
+		if err != nil {
+			continue
+		}
+		tree, ok := trees[schoolID]
+		if !ok {
+			tree = s.loadUnitTree(ctx, schoolID)
+			trees[schoolID] = tree
+		}
+
+		for _, d := range tree.descendants(rootUnitID) {
+			key := schoolUnitKey{root.SchoolID, d.unit.ID.String()}
+			if present[key] {
+				continue
+			}
+			present[key] = true
+
+			perms, err := s.inheritedPermissions(ctx, userID, schoolID, d.unit.ID, d.path)
+			if err != nil {
+				s.logger.Warn("error fetching permissions for inherited unit context",
+					"user_id", userID.String(), "academic_unit_id", d.unit.ID.String(), "error", err)
+			}
+			if perms == nil {
+				perms = []string{}
+			}
+			available = append(available, &dto.UserContextDTO{
+				RoleID:              root.RoleID,
+				RoleName:            root.RoleName,
+				SchoolID:            root.SchoolID,
+				SchoolName:          root.SchoolName,
+				AcademicUnitID:      key.unitID,
+				AcademicUnitName:    d.unit.Name,
+				Permissions:         perms,
+				InheritedFromUnitID: root.AcademicUnitID,
+			})
+		}
+	}
+	return available
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/auth/dto"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/auth"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unitHierarchyFixture is a school with two grade levels, each with sections
+type unitHierarchyFixture struct {
+	schoolID     uuid.UUID
+	grade        *entities.AcademicUnit
+	sectionA     *entities.AcademicUnit
+	sectionB     *entities.AcademicUnit
+	otherGrade   *entities.AcademicUnit
+	otherSection *entities.AcademicUnit
+}
+
+func newUnitHierarchyFixture() *unitHierarchyFixture {
+	schoolID := uuid.New()
+	unit := func(name string, parent *entities.AcademicUnit) *entities.AcademicUnit {
+		u := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: name, IsActive: true}
+		if parent != nil {
+			u.ParentUnitID = &parent.ID
+		}
+		return u
+	}
+	f := &unitHierarchyFixture{schoolID: schoolID}
+	f.grade = unit("Grade 5", nil)
+	f.sectionA = unit("5A", f.grade)
+	f.sectionB = unit("5B", f.grade)
+	f.otherGrade = unit("Grade 6", nil)
+	f.otherSection = unit("6A", f.otherGrade)
+	return f
+}
+
+func (f *unitHierarchyFixture) units() []*entities.AcademicUnit {
+	return []*entities.AcademicUnit{f.grade, f.sectionA, f.sectionB, f.otherGrade, f.otherSection}
+}
+
+func (f *unitHierarchyFixture) repo() *mockAcademicUnitRepo {
+	return &mockAcademicUnitRepo{
+		findByIDFn: func(_ context.Context, id uuid.UUID) (*entities.AcademicUnit, error) {
+			for _, u := range f.units() {
+				if u.ID == id {
+					return u, nil
+				}
+			}
+			return nil, nil
+		},
+		findBySchoolIDFn: func(_ context.Context, _ uuid.UUID, _ sharedrepo.ListFilters) ([]*entities.AcademicUnit, int64, error) {
+			return f.units(), int64(len(f.units())), nil
+		},
+	}
+}
+
+// newCoordinatorService builds a service for a coordinator with a membership
+// and a role on the given units of the fixture school
+func newCoordinatorService(f *unitHierarchyFixture, user *entities.User, role *entities.Role, memberUnits ...*entities.AcademicUnit) AuthService {
+	var memberships []*entities.Membership
+	for _, u := range memberUnits {
+		memberships = append(memberships, &entities.Membership{SchoolID: f.schoolID, AcademicUnitID: &u.ID, IsActive: true})
+	}
+	return NewAuthService(
+		&mockUserRepo{
+			findByIDFn: func(_ context.Context, _ uuid.UUID) (*entities.User, error) {
+				return user, nil
+			},
+		},
+		&mockUserRoleRepo{
+			findByUserFn: func(_ context.Context, _ uuid.UUID) ([]*entities.UserRole, error) {
+				return []*entities.UserRole{{RoleID: role.ID, UserID: user.ID, SchoolID: &f.schoolID, AcademicUnitID: &f.grade.ID}}, nil
+			},
+			findByUserInContextFn: func(_ context.Context, _ uuid.UUID, _ *uuid.UUID, unitID *uuid.UUID) ([]*entities.UserRole, error) {
+				if unitID == nil || *unitID == f.grade.ID {
+					return []*entities.UserRole{{RoleID: role.ID, UserID: user.ID, SchoolID: &f.schoolID, AcademicUnitID: &f.grade.ID}}, nil
+				}
+				return nil, nil
+			},
+			getUserPermissionsFn: func(_ context.Context, _ uuid.UUID, _ *uuid.UUID, unitID *uuid.UUID) ([]string, error) {
+				if unitID != nil && *unitID == f.grade.ID {
+					return []string{"grades:update"}, nil
+				}
+				return []string{"materials:read"}, nil
+			},
+		},
+		&mockRoleRepository{
+			findByIDFn: func(_ context.Context, _ uuid.UUID) (*entities.Role, error) {
+				return role, nil
+			},
+		},
+		&mockMembershipRepo{
+			findByUserFn: func(_ context.Context, _ uuid.UUID, _ sharedrepo.ListFilters) ([]*entities.Membership, int64, error) {
+				return memberships, int64(len(memberships)), nil
+			},
+			findByUserAndSchoolFn: func(_ context.Context, _, _ uuid.UUID) (*entities.Membership, error) {
+				if len(memberships) == 0 {
+					return nil, sharedrepo.ErrNotFound
+				}
+				return memberships[0], nil
+			},
+		},
+		&mockSchoolRepo{
+			findByIDFn: func(_ context.Context, _ uuid.UUID) (*entities.School, error) {
+				return &entities.School{ID: f.schoolID, Name: "Test School"}, nil
+			},
+		},
+		f.repo(),
+		newTestTokenService(),
+		&mockLog{},
+		&mockAuditLog{},
+		&mockLoginAttemptRepo{},
+		&auth.NoOpBlacklist{},
+	)
+}
+
+func TestSwitchContext_ParentUnitMembershipGrantsChildUnit(t *testing.T) {
+	f := newUnitHierarchyFixture()
+	user := newTestUser()
+	svc := newCoordinatorService(f, user, newTestRole("coordinator"), f.grade)
+
+	resp, err := svc.SwitchContext(context.Background(), user.ID.String(), f.schoolID.String(), f.sectionA.ID.String())
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, f.sectionA.ID.String(), resp.Context.AcademicUnitID)
+	assert.Equal(t, "5A", resp.Context.AcademicUnitName)
+	assert.Equal(t, "coordinator", resp.Context.Role)
+
+	claims, err := newTestTokenService().ValidateAccessToken(resp.AccessToken)
+	require.NoError(t, err)
+	require.NotNil(t, claims.ActiveContext)
+	assert.ElementsMatch(t, []string{"materials:read", "grades:update"}, claims.ActiveContext.Permissions,
+		"permissions held on the grade level should be inherited by its sections")
+}
+
+func TestSwitchContext_UnitOutsideMembershipSubtree(t *testing.T) {
+	f := newUnitHierarchyFixture()
+	user := newTestUser()
+	svc := newCoordinatorService(f, user, newTestRole("coordinator"), f.grade)
+
+	resp, err := svc.SwitchContext(context.Background(), user.ID.String(), f.schoolID.String(), f.otherSection.ID.String())
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, ErrUnauthorizedUnit)
+}
+
+func TestSwitchContext_AutoSelectsTopmostUnit(t *testing.T) {
+	f := newUnitHierarchyFixture()
+	user := newTestUser()
+	svc := newCoordinatorService(f, user, newTestRole("coordinator"), f.grade, f.sectionB)
+
+	resp, err := svc.SwitchContext(context.Background(), user.ID.String(), f.schoolID.String(), "")
+	require.NoError(t, err)
+	assert.Equal(t, f.grade.ID.String(), resp.Context.AcademicUnitID,
+		"a section under the grade level should not make the unit ambiguous")
+}
+
+func TestGetAvailableContexts_InheritsChildUnits(t *testing.T) {
+	f := newUnitHierarchyFixture()
+	user := newTestUser()
+	svc := newCoordinatorService(f, user, newTestRole("coordinator"), f.grade)
+
+	resp, err := svc.GetAvailableContexts(context.Background(), user.ID.String(), nil)
+	require.NoError(t, err)
+
+	byUnit := make(map[string]*dto.UserContextDTO)
+	for _, c := range resp.Available {
+		byUnit[c.AcademicUnitID] = c
+	}
+	require.Contains(t, byUnit, f.grade.ID.String())
+	assert.Empty(t, byUnit[f.grade.ID.String()].InheritedFromUnitID)
+
+	for _, section := range []*entities.AcademicUnit{f.sectionA, f.sectionB} {
+		c, ok := byUnit[section.ID.String()]
+		require.True(t, ok, "section %s should be available", section.Name)
+		assert.Equal(t, "coordinator", c.RoleName)
+		assert.Equal(t, section.Name, c.AcademicUnitName)
+		assert.Equal(t, f.grade.ID.String(), c.InheritedFromUnitID)
+		assert.Contains(t, c.Permissions, "grades:update")
+	}
+	assert.NotContains(t, byUnit, f.otherSection.ID.String())
+}
@@ -0,0 +1,54 @@
+// Package cli implements the administrative subcommands of the service binary.
+// When the binary receives a subcommand it runs it and exits instead of serving HTTP.
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/container"
+)
+
+const usage = `Usage: main [command]
+
+Without a command the HTTP server is started. On a fresh database run
+migrate up before seed.
+
+Commands:
+  migrate up                                          Apply pending database migrations
+  migrate down [-steps N]                             Roll back the last N migrations (default 1)
+  migrate status                                      List migrations and whether they are applied
+  seed                                                Load the base IAM catalog into a local database
+  iam export [-format yaml|json] [-out file]          Export the IAM catalog as a manifest
+  iam apply -file manifest.yaml [-dry-run] [-prune]   Reconcile the IAM catalog with a manifest
+`
+
+// Run executes the subcommand in args and returns the process exit code
+func Run(ctx context.Context, c *container.Container, args []string) int {
+	return run(ctx, c, args, os.Stdout, os.Stderr)
+}
+
+func run(ctx context.Context, c *container.Container, args []string, stdout, stderr io.Writer) int {
+	var err error
+	switch args[0] {
+	case "migrate":
+		err = runMigrate(ctx, c, args[1:], stdout)
+	case "seed":
+		err = runSeed(ctx, c, stdout)
+	case "iam":
+		err = runIAM(ctx, c, args[1:], stdout)
+	case "help", "-h", "--help":
+		_, _ = fmt.Fprint(stdout, usage)
+		return 0
+	default:
+		_, _ = fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "error: %v\n", err)
+		return 1
+	}
+	return 0
+}
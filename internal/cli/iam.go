@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/container"
+)
+
+func runIAM(ctx context.Context, c *container.Container, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("iam: expected export or apply")
+	}
+	switch args[0] {
+	case "export":
+		return runIAMExport(ctx, c, args[1:], stdout)
+	case "apply":
+		return runIAMApply(ctx, c, args[1:], stdout)
+	default:
+		return fmt.Errorf("iam: unknown subcommand %q", args[0])
+	}
+}
+
+func runIAMExport(ctx context.Context, c *container.Container, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("iam export", flag.ContinueOnError)
+	format := fs.String("format", "yaml", "manifest format: yaml or json")
+	out := fs.String("out", "", "write the manifest to this file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	manifest, err := c.IAMCatalogService.Export(ctx)
+	if err != nil {
+		return err
+	}
+	body, err := service.MarshalIAMManifest(manifest, *format)
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		_, err = stdout.Write(body)
+		return err
+	}
+	if err := os.WriteFile(*out, body, 0o644); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(stdout, "exported %d resources, %d permissions, %d roles to %s\n",
+		len(manifest.Resources), len(manifest.Permissions), len(manifest.Roles), *out)
+	return nil
+}
+
+func runIAMApply(ctx context.Context, c *container.Container, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("iam apply", flag.ContinueOnError)
+	file := fs.String("file", "", "manifest file (yaml or json)")
+	dryRun := fs.Bool("dry-run", false, "only print the plan")
+	prune := fs.Bool("prune", false, "deactivate entities not declared in the manifest")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("iam apply: -file is required")
+	}
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+	format := ""
+	switch {
+	case strings.HasSuffix(*file, ".json"):
+		format = "json"
+	case strings.HasSuffix(*file, ".yaml"), strings.HasSuffix(*file, ".yml"):
+		format = "yaml"
+	}
+	manifest, err := service.ParseIAMManifest(data, format)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.IAMCatalogService.Apply(ctx, manifest, *dryRun, *prune)
+	if err != nil {
+		return err
+	}
+	printIAMPlan(stdout, result)
+	return nil
+}
+
+func printIAMPlan(w io.Writer, result *dto.IAMApplyResponse) {
+	if len(result.Changes) == 0 {
+		_, _ = fmt.Fprintln(w, "No changes. The IAM catalog matches the manifest.")
+		return
+	}
+	symbols := map[string]string{
+		service.IAMOpCreate:     "+",
+		service.IAMOpUpdate:     "~",
+		service.IAMOpDeactivate: "-",
+		service.IAMOpRelink:     "~",
+	}
+	for _, ch := range result.Changes {
+		line := fmt.Sprintf("  %s %s %s", symbols[ch.Op], ch.Kind, ch.Key)
+		if len(ch.Fields) > 0 {
+			line += " (" + strings.Join(ch.Fields, ", ") + ")"
+		}
+		for _, a := range ch.Added {
+			line += "\n      + " + a
+		}
+		for _, r := range ch.Removed {
+			line += "\n      - " + r
+		}
+		_, _ = fmt.Fprintln(w, line)
+	}
+	_, _ = fmt.Fprintf(w, "\nPlan: %d to create, %d to update, %d to deactivate, %d role permission sets to relink.\n",
+		result.Summary.Create, result.Summary.Update, result.Summary.Deactivate, result.Summary.Relink)
+	if result.Applied {
+		_, _ = fmt.Fprintln(w, "Applied.")
+	} else {
+		_, _ = fmt.Fprintln(w, "Dry run: nothing was changed.")
+	}
+}
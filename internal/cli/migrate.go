@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/container"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/infrastructure/persistence/postgres/migrations"
+)
+
+func runMigrate(ctx context.Context, c *container.Container, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("migrate: expected up, down or status")
+	}
+	migrator, err := migrations.NewMigrator(c.DB)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			_, _ = fmt.Fprintf(stdout, "applied  %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			_, _ = fmt.Fprintln(stdout, "database is up to date")
+		}
+		return nil
+	case "down":
+		fs := flag.NewFlagSet("migrate down", flag.ContinueOnError)
+		steps := fs.Int("steps", 1, "number of migrations to roll back")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *steps < 1 {
+			return fmt.Errorf("migrate down: -steps must be at least 1")
+		}
+		reverted, err := migrator.Down(ctx, *steps)
+		for _, m := range reverted {
+			_, _ = fmt.Fprintf(stdout, "reverted %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(reverted) == 0 {
+			_, _ = fmt.Fprintln(stdout, "no applied migrations to roll back")
+		}
+		return nil
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.AppliedAt != nil {
+				state = "applied " + s.AppliedAt.Format(time.RFC3339)
+			}
+			_, _ = fmt.Fprintf(stdout, "%04d_%-40s %s\n", s.Version, s.Name, state)
+		}
+		return nil
+	default:
+		return fmt.Errorf("migrate: unknown subcommand %q", args[0])
+	}
+}
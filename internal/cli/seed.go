@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"context"
+	_ "embed"
+	"io"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/container"
+)
+
+// seedManifest is the IAM catalog needed to operate this service on a fresh local
+// database. It is applied without pruning, so existing entries are kept. Run
+// "migrate up" first.
+//
+//go:embed seed/iam_catalog.yaml
+var seedManifest []byte
+
+func runSeed(ctx context.Context, c *container.Container, stdout io.Writer) error {
+	manifest, err := service.ParseIAMManifest(seedManifest, "yaml")
+	if err != nil {
+		return err
+	}
+	result, err := c.IAMCatalogService.Apply(ctx, manifest, false, false)
+	if err != nil {
+		return err
+	}
+	printIAMPlan(stdout, result)
+	return nil
+}
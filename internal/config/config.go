@@ -8,12 +8,23 @@ import (
 )
 
 type Config struct {
-	Environment string         `env:"APP_ENV"     envDefault:"development"`
-	Server      ServerConfig   `envPrefix:"SERVER_"`
-	Database    DatabaseConfig `envPrefix:"DATABASE_"`
-	Auth        AuthConfig     `envPrefix:"AUTH_"`
-	Logging     LoggingConfig  `envPrefix:"LOGGING_"`
-	CORS        CORSConfig     `envPrefix:"CORS_"`
+	Environment   string              `env:"APP_ENV"     envDefault:"development"`
+	Server        ServerConfig        `envPrefix:"SERVER_"`
+	Database      DatabaseConfig      `envPrefix:"DATABASE_"`
+	Auth          AuthConfig          `envPrefix:"AUTH_"`
+	Authz         AuthzConfig         `envPrefix:"AUTHZ_"`
+	Approvals     ApprovalsConfig     `envPrefix:"APPROVALS_"`
+	UserRoles     UserRolesConfig     `envPrefix:"USER_ROLES_"`
+	Access        AccessConfig        `envPrefix:"ACCESS_REQUESTS_"`
+	Reviews       ReviewsConfig       `envPrefix:"ACCESS_REVIEWS_"`
+	Impersonation ImpersonationConfig `envPrefix:"IMPERSONATION_"`
+	Delegations   DelegationsConfig   `envPrefix:"DELEGATIONS_"`
+	BreakGlass    BreakGlassConfig    `envPrefix:"BREAK_GLASS_"`
+	RoleImports   RoleImportsConfig   `envPrefix:"ROLE_IMPORTS_"`
+	ScreenConfig  ScreenConfigConfig  `envPrefix:"SCREEN_CONFIG_"`
+	I18n          I18nConfig          `envPrefix:"I18N_"`
+	Logging       LoggingConfig       `envPrefix:"LOGGING_"`
+	CORS          CORSConfig          `envPrefix:"CORS_"`
 }
 
 type ServerConfig struct {
@@ -55,6 +66,68 @@ type JWTConfig struct {
 	RefreshTokenDuration time.Duration `env:"REFRESH_TOKEN_DURATION" envDefault:"168h"`
 }
 
+type AuthzConfig struct {
+	CacheTTL time.Duration `env:"CACHE_TTL" envDefault:"30s"`
+}
+
+type ApprovalsConfig struct {
+	RequestTTL    time.Duration `env:"REQUEST_TTL"    envDefault:"72h"`
+	SweepInterval time.Duration `env:"SWEEP_INTERVAL" envDefault:"15m"`
+}
+
+type UserRolesConfig struct {
+	SweepInterval time.Duration `env:"SWEEP_INTERVAL" envDefault:"5m"`
+}
+
+type AccessConfig struct {
+	GrantTTL time.Duration `env:"GRANT_TTL" envDefault:"2160h"`
+}
+
+type ReviewsConfig struct {
+	SweepInterval time.Duration `env:"SWEEP_INTERVAL" envDefault:"1h"`
+}
+
+type ImpersonationConfig struct {
+	TokenTTL time.Duration `env:"TOKEN_TTL" envDefault:"15m"`
+}
+
+type DelegationsConfig struct {
+	MaxDuration   time.Duration `env:"MAX_DURATION"   envDefault:"2160h"`
+	SweepInterval time.Duration `env:"SWEEP_INTERVAL" envDefault:"5m"`
+}
+
+// BreakGlassConfig bounds emergency elevations. Alerts go to AlertWebhookURL
+// when set and only to the log otherwise.
+type BreakGlassConfig struct {
+	Role            string        `env:"ROLE"              envDefault:"platform_admin"`
+	TTL             time.Duration `env:"TTL"               envDefault:"1h"`
+	MaxActivations  int           `env:"MAX_ACTIVATIONS"   envDefault:"1"`
+	RateWindow      time.Duration `env:"RATE_WINDOW"       envDefault:"24h"`
+	SweepInterval   time.Duration `env:"SWEEP_INTERVAL"    envDefault:"1m"`
+	AlertWebhookURL string        `env:"ALERT_WEBHOOK_URL"`
+}
+
+// RoleImportsConfig bounds bulk role assignment imports. Uploaded jobs are
+// picked up by a background job every PollInterval.
+type RoleImportsConfig struct {
+	MaxRows      int           `env:"MAX_ROWS"       envDefault:"10000"`
+	MaxFileBytes int64         `env:"MAX_FILE_BYTES" envDefault:"10485760"`
+	PollInterval time.Duration `env:"POLL_INTERVAL"  envDefault:"5s"`
+}
+
+// ScreenConfigConfig controls screen configuration publishing. Drafts
+// scheduled for a future time are published every PublishInterval.
+type ScreenConfigConfig struct {
+	PublishInterval time.Duration `env:"PUBLISH_INTERVAL" envDefault:"1m"`
+}
+
+// I18nConfig lists the locales menus, screens and sync bundles are served in.
+// DefaultLocale is the language of untranslated names.
+type I18nConfig struct {
+	DefaultLocale string   `env:"DEFAULT_LOCALE" envDefault:"es"`
+	Locales       []string `env:"LOCALES"        envDefault:"es,en,pt"`
+}
+
 type CORSConfig struct {
 	AllowedOrigins string `env:"ALLOWED_ORIGINS" envDefault:"*"`
 	AllowedMethods string `env:"ALLOWED_METHODS" envDefault:"GET,POST,PUT,PATCH,DELETE,OPTIONS"`
@@ -1,6 +1,9 @@
 package container
 
 import (
+	"context"
+	"time"
+
 	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/service"
 	auditHandler "github.com/EduGoGroup/edugo-api-iam-platform/internal/audit/handler"
 	auditRepo "github.com/EduGoGroup/edugo-api-iam-platform/internal/audit/repository"
@@ -11,7 +14,9 @@ import (
 	"github.com/EduGoGroup/edugo-api-iam-platform/internal/config"
 	"github.com/EduGoGroup/edugo-api-iam-platform/internal/infrastructure/cache"
 	"github.com/EduGoGroup/edugo-api-iam-platform/internal/infrastructure/http/handler"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/infrastructure/jobs"
 	pgRepo "github.com/EduGoGroup/edugo-api-iam-platform/internal/infrastructure/persistence/postgres/repository"
+	"github.com/EduGoGroup/edugo-shared/audit"
 	auditpostgres "github.com/EduGoGroup/edugo-shared/audit/postgres"
 	"github.com/EduGoGroup/edugo-shared/auth"
 	"github.com/EduGoGroup/edugo-shared/logger"
@@ -28,21 +33,47 @@ type Container struct {
 	JWTManager *auth.JWTManager
 	Blacklist  auth.TokenBlacklist
 
+	// Sessions tracks users whose tokens were revoked by deactivation
+	Sessions *authService.UserSessionRegistry
+
+	// AuditLogger records the impersonator on events written under an impersonation token
+	AuditLogger audit.AuditLogger
+
 	// Auth
-	TokenService  *authService.TokenService
-	AuthService   authService.AuthService
-	AuthHandler   *authHandler.AuthHandler
-	VerifyHandler *authHandler.VerifyHandler
+	TokenService         *authService.TokenService
+	AuthService          authService.AuthService
+	ImpersonationService authService.ImpersonationService
+	AuthHandler          *authHandler.AuthHandler
+	VerifyHandler        *authHandler.VerifyHandler
+	ImpersonationHandler *authHandler.ImpersonationHandler
+
+	// Services used by CLI subcommands
+	IAMCatalogService service.IAMCatalogService
+
+	// Background jobs started by the server
+	Jobs []jobs.Job
 
 	// Handlers
-	RoleHandler         *handler.RoleHandler
-	ResourceHandler     *handler.ResourceHandler
-	MenuHandler         *handler.MenuHandler
-	PermissionHandler   *handler.PermissionHandler
-	ScreenConfigHandler *handler.ScreenConfigHandler
-	SyncHandler         *handler.SyncHandler
-	HealthHandler       *handler.HealthHandler
-	AuditHandler        *auditHandler.AuditHandler
+	RoleHandler           *handler.RoleHandler
+	ResourceHandler       *handler.ResourceHandler
+	MenuHandler           *handler.MenuHandler
+	I18nHandler           *handler.I18nHandler
+	GlossaryHandler       *handler.GlossaryHandler
+	PermissionHandler     *handler.PermissionHandler
+	ScreenConfigHandler   *handler.ScreenConfigHandler
+	SyncHandler           *handler.SyncHandler
+	AuthzHandler          *handler.AuthzHandler
+	RoleGrantHandler      *handler.RoleGrantHandler
+	AccessRequestHandler  *handler.AccessRequestHandler
+	AccessReviewHandler   *handler.AccessReviewHandler
+	SoDHandler            *handler.SoDHandler
+	RoleDelegationHandler *handler.RoleDelegationHandler
+	BreakGlassHandler     *handler.BreakGlassHandler
+	UserHandler           *handler.UserHandler
+	RoleImportHandler     *handler.RoleImportHandler
+	IAMCatalogHandler     *handler.IAMCatalogHandler
+	HealthHandler         *handler.HealthHandler
+	AuditHandler          *auditHandler.AuditHandler
 }
 
 // NewContainer creates a new container and initializes all dependencies
@@ -53,10 +84,12 @@ func NewContainer(db *gorm.DB, log logger.Logger, cfg *config.Config, blacklist
 		Metrics:    metrics.New("edugo-api-iam-platform"),
 		JWTManager: auth.NewJWTManager(cfg.Auth.JWT.Secret, cfg.Auth.JWT.Issuer),
 		Blacklist:  blacklist,
+		Sessions:   authService.NewUserSessionRegistry(cfg.Auth.JWT.AccessTokenDuration),
 	}
 
 	// Audit logger
-	auditLogger := auditpostgres.NewPostgresAuditLogger(db, "iam-platform")
+	auditLogger := authService.NewImpersonationAuditLogger(auditpostgres.NewPostgresAuditLogger(db, "iam-platform"))
+	c.AuditLogger = auditLogger
 
 	// Shared Repositories (from edugo-shared/repository)
 	userRepo := sharedPgRepo.NewPostgresUserRepository(db)
@@ -74,26 +107,76 @@ func NewContainer(db *gorm.DB, log logger.Logger, cfg *config.Config, blacklist
 	cachedTemplateRepo := cache.NewCachedScreenTemplateRepository(screenTemplateRepo)
 	screenInstanceRepo := pgRepo.NewPostgresScreenInstanceRepository(db)
 	resourceScreenRepo := pgRepo.NewPostgresResourceScreenRepository(db)
+	screenVersionRepo := pgRepo.NewPostgresScreenVersionRepository(db)
+	screenDraftRepo := pgRepo.NewPostgresScreenDraftRepository(db)
+	screenSchemaRepo := pgRepo.NewPostgresScreenPatternSchemaRepository(db)
+	screenOverrideRepo := pgRepo.NewPostgresScreenOverrideRepository(db)
+	screenBundleRepo := cache.NewScreenConfigBundleRepository(pgRepo.NewPostgresScreenConfigBundleRepository(db), cachedTemplateRepo)
+	translationRepo := pgRepo.NewPostgresTranslationRepository(db)
+	preferenceRepo := pgRepo.NewPostgresUserPreferenceRepository(db)
 	schoolConceptRepo := pgRepo.NewPostgresSchoolConceptRepository(db)
+	glossaryDefaultRepo := pgRepo.NewPostgresGlossaryDefaultRepository(db)
+	iamCatalogRepo := pgRepo.NewPostgresIAMCatalogRepository(db)
+	grantPolicyRepo := pgRepo.NewPostgresRoleGrantPolicyRepository(db)
+	grantRequestRepo := pgRepo.NewPostgresRoleGrantRequestRepository(db)
+	accessRequestRepo := pgRepo.NewPostgresAccessRequestRepository(db)
+	accessReviewRepo := pgRepo.NewPostgresAccessReviewRepository(db)
+	sodConstraintRepo := pgRepo.NewPostgresSoDConstraintRepository(db)
+	delegationRepo := pgRepo.NewPostgresRoleDelegationRepository(db)
+	breakGlassRepo := pgRepo.NewPostgresBreakGlassRepository(db)
+	roleImportRepo := pgRepo.NewPostgresRoleImportRepository(db)
+	userErasureRepo := pgRepo.NewPostgresUserErasureRepository(db)
 
 	// Login attempt repository
 	loginAttemptRepo := authrepo.NewPostgresLoginAttemptRepository(db)
+	impersonationRepo := authrepo.NewPostgresImpersonationRepository(db)
 
 	// Auth
-	c.TokenService = authService.NewTokenService(c.JWTManager, cfg.Auth.JWT.AccessTokenDuration, cfg.Auth.JWT.RefreshTokenDuration)
-	c.AuthService = authService.NewAuthService(userRepo, userRoleRepo, roleRepo, membershipRepo, schoolRepo, academicUnitRepo, c.TokenService, log, auditLogger, loginAttemptRepo, blacklist)
+	c.TokenService = authService.NewTokenService(c.JWTManager, cfg.Auth.JWT.Secret, cfg.Auth.JWT.AccessTokenDuration, cfg.Auth.JWT.RefreshTokenDuration)
+	c.AuthService = authService.NewDelegationAwareAuthService(
+		authService.NewAuthService(userRepo, userRoleRepo, roleRepo, membershipRepo, schoolRepo, academicUnitRepo, c.TokenService, log, auditLogger, loginAttemptRepo, blacklist),
+		delegationRepo, log)
 	c.AuthHandler = authHandler.NewAuthHandler(c.AuthService, log)
 	c.VerifyHandler = authHandler.NewVerifyHandler(c.TokenService)
+	c.ImpersonationService = authService.NewImpersonationService(impersonationRepo, userRepo, userRoleRepo, c.AuthService, c.TokenService, blacklist, log, auditLogger, cfg.Impersonation.TokenTTL)
+	c.ImpersonationHandler = authHandler.NewImpersonationHandler(c.ImpersonationService, log)
+
+	// Workflow notifications (log only until a delivery channel is configured)
+	notifier := service.NewLogNotifier(log)
+
+	// Security alerts (break-glass) go to a webhook when one is configured
+	alerter := service.NewLogAlerter(log)
+	if cfg.BreakGlass.AlertWebhookURL != "" {
+		alerter = service.NewWebhookAlerter(cfg.BreakGlass.AlertWebhookURL, 5*time.Second)
+	}
 
 	// Services
-	roleService := service.NewRoleService(roleRepo, permissionRepo, userRoleRepo, rolePermRepo, log, auditLogger)
-	resourceService := service.NewResourceService(resourceRepo, log)
-	menuService := service.NewMenuService(resourceRepo, resourceScreenRepo, log)
+	locales := service.NewLocaleSettings(cfg.I18n.DefaultLocale, cfg.I18n.Locales)
+	i18nService := service.NewI18nService(translationRepo, preferenceRepo, resourceRepo, screenInstanceRepo, locales, log)
+	menuService := service.NewMenuService(resourceRepo, resourceScreenRepo, translationRepo, log)
+	sodService := service.NewSoDService(sodConstraintRepo, roleRepo, permissionRepo, userRoleRepo, log, auditLogger)
+	grantApprovalService := service.NewRoleGrantApprovalService(grantPolicyRepo, grantRequestRepo, roleRepo, userRoleRepo, sodService, notifier, log, auditLogger, cfg.Approvals.RequestTTL)
+	roleService := service.NewRoleService(roleRepo, permissionRepo, userRoleRepo, rolePermRepo, menuService, grantApprovalService, sodService, log, auditLogger)
+	accessRequestService := service.NewAccessRequestService(accessRequestRepo, roleRepo, userRoleRepo, roleService, notifier, log, auditLogger, cfg.Access.GrantTTL)
+	accessReviewService := service.NewAccessReviewService(accessReviewRepo, userRoleRepo, roleRepo, notifier, log, auditLogger)
+	breakGlassService := service.NewBreakGlassService(breakGlassRepo, roleRepo, userRoleRepo, alerter, log, auditLogger, service.BreakGlassPolicy{
+		RoleName:       cfg.BreakGlass.Role,
+		TTL:            cfg.BreakGlass.TTL,
+		MaxActivations: cfg.BreakGlass.MaxActivations,
+		RateWindow:     cfg.BreakGlass.RateWindow,
+	})
+	delegationService := service.NewRoleDelegationService(delegationRepo, userRepo, userRoleRepo, roleRepo, sodService, grantApprovalService, notifier, log, auditLogger, cfg.Delegations.MaxDuration)
+	roleImportService := service.NewRoleImportService(roleImportRepo, userRepo, roleRepo, schoolRepo, academicUnitRepo, userRoleRepo, sodService, grantApprovalService, log, auditLogger, cfg.RoleImports.MaxRows)
+	userService := service.NewUserService(userRepo, userErasureRepo, userRoleRepo, c.Sessions, log, auditLogger)
+	resourceService := service.NewResourceService(resourceRepo, screenBundleRepo, log)
 	permissionService := service.NewPermissionService(permissionRepo, resourceRepo, log, auditLogger)
-	screenConfigService := service.NewScreenConfigService(cachedTemplateRepo, screenInstanceRepo, resourceScreenRepo, log)
+	screenConfigService := service.NewScreenConfigService(cachedTemplateRepo, screenInstanceRepo, resourceScreenRepo, screenVersionRepo, screenDraftRepo, screenSchemaRepo, screenOverrideRepo, translationRepo, screenBundleRepo, locales, log)
+	glossaryService := service.NewGlossaryService(glossaryDefaultRepo, schoolConceptRepo, log, auditLogger)
+	authzService := service.NewAuthzService(userRoleRepo, log, cfg.Authz.CacheTTL)
+	c.IAMCatalogService = service.NewIAMCatalogService(iamCatalogRepo, sodService, log, auditLogger)
 
 	// Sync
-	syncService := service.NewSyncService(menuService, screenConfigService, c.AuthService, screenInstanceRepo, schoolConceptRepo, log)
+	syncService := service.NewSyncService(menuService, screenConfigService, c.AuthService, screenInstanceRepo, glossaryService, log)
 
 	// Audit query
 	auditRepository := auditRepo.NewPostgresAuditRepository(db)
@@ -104,11 +187,55 @@ func NewContainer(db *gorm.DB, log logger.Logger, cfg *config.Config, blacklist
 	c.RoleHandler = handler.NewRoleHandler(roleService, log)
 	c.ResourceHandler = handler.NewResourceHandler(resourceService, log)
 	c.MenuHandler = handler.NewMenuHandler(menuService, log)
+	c.I18nHandler = handler.NewI18nHandler(i18nService, log)
+	c.GlossaryHandler = handler.NewGlossaryHandler(glossaryService, log)
 	c.PermissionHandler = handler.NewPermissionHandler(permissionService, log)
 	c.ScreenConfigHandler = handler.NewScreenConfigHandler(screenConfigService, log)
 	c.SyncHandler = handler.NewSyncHandler(syncService, log)
+	c.AuthzHandler = handler.NewAuthzHandler(authzService, log)
+	c.RoleGrantHandler = handler.NewRoleGrantHandler(grantApprovalService, log)
+	c.AccessRequestHandler = handler.NewAccessRequestHandler(accessRequestService, log)
+	c.AccessReviewHandler = handler.NewAccessReviewHandler(accessReviewService, log)
+	c.SoDHandler = handler.NewSoDHandler(sodService, log)
+	c.RoleDelegationHandler = handler.NewRoleDelegationHandler(delegationService, log)
+	c.BreakGlassHandler = handler.NewBreakGlassHandler(breakGlassService, log)
+	c.UserHandler = handler.NewUserHandler(userService, log)
+	c.RoleImportHandler = handler.NewRoleImportHandler(roleImportService, cfg.RoleImports.MaxFileBytes, log)
+	c.IAMCatalogHandler = handler.NewIAMCatalogHandler(c.IAMCatalogService, log)
 	c.HealthHandler = handler.NewHealthHandler(db, "dev")
 
+	// Background jobs
+	c.Jobs = []jobs.Job{
+		{Name: "expire_user_roles", Interval: cfg.UserRoles.SweepInterval, Run: func(ctx context.Context) error {
+			_, err := roleService.ExpireGrants(ctx)
+			return err
+		}},
+		{Name: "expire_role_grant_requests", Interval: cfg.Approvals.SweepInterval, Run: func(ctx context.Context) error {
+			_, err := grantApprovalService.ExpirePending(ctx)
+			return err
+		}},
+		{Name: "close_due_access_reviews", Interval: cfg.Reviews.SweepInterval, Run: func(ctx context.Context) error {
+			_, err := accessReviewService.CloseDueCampaigns(ctx)
+			return err
+		}},
+		{Name: "process_role_delegations", Interval: cfg.Delegations.SweepInterval, Run: func(ctx context.Context) error {
+			_, err := delegationService.ProcessDue(ctx)
+			return err
+		}},
+		{Name: "process_role_imports", Interval: cfg.RoleImports.PollInterval, Run: func(ctx context.Context) error {
+			_, err := roleImportService.ProcessPending(ctx)
+			return err
+		}},
+		{Name: "expire_break_glass_elevations", Interval: cfg.BreakGlass.SweepInterval, Run: func(ctx context.Context) error {
+			_, err := breakGlassService.ExpireDue(ctx)
+			return err
+		}},
+		{Name: "publish_scheduled_screens", Interval: cfg.ScreenConfig.PublishInterval, Run: func(ctx context.Context) error {
+			_, err := screenConfigService.PublishDue(ctx)
+			return err
+		}},
+	}
+
 	return c
 }
 
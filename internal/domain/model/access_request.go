@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Access request statuses
+const (
+	AccessRequestPending   = "pending"
+	AccessRequestApproved  = "approved"
+	AccessRequestRejected  = "rejected"
+	AccessRequestCancelled = "cancelled"
+)
+
+// AccessRequest maps to iam.access_requests: a user asking for a role in a
+// school or academic unit. Approval grants the role through the regular
+// grant flow; for roles that require approval it yields a RoleGrantRequest.
+type AccessRequest struct {
+	ID                 uuid.UUID  `gorm:"column:id;type:uuid;primaryKey"`
+	UserID             uuid.UUID  `gorm:"column:user_id;type:uuid;not null"`
+	RoleID             uuid.UUID  `gorm:"column:role_id;type:uuid;not null"`
+	SchoolID           uuid.UUID  `gorm:"column:school_id;type:uuid;not null"`
+	AcademicUnitID     *uuid.UUID `gorm:"column:academic_unit_id;type:uuid"`
+	Justification      string     `gorm:"column:justification;not null"`
+	Status             string     `gorm:"column:status;not null;default:pending"`
+	DecidedBy          *uuid.UUID `gorm:"column:decided_by;type:uuid"`
+	DecidedAt          *time.Time `gorm:"column:decided_at"`
+	DecisionNote       *string    `gorm:"column:decision_note"`
+	GrantExpiresAt     *time.Time `gorm:"column:grant_expires_at"`
+	UserRoleID         *uuid.UUID `gorm:"column:user_role_id;type:uuid"`
+	RoleGrantRequestID *uuid.UUID `gorm:"column:role_grant_request_id;type:uuid"`
+	CreatedAt          time.Time  `gorm:"column:created_at;not null;default:now()"`
+	UpdatedAt          time.Time  `gorm:"column:updated_at;not null;default:now()"`
+}
+
+func (AccessRequest) TableName() string {
+	return "iam.access_requests"
+}
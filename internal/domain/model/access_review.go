@@ -0,0 +1,66 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Access review campaign statuses
+const (
+	ReviewCampaignActive    = "active"
+	ReviewCampaignCompleted = "completed"
+)
+
+// Access review item decisions
+const (
+	ReviewDecisionPending     = "pending"
+	ReviewDecisionCertified   = "certified"
+	ReviewDecisionRevoked     = "revoked"
+	ReviewDecisionAutoRevoked = "auto_revoked"
+)
+
+// AccessReviewCampaign maps to iam.access_review_campaigns: a periodic
+// certification of the active user_roles matching its scope. Nil scope
+// fields match everything.
+type AccessReviewCampaign struct {
+	ID             uuid.UUID  `gorm:"column:id;type:uuid;primaryKey"`
+	Name           string     `gorm:"column:name;not null"`
+	Description    string     `gorm:"column:description"`
+	SchoolID       *uuid.UUID `gorm:"column:school_id;type:uuid"`
+	RoleID         *uuid.UUID `gorm:"column:role_id;type:uuid"`
+	AcademicUnitID *uuid.UUID `gorm:"column:academic_unit_id;type:uuid"`
+	Deadline       time.Time  `gorm:"column:deadline;not null"`
+	Status         string     `gorm:"column:status;not null;default:active"`
+	CreatedBy      *uuid.UUID `gorm:"column:created_by;type:uuid"`
+	CompletedAt    *time.Time `gorm:"column:completed_at"`
+	CreatedAt      time.Time  `gorm:"column:created_at;not null;default:now()"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at;not null;default:now()"`
+}
+
+func (AccessReviewCampaign) TableName() string {
+	return "iam.access_review_campaigns"
+}
+
+// AccessReviewItem maps to iam.access_review_items: one user_role under
+// review, assigned to a reviewer.
+type AccessReviewItem struct {
+	ID             uuid.UUID  `gorm:"column:id;type:uuid;primaryKey"`
+	CampaignID     uuid.UUID  `gorm:"column:campaign_id;type:uuid;not null"`
+	UserRoleID     uuid.UUID  `gorm:"column:user_role_id;type:uuid;not null"`
+	UserID         uuid.UUID  `gorm:"column:user_id;type:uuid;not null"`
+	RoleID         uuid.UUID  `gorm:"column:role_id;type:uuid;not null"`
+	SchoolID       *uuid.UUID `gorm:"column:school_id;type:uuid"`
+	AcademicUnitID *uuid.UUID `gorm:"column:academic_unit_id;type:uuid"`
+	ReviewerID     *uuid.UUID `gorm:"column:reviewer_id;type:uuid"`
+	Decision       string     `gorm:"column:decision;not null;default:pending"`
+	DecidedBy      *uuid.UUID `gorm:"column:decided_by;type:uuid"`
+	DecidedAt      *time.Time `gorm:"column:decided_at"`
+	Note           *string    `gorm:"column:note"`
+	CreatedAt      time.Time  `gorm:"column:created_at;not null;default:now()"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at;not null;default:now()"`
+}
+
+func (AccessReviewItem) TableName() string {
+	return "iam.access_review_items"
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BreakGlassElevation maps to iam.break_glass_elevations: an emergency,
+// time-boxed global role assignment a pre-provisioned responder granted to
+// themselves. The granted assignment is UserRoleID; it is revoked when the
+// elevation ends or expires.
+type BreakGlassElevation struct {
+	ID            uuid.UUID  `gorm:"column:id;type:uuid;primaryKey"`
+	UserID        uuid.UUID  `gorm:"column:user_id;type:uuid;not null"`
+	RoleID        uuid.UUID  `gorm:"column:role_id;type:uuid;not null"`
+	UserRoleID    uuid.UUID  `gorm:"column:user_role_id;type:uuid;not null"`
+	SchoolID      *uuid.UUID `gorm:"column:school_id;type:uuid"`
+	Justification string     `gorm:"column:justification;not null"`
+	ClientIP      *string    `gorm:"column:client_ip"`
+	ActivatedAt   time.Time  `gorm:"column:activated_at;not null;default:now()"`
+	ExpiresAt     time.Time  `gorm:"column:expires_at;not null"`
+	EndedAt       *time.Time `gorm:"column:ended_at"`
+	EndedBy       *uuid.UUID `gorm:"column:ended_by;type:uuid"`
+}
+
+func (BreakGlassElevation) TableName() string {
+	return "iam.break_glass_elevations"
+}
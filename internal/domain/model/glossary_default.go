@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// GlossaryDefault maps to ui_config.glossary_defaults: the platform-wide value
+// of a glossary term, used by schools that don't override it
+type GlossaryDefault struct {
+	TermKey     string    `gorm:"column:term_key;primaryKey"`
+	TermValue   string    `gorm:"column:term_value;not null"`
+	Description *string   `gorm:"column:description"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;not null;default:now()"`
+}
+
+func (GlossaryDefault) TableName() string {
+	return "ui_config.glossary_defaults"
+}
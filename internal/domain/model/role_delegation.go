@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Role delegation statuses
+const (
+	DelegationScheduled = "scheduled"
+	DelegationActive    = "active"
+	DelegationExpired   = "expired"
+	DelegationRevoked   = "revoked"
+)
+
+// RoleDelegation maps to iam.role_delegations: a user handing one of their own
+// unit role assignments to another user for a time window. While active the
+// delegate holds a regular user_role (DelegatedUserRoleID) mirroring the
+// delegator's assignment (SourceUserRoleID) that expires at EndsAt.
+type RoleDelegation struct {
+	ID                  uuid.UUID  `gorm:"column:id;type:uuid;primaryKey"`
+	DelegatorID         uuid.UUID  `gorm:"column:delegator_id;type:uuid;not null"`
+	DelegateID          uuid.UUID  `gorm:"column:delegate_id;type:uuid;not null"`
+	SourceUserRoleID    uuid.UUID  `gorm:"column:source_user_role_id;type:uuid;not null"`
+	RoleID              uuid.UUID  `gorm:"column:role_id;type:uuid;not null"`
+	SchoolID            uuid.UUID  `gorm:"column:school_id;type:uuid;not null"`
+	AcademicUnitID      uuid.UUID  `gorm:"column:academic_unit_id;type:uuid;not null"`
+	StartsAt            time.Time  `gorm:"column:starts_at;not null"`
+	EndsAt              time.Time  `gorm:"column:ends_at;not null"`
+	Reason              *string    `gorm:"column:reason"`
+	Status              string     `gorm:"column:status;not null;default:scheduled"`
+	DelegatedUserRoleID *uuid.UUID `gorm:"column:delegated_user_role_id;type:uuid"`
+	RevokedBy           *uuid.UUID `gorm:"column:revoked_by;type:uuid"`
+	EndedAt             *time.Time `gorm:"column:ended_at"`
+	CreatedAt           time.Time  `gorm:"column:created_at;not null;default:now()"`
+	UpdatedAt           time.Time  `gorm:"column:updated_at;not null;default:now()"`
+}
+
+func (RoleDelegation) TableName() string {
+	return "iam.role_delegations"
+}
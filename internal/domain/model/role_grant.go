@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Role grant request statuses
+const (
+	GrantRequestPending  = "pending"
+	GrantRequestApproved = "approved"
+	GrantRequestRejected = "rejected"
+	GrantRequestExpired  = "expired"
+)
+
+// RoleGrantPolicy maps to iam.role_grant_policies. Roles flagged with
+// RequiresApproval cannot be granted directly: grants become pending requests.
+type RoleGrantPolicy struct {
+	RoleID           uuid.UUID  `gorm:"column:role_id;type:uuid;primaryKey"`
+	RequiresApproval bool       `gorm:"column:requires_approval;not null;default:false"`
+	UpdatedBy        *uuid.UUID `gorm:"column:updated_by;type:uuid"`
+	UpdatedAt        time.Time  `gorm:"column:updated_at;not null;default:now()"`
+}
+
+func (RoleGrantPolicy) TableName() string {
+	return "iam.role_grant_policies"
+}
+
+// RoleGrantRequest maps to iam.role_grant_requests: a grant of a role that
+// requires approval, waiting for a second admin to decide on it.
+type RoleGrantRequest struct {
+	ID             uuid.UUID  `gorm:"column:id;type:uuid;primaryKey"`
+	UserID         uuid.UUID  `gorm:"column:user_id;type:uuid;not null"`
+	RoleID         uuid.UUID  `gorm:"column:role_id;type:uuid;not null"`
+	SchoolID       *uuid.UUID `gorm:"column:school_id;type:uuid"`
+	AcademicUnitID *uuid.UUID `gorm:"column:academic_unit_id;type:uuid"`
+	GrantExpiresAt *time.Time `gorm:"column:grant_expires_at"`
+	RequestedBy    *uuid.UUID `gorm:"column:requested_by;type:uuid"`
+	Status         string     `gorm:"column:status;not null;default:pending"`
+	DecidedBy      *uuid.UUID `gorm:"column:decided_by;type:uuid"`
+	DecidedAt      *time.Time `gorm:"column:decided_at"`
+	DecisionNote   *string    `gorm:"column:decision_note"`
+	UserRoleID     *uuid.UUID `gorm:"column:user_role_id;type:uuid"`
+	ExpiresAt      time.Time  `gorm:"column:expires_at;not null"`
+	CreatedAt      time.Time  `gorm:"column:created_at;not null;default:now()"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at;not null;default:now()"`
+}
+
+func (RoleGrantRequest) TableName() string {
+	return "iam.role_grant_requests"
+}
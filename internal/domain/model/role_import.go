@@ -0,0 +1,76 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Role import job statuses
+const (
+	RoleImportPending   = "pending"
+	RoleImportRunning   = "running"
+	RoleImportCompleted = "completed"
+	RoleImportFailed    = "failed"
+)
+
+// Role import row statuses. Dry runs stop at valid/invalid; applied jobs turn
+// valid rows into applied, or leave them valid when the transaction failed.
+const (
+	RoleImportRowPending = "pending"
+	RoleImportRowValid   = "valid"
+	RoleImportRowInvalid = "invalid"
+	RoleImportRowSkipped = "skipped"
+	RoleImportRowApplied = "applied"
+)
+
+// RoleImportJob maps to iam.role_import_jobs: one uploaded CSV/XLSX file of
+// role assignments, validated and (unless DryRun) applied in the background.
+type RoleImportJob struct {
+	ID            uuid.UUID  `gorm:"column:id;type:uuid;primaryKey"`
+	RequestedBy   uuid.UUID  `gorm:"column:requested_by;type:uuid;not null"`
+	FileName      string     `gorm:"column:file_name;not null"`
+	Format        string     `gorm:"column:format;not null"`
+	DryRun        bool       `gorm:"column:dry_run;not null"`
+	Status        string     `gorm:"column:status;not null;default:pending"`
+	TotalRows     int        `gorm:"column:total_rows;not null"`
+	ProcessedRows int        `gorm:"column:processed_rows;not null"`
+	ValidRows     int        `gorm:"column:valid_rows;not null"`
+	InvalidRows   int        `gorm:"column:invalid_rows;not null"`
+	SkippedRows   int        `gorm:"column:skipped_rows;not null"`
+	AppliedRows   int        `gorm:"column:applied_rows;not null"`
+	Error         *string    `gorm:"column:error"`
+	StartedAt     *time.Time `gorm:"column:started_at"`
+	FinishedAt    *time.Time `gorm:"column:finished_at"`
+	CreatedAt     time.Time  `gorm:"column:created_at;not null;default:now()"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at;not null;default:now()"`
+}
+
+func (RoleImportJob) TableName() string {
+	return "iam.role_import_jobs"
+}
+
+// RoleImportRow maps to iam.role_import_rows: one line of an import file as
+// uploaded, plus the references it resolved to and its outcome.
+type RoleImportRow struct {
+	ID             uuid.UUID  `gorm:"column:id;type:uuid;primaryKey"`
+	JobID          uuid.UUID  `gorm:"column:job_id;type:uuid;not null"`
+	LineNumber     int        `gorm:"column:line_number;not null"`
+	Email          string     `gorm:"column:email;not null"`
+	RoleName       string     `gorm:"column:role_name;not null"`
+	School         string     `gorm:"column:school;not null"`
+	Unit           string     `gorm:"column:unit;not null"`
+	ExpiresAtRaw   string     `gorm:"column:expires_at_raw;not null"`
+	Status         string     `gorm:"column:status;not null;default:pending"`
+	Message        *string    `gorm:"column:message"`
+	UserID         *uuid.UUID `gorm:"column:user_id;type:uuid"`
+	RoleID         *uuid.UUID `gorm:"column:role_id;type:uuid"`
+	SchoolID       *uuid.UUID `gorm:"column:school_id;type:uuid"`
+	AcademicUnitID *uuid.UUID `gorm:"column:academic_unit_id;type:uuid"`
+	ExpiresAt      *time.Time `gorm:"column:expires_at"`
+	UserRoleID     *uuid.UUID `gorm:"column:user_role_id;type:uuid"`
+}
+
+func (RoleImportRow) TableName() string {
+	return "iam.role_import_rows"
+}
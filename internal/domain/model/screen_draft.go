@@ -0,0 +1,47 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScreenTemplateDraft maps to ui_config.screen_template_drafts: pending edits
+// of a template, invisible to clients until published. PublishAt schedules
+// the publication.
+type ScreenTemplateDraft struct {
+	TemplateID  uuid.UUID       `gorm:"column:template_id;type:uuid;primaryKey"`
+	Pattern     string          `gorm:"column:pattern;not null"`
+	Name        string          `gorm:"column:name;not null"`
+	Description *string         `gorm:"column:description"`
+	Definition  json.RawMessage `gorm:"column:definition;type:jsonb;not null"`
+	PublishAt   *time.Time      `gorm:"column:publish_at"`
+	CreatedAt   time.Time       `gorm:"column:created_at;not null;default:now()"`
+	UpdatedAt   time.Time       `gorm:"column:updated_at;not null;default:now()"`
+}
+
+func (ScreenTemplateDraft) TableName() string {
+	return "ui_config.screen_template_drafts"
+}
+
+// ScreenInstanceDraft maps to ui_config.screen_instance_drafts: pending edits
+// of an instance, invisible to clients until published
+type ScreenInstanceDraft struct {
+	InstanceID         uuid.UUID       `gorm:"column:instance_id;type:uuid;primaryKey"`
+	ScreenKey          string          `gorm:"column:screen_key;not null"`
+	TemplateID         uuid.UUID       `gorm:"column:template_id;type:uuid;not null"`
+	Name               string          `gorm:"column:name;not null"`
+	Description        *string         `gorm:"column:description"`
+	SlotData           json.RawMessage `gorm:"column:slot_data;type:jsonb;not null"`
+	Scope              string          `gorm:"column:scope;not null"`
+	RequiredPermission *string         `gorm:"column:required_permission"`
+	HandlerKey         *string         `gorm:"column:handler_key"`
+	PublishAt          *time.Time      `gorm:"column:publish_at"`
+	CreatedAt          time.Time       `gorm:"column:created_at;not null;default:now()"`
+	UpdatedAt          time.Time       `gorm:"column:updated_at;not null;default:now()"`
+}
+
+func (ScreenInstanceDraft) TableName() string {
+	return "ui_config.screen_instance_drafts"
+}
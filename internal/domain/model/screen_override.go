@@ -0,0 +1,25 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScreenOverride maps to ui_config.screen_overrides: a JSON merge patch over
+// an instance's slot data for one school, optionally narrowed to one role
+type ScreenOverride struct {
+	ID         uuid.UUID       `gorm:"column:id;type:uuid;primaryKey"`
+	InstanceID uuid.UUID       `gorm:"column:instance_id;type:uuid;not null"`
+	SchoolID   uuid.UUID       `gorm:"column:school_id;type:uuid;not null"`
+	RoleID     *uuid.UUID      `gorm:"column:role_id;type:uuid"`
+	SlotData   json.RawMessage `gorm:"column:slot_data;type:jsonb;not null"`
+	CreatedBy  *uuid.UUID      `gorm:"column:created_by;type:uuid"`
+	CreatedAt  time.Time       `gorm:"column:created_at;not null;default:now()"`
+	UpdatedAt  time.Time       `gorm:"column:updated_at;not null;default:now()"`
+}
+
+func (ScreenOverride) TableName() string {
+	return "ui_config.screen_overrides"
+}
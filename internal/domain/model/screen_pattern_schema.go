@@ -0,0 +1,21 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ScreenPatternSchema maps to ui_config.screen_pattern_schemas: the JSON
+// Schemas a template pattern declares for its definition and for the slot
+// data of its instances
+type ScreenPatternSchema struct {
+	Pattern          string          `gorm:"column:pattern;primaryKey"`
+	DefinitionSchema json.RawMessage `gorm:"column:definition_schema;type:jsonb"`
+	SlotDataSchema   json.RawMessage `gorm:"column:slot_data_schema;type:jsonb"`
+	CreatedAt        time.Time       `gorm:"column:created_at;not null;default:now()"`
+	UpdatedAt        time.Time       `gorm:"column:updated_at;not null;default:now()"`
+}
+
+func (ScreenPatternSchema) TableName() string {
+	return "ui_config.screen_pattern_schemas"
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Screen version sources: what produced a history entry
+const (
+	ScreenVersionBaseline = "baseline"
+	ScreenVersionCreate   = "create"
+	ScreenVersionPublish  = "publish"
+	ScreenVersionRollback = "rollback"
+	ScreenVersionImport   = "import"
+)
+
+// ScreenTemplateVersion maps to ui_config.screen_template_versions: the state
+// of a template right after one of its definition changes. Version matches
+// ScreenTemplate.Version at that point.
+type ScreenTemplateVersion struct {
+	ID           uuid.UUID       `gorm:"column:id;type:uuid;primaryKey"`
+	TemplateID   uuid.UUID       `gorm:"column:template_id;type:uuid;not null"`
+	Version      int             `gorm:"column:version;not null"`
+	Pattern      string          `gorm:"column:pattern;not null"`
+	Name         string          `gorm:"column:name;not null"`
+	Description  *string         `gorm:"column:description"`
+	Definition   json.RawMessage `gorm:"column:definition;type:jsonb;not null"`
+	Source       string          `gorm:"column:source;not null"`
+	RestoredFrom *int            `gorm:"column:restored_from"`
+	CreatedAt    time.Time       `gorm:"column:created_at;not null;default:now()"`
+}
+
+func (ScreenTemplateVersion) TableName() string {
+	return "ui_config.screen_template_versions"
+}
+
+// ScreenInstanceVersion maps to ui_config.screen_instance_versions: the slot
+// data of an instance right after one of its changes. Instances carry no
+// version column, so numbering lives only here.
+type ScreenInstanceVersion struct {
+	ID           uuid.UUID       `gorm:"column:id;type:uuid;primaryKey"`
+	InstanceID   uuid.UUID       `gorm:"column:instance_id;type:uuid;not null"`
+	Version      int             `gorm:"column:version;not null"`
+	SlotData     json.RawMessage `gorm:"column:slot_data;type:jsonb;not null"`
+	Source       string          `gorm:"column:source;not null"`
+	RestoredFrom *int            `gorm:"column:restored_from"`
+	CreatedAt    time.Time       `gorm:"column:created_at;not null;default:now()"`
+}
+
+func (ScreenInstanceVersion) TableName() string {
+	return "ui_config.screen_instance_versions"
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Separation-of-duties constraint kinds
+const (
+	SoDKindRole       = "role"
+	SoDKindPermission = "permission"
+)
+
+// SoDConstraint maps to iam.sod_constraints: a pair of roles or permissions
+// that no user may hold together. A nil SchoolID makes the constraint global;
+// otherwise it only applies to assignments effective in that school.
+type SoDConstraint struct {
+	ID          uuid.UUID  `gorm:"column:id;type:uuid;primaryKey"`
+	Name        string     `gorm:"column:name;not null"`
+	Description *string    `gorm:"column:description"`
+	Kind        string     `gorm:"column:kind;not null"`
+	LeftID      uuid.UUID  `gorm:"column:left_id;type:uuid;not null"`
+	RightID     uuid.UUID  `gorm:"column:right_id;type:uuid;not null"`
+	SchoolID    *uuid.UUID `gorm:"column:school_id;type:uuid"`
+	IsActive    bool       `gorm:"column:is_active;not null;default:true"`
+	CreatedBy   *uuid.UUID `gorm:"column:created_by;type:uuid"`
+	CreatedAt   time.Time  `gorm:"column:created_at;not null;default:now()"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at;not null;default:now()"`
+}
+
+func (SoDConstraint) TableName() string {
+	return "iam.sod_constraints"
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Translatable entities and fields
+const (
+	TranslationEntityResource       = "resource"
+	TranslationEntityScreenInstance = "screen_instance"
+
+	TranslationFieldDisplayName = "display_name"
+	TranslationFieldName        = "name"
+)
+
+// Translation maps to ui_config.translations: the value of one field of an
+// entity in one locale
+type Translation struct {
+	EntityType string    `gorm:"column:entity_type;primaryKey"`
+	EntityID   uuid.UUID `gorm:"column:entity_id;type:uuid;primaryKey"`
+	Field      string    `gorm:"column:field;primaryKey"`
+	Locale     string    `gorm:"column:locale;primaryKey"`
+	Value      string    `gorm:"column:value;not null"`
+	UpdatedAt  time.Time `gorm:"column:updated_at;not null;default:now()"`
+}
+
+func (Translation) TableName() string {
+	return "ui_config.translations"
+}
+
+// UserPreference maps to iam.user_preferences: per-user settings kept outside
+// the shared user account
+type UserPreference struct {
+	UserID    uuid.UUID `gorm:"column:user_id;type:uuid;primaryKey"`
+	Locale    *string   `gorm:"column:locale"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null;default:now()"`
+}
+
+func (UserPreference) TableName() string {
+	return "iam.user_preferences"
+}
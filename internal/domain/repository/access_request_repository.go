@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+// AccessRequestFilter narrows access request listings; zero values match all
+type AccessRequestFilter struct {
+	UserID   *uuid.UUID
+	SchoolID *uuid.UUID
+	Status   string
+}
+
+type AccessRequestRepository interface {
+	Create(ctx context.Context, req *model.AccessRequest) error
+	FindByID(ctx context.Context, id uuid.UUID) (*model.AccessRequest, error)
+	List(ctx context.Context, filter AccessRequestFilter, filters sharedrepo.ListFilters) ([]*model.AccessRequest, int, error)
+	Update(ctx context.Context, req *model.AccessRequest) error
+	// Decide moves a request that is still pending to req.Status with its
+	// decision. False means it was decided or cancelled concurrently and
+	// nothing was written.
+	Decide(ctx context.Context, req *model.AccessRequest) (bool, error)
+	// Reopen returns an approved request to pending when its grant failed
+	Reopen(ctx context.Context, id uuid.UUID) error
+	HasPending(ctx context.Context, userID, roleID, schoolID uuid.UUID, unitID *uuid.UUID) (bool, error)
+}
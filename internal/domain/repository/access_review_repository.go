@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+// AccessReviewItemFilter narrows review item listings; zero values match all
+type AccessReviewItemFilter struct {
+	CampaignID *uuid.UUID
+	ReviewerID *uuid.UUID
+	Decision   string
+}
+
+type AccessReviewRepository interface {
+	CreateCampaign(ctx context.Context, campaign *model.AccessReviewCampaign, items []*model.AccessReviewItem) error
+	FindCampaign(ctx context.Context, id uuid.UUID) (*model.AccessReviewCampaign, error)
+	ListCampaigns(ctx context.Context, status string, filters sharedrepo.ListFilters) ([]*model.AccessReviewCampaign, int, error)
+	UpdateCampaign(ctx context.Context, campaign *model.AccessReviewCampaign) error
+	FindDueCampaigns(ctx context.Context, now time.Time) ([]*model.AccessReviewCampaign, error)
+	FindItem(ctx context.Context, id uuid.UUID) (*model.AccessReviewItem, error)
+	ListItems(ctx context.Context, filter AccessReviewItemFilter) ([]*model.AccessReviewItem, error)
+	// DecideItem stores the decision of an item that is still pending and, when
+	// revoke is set, deactivates the reviewed assignment in the same
+	// transaction. False means the item was decided concurrently and nothing
+	// was written.
+	DecideItem(ctx context.Context, item *model.AccessReviewItem, revoke bool) (bool, error)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+type BreakGlassRepository interface {
+	// Activate stores the elevation and its grant in one transaction. Activations
+	// of the same user are serialized, and it returns false without writing when
+	// the user already has an open elevation or reached maxActivations since since.
+	Activate(ctx context.Context, elevation *model.BreakGlassElevation, grant *entities.UserRole, since time.Time, maxActivations int) (bool, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*model.BreakGlassElevation, error)
+	// FindActiveByUser returns the user's elevation that has not ended or expired
+	FindActiveByUser(ctx context.Context, userID uuid.UUID, now time.Time) (*model.BreakGlassElevation, error)
+	CountSince(ctx context.Context, userID uuid.UUID, since time.Time) (int64, error)
+	List(ctx context.Context, filters sharedrepo.ListFilters) ([]*model.BreakGlassElevation, int, error)
+	// FindExpired returns elevations past their expiry that were not ended yet
+	FindExpired(ctx context.Context, now time.Time) ([]*model.BreakGlassElevation, error)
+	End(ctx context.Context, id uuid.UUID, endedAt time.Time, endedBy *uuid.UUID) error
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/google/uuid"
+)
+
+// IAMCatalogSnapshot is the full IAM catalog, including inactive rows
+type IAMCatalogSnapshot struct {
+	Resources       []*entities.Resource
+	Permissions     []*entities.Permission
+	Roles           []*entities.Role
+	RolePermissions []*entities.RolePermission
+}
+
+// IAMCatalogChangeSet groups the writes needed to reconcile the catalog with a manifest.
+// Upserts are keyed by primary key; RolePermissions replaces the full permission set of each listed role.
+type IAMCatalogChangeSet struct {
+	UpsertResources       []*entities.Resource
+	UpsertPermissions     []*entities.Permission
+	UpsertRoles           []*entities.Role
+	RolePermissions       map[uuid.UUID][]uuid.UUID
+	DeactivateResources   []uuid.UUID
+	DeactivatePermissions []uuid.UUID
+	DeactivateRoles       []uuid.UUID
+}
+
+type IAMCatalogRepository interface {
+	Snapshot(ctx context.Context) (*IAMCatalogSnapshot, error)
+	// Reconcile takes the snapshot, plans against it and applies the planned
+	// change set in one transaction; concurrent reconciliations run one at a
+	// time. A nil change set writes nothing.
+	Reconcile(ctx context.Context, plan func(snap *IAMCatalogSnapshot) (*IAMCatalogChangeSet, error)) error
+}
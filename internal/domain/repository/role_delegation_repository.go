@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/google/uuid"
+)
+
+// RoleDelegationFilter narrows delegation listings; zero values match all
+type RoleDelegationFilter struct {
+	DelegatorID *uuid.UUID
+	DelegateID  *uuid.UUID
+	Statuses    []string
+}
+
+type RoleDelegationRepository interface {
+	// Create stores the delegations and the grants of the ones already active
+	// in one transaction. The grants are inserted first since active
+	// delegations reference them.
+	Create(ctx context.Context, delegations []*model.RoleDelegation, grants []*entities.UserRole) error
+	FindByID(ctx context.Context, id uuid.UUID) (*model.RoleDelegation, error)
+	List(ctx context.Context, filter RoleDelegationFilter) ([]*model.RoleDelegation, error)
+	Update(ctx context.Context, delegation *model.RoleDelegation) error
+	// Activate marks a scheduled delegation active and creates the delegate's
+	// assignment in one transaction. It returns false without writing when the
+	// delegation is no longer scheduled.
+	Activate(ctx context.Context, delegation *model.RoleDelegation, grant *entities.UserRole) (bool, error)
+	// Overlaps reports whether the delegate already has a scheduled or active
+	// delegation of the same source assignment overlapping [startsAt, endsAt).
+	Overlaps(ctx context.Context, sourceUserRoleID, delegateID uuid.UUID, startsAt, endsAt time.Time) (bool, error)
+}
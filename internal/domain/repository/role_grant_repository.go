@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+type RoleGrantPolicyRepository interface {
+	FindByRole(ctx context.Context, roleID uuid.UUID) (*model.RoleGrantPolicy, error)
+	Upsert(ctx context.Context, policy *model.RoleGrantPolicy) error
+}
+
+type RoleGrantRequestRepository interface {
+	// Create stores a pending request; false means another pending request for
+	// the same grant already exists and nothing was stored
+	Create(ctx context.Context, req *model.RoleGrantRequest) (bool, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*model.RoleGrantRequest, error)
+	List(ctx context.Context, status string, filters sharedrepo.ListFilters) ([]*model.RoleGrantRequest, int, error)
+	// Decide stores the decision of a request that is still pending and not
+	// past its deadline, granting grant (when set) in the same transaction.
+	// False means the request was decided or expired concurrently and nothing
+	// was written.
+	Decide(ctx context.Context, req *model.RoleGrantRequest, grant *entities.UserRole) (bool, error)
+	HasPending(ctx context.Context, userID, roleID uuid.UUID, schoolID, unitID *uuid.UUID) (bool, error)
+	ExpirePending(ctx context.Context, now time.Time) ([]*model.RoleGrantRequest, error)
+}
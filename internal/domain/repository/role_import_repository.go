@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+type RoleImportRepository interface {
+	// Create stores the job together with its uploaded rows
+	Create(ctx context.Context, job *model.RoleImportJob, rows []*model.RoleImportRow) error
+	FindByID(ctx context.Context, id uuid.UUID) (*model.RoleImportJob, error)
+	List(ctx context.Context, requestedBy *uuid.UUID, filters sharedrepo.ListFilters) ([]*model.RoleImportJob, int, error)
+	// FindPending returns jobs waiting to be processed, oldest first
+	FindPending(ctx context.Context, limit int) ([]*model.RoleImportJob, error)
+	// Claim moves a pending job to running; false means another worker got it
+	Claim(ctx context.Context, id uuid.UUID, now time.Time) (bool, error)
+	UpdateJob(ctx context.Context, job *model.RoleImportJob) error
+	// FindRows returns the job's rows by line number; status filters when set
+	FindRows(ctx context.Context, jobID uuid.UUID, status string, filters sharedrepo.ListFilters) ([]*model.RoleImportRow, int, error)
+	SaveRows(ctx context.Context, rows []*model.RoleImportRow) error
+	// Apply grants userRoles and stores the rows' outcome atomically: either
+	// every assignment is created or none is.
+	Apply(ctx context.Context, userRoles []*entities.UserRole, rows []*model.RoleImportRow) error
+}
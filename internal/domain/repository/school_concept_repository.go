@@ -3,10 +3,32 @@ package repository
 import (
 	"context"
 
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	"github.com/google/uuid"
 )
 
+// SchoolConceptRepository manages the glossary terms a school overrides
 type SchoolConceptRepository interface {
 	FindBySchoolID(ctx context.Context, schoolID uuid.UUID) ([]*entities.SchoolConcept, error)
+	// Save sets the value of one term of a school, creating it if needed
+	Save(ctx context.Context, schoolID uuid.UUID, termKey, termValue string) error
+	// Delete removes one term of a school; it reports whether the term existed
+	Delete(ctx context.Context, schoolID uuid.UUID, termKey string) (bool, error)
+	// SaveBatch sets several terms of a school in a transaction. With replace
+	// the terms not in terms are removed.
+	SaveBatch(ctx context.Context, schoolID uuid.UUID, terms map[string]string, replace bool) error
+}
+
+// GlossaryDefaultRepository manages the platform default glossary
+type GlossaryDefaultRepository interface {
+	List(ctx context.Context) ([]*model.GlossaryDefault, error)
+	// Get returns a default term, or nil when it doesn't exist
+	Get(ctx context.Context, termKey string) (*model.GlossaryDefault, error)
+	// Save creates or replaces a default term
+	Save(ctx context.Context, term *model.GlossaryDefault) error
+	Delete(ctx context.Context, termKey string) error
+	// SaveBatch saves several default terms in a transaction. With replace the
+	// terms not in terms are removed.
+	SaveBatch(ctx context.Context, terms []*model.GlossaryDefault, replace bool) error
 }
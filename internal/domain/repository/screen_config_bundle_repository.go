@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/google/uuid"
+)
+
+// ScreenConfigSnapshot is the screen configuration imports, cascading deletes
+// and consistency checks are planned against: every template, instance and
+// resource-screen link (inactive rows included), the active resources and
+// permissions they can reference, the pending drafts and the latest history
+// version of each instance
+type ScreenConfigSnapshot struct {
+	Templates              []*entities.ScreenTemplate
+	Instances              []*entities.ScreenInstance
+	ResourceScreens        []*entities.ResourceScreen
+	Resources              []*entities.Resource
+	Permissions            []*entities.Permission
+	TemplateDrafts         []*model.ScreenTemplateDraft
+	InstanceDrafts         []*model.ScreenInstanceDraft
+	LatestInstanceVersions map[uuid.UUID]int
+}
+
+// ScreenConfigChangeSet groups the writes of a bundle import, a draft
+// publication or a cascading delete. Upserts are keyed by primary key; the
+// versions record the new published revisions. HideResources removes
+// resources from the menu without deactivating them.
+type ScreenConfigChangeSet struct {
+	UpsertTemplates       []*entities.ScreenTemplate
+	UpsertInstances       []*entities.ScreenInstance
+	UpsertResourceScreens []*entities.ResourceScreen
+	TemplateVersions      []*model.ScreenTemplateVersion
+	InstanceVersions      []*model.ScreenInstanceVersion
+	DeleteResourceScreens []uuid.UUID
+	DeleteTemplateDrafts  []uuid.UUID
+	DeleteInstanceDrafts  []uuid.UUID
+	DeactivateInstances   []uuid.UUID
+	DeactivateTemplates   []uuid.UUID
+	DeactivateResources   []uuid.UUID
+	HideResources         []uuid.UUID
+}
+
+type ScreenConfigBundleRepository interface {
+	Snapshot(ctx context.Context) (*ScreenConfigSnapshot, error)
+	Apply(ctx context.Context, changes *ScreenConfigChangeSet) error
+	// Reconcile takes the snapshot, plans against it and applies the planned
+	// change set in one transaction, serialized with Apply. A nil change set
+	// writes nothing.
+	Reconcile(ctx context.Context, plan func(snap *ScreenConfigSnapshot) (*ScreenConfigChangeSet, error)) error
+	// VisibleLeafResource returns the resource when it is active, shown in the
+	// menu and has no active children; nil otherwise
+	VisibleLeafResource(ctx context.Context, id uuid.UUID) (*entities.Resource, error)
+}
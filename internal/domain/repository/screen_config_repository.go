@@ -12,6 +12,9 @@ type ScreenTemplateRepository interface {
 	Create(ctx context.Context, template *entities.ScreenTemplate) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.ScreenTemplate, error)
 	List(ctx context.Context, filter sharedrepo.ListFilters) ([]*entities.ScreenTemplate, int, error)
+	// ListAfter returns up to limit active templates with an id greater than
+	// after, ordered by id. uuid.Nil starts from the beginning.
+	ListAfter(ctx context.Context, after uuid.UUID, limit int) ([]*entities.ScreenTemplate, error)
 	Update(ctx context.Context, template *entities.ScreenTemplate) error
 	Delete(ctx context.Context, id uuid.UUID) error
 }
@@ -21,14 +24,22 @@ type ScreenInstanceRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.ScreenInstance, error)
 	GetByScreenKey(ctx context.Context, key string) (*entities.ScreenInstance, error)
 	List(ctx context.Context, filter sharedrepo.ListFilters) ([]*entities.ScreenInstance, int, error)
+	// ListAfter returns up to limit active instances with an id greater than
+	// after, ordered by id. uuid.Nil starts from the beginning.
+	ListAfter(ctx context.Context, after uuid.UUID, limit int) ([]*entities.ScreenInstance, error)
+	// ListByTemplate returns the active instances of a template
+	ListByTemplate(ctx context.Context, templateID uuid.UUID) ([]*entities.ScreenInstance, error)
 	Update(ctx context.Context, instance *entities.ScreenInstance) error
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
 type ResourceScreenRepository interface {
 	Create(ctx context.Context, rs *entities.ResourceScreen) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.ResourceScreen, error)
 	GetByResourceID(ctx context.Context, resourceID uuid.UUID) ([]*entities.ResourceScreen, error)
 	GetByResourceKey(ctx context.Context, key string) ([]*entities.ResourceScreen, error)
 	GetByResourceKeys(ctx context.Context, keys []string) ([]*entities.ResourceScreen, error)
+	// GetByScreenKeys returns the active links pointing at any of the screens
+	GetByScreenKeys(ctx context.Context, keys []string) ([]*entities.ResourceScreen, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 }
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/google/uuid"
+)
+
+type ScreenDraftRepository interface {
+	// SaveTemplateDraft creates or replaces the template's draft
+	SaveTemplateDraft(ctx context.Context, draft *model.ScreenTemplateDraft) error
+	GetTemplateDraft(ctx context.Context, templateID uuid.UUID) (*model.ScreenTemplateDraft, error)
+	ListTemplateDrafts(ctx context.Context) ([]*model.ScreenTemplateDraft, error)
+	DeleteTemplateDraft(ctx context.Context, templateID uuid.UUID) error
+	// FindDueTemplateDrafts returns drafts scheduled at or before now
+	FindDueTemplateDrafts(ctx context.Context, now time.Time) ([]*model.ScreenTemplateDraft, error)
+	// SaveInstanceDraft creates or replaces the instance's draft
+	SaveInstanceDraft(ctx context.Context, draft *model.ScreenInstanceDraft) error
+	GetInstanceDraft(ctx context.Context, instanceID uuid.UUID) (*model.ScreenInstanceDraft, error)
+	ListInstanceDrafts(ctx context.Context) ([]*model.ScreenInstanceDraft, error)
+	DeleteInstanceDraft(ctx context.Context, instanceID uuid.UUID) error
+	// FindDueInstanceDrafts returns drafts scheduled at or before now
+	FindDueInstanceDrafts(ctx context.Context, now time.Time) ([]*model.ScreenInstanceDraft, error)
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/google/uuid"
+)
+
+type ScreenOverrideRepository interface {
+	Create(ctx context.Context, override *model.ScreenOverride) error
+	GetByID(ctx context.Context, id uuid.UUID) (*model.ScreenOverride, error)
+	// GetByContext returns the override of the instance for exactly that
+	// school and role (nil role: the school-wide one), or nil
+	GetByContext(ctx context.Context, instanceID, schoolID uuid.UUID, roleID *uuid.UUID) (*model.ScreenOverride, error)
+	Update(ctx context.Context, override *model.ScreenOverride) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// ListByInstance returns the overrides of an instance, optionally of one school
+	ListByInstance(ctx context.Context, instanceID uuid.UUID, schoolID *uuid.UUID) ([]*model.ScreenOverride, error)
+	// FindForContext returns the overrides that apply to a school and role:
+	// the school-wide ones plus those of roleID, school-wide first. A nil
+	// instanceID returns them for every instance.
+	FindForContext(ctx context.Context, schoolID uuid.UUID, roleID *uuid.UUID, instanceID *uuid.UUID) ([]*model.ScreenOverride, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+)
+
+type ScreenPatternSchemaRepository interface {
+	// Get returns nil when the pattern declares no schemas
+	Get(ctx context.Context, pattern string) (*model.ScreenPatternSchema, error)
+	List(ctx context.Context) ([]*model.ScreenPatternSchema, error)
+	// Save creates or replaces the schemas of a pattern
+	Save(ctx context.Context, schema *model.ScreenPatternSchema) error
+	Delete(ctx context.Context, pattern string) error
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+type ScreenVersionRepository interface {
+	CreateTemplateVersion(ctx context.Context, v *model.ScreenTemplateVersion) error
+	// ListTemplateVersions returns the template's history, newest first
+	ListTemplateVersions(ctx context.Context, templateID uuid.UUID, filters sharedrepo.ListFilters) ([]*model.ScreenTemplateVersion, int, error)
+	GetTemplateVersion(ctx context.Context, templateID uuid.UUID, version int) (*model.ScreenTemplateVersion, error)
+	CreateInstanceVersion(ctx context.Context, v *model.ScreenInstanceVersion) error
+	// ListInstanceVersions returns the instance's history, newest first
+	ListInstanceVersions(ctx context.Context, instanceID uuid.UUID, filters sharedrepo.ListFilters) ([]*model.ScreenInstanceVersion, int, error)
+	GetInstanceVersion(ctx context.Context, instanceID uuid.UUID, version int) (*model.ScreenInstanceVersion, error)
+	// LatestInstanceVersion returns the highest recorded version, 0 when none
+	LatestInstanceVersion(ctx context.Context, instanceID uuid.UUID) (int, error)
+}
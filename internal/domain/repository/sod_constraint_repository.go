@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/google/uuid"
+)
+
+type SoDConstraintRepository interface {
+	Create(ctx context.Context, constraint *model.SoDConstraint) error
+	FindByID(ctx context.Context, id uuid.UUID) (*model.SoDConstraint, error)
+	// FindActive returns every active constraint; the set is expected to stay small.
+	FindActive(ctx context.Context) ([]*model.SoDConstraint, error)
+	Exists(ctx context.Context, kind string, leftID, rightID uuid.UUID, schoolID *uuid.UUID) (bool, error)
+	Deactivate(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/google/uuid"
+)
+
+type TranslationRepository interface {
+	// ListForEntity returns every translation of one entity
+	ListForEntity(ctx context.Context, entityType string, entityID uuid.UUID) ([]*model.Translation, error)
+	// FindByLocale returns the translations of a field in one locale for every
+	// entity of a type
+	FindByLocale(ctx context.Context, entityType, field, locale string) ([]*model.Translation, error)
+	// ReplaceForEntity replaces the translations of one entity in a transaction
+	ReplaceForEntity(ctx context.Context, entityType string, entityID uuid.UUID, translations []*model.Translation) error
+}
+
+type UserPreferenceRepository interface {
+	// Get returns the user's preferences, or nil when none were saved
+	Get(ctx context.Context, userID uuid.UUID) (*model.UserPreference, error)
+	// Save creates or replaces the user's preferences
+	Save(ctx context.Context, pref *model.UserPreference) error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+)
+
+// UserErasureRepository erases the personal data kept about a user
+type UserErasureRepository interface {
+	// Anonymize saves the scrubbed user and deletes the login attempts recorded
+	// under loginIdentifier (the user's former email) in one transaction
+	Anonymize(ctx context.Context, user *entities.User, loginIdentifier string) error
+}
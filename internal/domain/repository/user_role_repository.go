@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	"github.com/google/uuid"
@@ -9,10 +10,20 @@ import (
 
 type UserRoleRepository interface {
 	FindByUser(ctx context.Context, userID uuid.UUID) ([]*entities.UserRole, error)
+	FindActiveByRole(ctx context.Context, roleID uuid.UUID) ([]*entities.UserRole, error)
+	FindActiveInScope(ctx context.Context, schoolID, unitID, roleID *uuid.UUID) ([]*entities.UserRole, error)
+	// FindActiveByUsers loads the active assignments of many users at once
+	FindActiveByUsers(ctx context.Context, userIDs []uuid.UUID) ([]*entities.UserRole, error)
 	FindByUserInContext(ctx context.Context, userID uuid.UUID, schoolID *uuid.UUID, unitID *uuid.UUID) ([]*entities.UserRole, error)
 	Grant(ctx context.Context, userRole *entities.UserRole) error
 	Revoke(ctx context.Context, id uuid.UUID) error
 	RevokeByUserAndRole(ctx context.Context, userID, roleID uuid.UUID, schoolID, unitID *uuid.UUID) error
+	// DeactivateExpired deactivates the active assignments past their expiry
+	// and returns them
+	DeactivateExpired(ctx context.Context, now time.Time) ([]*entities.UserRole, error)
 	UserHasRole(ctx context.Context, userID, roleID uuid.UUID, schoolID, unitID *uuid.UUID) (bool, error)
 	GetUserPermissions(ctx context.Context, userID uuid.UUID, schoolID, unitID *uuid.UUID) ([]string, error)
+	// GetContextPermissions resolves a context the way switch-context does:
+	// global roles, plus the school's roles, plus the unit's roles
+	GetContextPermissions(ctx context.Context, userID uuid.UUID, schoolID, unitID *uuid.UUID) ([]string, error)
 }
@@ -0,0 +1,33 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+)
+
+// ScreenConfigBundleRepository wraps a ScreenConfigBundleRepository so that
+// bundle imports and cascading deletes, which write templates outside the
+// cached repository, evict the templates they touched once the transaction
+// commits.
+type ScreenConfigBundleRepository struct {
+	repository.ScreenConfigBundleRepository
+	templates *CachedScreenTemplateRepository
+}
+
+func NewScreenConfigBundleRepository(inner repository.ScreenConfigBundleRepository, templates *CachedScreenTemplateRepository) *ScreenConfigBundleRepository {
+	return &ScreenConfigBundleRepository{ScreenConfigBundleRepository: inner, templates: templates}
+}
+
+func (r *ScreenConfigBundleRepository) Apply(ctx context.Context, changes *repository.ScreenConfigChangeSet) error {
+	err := r.ScreenConfigBundleRepository.Apply(ctx, changes)
+	if err == nil {
+		for _, t := range changes.UpsertTemplates {
+			r.templates.cache.Delete(t.ID)
+		}
+		for _, id := range changes.DeactivateTemplates {
+			r.templates.cache.Delete(id)
+		}
+	}
+	return err
+}
@@ -63,6 +63,10 @@ func (r *CachedScreenTemplateRepository) List(ctx context.Context, filter shared
 	return r.inner.List(ctx, filter)
 }
 
+func (r *CachedScreenTemplateRepository) ListAfter(ctx context.Context, after uuid.UUID, limit int) ([]*entities.ScreenTemplate, error) {
+	return r.inner.ListAfter(ctx, after, limit)
+}
+
 func (r *CachedScreenTemplateRepository) Update(ctx context.Context, t *entities.ScreenTemplate) error {
 	err := r.inner.Update(ctx, t)
 	if err == nil {
@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/service"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	ginhelper "github.com/EduGoGroup/edugo-shared/middleware/gin"
+)
+
+type AccessRequestHandler struct {
+	accessRequestService service.AccessRequestService
+	logger               logger.Logger
+}
+
+func NewAccessRequestHandler(accessRequestService service.AccessRequestService, logger logger.Logger) *AccessRequestHandler {
+	return &AccessRequestHandler{accessRequestService: accessRequestService, logger: logger}
+}
+
+// Create submits a self-service access request
+// @Summary Request access
+// @Description Request a role in a school or academic unit with a justification. Any authenticated user may request access for themselves.
+// @Tags Access Requests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateAccessRequestRequest true "Access request"
+// @Success 201 {object} dto.AccessRequestDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /access-requests [post]
+func (h *AccessRequestHandler) Create(c *gin.Context) {
+	var req dto.CreateAccessRequestRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	userID, _ := ginhelper.GetUserID(c)
+	result, err := h.accessRequestService.Create(c.Request.Context(), userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+}
+
+// ListMine lists the caller's access requests
+// @Summary List my access requests
+// @Tags Access Requests
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number (1-based)" minimum(1)
+// @Param limit query int false "Items per page" minimum(1) maximum(200)
+// @Success 200 {object} dto.AccessRequestsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /access-requests/mine [get]
+func (h *AccessRequestHandler) ListMine(c *gin.Context) {
+	filters, err := ginhelper.ParseListFilters(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	userID, _ := ginhelper.GetUserID(c)
+	result, err := h.accessRequestService.ListMine(c.Request.Context(), userID, filters)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// List lists access requests for admins
+// @Summary List access requests
+// @Tags Access Requests
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filter by status (pending, approved, rejected, cancelled)"
+// @Param school_id query string false "Filter by school"
+// @Param page query int false "Page number (1-based)" minimum(1)
+// @Param limit query int false "Items per page" minimum(1) maximum(200)
+// @Success 200 {object} dto.AccessRequestsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /access-requests [get]
+func (h *AccessRequestHandler) List(c *gin.Context) {
+	filters, err := ginhelper.ParseListFilters(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	result, err := h.accessRequestService.List(c.Request.Context(), c.Query("school_id"), c.Query("status"), filters)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// Get gets an access request
+// @Summary Get access request
+// @Tags Access Requests
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Access request ID"
+// @Success 200 {object} dto.AccessRequestDTO
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /access-requests/{id} [get]
+func (h *AccessRequestHandler) Get(c *gin.Context) {
+	result, err := h.accessRequestService.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// Approve approves an access request and grants the role
+// @Summary Approve access request
+// @Description Grant the requested role with an expiry. For roles that require approval, a pending role grant request is created instead and linked in role_grant_request_id.
+// @Tags Access Requests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Access request ID"
+// @Param request body dto.DecideAccessRequestRequest false "Decision"
+// @Success 200 {object} dto.AccessRequestDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /access-requests/{id}/approve [post]
+func (h *AccessRequestHandler) Approve(c *gin.Context) {
+	req, ok := h.bindDecision(c)
+	if !ok {
+		return
+	}
+	deciderID, _ := ginhelper.GetUserID(c)
+	result, err := h.accessRequestService.Approve(c.Request.Context(), c.Param("id"), req, deciderID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// Reject rejects an access request
+// @Summary Reject access request
+// @Tags Access Requests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Access request ID"
+// @Param request body dto.DecideAccessRequestRequest false "Decision"
+// @Success 200 {object} dto.AccessRequestDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /access-requests/{id}/reject [post]
+func (h *AccessRequestHandler) Reject(c *gin.Context) {
+	req, ok := h.bindDecision(c)
+	if !ok {
+		return
+	}
+	deciderID, _ := ginhelper.GetUserID(c)
+	result, err := h.accessRequestService.Reject(c.Request.Context(), c.Param("id"), req, deciderID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// Cancel withdraws the caller's pending access request
+// @Summary Cancel access request
+// @Tags Access Requests
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Access request ID"
+// @Success 200 {object} dto.AccessRequestDTO
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /access-requests/{id}/cancel [post]
+func (h *AccessRequestHandler) Cancel(c *gin.Context) {
+	userID, _ := ginhelper.GetUserID(c)
+	result, err := h.accessRequestService.Cancel(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// bindDecision binds the optional decision body
+func (h *AccessRequestHandler) bindDecision(c *gin.Context) (*dto.DecideAccessRequestRequest, bool) {
+	var req dto.DecideAccessRequestRequest
+	if c.Request.ContentLength > 0 {
+		if err := bindJSON(c, &req); err != nil {
+			_ = c.Error(err)
+			return nil, false
+		}
+	}
+	return &req, true
+}
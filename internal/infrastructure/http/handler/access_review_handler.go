@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/service"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	ginhelper "github.com/EduGoGroup/edugo-shared/middleware/gin"
+)
+
+type AccessReviewHandler struct {
+	reviewService service.AccessReviewService
+	logger        logger.Logger
+}
+
+func NewAccessReviewHandler(reviewService service.AccessReviewService, logger logger.Logger) *AccessReviewHandler {
+	return &AccessReviewHandler{reviewService: reviewService, logger: logger}
+}
+
+// CreateCampaign opens an access review campaign
+// @Summary Create access review campaign
+// @Description Snapshot the active role assignments matching the scope (school, role and/or unit) and split them between reviewers. Items left unreviewed at the deadline are revoked automatically.
+// @Tags Access Reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateAccessReviewRequest true "Campaign"
+// @Success 201 {object} dto.AccessReviewCampaignDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /access-reviews [post]
+func (h *AccessReviewHandler) CreateCampaign(c *gin.Context) {
+	var req dto.CreateAccessReviewRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	createdBy, _ := ginhelper.GetUserID(c)
+	result, err := h.reviewService.CreateCampaign(c.Request.Context(), &req, createdBy)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+}
+
+// ListCampaigns lists access review campaigns
+// @Summary List access review campaigns
+// @Tags Access Reviews
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filter by status (active, completed)"
+// @Param page query int false "Page number (1-based)" minimum(1)
+// @Param limit query int false "Items per page" minimum(1) maximum(200)
+// @Success 200 {object} dto.AccessReviewCampaignsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /access-reviews [get]
+func (h *AccessReviewHandler) ListCampaigns(c *gin.Context) {
+	filters, err := ginhelper.ParseListFilters(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	result, err := h.reviewService.ListCampaigns(c.Request.Context(), c.Query("status"), filters)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GetCampaign gets a campaign with its progress
+// @Summary Get access review campaign
+// @Tags Access Reviews
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Campaign ID"
+// @Success 200 {object} dto.AccessReviewCampaignDTO
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /access-reviews/{id} [get]
+func (h *AccessReviewHandler) GetCampaign(c *gin.Context) {
+	result, err := h.reviewService.GetCampaign(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// ListItems lists the items of a campaign
+// @Summary List access review items
+// @Tags Access Reviews
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Campaign ID"
+// @Param decision query string false "Filter by decision (pending, certified, revoked, auto_revoked)"
+// @Success 200 {object} dto.AccessReviewItemsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /access-reviews/{id}/items [get]
+func (h *AccessReviewHandler) ListItems(c *gin.Context) {
+	result, err := h.reviewService.ListItems(c.Request.Context(), c.Param("id"), c.Query("decision"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// ListMyItems lists the caller's pending review items
+// @Summary List my pending access review items
+// @Tags Access Reviews
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.AccessReviewItemsResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /access-reviews/my-items [get]
+func (h *AccessReviewHandler) ListMyItems(c *gin.Context) {
+	reviewerID, _ := ginhelper.GetUserID(c)
+	result, err := h.reviewService.ListMyItems(c.Request.Context(), reviewerID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// Decide certifies or revokes a reviewed assignment
+// @Summary Decide access review item
+// @Description Certify keeps the role assignment; revoke deactivates it immediately
+// @Tags Access Reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Campaign ID"
+// @Param item_id path string true "Item ID"
+// @Param request body dto.AccessReviewDecisionRequest true "Decision"
+// @Success 200 {object} dto.AccessReviewItemDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /access-reviews/{id}/items/{item_id}/decision [post]
+func (h *AccessReviewHandler) Decide(c *gin.Context) {
+	var req dto.AccessReviewDecisionRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	reviewerID, _ := ginhelper.GetUserID(c)
+	result, err := h.reviewService.Decide(c.Request.Context(), c.Param("id"), c.Param("item_id"), &req, reviewerID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// Report summarizes a campaign
+// @Summary Access review report
+// @Description Progress per reviewer, every revocation (manual and automatic) and the items still pending
+// @Tags Access Reviews
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Campaign ID"
+// @Success 200 {object} dto.AccessReviewReportDTO
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /access-reviews/{id}/report [get]
+func (h *AccessReviewHandler) Report(c *gin.Context) {
+	result, err := h.reviewService.Report(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/service"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	ginmiddleware "github.com/EduGoGroup/edugo-shared/middleware/gin"
+)
+
+type AuthzHandler struct {
+	authzService service.AuthzService
+	logger       logger.Logger
+}
+
+func NewAuthzHandler(authzService service.AuthzService, logger logger.Logger) *AuthzHandler {
+	return &AuthzHandler{authzService: authzService, logger: logger}
+}
+
+// Check evaluates a single authorization decision
+// @Summary Check authorization
+// @Description Decide whether a subject may perform an action on a resource in a school/unit context, using live role assignments. Set explain=true to get a decision trace.
+// @Tags Authz
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.AuthzCheckRequest true "Authorization check"
+// @Success 200 {object} dto.AuthzDecisionDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /authz/check [post]
+func (h *AuthzHandler) Check(c *gin.Context) {
+	var req dto.AuthzCheckRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	h.defaultSubject(c, &req)
+	decision, err := h.authzService.Check(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, decision)
+}
+
+// BatchCheck evaluates several authorization decisions
+// @Summary Batch check authorization
+// @Description Evaluate up to 100 authorization checks in one call. Decisions are returned in request order.
+// @Tags Authz
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.AuthzBatchCheckRequest true "Authorization checks"
+// @Success 200 {object} dto.AuthzBatchCheckResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /authz/check/batch [post]
+func (h *AuthzHandler) BatchCheck(c *gin.Context) {
+	var req dto.AuthzBatchCheckRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	for i := range req.Checks {
+		h.defaultSubject(c, &req.Checks[i])
+	}
+	result, err := h.authzService.BatchCheck(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// defaultSubject fills in the authenticated caller as subject when none was given
+func (h *AuthzHandler) defaultSubject(c *gin.Context, req *dto.AuthzCheckRequest) {
+	if req.SubjectID != "" {
+		return
+	}
+	if userID, err := ginmiddleware.GetUserID(c); err == nil {
+		req.SubjectID = userID
+	}
+}
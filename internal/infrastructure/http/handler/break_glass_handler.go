@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/service"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	ginhelper "github.com/EduGoGroup/edugo-shared/middleware/gin"
+)
+
+type BreakGlassHandler struct {
+	breakGlassService service.BreakGlassService
+	logger            logger.Logger
+}
+
+func NewBreakGlassHandler(breakGlassService service.BreakGlassService, logger logger.Logger) *BreakGlassHandler {
+	return &BreakGlassHandler{breakGlassService: breakGlassService, logger: logger}
+}
+
+// Activate grants the caller emergency global privileges
+// @Summary Activate break-glass elevation
+// @Description Pre-provisioned emergency responders (users:break_glass) get a time-boxed global role. A justification is mandatory, activations are rate limited, and every activation is audited as critical and alerted immediately. Log in again or switch context to use the elevated role.
+// @Tags Break Glass
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.ActivateBreakGlassRequest true "Justification"
+// @Success 201 {object} dto.BreakGlassElevationDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /break-glass [post]
+func (h *BreakGlassHandler) Activate(c *gin.Context) {
+	var req dto.ActivateBreakGlassRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	userID, _ := ginhelper.GetUserID(c)
+	result, err := h.breakGlassService.Activate(c.Request.Context(), userID, c.ClientIP(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+}
+
+// End ends the caller's own elevation early
+// @Summary End my break-glass elevation
+// @Tags Break Glass
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Elevation ID"
+// @Success 200 {object} dto.BreakGlassElevationDTO
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /break-glass/{id}/end [post]
+func (h *BreakGlassHandler) End(c *gin.Context) {
+	h.end(c, false)
+}
+
+// Revoke ends any user's elevation. It is limited to break-glass responders.
+// @Summary Revoke break-glass elevation
+// @Tags Break Glass
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Elevation ID"
+// @Success 200 {object} dto.BreakGlassElevationDTO
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /break-glass/{id}/revoke [post]
+func (h *BreakGlassHandler) Revoke(c *gin.Context) {
+	h.end(c, true)
+}
+
+func (h *BreakGlassHandler) end(c *gin.Context, admin bool) {
+	userID, _ := ginhelper.GetUserID(c)
+	result, err := h.breakGlassService.End(c.Request.Context(), c.Param("id"), userID, admin)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// List lists break-glass elevations
+// @Summary List break-glass elevations
+// @Tags Break Glass
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number (1-based)" minimum(1)
+// @Param limit query int false "Items per page" minimum(1) maximum(200)
+// @Success 200 {object} dto.BreakGlassElevationsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /break-glass [get]
+func (h *BreakGlassHandler) List(c *gin.Context) {
+	filters, err := ginhelper.ParseListFilters(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	result, err := h.breakGlassService.List(c.Request.Context(), filters)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
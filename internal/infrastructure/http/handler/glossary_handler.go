@@ -0,0 +1,269 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/service"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	ginmiddleware "github.com/EduGoGroup/edugo-shared/middleware/gin"
+)
+
+type GlossaryHandler struct {
+	glossaryService service.GlossaryService
+	logger          logger.Logger
+}
+
+func NewGlossaryHandler(glossaryService service.GlossaryService, logger logger.Logger) *GlossaryHandler {
+	return &GlossaryHandler{glossaryService: glossaryService, logger: logger}
+}
+
+// Platform defaults
+
+// ListGlossaryDefaults lists the platform default terms
+// @Summary List glossary defaults
+// @Description List the platform default glossary terms used by schools that don't override them
+// @Tags Glossary
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /glossary/defaults [get]
+func (h *GlossaryHandler) ListGlossaryDefaults(c *gin.Context) {
+	terms, err := h.glossaryService.ListDefaults(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": terms, "total": len(terms)})
+}
+
+// SaveGlossaryDefault creates or updates a platform default term
+// @Summary Save glossary default
+// @Description Create or update a platform default glossary term
+// @Tags Glossary
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param term_key path string true "Term key"
+// @Param request body service.SaveGlossaryDefaultRequest true "Term value"
+// @Success 200 {object} service.GlossaryDefaultDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /glossary/defaults/{term_key} [put]
+func (h *GlossaryHandler) SaveGlossaryDefault(c *gin.Context) {
+	var req service.SaveGlossaryDefaultRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+	term, err := h.glossaryService.SaveDefault(c.Request.Context(), c.Param("term_key"), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, term)
+}
+
+// DeleteGlossaryDefault removes a platform default term
+// @Summary Delete glossary default
+// @Description Remove a platform default glossary term. Schools that override it keep their value.
+// @Tags Glossary
+// @Security BearerAuth
+// @Param term_key path string true "Term key"
+// @Success 204
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /glossary/defaults/{term_key} [delete]
+func (h *GlossaryHandler) DeleteGlossaryDefault(c *gin.Context) {
+	if err := h.glossaryService.DeleteDefault(c.Request.Context(), c.Param("term_key")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ImportGlossaryDefaults sets many platform default terms at once
+// @Summary Import glossary defaults
+// @Description Create or update many platform default terms in one transaction. With replace=true the defaults missing from the import are removed.
+// @Tags Glossary
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body service.ImportGlossaryRequest true "Terms"
+// @Success 200 {object} service.GlossaryImportResultDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /glossary/defaults/import [post]
+func (h *GlossaryHandler) ImportGlossaryDefaults(c *gin.Context) {
+	var req service.ImportGlossaryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+	result, err := h.glossaryService.ImportDefaults(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// School glossary
+
+// ListSchoolGlossary lists the effective glossary of a school
+// @Summary List school glossary
+// @Description List the terms of a school: the platform defaults with the school's own values on top
+// @Tags Glossary
+// @Produce json
+// @Security BearerAuth
+// @Param school_id path string true "School ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /schools/{school_id}/glossary [get]
+func (h *GlossaryHandler) ListSchoolGlossary(c *gin.Context) {
+	if !requireSchoolGlossaryAccess(c) {
+		return
+	}
+	terms, err := h.glossaryService.ListSchoolTerms(c.Request.Context(), c.Param("school_id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": terms, "total": len(terms)})
+}
+
+// GetSchoolGlossaryTerm returns one term of a school's glossary
+// @Summary Get school glossary term
+// @Description Get the effective value of a term for a school
+// @Tags Glossary
+// @Produce json
+// @Security BearerAuth
+// @Param school_id path string true "School ID"
+// @Param term_key path string true "Term key"
+// @Success 200 {object} service.GlossaryTermDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /schools/{school_id}/glossary/{term_key} [get]
+func (h *GlossaryHandler) GetSchoolGlossaryTerm(c *gin.Context) {
+	if !requireSchoolGlossaryAccess(c) {
+		return
+	}
+	term, err := h.glossaryService.GetSchoolTerm(c.Request.Context(), c.Param("school_id"), c.Param("term_key"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, term)
+}
+
+// SaveSchoolGlossaryTerm sets the school's value of a term
+// @Summary Save school glossary term
+// @Description Override a default term or add a school-specific one
+// @Tags Glossary
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param school_id path string true "School ID"
+// @Param term_key path string true "Term key"
+// @Param request body service.SaveGlossaryTermRequest true "Term value"
+// @Success 200 {object} service.GlossaryTermDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /schools/{school_id}/glossary/{term_key} [put]
+func (h *GlossaryHandler) SaveSchoolGlossaryTerm(c *gin.Context) {
+	if !requireSchoolGlossaryAccess(c) {
+		return
+	}
+	var req service.SaveGlossaryTermRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+	term, err := h.glossaryService.SaveSchoolTerm(c.Request.Context(), c.Param("school_id"), c.Param("term_key"), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, term)
+}
+
+// DeleteSchoolGlossaryTerm removes the school's value of a term
+// @Summary Delete school glossary term
+// @Description Remove the school's value of a term; the platform default applies again
+// @Tags Glossary
+// @Security BearerAuth
+// @Param school_id path string true "School ID"
+// @Param term_key path string true "Term key"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /schools/{school_id}/glossary/{term_key} [delete]
+func (h *GlossaryHandler) DeleteSchoolGlossaryTerm(c *gin.Context) {
+	if !requireSchoolGlossaryAccess(c) {
+		return
+	}
+	if err := h.glossaryService.DeleteSchoolTerm(c.Request.Context(), c.Param("school_id"), c.Param("term_key")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ImportSchoolGlossary sets many terms of a school at once
+// @Summary Import school glossary
+// @Description Set many terms of a school in one transaction. With replace=true the school's terms missing from the import are removed and their defaults apply again.
+// @Tags Glossary
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param school_id path string true "School ID"
+// @Param request body service.ImportGlossaryRequest true "Terms"
+// @Success 200 {object} service.GlossaryImportResultDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /schools/{school_id}/glossary/import [post]
+func (h *GlossaryHandler) ImportSchoolGlossary(c *gin.Context) {
+	if !requireSchoolGlossaryAccess(c) {
+		return
+	}
+	var req service.ImportGlossaryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+	result, err := h.glossaryService.ImportSchoolTerms(c.Request.Context(), c.Param("school_id"), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// requireSchoolGlossaryAccess allows glossary:manage on any school and
+// everyone else on their active school only. The route already requires the
+// static permission.
+func requireSchoolGlossaryAccess(c *gin.Context) bool {
+	if hasActivePermission(c, service.PermissionGlossaryManage) {
+		return true
+	}
+	if claims, err := ginmiddleware.GetClaims(c); err == nil && claims.ActiveContext != nil && claims.ActiveContext.SchoolID == c.Param("school_id") {
+		return true
+	}
+	c.JSON(http.StatusForbidden, dto.ErrorResponse{Error: "forbidden", Message: "Only the glossary of your active school can be accessed", Code: "GLOSSARY_FORBIDDEN"})
+	return false
+}
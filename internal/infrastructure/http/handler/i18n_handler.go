@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	ginmiddleware "github.com/EduGoGroup/edugo-shared/middleware/gin"
+)
+
+// localeKey is the gin context key of the negotiated response locale
+const localeKey = "locale"
+
+type I18nHandler struct {
+	i18nService service.I18nService
+	logger      logger.Logger
+}
+
+func NewI18nHandler(i18nService service.I18nService, logger logger.Logger) *I18nHandler {
+	return &I18nHandler{i18nService: i18nService, logger: logger}
+}
+
+// NegotiateLocale picks the response locale from the user's preference or
+// Accept-Language and stores it for requestLocale. It must run after JWT
+// authentication.
+func (h *I18nHandler) NegotiateLocale(c *gin.Context) {
+	userID, _ := ginmiddleware.GetUserID(c)
+	locale := h.i18nService.ResolveLocale(c.Request.Context(), userID, c.GetHeader("Accept-Language"))
+	c.Set(localeKey, locale)
+	c.Header("Content-Language", locale)
+	c.Header("Vary", "Accept-Language")
+	c.Next()
+}
+
+// requestLocale returns the locale set by NegotiateLocale, or "" for the
+// default locale
+func requestLocale(c *gin.Context) string {
+	return c.GetString(localeKey)
+}
+
+// ListLocales returns the supported locales
+// @Summary List locales
+// @Description List the locales served by menu, screen and sync responses and the default one
+// @Tags I18n
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} service.LocalesDTO
+// @Router /locales [get]
+func (h *I18nHandler) ListLocales(c *gin.Context) {
+	c.JSON(http.StatusOK, h.i18nService.Locales())
+}
+
+// GetMyPreferences returns the preferences of the authenticated user
+// @Summary Get my preferences
+// @Description Get the saved preferences of the authenticated user. A null locale means it is negotiated from Accept-Language.
+// @Tags I18n
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} service.PreferencesDTO
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /me/preferences [get]
+func (h *I18nHandler) GetMyPreferences(c *gin.Context) {
+	userID, err := ginmiddleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized", Code: "UNAUTHORIZED"})
+		return
+	}
+	prefs, err := h.i18nService.GetPreferences(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, prefs)
+}
+
+// UpdateMyPreferences saves the preferences of the authenticated user
+// @Summary Update my preferences
+// @Description Save the preferred locale of the authenticated user; it takes precedence over Accept-Language. A null locale clears it.
+// @Tags I18n
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body service.UpdatePreferencesRequest true "Preferences"
+// @Success 200 {object} service.PreferencesDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /me/preferences [put]
+func (h *I18nHandler) UpdateMyPreferences(c *gin.Context) {
+	userID, err := ginmiddleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized", Code: "UNAUTHORIZED"})
+		return
+	}
+	var req service.UpdatePreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+	prefs, err := h.i18nService.UpdatePreferences(c.Request.Context(), userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, prefs)
+}
+
+// GetResourceTranslations returns the translations of a resource
+// @Summary Get resource translations
+// @Description Get the display name translations of a resource by locale
+// @Tags I18n
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Resource ID"
+// @Success 200 {object} service.TranslationsDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /resources/{id}/translations [get]
+func (h *I18nHandler) GetResourceTranslations(c *gin.Context) {
+	h.getTranslations(c, model.TranslationEntityResource)
+}
+
+// SaveResourceTranslations replaces the translations of a resource
+// @Summary Save resource translations
+// @Description Replace the translations of a resource, as field → locale → value. Only display_name is translatable.
+// @Tags I18n
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Resource ID"
+// @Param request body service.SaveTranslationsRequest true "Translations"
+// @Success 200 {object} service.TranslationsDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /resources/{id}/translations [put]
+func (h *I18nHandler) SaveResourceTranslations(c *gin.Context) {
+	h.saveTranslations(c, model.TranslationEntityResource)
+}
+
+// GetInstanceTranslations returns the translations of a screen instance
+// @Summary Get screen instance translations
+// @Description Get the name translations of a screen instance by locale. Slot data text is translated inline with {"$i18n": {...}} values.
+// @Tags I18n
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Instance ID"
+// @Success 200 {object} service.TranslationsDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/instances/{id}/translations [get]
+func (h *I18nHandler) GetInstanceTranslations(c *gin.Context) {
+	h.getTranslations(c, model.TranslationEntityScreenInstance)
+}
+
+// SaveInstanceTranslations replaces the translations of a screen instance
+// @Summary Save screen instance translations
+// @Description Replace the translations of a screen instance, as field → locale → value. Only name is translatable.
+// @Tags I18n
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Instance ID"
+// @Param request body service.SaveTranslationsRequest true "Translations"
+// @Success 200 {object} service.TranslationsDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/instances/{id}/translations [put]
+func (h *I18nHandler) SaveInstanceTranslations(c *gin.Context) {
+	h.saveTranslations(c, model.TranslationEntityScreenInstance)
+}
+
+func (h *I18nHandler) getTranslations(c *gin.Context, entityType string) {
+	translations, err := h.i18nService.GetTranslations(c.Request.Context(), entityType, c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, translations)
+}
+
+func (h *I18nHandler) saveTranslations(c *gin.Context, entityType string) {
+	var req service.SaveTranslationsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+	translations, err := h.i18nService.SaveTranslations(c.Request.Context(), entityType, c.Param("id"), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, translations)
+}
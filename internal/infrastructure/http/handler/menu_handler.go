@@ -25,10 +25,11 @@ func NewMenuHandler(menuService service.MenuService, logger logger.Logger) *Menu
 
 // GetUserMenu returns the menu filtered by user permissions
 // @Summary Get user menu
-// @Description Get the navigation menu filtered by the authenticated user's permissions
+// @Description Get the navigation menu filtered by the authenticated user's permissions. Display names are in the user's preferred locale or the Accept-Language one.
 // @Tags Menu
 // @Produce json
 // @Security BearerAuth
+// @Param Accept-Language header string false "Preferred locales"
 // @Success 200 {object} map[string]interface{}
 // @Failure 401 {object} map[string]string
 // @Failure 403 {object} map[string]string
@@ -46,7 +47,7 @@ func (h *MenuHandler) GetUserMenu(c *gin.Context) {
 		return
 	}
 
-	menu, err := h.menuService.GetMenuForUser(c.Request.Context(), jwtClaims.ActiveContext.Permissions)
+	menu, err := h.menuService.GetMenuForUser(c.Request.Context(), jwtClaims.ActiveContext.Permissions, requestLocale(c))
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -56,15 +57,16 @@ func (h *MenuHandler) GetUserMenu(c *gin.Context) {
 
 // GetFullMenu returns the complete menu tree
 // @Summary Get full menu
-// @Description Get the complete navigation menu tree without permission filtering
+// @Description Get the complete navigation menu tree without permission filtering, localized like /menu
 // @Tags Menu
 // @Produce json
 // @Security BearerAuth
+// @Param Accept-Language header string false "Preferred locales"
 // @Success 200 {object} map[string]interface{}
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /menu/full [get]
 func (h *MenuHandler) GetFullMenu(c *gin.Context) {
-	menu, err := h.menuService.GetFullMenu(c.Request.Context())
+	menu, err := h.menuService.GetFullMenu(c.Request.Context(), requestLocale(c))
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+)
+
+// RequireAnyPermission rejects callers whose active context grants none of
+// perms. It gates routes on permissions that are not in the shared enum or
+// that accept alternatives, which ginmiddleware.RequirePermission cannot express.
+func RequireAnyPermission(perms ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if hasActivePermission(c, perms...) {
+			c.Next()
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusForbidden, dto.ErrorResponse{
+			Error:   "forbidden",
+			Message: "Missing permission: requires one of " + strings.Join(perms, ", "),
+			Code:    "PERMISSION_DENIED",
+		})
+	}
+}
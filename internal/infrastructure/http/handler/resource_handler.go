@@ -126,16 +126,18 @@ func (h *ResourceHandler) CreateResource(c *gin.Context) {
 
 // UpdateResource updates a resource
 // @Summary Update resource
-// @Description Update an existing resource
+// @Description Update an existing resource. Deactivating a resource with active children or linked screens is refused with 409 unless cascade=true, which deactivates the subtree and unlinks its screens.
 // @Tags Resources
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Resource ID"
+// @Param cascade query bool false "Deactivate children and unlink screens"
 // @Param request body dto.UpdateResourceRequest true "Updated resource data"
 // @Success 200 {object} dto.ResourceDTO
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /resources/{id} [put]
 func (h *ResourceHandler) UpdateResource(c *gin.Context) {
@@ -145,7 +147,7 @@ func (h *ResourceHandler) UpdateResource(c *gin.Context) {
 		_ = c.Error(err)
 		return
 	}
-	resource, err := h.resourceService.UpdateResource(c.Request.Context(), id, req)
+	resource, err := h.resourceService.UpdateResource(c.Request.Context(), id, req, c.Query("cascade") == "true")
 	if err != nil {
 		_ = c.Error(err)
 		return
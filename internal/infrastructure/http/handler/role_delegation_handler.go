@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/service"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	ginhelper "github.com/EduGoGroup/edugo-shared/middleware/gin"
+)
+
+type RoleDelegationHandler struct {
+	delegationService service.RoleDelegationService
+	logger            logger.Logger
+}
+
+func NewRoleDelegationHandler(delegationService service.RoleDelegationService, logger logger.Logger) *RoleDelegationHandler {
+	return &RoleDelegationHandler{delegationService: delegationService, logger: logger}
+}
+
+// Create delegates the caller's role assignments in a unit
+// @Summary Delegate my roles
+// @Description Hand some or all of your role assignments in an academic unit to another user for a time window. The delegate gets the same roles in that unit until ends_at; no admin approval is involved.
+// @Tags Delegations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateDelegationRequest true "Delegation"
+// @Success 201 {array} dto.RoleDelegationDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /delegations [post]
+func (h *RoleDelegationHandler) Create(c *gin.Context) {
+	var req dto.CreateDelegationRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	userID, _ := ginhelper.GetUserID(c)
+	result, err := h.delegationService.Create(c.Request.Context(), userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+}
+
+// ListMine lists the delegations the caller has given and received
+// @Summary List my delegations
+// @Tags Delegations
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.RoleDelegationsResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /delegations [get]
+func (h *RoleDelegationHandler) ListMine(c *gin.Context) {
+	userID, _ := ginhelper.GetUserID(c)
+	result, err := h.delegationService.ListMine(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// Revoke ends a delegation early
+// @Summary Revoke delegation
+// @Description End a scheduled or active delegation. Either the delegator or the delegate may revoke it.
+// @Tags Delegations
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Delegation ID"
+// @Success 200 {object} dto.RoleDelegationDTO
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /delegations/{id}/revoke [post]
+func (h *RoleDelegationHandler) Revoke(c *gin.Context) {
+	userID, _ := ginhelper.GetUserID(c)
+	result, err := h.delegationService.Revoke(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/service"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	ginhelper "github.com/EduGoGroup/edugo-shared/middleware/gin"
+)
+
+type RoleGrantHandler struct {
+	approvalService service.RoleGrantApprovalService
+	logger          logger.Logger
+}
+
+func NewRoleGrantHandler(approvalService service.RoleGrantApprovalService, logger logger.Logger) *RoleGrantHandler {
+	return &RoleGrantHandler{approvalService: approvalService, logger: logger}
+}
+
+// GetPolicy gets the grant policy of a role
+// @Summary Get role grant policy
+// @Description Whether grants of this role require approval by a second admin
+// @Tags Role Grants
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Role ID"
+// @Success 200 {object} dto.RoleGrantPolicyDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /roles/{id}/grant-policy [get]
+func (h *RoleGrantHandler) GetPolicy(c *gin.Context) {
+	policy, err := h.approvalService.GetPolicy(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, policy)
+}
+
+// UpdatePolicy flags or unflags a role as requiring approval
+// @Summary Update role grant policy
+// @Description Flag a role as requiring approval: its grants become pending requests that a second admin must approve
+// @Tags Role Grants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Role ID"
+// @Param request body dto.UpdateRoleGrantPolicyRequest true "Grant policy"
+// @Success 200 {object} dto.RoleGrantPolicyDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /roles/{id}/grant-policy [put]
+func (h *RoleGrantHandler) UpdatePolicy(c *gin.Context) {
+	var req dto.UpdateRoleGrantPolicyRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	updatedBy, _ := ginhelper.GetUserID(c)
+	policy, err := h.approvalService.SetPolicy(c.Request.Context(), c.Param("id"), &req, updatedBy)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, policy)
+}
+
+// ListRequests lists role grant requests
+// @Summary List role grant requests
+// @Description List grant requests for roles that require approval, newest first
+// @Tags Role Grants
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filter by status (pending, approved, rejected, expired)"
+// @Param user_id query string false "Filter by target user"
+// @Param role_id query string false "Filter by role"
+// @Param page query int false "Page number (1-based)" minimum(1)
+// @Param limit query int false "Items per page" minimum(1) maximum(200)
+// @Success 200 {object} dto.RoleGrantRequestsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /role-grant-requests [get]
+func (h *RoleGrantHandler) ListRequests(c *gin.Context) {
+	filters, err := ginhelper.ParseListFilters(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	for _, field := range []string{"user_id", "role_id"} {
+		if v := c.Query(field); v != "" {
+			if filters.FieldFilters == nil {
+				filters.FieldFilters = map[string][]string{}
+			}
+			filters.FieldFilters[field] = []string{v}
+		}
+	}
+	result, err := h.approvalService.ListRequests(c.Request.Context(), c.Query("status"), filters)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GetRequest gets a role grant request
+// @Summary Get role grant request
+// @Tags Role Grants
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Request ID"
+// @Success 200 {object} dto.RoleGrantRequestDTO
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /role-grant-requests/{id} [get]
+func (h *RoleGrantHandler) GetRequest(c *gin.Context) {
+	result, err := h.approvalService.GetRequest(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// ApproveRequest approves a pending role grant request
+// @Summary Approve role grant request
+// @Description Approve a pending request and grant the role. The approver must be a different admin than the requester.
+// @Tags Role Grants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Request ID"
+// @Param request body dto.DecideRoleGrantRequest false "Decision note"
+// @Success 200 {object} dto.RoleGrantRequestDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /role-grant-requests/{id}/approve [post]
+func (h *RoleGrantHandler) ApproveRequest(c *gin.Context) {
+	h.decide(c, h.approvalService.Approve)
+}
+
+// RejectRequest rejects a pending role grant request
+// @Summary Reject role grant request
+// @Tags Role Grants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Request ID"
+// @Param request body dto.DecideRoleGrantRequest false "Decision note"
+// @Success 200 {object} dto.RoleGrantRequestDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /role-grant-requests/{id}/reject [post]
+func (h *RoleGrantHandler) RejectRequest(c *gin.Context) {
+	h.decide(c, h.approvalService.Reject)
+}
+
+type grantDecisionFunc func(ctx context.Context, id string, req *dto.DecideRoleGrantRequest, approverID string) (*dto.RoleGrantRequestDTO, error)
+
+func (h *RoleGrantHandler) decide(c *gin.Context, fn grantDecisionFunc) {
+	var req dto.DecideRoleGrantRequest
+	if c.Request.ContentLength > 0 {
+		if err := bindJSON(c, &req); err != nil {
+			_ = c.Error(err)
+			return
+		}
+	}
+	approverID, _ := ginhelper.GetUserID(c)
+	result, err := fn(c.Request.Context(), c.Param("id"), &req, approverID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
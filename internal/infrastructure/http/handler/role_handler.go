@@ -220,14 +220,16 @@ func (h *RoleHandler) RevokePermission(c *gin.Context) {
 
 // BulkReplacePermissions replaces all permissions for a role
 // @Summary Bulk replace role permissions
-// @Description Replace all permissions assigned to a role
+// @Description Replace all permissions assigned to a role. With dry_run=true nothing is persisted and the diff, affected assignments and menu impact are returned instead.
 // @Tags Roles
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Role ID"
+// @Param dry_run query bool false "Preview the change without applying it"
 // @Param request body dto.BulkPermissionsRequest true "Permission IDs"
 // @Success 200 {object} dto.PermissionsResponse
+// @Success 200 {object} dto.BulkPermissionsPreviewResponse "dry_run=true"
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
@@ -239,6 +241,15 @@ func (h *RoleHandler) BulkReplacePermissions(c *gin.Context) {
 		_ = c.Error(err)
 		return
 	}
+	if c.Query("dry_run") == "true" {
+		preview, err := h.roleService.PreviewBulkReplacePermissions(c.Request.Context(), id, &req)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, preview)
+		return
+	}
 	result, err := h.roleService.BulkReplacePermissions(c.Request.Context(), id, &req)
 	if err != nil {
 		_ = c.Error(err)
@@ -267,9 +278,32 @@ func (h *RoleHandler) GetUserRoles(c *gin.Context) {
 	c.JSON(http.StatusOK, roles)
 }
 
+// GetUserEffectivePermissions explains the effective permissions of a user
+// @Summary Get user effective permissions
+// @Description List every permission a user holds in a school/unit context, with the role assignments that grant it and their expiry
+// @Tags Roles
+// @Produce json
+// @Security BearerAuth
+// @Param user_id path string true "User ID"
+// @Param school_id query string false "School ID"
+// @Param unit_id query string false "Academic unit ID"
+// @Success 200 {object} dto.EffectivePermissionsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /users/{user_id}/effective-permissions [get]
+func (h *RoleHandler) GetUserEffectivePermissions(c *gin.Context) {
+	userID := c.Param("user_id")
+	result, err := h.roleService.GetUserEffectivePermissions(c.Request.Context(), userID, c.Query("school_id"), c.Query("unit_id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
 // GrantRole grants a role to a user
 // @Summary Grant role to user
-// @Description Assign a role to a user
+// @Description Assign a role to a user. Roles that require approval are not granted: a pending approval request is returned with 202.
 // @Tags Roles
 // @Accept json
 // @Produce json
@@ -277,6 +311,7 @@ func (h *RoleHandler) GetUserRoles(c *gin.Context) {
 // @Param user_id path string true "User ID"
 // @Param request body dto.GrantRoleRequest true "Role grant request"
 // @Success 201 {object} dto.GrantRoleResponse
+// @Success 202 {object} dto.GrantRoleResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /users/{user_id}/roles [post]
@@ -297,6 +332,10 @@ func (h *RoleHandler) GrantRole(c *gin.Context) {
 		_ = c.Error(err)
 		return
 	}
+	if result.ApprovalRequest != nil {
+		c.JSON(http.StatusAccepted, result)
+		return
+	}
 	c.JSON(http.StatusCreated, result)
 }
 
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/service"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	ginhelper "github.com/EduGoGroup/edugo-shared/middleware/gin"
+)
+
+type RoleImportHandler struct {
+	importService service.RoleImportService
+	maxFileBytes  int64
+	logger        logger.Logger
+}
+
+func NewRoleImportHandler(importService service.RoleImportService, maxFileBytes int64, logger logger.Logger) *RoleImportHandler {
+	return &RoleImportHandler{importService: importService, maxFileBytes: maxFileBytes, logger: logger}
+}
+
+// Submit queues a bulk role assignment import
+// @Summary Import role assignments from CSV/XLSX
+// @Description Upload a CSV or XLSX file whose header names the columns email, role, school (ID or code), unit (ID or name) and expires_at (YYYY-MM-DD or RFC3339). The file is processed in the background: every row is validated and, unless dry_run=true, valid rows are granted in a single transaction. Poll the job for progress and fetch its per-row report.
+// @Tags Role Imports
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "CSV or XLSX file"
+// @Param dry_run query bool false "Only validate the rows"
+// @Success 202 {object} dto.RoleImportJobDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /role-imports [post]
+func (h *RoleImportHandler) Submit(c *gin.Context) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		_ = c.Error(errors.NewValidationError("file is required"))
+		return
+	}
+	if header.Size > h.maxFileBytes {
+		_ = c.Error(errors.NewValidationError("file exceeds " + strconv.FormatInt(h.maxFileBytes, 10) + " bytes"))
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		_ = c.Error(errors.NewValidationError("could not read file"))
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, h.maxFileBytes+1))
+	if err != nil {
+		_ = c.Error(errors.NewValidationError("could not read file"))
+		return
+	}
+	if int64(len(data)) > h.maxFileBytes {
+		_ = c.Error(errors.NewValidationError("file exceeds " + strconv.FormatInt(h.maxFileBytes, 10) + " bytes"))
+		return
+	}
+
+	userID, _ := ginhelper.GetUserID(c)
+	result, err := h.importService.Submit(c.Request.Context(), userID, header.Filename, data, c.Query("dry_run") == "true")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusAccepted, result)
+}
+
+// ListJobs lists bulk role imports
+// @Summary List role imports
+// @Tags Role Imports
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number (1-based)" minimum(1)
+// @Param limit query int false "Items per page" minimum(1) maximum(200)
+// @Success 200 {object} dto.RoleImportJobsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /role-imports [get]
+func (h *RoleImportHandler) ListJobs(c *gin.Context) {
+	filters, err := ginhelper.ParseListFilters(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	result, err := h.importService.ListJobs(c.Request.Context(), filters)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GetJob gets the status of a bulk role import
+// @Summary Get role import status
+// @Tags Role Imports
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Import job ID"
+// @Success 200 {object} dto.RoleImportJobDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /role-imports/{id} [get]
+func (h *RoleImportHandler) GetJob(c *gin.Context) {
+	result, err := h.importService.GetJob(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GetRows returns the per-row report of a bulk role import
+// @Summary Get role import report
+// @Tags Role Imports
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Import job ID"
+// @Param status query string false "Row status: pending, valid, invalid, skipped or applied"
+// @Param page query int false "Page number (1-based)" minimum(1)
+// @Param limit query int false "Items per page" minimum(1) maximum(200)
+// @Success 200 {object} dto.RoleImportRowsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /role-imports/{id}/rows [get]
+func (h *RoleImportHandler) GetRows(c *gin.Context) {
+	filters, err := ginhelper.ParseListFilters(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	result, err := h.importService.GetRows(c.Request.Context(), c.Param("id"), c.Query("status"), filters)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
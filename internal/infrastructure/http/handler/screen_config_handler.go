@@ -1,13 +1,18 @@
 package handler
 
 import (
+	"io"
 	"net/http"
+	"slices"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
 	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/service"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
 	"github.com/EduGoGroup/edugo-shared/logger"
+	ginmiddleware "github.com/EduGoGroup/edugo-shared/middleware/gin"
 )
 
 type ScreenConfigHandler struct {
@@ -23,7 +28,7 @@ func NewScreenConfigHandler(screenService service.ScreenConfigService, logger lo
 
 // CreateTemplate creates a new screen template
 // @Summary Create screen template
-// @Description Create a new screen configuration template
+// @Description Create a new screen configuration template. The definition is validated against the JSON Schema of its pattern, if one is declared.
 // @Tags Screen Config
 // @Accept json
 // @Produce json
@@ -90,7 +95,7 @@ func (h *ScreenConfigHandler) GetTemplate(c *gin.Context) {
 
 // UpdateTemplate updates a screen template
 // @Summary Update screen template
-// @Description Update an existing screen template
+// @Description Save changes to the template's draft. Clients keep resolving the published revision until the draft is published.
 // @Tags Screen Config
 // @Accept json
 // @Produce json
@@ -119,17 +124,19 @@ func (h *ScreenConfigHandler) UpdateTemplate(c *gin.Context) {
 
 // DeleteTemplate deletes a screen template
 // @Summary Delete screen template
-// @Description Delete a screen template by its ID
+// @Description Delete a screen template by its ID. Refused with 409 while active instances use it unless cascade=true, which also deactivates them and removes their resource links.
 // @Tags Screen Config
 // @Security BearerAuth
 // @Param id path string true "Template ID"
+// @Param cascade query bool false "Deactivate dependent instances and links"
 // @Success 204
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /screen-config/templates/{id} [delete]
 func (h *ScreenConfigHandler) DeleteTemplate(c *gin.Context) {
 	id := c.Param("id")
-	if err := h.screenService.DeleteTemplate(c.Request.Context(), id); err != nil {
+	if err := h.screenService.DeleteTemplate(c.Request.Context(), id, c.Query("cascade") == "true"); err != nil {
 		_ = c.Error(err)
 		return
 	}
@@ -140,7 +147,7 @@ func (h *ScreenConfigHandler) DeleteTemplate(c *gin.Context) {
 
 // CreateInstance creates a new screen instance
 // @Summary Create screen instance
-// @Description Create a new screen configuration instance
+// @Description Create a new screen configuration instance. The slot data is validated against the JSON Schema of its template's pattern, if one is declared.
 // @Tags Screen Config
 // @Accept json
 // @Produce json
@@ -228,7 +235,7 @@ func (h *ScreenConfigHandler) GetInstanceByKey(c *gin.Context) {
 
 // UpdateInstance updates a screen instance
 // @Summary Update screen instance
-// @Description Update an existing screen instance
+// @Description Save changes to the instance's draft. Clients keep resolving the published revision until the draft is published.
 // @Tags Screen Config
 // @Accept json
 // @Produce json
@@ -257,17 +264,19 @@ func (h *ScreenConfigHandler) UpdateInstance(c *gin.Context) {
 
 // DeleteInstance deletes a screen instance
 // @Summary Delete screen instance
-// @Description Delete a screen instance by its ID
+// @Description Delete a screen instance by its ID. Refused with 409 while resources link to it unless cascade=true, which also removes the links.
 // @Tags Screen Config
 // @Security BearerAuth
 // @Param id path string true "Instance ID"
+// @Param cascade query bool false "Remove dependent resource links"
 // @Success 204
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /screen-config/instances/{id} [delete]
 func (h *ScreenConfigHandler) DeleteInstance(c *gin.Context) {
 	id := c.Param("id")
-	if err := h.screenService.DeleteInstance(c.Request.Context(), id); err != nil {
+	if err := h.screenService.DeleteInstance(c.Request.Context(), id, c.Query("cascade") == "true"); err != nil {
 		_ = c.Error(err)
 		return
 	}
@@ -301,18 +310,25 @@ func (h *ScreenConfigHandler) GetScreenVersion(c *gin.Context) {
 
 // ResolveScreenByKey resolves a screen configuration by key
 // @Summary Resolve screen by key
-// @Description Resolve and return the combined screen configuration for a given key
+// @Description Resolve and return the combined screen configuration for a given key, with the overrides of the caller's active school and role merged onto the slot data. With preview=draft pending drafts are overlaid; this requires permission to edit or publish screens.
 // @Tags Screen Config
 // @Produce json
 // @Security BearerAuth
 // @Param key path string true "Screen key"
+// @Param preview query string false "Set to draft to preview unpublished changes"
+// @Param Accept-Language header string false "Preferred locales"
 // @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /screen-config/resolve/key/{key} [get]
 func (h *ScreenConfigHandler) ResolveScreenByKey(c *gin.Context) {
+	opts, ok := resolveOptions(c)
+	if !ok {
+		return
+	}
 	key := c.Param("key")
-	combined, err := h.screenService.ResolveScreenByKey(c.Request.Context(), key)
+	combined, err := h.screenService.ResolveScreenByKey(c.Request.Context(), key, opts)
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -370,19 +386,790 @@ func (h *ScreenConfigHandler) GetScreensForResource(c *gin.Context) {
 
 // UnlinkScreen removes a screen-resource link
 // @Summary Unlink screen from resource
-// @Description Remove the link between a screen instance and a resource
+// @Description Remove the link between a screen instance and a resource. Removing the last screen of a visible menu item is refused with 409 unless cascade=true, which hides the item from the menu.
 // @Tags Screen Config
 // @Security BearerAuth
 // @Param id path string true "Resource-Screen link ID"
+// @Param cascade query bool false "Hide the menu item left without screens"
 // @Success 204
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /screen-config/resource-screens/{id} [delete]
 func (h *ScreenConfigHandler) UnlinkScreen(c *gin.Context) {
 	id := c.Param("id")
-	if err := h.screenService.UnlinkScreen(c.Request.Context(), id); err != nil {
+	if err := h.screenService.UnlinkScreen(c.Request.Context(), id, c.Query("cascade") == "true"); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Versions
+
+// parseVersion reads a positive version number, answering 400 otherwise
+func parseVersion(c *gin.Context, name, value string) (int, bool) {
+	version, err := strconv.Atoi(value)
+	if err != nil || version <= 0 {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: name + " must be a positive integer", Code: "INVALID_REQUEST"})
+		return 0, false
+	}
+	return version, true
+}
+
+// ListTemplateVersions lists the version history of a screen template
+// @Summary List screen template versions
+// @Description Get the version history of a template, newest first. Entries omit the definition.
+// @Tags Screen Config
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Param page query int false "Page number (1-based)"
+// @Param per_page query int false "Items per page"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/templates/{id}/versions [get]
+func (h *ScreenConfigHandler) ListTemplateVersions(c *gin.Context) {
+	var filter service.VersionFilter
+	_ = c.ShouldBindQuery(&filter)
+	versions, total, err := h.screenService.ListTemplateVersions(c.Request.Context(), c.Param("id"), filter)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": versions, "total": total})
+}
+
+// GetTemplateVersion gets one version of a screen template
+// @Summary Get screen template version
+// @Description Get a template exactly as it was at the given version
+// @Tags Screen Config
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Param version path int true "Version number"
+// @Success 200 {object} service.ScreenTemplateVersionDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/templates/{id}/versions/{version} [get]
+func (h *ScreenConfigHandler) GetTemplateVersion(c *gin.Context) {
+	version, ok := parseVersion(c, "version", c.Param("version"))
+	if !ok {
+		return
+	}
+	result, err := h.screenService.GetTemplateVersion(c.Request.Context(), c.Param("id"), version)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// DiffTemplateVersions compares two versions of a screen template
+// @Summary Diff screen template versions
+// @Description List the changes between two versions of a template. Paths are rooted at the template ($.name, $.definition.columns[0].label).
+// @Tags Screen Config
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Param from query int true "Base version"
+// @Param to query int true "Target version"
+// @Success 200 {object} service.ScreenVersionDiffDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/templates/{id}/diff [get]
+func (h *ScreenConfigHandler) DiffTemplateVersions(c *gin.Context) {
+	from, ok := parseVersion(c, "from", c.Query("from"))
+	if !ok {
+		return
+	}
+	to, ok := parseVersion(c, "to", c.Query("to"))
+	if !ok {
+		return
+	}
+	result, err := h.screenService.DiffTemplateVersions(c.Request.Context(), c.Param("id"), from, to)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// RollbackTemplate restores an earlier version of a screen template
+// @Summary Roll back screen template
+// @Description Requires screens:publish. Restore the definition, pattern, name and description of an earlier version. The result is published directly and saved as a new version.
+// @Tags Screen Config
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Param version path int true "Version to restore"
+// @Success 200 {object} service.ScreenTemplateDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/templates/{id}/versions/{version}/rollback [post]
+func (h *ScreenConfigHandler) RollbackTemplate(c *gin.Context) {
+	version, ok := parseVersion(c, "version", c.Param("version"))
+	if !ok {
+		return
+	}
+	result, err := h.screenService.RollbackTemplate(c.Request.Context(), c.Param("id"), version)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// ListInstanceVersions lists the slot data history of a screen instance
+// @Summary List screen instance versions
+// @Description Get the slot data history of an instance, newest first. Entries omit the slot data.
+// @Tags Screen Config
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Instance ID"
+// @Param page query int false "Page number (1-based)"
+// @Param per_page query int false "Items per page"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/instances/{id}/versions [get]
+func (h *ScreenConfigHandler) ListInstanceVersions(c *gin.Context) {
+	var filter service.VersionFilter
+	_ = c.ShouldBindQuery(&filter)
+	versions, total, err := h.screenService.ListInstanceVersions(c.Request.Context(), c.Param("id"), filter)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": versions, "total": total})
+}
+
+// GetInstanceVersion gets one version of a screen instance
+// @Summary Get screen instance version
+// @Description Get the slot data of an instance exactly as it was at the given version
+// @Tags Screen Config
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Instance ID"
+// @Param version path int true "Version number"
+// @Success 200 {object} service.ScreenInstanceVersionDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/instances/{id}/versions/{version} [get]
+func (h *ScreenConfigHandler) GetInstanceVersion(c *gin.Context) {
+	version, ok := parseVersion(c, "version", c.Param("version"))
+	if !ok {
+		return
+	}
+	result, err := h.screenService.GetInstanceVersion(c.Request.Context(), c.Param("id"), version)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// DiffInstanceVersions compares the slot data of two versions of a screen instance
+// @Summary Diff screen instance versions
+// @Description List the changes between the slot data of two versions. Paths are rooted at the slot data ($.columns[0].label).
+// @Tags Screen Config
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Instance ID"
+// @Param from query int true "Base version"
+// @Param to query int true "Target version"
+// @Success 200 {object} service.ScreenVersionDiffDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/instances/{id}/diff [get]
+func (h *ScreenConfigHandler) DiffInstanceVersions(c *gin.Context) {
+	from, ok := parseVersion(c, "from", c.Query("from"))
+	if !ok {
+		return
+	}
+	to, ok := parseVersion(c, "to", c.Query("to"))
+	if !ok {
+		return
+	}
+	result, err := h.screenService.DiffInstanceVersions(c.Request.Context(), c.Param("id"), from, to)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// RollbackInstance restores the slot data of an earlier version of a screen instance
+// @Summary Roll back screen instance
+// @Description Requires screens:publish. Restore the slot data of an earlier version. The result is published directly and saved as a new version.
+// @Tags Screen Config
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Instance ID"
+// @Param version path int true "Version to restore"
+// @Success 200 {object} service.ScreenInstanceDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/instances/{id}/versions/{version}/rollback [post]
+func (h *ScreenConfigHandler) RollbackInstance(c *gin.Context) {
+	version, ok := parseVersion(c, "version", c.Param("version"))
+	if !ok {
+		return
+	}
+	result, err := h.screenService.RollbackInstance(c.Request.Context(), c.Param("id"), version)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// resolveOptions resolves for the caller's active school, role and locale and
+// reads ?preview=draft. Only screen authors and publishers may see unpublished
+// drafts.
+func resolveOptions(c *gin.Context) (service.ResolveOptions, bool) {
+	opts := service.ResolveOptions{Locale: requestLocale(c)}
+	if claims, err := ginmiddleware.GetClaims(c); err == nil && claims != nil && claims.ActiveContext != nil {
+		opts.SchoolID, opts.RoleID = claims.ActiveContext.SchoolID, claims.ActiveContext.RoleID
+	}
+	switch c.Query("preview") {
+	case "":
+		return opts, true
+	case service.ScreenStatusDraft:
+	default:
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "preview must be 'draft'", Code: "INVALID_REQUEST"})
+		return opts, false
+	}
+	if !hasActivePermission(c, service.ScreenPreviewPermissions...) {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{Error: "forbidden", Message: "Previewing drafts requires permission to edit or publish screens", Code: "PREVIEW_FORBIDDEN"})
+		return opts, false
+	}
+	opts.Draft = true
+	return opts, true
+}
+
+// hasActivePermission reports whether the active context grants any of perms
+func hasActivePermission(c *gin.Context, perms ...string) bool {
+	claims, err := ginmiddleware.GetClaims(c)
+	if err != nil || claims == nil || claims.ActiveContext == nil {
+		return false
+	}
+	return slices.ContainsFunc(perms, func(p string) bool {
+		return slices.Contains(claims.ActiveContext.Permissions, p)
+	})
+}
+
+// requirePublish rejects callers without the screens:publish permission. Only
+// routes that publish conditionally need it; the publish routes are gated by
+// RequireAnyPermission.
+func requirePublish(c *gin.Context) bool {
+	if hasActivePermission(c, service.PermissionScreensPublish) {
+		return true
+	}
+	c.JSON(http.StatusForbidden, dto.ErrorResponse{Error: "forbidden", Message: "Missing permission " + service.PermissionScreensPublish, Code: "PUBLISH_FORBIDDEN"})
+	return false
+}
+
+// Drafts
+
+// GetTemplateDraft returns the pending draft of a screen template
+// @Summary Get screen template draft
+// @Tags Screen Config
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Success 200 {object} service.ScreenTemplateDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/templates/{id}/draft [get]
+func (h *ScreenConfigHandler) GetTemplateDraft(c *gin.Context) {
+	result, err := h.screenService.GetTemplateDraft(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// DiscardTemplateDraft drops the pending draft of a screen template
+// @Summary Discard screen template draft
+// @Tags Screen Config
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/templates/{id}/draft [delete]
+func (h *ScreenConfigHandler) DiscardTemplateDraft(c *gin.Context) {
+	if err := h.screenService.DiscardTemplateDraft(c.Request.Context(), c.Param("id")); err != nil {
 		_ = c.Error(err)
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
+
+// PublishTemplate publishes the draft of a screen template
+// @Summary Publish screen template
+// @Description Requires screens:publish. Promote the template's draft to the published revision. With a future publish_at the draft is scheduled and published by a background job.
+// @Tags Screen Config
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Param request body service.PublishRequest false "Optional schedule"
+// @Success 200 {object} service.ScreenTemplateDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/templates/{id}/publish [post]
+func (h *ScreenConfigHandler) PublishTemplate(c *gin.Context) {
+	req, ok := bindPublishRequest(c)
+	if !ok {
+		return
+	}
+	result, err := h.screenService.PublishTemplate(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GetInstanceDraft returns the pending draft of a screen instance
+// @Summary Get screen instance draft
+// @Tags Screen Config
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Instance ID"
+// @Success 200 {object} service.ScreenInstanceDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/instances/{id}/draft [get]
+func (h *ScreenConfigHandler) GetInstanceDraft(c *gin.Context) {
+	result, err := h.screenService.GetInstanceDraft(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// DiscardInstanceDraft drops the pending draft of a screen instance
+// @Summary Discard screen instance draft
+// @Tags Screen Config
+// @Security BearerAuth
+// @Param id path string true "Instance ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/instances/{id}/draft [delete]
+func (h *ScreenConfigHandler) DiscardInstanceDraft(c *gin.Context) {
+	if err := h.screenService.DiscardInstanceDraft(c.Request.Context(), c.Param("id")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// PublishInstance publishes the draft of a screen instance
+// @Summary Publish screen instance
+// @Description Requires screens:publish. Promote the instance's draft to the published revision. With a future publish_at the draft is scheduled and published by a background job.
+// @Tags Screen Config
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Instance ID"
+// @Param request body service.PublishRequest false "Optional schedule"
+// @Success 200 {object} service.ScreenInstanceDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/instances/{id}/publish [post]
+func (h *ScreenConfigHandler) PublishInstance(c *gin.Context) {
+	req, ok := bindPublishRequest(c)
+	if !ok {
+		return
+	}
+	result, err := h.screenService.PublishInstance(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// bindPublishRequest reads the optional publish body; an empty body publishes now
+func bindPublishRequest(c *gin.Context) (*service.PublishRequest, bool) {
+	var req service.PublishRequest
+	if c.Request.ContentLength == 0 {
+		return &req, true
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return nil, false
+	}
+	return &req, true
+}
+
+// Pattern schemas
+
+// ListPatternSchemas lists the JSON Schemas declared for template patterns
+// @Summary List pattern schemas
+// @Description Get the definition and slot data JSON Schemas declared per template pattern
+// @Tags Screen Config
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/patterns [get]
+func (h *ScreenConfigHandler) ListPatternSchemas(c *gin.Context) {
+	schemas, err := h.screenService.ListPatternSchemas(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": schemas, "total": len(schemas)})
+}
+
+// GetPatternSchema gets the JSON Schemas of a template pattern
+// @Summary Get pattern schema
+// @Description Get the definition and slot data JSON Schemas of a template pattern
+// @Tags Screen Config
+// @Produce json
+// @Security BearerAuth
+// @Param pattern path string true "Template pattern"
+// @Success 200 {object} service.PatternSchemaDTO
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/patterns/{pattern}/schema [get]
+func (h *ScreenConfigHandler) GetPatternSchema(c *gin.Context) {
+	schema, err := h.screenService.GetPatternSchema(c.Request.Context(), c.Param("pattern"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, schema)
+}
+
+// SavePatternSchema sets the JSON Schemas of a template pattern
+// @Summary Save pattern schema
+// @Description Set the JSON Schemas that template definitions and instance slot data of a pattern must match. Existing screens are validated on their next create or update.
+// @Tags Screen Config
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param pattern path string true "Template pattern"
+// @Param request body service.SavePatternSchemaRequest true "Pattern schemas"
+// @Success 200 {object} service.PatternSchemaDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/patterns/{pattern}/schema [put]
+func (h *ScreenConfigHandler) SavePatternSchema(c *gin.Context) {
+	var req service.SavePatternSchemaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+	schema, err := h.screenService.SavePatternSchema(c.Request.Context(), c.Param("pattern"), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, schema)
+}
+
+// DeletePatternSchema removes the JSON Schemas of a template pattern
+// @Summary Delete pattern schema
+// @Description Remove the JSON Schemas of a template pattern; its screens are no longer validated
+// @Tags Screen Config
+// @Security BearerAuth
+// @Param pattern path string true "Template pattern"
+// @Success 204
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/patterns/{pattern}/schema [delete]
+func (h *ScreenConfigHandler) DeletePatternSchema(c *gin.Context) {
+	if err := h.screenService.DeletePatternSchema(c.Request.Context(), c.Param("pattern")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Overrides
+
+// overrideSchoolScope returns the school whose screen overrides the caller
+// manages: "" (every school) with screen_instances:update, otherwise the
+// active school. The route already requires one of the override permissions;
+// a caller limited to screens:override is rejected without an active school.
+func overrideSchoolScope(c *gin.Context) (string, bool) {
+	if hasActivePermission(c, service.ScreenOverrideAdminPermissions...) {
+		return "", true
+	}
+	if claims, err := ginmiddleware.GetClaims(c); err == nil && claims.ActiveContext != nil && claims.ActiveContext.SchoolID != "" {
+		return claims.ActiveContext.SchoolID, true
+	}
+	c.JSON(http.StatusForbidden, dto.ErrorResponse{Error: "forbidden", Message: "Managing screen overrides requires an active school", Code: "OVERRIDE_FORBIDDEN"})
+	return "", false
+}
+
+// ListScreenOverrides lists the school and role overrides of an instance
+// @Summary List screen overrides
+// @Description List the per-school and per-role overrides of a screen instance. Callers limited to their school only see its overrides.
+// @Tags Screen Config
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Instance ID"
+// @Param school_id query string false "Only the overrides of this school"
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/instances/{id}/overrides [get]
+func (h *ScreenConfigHandler) ListScreenOverrides(c *gin.Context) {
+	scope, ok := overrideSchoolScope(c)
+	if !ok {
+		return
+	}
+	schoolID := c.Query("school_id")
+	if scope != "" {
+		schoolID = scope
+	}
+	overrides, err := h.screenService.ListScreenOverrides(c.Request.Context(), c.Param("id"), schoolID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": overrides, "total": len(overrides)})
+}
+
+// CreateScreenOverride adds a school or role override to an instance
+// @Summary Create screen override
+// @Description Add a JSON merge patch over the instance's slot data for a school, optionally only for one role. The school-wide override is applied before the role's.
+// @Tags Screen Config
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Instance ID"
+// @Param request body service.CreateScreenOverrideRequest true "Override data"
+// @Success 201 {object} service.ScreenOverrideDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/instances/{id}/overrides [post]
+func (h *ScreenConfigHandler) CreateScreenOverride(c *gin.Context) {
+	scope, ok := overrideSchoolScope(c)
+	if !ok {
+		return
+	}
+	var req service.CreateScreenOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+	if scope != "" && req.SchoolID != scope {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{Error: "forbidden", Message: "Overrides can only be created for your active school", Code: "OVERRIDE_FORBIDDEN"})
+		return
+	}
+	userID, _ := ginmiddleware.GetUserID(c)
+	override, err := h.screenService.CreateScreenOverride(c.Request.Context(), c.Param("id"), &req, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, override)
+}
+
+// UpdateScreenOverride replaces the patch of a screen override
+// @Summary Update screen override
+// @Description Replace the slot data patch of a school or role override
+// @Tags Screen Config
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Instance ID"
+// @Param overrideId path string true "Override ID"
+// @Param request body service.UpdateScreenOverrideRequest true "Override data"
+// @Success 200 {object} service.ScreenOverrideDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/instances/{id}/overrides/{overrideId} [put]
+func (h *ScreenConfigHandler) UpdateScreenOverride(c *gin.Context) {
+	scope, ok := overrideSchoolScope(c)
+	if !ok {
+		return
+	}
+	var req service.UpdateScreenOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+	override, err := h.screenService.UpdateScreenOverride(c.Request.Context(), c.Param("id"), c.Param("overrideId"), scope, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, override)
+}
+
+// DeleteScreenOverride removes a screen override
+// @Summary Delete screen override
+// @Description Remove a school or role override; the school falls back to the base slot data
+// @Tags Screen Config
+// @Security BearerAuth
+// @Param id path string true "Instance ID"
+// @Param overrideId path string true "Override ID"
+// @Success 204
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/instances/{id}/overrides/{overrideId} [delete]
+func (h *ScreenConfigHandler) DeleteScreenOverride(c *gin.Context) {
+	scope, ok := overrideSchoolScope(c)
+	if !ok {
+		return
+	}
+	if err := h.screenService.DeleteScreenOverride(c.Request.Context(), c.Param("id"), c.Param("overrideId"), scope); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Bundles
+
+// maxBundleSize limits the bundle body accepted by import (8 MiB)
+const maxBundleSize = 8 << 20
+
+// ExportBundle exports the screen configuration as a portable bundle
+// @Summary Export screen config bundle
+// @Description Export the published revision of the active templates, instances and resource-screen links. Entities reference each other by natural key (pattern, screen_key, resource_key) so the bundle can be imported into another environment.
+// @Tags Screen Config
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} service.ScreenConfigBundle
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/export [get]
+func (h *ScreenConfigHandler) ExportBundle(c *gin.Context) {
+	bundle, err := h.screenService.ExportBundle(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, bundle)
+}
+
+// ImportBundle reconciles the screen configuration with a bundle
+// @Summary Import screen config bundle
+// @Description Create or update templates, instances and resource-screen links from a bundle in a single transaction, matching them by natural key. Imported changes are published directly, so screens:publish is required unless dry_run=true. Returns the plan with the diff of each change; with dry_run=true or any conflict nothing is persisted.
+// @Tags Screen Config
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param dry_run query bool false "Only compute the plan"
+// @Param request body service.ScreenConfigBundle true "Screen config bundle"
+// @Success 200 {object} service.ScreenBundleImportResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} service.ScreenBundleImportResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/import [post]
+func (h *ScreenConfigHandler) ImportBundle(c *gin.Context) {
+	dryRun := c.Query("dry_run") == "true"
+	if !dryRun && !requirePublish(c) {
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxBundleSize+1))
+	if err != nil {
+		_ = c.Error(errors.NewValidationError("could not read bundle"))
+		return
+	}
+	if len(body) > maxBundleSize {
+		_ = c.Error(errors.NewValidationError("bundle exceeds 8 MiB"))
+		return
+	}
+	bundle, err := service.ParseScreenConfigBundle(body)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	result, err := h.screenService.ImportBundle(c.Request.Context(), bundle, dryRun)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if !dryRun && len(result.Conflicts) > 0 {
+		c.JSON(http.StatusConflict, result)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// Consistency
+
+// CheckConsistency reports broken references in the screen configuration
+// @Summary Screen config consistency report
+// @Description List active instances whose template is missing or inactive, active resource-screen links whose screen or resource is gone and instances requiring unknown permissions
+// @Tags Screen Config
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} service.ScreenConsistencyReport
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/consistency [get]
+func (h *ScreenConfigHandler) CheckConsistency(c *gin.Context) {
+	report, err := h.screenService.CheckConsistency(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// Preview
+
+// PreviewScreen resolves a candidate screen for a simulated user
+// @Summary Preview screen
+// @Description Resolve a template with candidate slot data for a simulated context (role, school, permissions, locale) and return the screen exactly as the sync bundle would deliver it, with validation warnings. Nothing is persisted.
+// @Tags Screen Config
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body service.PreviewScreenRequest true "Candidate screen and simulated context"
+// @Success 200 {object} service.ScreenPreviewResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /screen-config/preview [post]
+func (h *ScreenConfigHandler) PreviewScreen(c *gin.Context) {
+	var req service.PreviewScreenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+	preview, err := h.screenService.PreviewScreen(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, preview)
+}
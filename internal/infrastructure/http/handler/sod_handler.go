@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/service"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	ginhelper "github.com/EduGoGroup/edugo-shared/middleware/gin"
+)
+
+type SoDHandler struct {
+	sodService service.SoDService
+	logger     logger.Logger
+}
+
+func NewSoDHandler(sodService service.SoDService, logger logger.Logger) *SoDHandler {
+	return &SoDHandler{sodService: sodService, logger: logger}
+}
+
+// ListConstraints lists the active separation-of-duties constraints
+// @Summary List SoD constraints
+// @Tags Separation of Duties
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SoDConstraintsResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /sod/constraints [get]
+func (h *SoDHandler) ListConstraints(c *gin.Context) {
+	result, err := h.sodService.ListConstraints(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// CreateConstraint declares two roles or permissions as mutually exclusive
+// @Summary Create SoD constraint
+// @Description Declare a pair of roles (kind=role) or permissions (kind=permission) that no user may hold in the same context. Set school_id to restrict it to one school. Existing violations are not revoked; see the violations report.
+// @Tags Separation of Duties
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateSoDConstraintRequest true "Constraint"
+// @Success 201 {object} dto.SoDConstraintDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /sod/constraints [post]
+func (h *SoDHandler) CreateConstraint(c *gin.Context) {
+	var req dto.CreateSoDConstraintRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	createdBy, _ := ginhelper.GetUserID(c)
+	result, err := h.sodService.CreateConstraint(c.Request.Context(), &req, createdBy)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+}
+
+// DeleteConstraint deactivates a constraint
+// @Summary Delete SoD constraint
+// @Tags Separation of Duties
+// @Security BearerAuth
+// @Param id path string true "Constraint ID"
+// @Success 204
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /sod/constraints/{id} [delete]
+func (h *SoDHandler) DeleteConstraint(c *gin.Context) {
+	if err := h.sodService.DeleteConstraint(c.Request.Context(), c.Param("id")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Report lists users whose current assignments violate a constraint
+// @Summary SoD violations report
+// @Tags Separation of Duties
+// @Produce json
+// @Security BearerAuth
+// @Param school_id query string false "Only violations effective in this school"
+// @Success 200 {object} dto.SoDReportResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /sod/violations [get]
+func (h *SoDHandler) Report(c *gin.Context) {
+	result, err := h.sodService.Report(c.Request.Context(), c.Query("school_id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
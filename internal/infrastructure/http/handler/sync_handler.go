@@ -26,10 +26,11 @@ func NewSyncHandler(syncService service.SyncService, logger logger.Logger) *Sync
 
 // GetBundle returns the full sync bundle for the authenticated user
 // @Summary Get full sync bundle
-// @Description Returns the sync bundle. Use ?buckets=menu,permissions,available_contexts,screens to load specific buckets only.
+// @Description Returns the sync bundle. Use ?buckets=menu,permissions,available_contexts,screens to load specific buckets only. Menu and screens are localized and hashed per locale. The screens bucket is streamed as it is resolved, so large bundles are not held in memory.
 // @Tags Sync
 // @Produce json
 // @Security BearerAuth
+// @Param Accept-Language header string false "Preferred locales"
 // @Param buckets query string false "Comma-separated bucket names to load (menu,permissions,available_contexts,screens). Empty = all."
 // @Success 200 {object} dto.SyncBundleResponse
 // @Failure 401 {object} dto.ErrorResponse
@@ -53,17 +54,21 @@ func (h *SyncHandler) GetBundle(c *gin.Context) {
 		}
 	}
 
-	bundle, err := h.syncService.GetFullBundle(c.Request.Context(), userID, activeContext, buckets)
+	// the screens bucket is streamed, so the body is written as it is built
+	c.Header("Content-Type", "application/json; charset=utf-8")
+	c.Status(http.StatusOK)
+	err := h.syncService.WriteFullBundle(c.Request.Context(), c.Writer, userID, activeContext, buckets, requestLocale(c))
 	if err != nil {
 		h.logger.Error("error building sync bundle", "user_id", userID, "error", err)
+		if c.Writer.Written() {
+			// the response is already committed; the client sees a truncated body
+			return
+		}
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error: "internal_error",
 			Code:  "SYNC_BUNDLE_ERROR",
 		})
-		return
 	}
-
-	c.JSON(http.StatusOK, bundle)
 }
 
 // DeltaSync returns only changed buckets based on client hashes
@@ -73,6 +78,7 @@ func (h *SyncHandler) GetBundle(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param Accept-Language header string false "Preferred locales"
 // @Param request body dto.DeltaSyncRequest true "Client hashes for comparison"
 // @Success 200 {object} dto.DeltaSyncResponse
 // @Failure 400 {object} dto.ErrorResponse
@@ -95,7 +101,7 @@ func (h *SyncHandler) DeltaSync(c *gin.Context) {
 		return
 	}
 
-	delta, err := h.syncService.GetDeltaSync(c.Request.Context(), userID, activeContext, req.Hashes)
+	delta, err := h.syncService.GetDeltaSync(c.Request.Context(), userID, activeContext, req.Hashes, requestLocale(c))
 	if err != nil {
 		h.logger.Error("error computing delta sync", "user_id", userID, "error", err)
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/application/service"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	ginhelper "github.com/EduGoGroup/edugo-shared/middleware/gin"
+)
+
+type UserHandler struct {
+	userService service.UserService
+	logger      logger.Logger
+}
+
+func NewUserHandler(userService service.UserService, logger logger.Logger) *UserHandler {
+	return &UserHandler{userService: userService, logger: logger}
+}
+
+// ListUsers lists user accounts
+// @Summary List users
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number (1-based)" minimum(1)
+// @Param limit query int false "Items per page" minimum(1) maximum(200)
+// @Param search query string false "Search by name or email"
+// @Param is_active query bool false "Filter by active status"
+// @Success 200 {object} dto.UsersResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /users [get]
+func (h *UserHandler) ListUsers(c *gin.Context) {
+	filters, err := ginhelper.ParseListFilters(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	result, err := h.userService.ListUsers(c.Request.Context(), filters)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GetUser gets a user account
+// @Summary Get user
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Param user_id path string true "User ID"
+// @Success 200 {object} dto.UserDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /users/{user_id} [get]
+func (h *UserHandler) GetUser(c *gin.Context) {
+	result, err := h.userService.GetUser(c.Request.Context(), c.Param("user_id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// CreateUser creates a user account
+// @Summary Create user
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateUserRequest true "User data"
+// @Success 201 {object} dto.UserDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /users [post]
+func (h *UserHandler) CreateUser(c *gin.Context) {
+	var req dto.CreateUserRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	result, err := h.userService.CreateUser(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+}
+
+// UpdateUser updates a user's profile
+// @Summary Update user
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param user_id path string true "User ID"
+// @Param request body dto.UpdateUserRequest true "Profile changes"
+// @Success 200 {object} dto.UserDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /users/{user_id} [put]
+func (h *UserHandler) UpdateUser(c *gin.Context) {
+	var req dto.UpdateUserRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	result, err := h.userService.UpdateUser(c.Request.Context(), c.Param("user_id"), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// DeactivateUser deactivates a user account
+// @Summary Deactivate user
+// @Description Blocks login, revokes every active role assignment and invalidates the user's live sessions
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param user_id path string true "User ID"
+// @Param request body dto.UserLifecycleRequest false "Reason"
+// @Success 200 {object} dto.UserLifecycleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /users/{user_id}/deactivate [post]
+func (h *UserHandler) DeactivateUser(c *gin.Context) {
+	h.lifecycle(c, h.userService.DeactivateUser)
+}
+
+// ReactivateUser reactivates a user account
+// @Summary Reactivate user
+// @Description Allows the user to log in again. Revoked role assignments are not restored.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param user_id path string true "User ID"
+// @Param request body dto.UserLifecycleRequest false "Reason"
+// @Success 200 {object} dto.UserLifecycleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /users/{user_id}/reactivate [post]
+func (h *UserHandler) ReactivateUser(c *gin.Context) {
+	h.lifecycle(c, h.userService.ReactivateUser)
+}
+
+// AnonymizeUser erases a user's personal data
+// @Summary Anonymize user (GDPR erasure)
+// @Description Irreversibly scrubs the user's personal data, deactivates the account and revokes its access. The user ID is kept so audit history stays referentially intact.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param user_id path string true "User ID"
+// @Param request body dto.UserLifecycleRequest false "Reason"
+// @Success 200 {object} dto.UserLifecycleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /users/{user_id}/anonymize [post]
+func (h *UserHandler) AnonymizeUser(c *gin.Context) {
+	h.lifecycle(c, h.userService.AnonymizeUser)
+}
+
+type userLifecycleFunc func(ctx context.Context, id, actorID string, req *dto.UserLifecycleRequest) (*dto.UserLifecycleResponse, error)
+
+func (h *UserHandler) lifecycle(c *gin.Context, fn userLifecycleFunc) {
+	var req dto.UserLifecycleRequest
+	if c.Request.ContentLength > 0 {
+		if err := bindJSON(c, &req); err != nil {
+			_ = c.Error(err)
+			return
+		}
+	}
+	actorID, _ := ginhelper.GetUserID(c)
+	result, err := fn(c.Request.Context(), c.Param("user_id"), actorID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,45 @@
+// Package jobs runs periodic background tasks (expiry sweeps, scheduled
+// revocations) inside the API process.
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// Job is a task run every Interval. Run must be idempotent: with several API
+// replicas every instance executes it.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Start launches one goroutine per job. Jobs stop when ctx is cancelled; an
+// error only ends the current run and is logged.
+func Start(ctx context.Context, jobs []Job, log logger.Logger) {
+	for _, job := range jobs {
+		if job.Interval <= 0 {
+			log.Warn("background job disabled", "job", job.Name)
+			continue
+		}
+		go run(ctx, job, log)
+	}
+}
+
+func run(ctx context.Context, job Job, log logger.Logger) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := job.Run(ctx); err != nil {
+				log.Error("background job failed", "job", job.Name, "error", err)
+			}
+		}
+	}
+}
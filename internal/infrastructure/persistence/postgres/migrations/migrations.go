@@ -0,0 +1,240 @@
+// Package migrations holds the versioned SQL migrations for the tables owned by
+// this service. Migrations are embedded in the binary and applied with the
+// "migrate" subcommand; each one runs in its own transaction.
+//
+// The first migration creates the shared IAM and UI config tables (iam.roles,
+// iam.user_roles, ui_config.screen_templates, ...) when they are missing, so a
+// fresh local database can be bootstrapped without the edugo-infrastructure
+// migrations. On a shared database those tables already exist and are left
+// untouched.
+package migrations
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed sql/*.sql
+var files embed.FS
+
+// trackingTable records applied migrations. It is specific to this service so it
+// does not collide with migrations run by other EduGo services on the same database.
+const trackingTable = "public.iam_platform_schema_migrations"
+
+// advisoryLockKey serializes concurrent migration runs (e.g. several replicas starting at once)
+const advisoryLockKey = 7_070_001
+
+var fileNameRegex = regexp.MustCompile(`^(\d{4})_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration is a versioned pair of up/down SQL scripts
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status reports whether a migration has been applied
+type Status struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+// Load returns the embedded migrations ordered by version
+func Load() ([]Migration, error) {
+	return load(files)
+}
+
+func load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, "sql")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int]*Migration)
+	for _, e := range entries {
+		match := fileNameRegex.FindStringSubmatch(e.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", e.Name())
+		}
+		version, _ := strconv.Atoi(match[1])
+		body, err := fs.ReadFile(fsys, "sql/"+e.Name())
+		if err != nil {
+			return nil, err
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %04d has conflicting names %q and %q", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %04d_%s has no up script", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			return nil, fmt.Errorf("migration versions must be contiguous: expected %04d, found %04d", i+1, m.Version)
+		}
+	}
+	return migrations, nil
+}
+
+// Migrator applies and rolls back the embedded migrations
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator creates a migrator for the embedded migrations
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Up applies every pending migration in order and returns the ones applied
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	if err := m.ensureTrackingTable(ctx); err != nil {
+		return nil, err
+	}
+	var applied []Migration
+	for _, mig := range m.migrations {
+		done, err := m.run(ctx, mig, true)
+		if err != nil {
+			return applied, fmt.Errorf("migration %04d_%s: %w", mig.Version, mig.Name, err)
+		}
+		if done {
+			applied = append(applied, mig)
+		}
+	}
+	return applied, nil
+}
+
+// Down rolls back the last steps applied migrations and returns the ones reverted
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if err := m.ensureTrackingTable(ctx); err != nil {
+		return nil, err
+	}
+	var reverted []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+		mig := m.migrations[i]
+		done, err := m.run(ctx, mig, false)
+		if err != nil {
+			return reverted, fmt.Errorf("rollback %04d_%s: %w", mig.Version, mig.Name, err)
+		}
+		if done {
+			reverted = append(reverted, mig)
+		}
+	}
+	return reverted, nil
+}
+
+// Status lists every embedded migration with its applied timestamp, if any
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	if err := m.ensureTrackingTable(ctx); err != nil {
+		return nil, err
+	}
+	type row struct {
+		Version   int
+		AppliedAt time.Time
+	}
+	var rows []row
+	if err := m.db.WithContext(ctx).Raw("SELECT version, applied_at FROM " + trackingTable).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	appliedAt := make(map[int]time.Time, len(rows))
+	for _, r := range rows {
+		appliedAt[r.Version] = r.AppliedAt
+	}
+	statuses := make([]Status, len(m.migrations))
+	for i, mig := range m.migrations {
+		statuses[i] = Status{Version: mig.Version, Name: mig.Name}
+		if t, ok := appliedAt[mig.Version]; ok {
+			statuses[i].AppliedAt = &t
+		}
+	}
+	return statuses, nil
+}
+
+func (m *Migrator) ensureTrackingTable(ctx context.Context) error {
+	return m.db.WithContext(ctx).Exec(`CREATE TABLE IF NOT EXISTS ` + trackingTable + ` (
+		version    INTEGER PRIMARY KEY,
+		name       VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`).Error
+}
+
+// run applies (up) or reverts (down) a single migration in a transaction. The
+// applied state is re-checked under an advisory lock so concurrent runs are safe.
+// It reports whether the migration was actually executed.
+func (m *Migrator) run(ctx context.Context, mig Migration, up bool) (bool, error) {
+	executed := false
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", advisoryLockKey).Error; err != nil {
+			return err
+		}
+		var count int64
+		if err := tx.Raw("SELECT COUNT(*) FROM "+trackingTable+" WHERE version = ?", mig.Version).Scan(&count).Error; err != nil {
+			return err
+		}
+		if (count > 0) == up {
+			return nil
+		}
+
+		script := mig.Down
+		if up {
+			script = mig.Up
+		}
+		if hasStatements(script) {
+			if err := tx.Exec(script).Error; err != nil {
+				return err
+			}
+		}
+
+		if up {
+			if err := tx.Exec("INSERT INTO "+trackingTable+" (version, name) VALUES (?, ?)", mig.Version, mig.Name).Error; err != nil {
+				return err
+			}
+		} else if err := tx.Exec("DELETE FROM "+trackingTable+" WHERE version = ?", mig.Version).Error; err != nil {
+			return err
+		}
+		executed = true
+		return nil
+	})
+	return executed, err
+}
+
+// hasStatements reports whether a script contains anything besides comments and whitespace
+func hasStatements(script string) bool {
+	for _, line := range strings.Split(script, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "--") {
+			return true
+		}
+	}
+	return false
+}
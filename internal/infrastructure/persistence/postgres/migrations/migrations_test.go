@@ -0,0 +1,82 @@
+package migrations
+
+import (
+	"regexp"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoad_EmbeddedMigrations(t *testing.T) {
+	migrations, err := Load()
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("esperaba al menos una migración embebida")
+	}
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			t.Errorf("versión fuera de orden: esperaba %d, obtuvo %d", i+1, m.Version)
+		}
+		if m.Down == "" {
+			t.Errorf("la migración %04d_%s no tiene script down", m.Version, m.Name)
+		}
+	}
+}
+
+func TestLoad_Validation(t *testing.T) {
+	t.Run("rechaza nombres inválidos", func(t *testing.T) {
+		fsys := fstest.MapFS{"sql/create_things.sql": {Data: []byte("SELECT 1;")}}
+		if _, err := load(fsys); err == nil {
+			t.Fatal("esperaba error por nombre inválido")
+		}
+	})
+
+	t.Run("rechaza versiones no contiguas", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"sql/0001_a.up.sql": {Data: []byte("SELECT 1;")},
+			"sql/0003_c.up.sql": {Data: []byte("SELECT 1;")},
+		}
+		if _, err := load(fsys); err == nil {
+			t.Fatal("esperaba error por versiones no contiguas")
+		}
+	})
+
+	t.Run("rechaza migraciones sin up", func(t *testing.T) {
+		fsys := fstest.MapFS{"sql/0001_a.down.sql": {Data: []byte("SELECT 1;")}}
+		if _, err := load(fsys); err == nil {
+			t.Fatal("esperaba error por falta de script up")
+		}
+	})
+}
+
+func TestHasStatements(t *testing.T) {
+	if hasStatements("-- solo comentario\n\n") {
+		t.Error("un script con solo comentarios no tiene sentencias")
+	}
+	if !hasStatements("-- crea tabla\nCREATE TABLE x (id int);") {
+		t.Error("esperaba detectar sentencias")
+	}
+}
+
+// TestLoad_ReferencesCreatedEarlier guards the fresh-database bootstrap: every
+// table a foreign key points at is created by the same or an earlier migration
+func TestLoad_ReferencesCreatedEarlier(t *testing.T) {
+	migrations, err := Load()
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	createRegex := regexp.MustCompile(`(?i)CREATE TABLE (?:IF NOT EXISTS )?([a-z_]+\.[a-z_]+)`)
+	referenceRegex := regexp.MustCompile(`(?i)REFERENCES ([a-z_]+\.[a-z_]+)`)
+	created := map[string]bool{}
+	for _, m := range migrations {
+		for _, match := range createRegex.FindAllStringSubmatch(m.Up, -1) {
+			created[match[1]] = true
+		}
+		for _, match := range referenceRegex.FindAllStringSubmatch(m.Up, -1) {
+			if !created[match[1]] {
+				t.Errorf("la migración %04d_%s referencia %s antes de crearla", m.Version, m.Name, match[1])
+			}
+		}
+	}
+}
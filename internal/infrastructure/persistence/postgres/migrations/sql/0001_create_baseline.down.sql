@@ -0,0 +1,2 @@
+-- Schemas and the baseline tables are shared with other EduGo services and are
+-- never dropped here.
@@ -0,0 +1,118 @@
+-- Schemas and shared IAM / UI config tables used by the IAM platform. On a
+-- database shared with other EduGo services they already exist (created by
+-- the edugo-infrastructure migrations), so every statement is idempotent and
+-- only fills in what is missing on a fresh local database.
+CREATE SCHEMA IF NOT EXISTS auth;
+CREATE SCHEMA IF NOT EXISTS iam;
+CREATE SCHEMA IF NOT EXISTS academic;
+CREATE SCHEMA IF NOT EXISTS ui_config;
+CREATE SCHEMA IF NOT EXISTS audit;
+
+CREATE TABLE IF NOT EXISTS iam.resources (
+    id              UUID         PRIMARY KEY,
+    key             VARCHAR(100) NOT NULL UNIQUE,
+    display_name    VARCHAR(150) NOT NULL,
+    description     TEXT,
+    icon            VARCHAR(100),
+    parent_id       UUID         REFERENCES iam.resources (id),
+    sort_order      INTEGER      NOT NULL DEFAULT 0,
+    is_menu_visible BOOLEAN      NOT NULL DEFAULT true,
+    scope           VARCHAR(20),
+    is_active       BOOLEAN      NOT NULL DEFAULT true,
+    created_at      TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS iam.permissions (
+    id           UUID         PRIMARY KEY,
+    name         VARCHAR(150) NOT NULL UNIQUE,
+    display_name VARCHAR(150) NOT NULL,
+    description  TEXT,
+    resource_id  UUID         REFERENCES iam.resources (id),
+    action       VARCHAR(50),
+    scope        VARCHAR(20),
+    is_active    BOOLEAN      NOT NULL DEFAULT true,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    updated_at   TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS iam.roles (
+    id           UUID         PRIMARY KEY,
+    name         VARCHAR(100) NOT NULL UNIQUE,
+    display_name VARCHAR(150) NOT NULL,
+    description  TEXT,
+    scope        VARCHAR(20),
+    is_active    BOOLEAN      NOT NULL DEFAULT true,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    updated_at   TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS iam.role_permissions (
+    id            UUID        PRIMARY KEY,
+    role_id       UUID        NOT NULL REFERENCES iam.roles (id),
+    permission_id UUID        NOT NULL REFERENCES iam.permissions (id),
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (role_id, permission_id)
+);
+
+CREATE TABLE IF NOT EXISTS iam.user_roles (
+    id               UUID        PRIMARY KEY,
+    user_id          UUID        NOT NULL,
+    role_id          UUID        NOT NULL REFERENCES iam.roles (id),
+    school_id        UUID,
+    academic_unit_id UUID,
+    is_active        BOOLEAN     NOT NULL DEFAULT true,
+    granted_by       UUID,
+    granted_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at       TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_roles_user
+    ON iam.user_roles (user_id)
+    WHERE is_active = true;
+
+CREATE TABLE IF NOT EXISTS ui_config.screen_templates (
+    id          UUID         PRIMARY KEY,
+    pattern     VARCHAR(50)  NOT NULL,
+    name        VARCHAR(150) NOT NULL,
+    description TEXT,
+    version     INTEGER      NOT NULL DEFAULT 1,
+    definition  JSONB        NOT NULL DEFAULT '{}',
+    is_active   BOOLEAN      NOT NULL DEFAULT true,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS ui_config.screen_instances (
+    id                  UUID         PRIMARY KEY,
+    screen_key          VARCHAR(100) NOT NULL,
+    template_id         UUID         NOT NULL REFERENCES ui_config.screen_templates (id),
+    name                VARCHAR(150) NOT NULL,
+    description         TEXT,
+    slot_data           JSONB        NOT NULL DEFAULT '{}',
+    scope               VARCHAR(20),
+    required_permission VARCHAR(150),
+    handler_key         VARCHAR(100),
+    is_active           BOOLEAN      NOT NULL DEFAULT true,
+    created_at          TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    updated_at          TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_screen_instances_screen_key
+    ON ui_config.screen_instances (screen_key)
+    WHERE is_active = true;
+
+CREATE TABLE IF NOT EXISTS ui_config.resource_screens (
+    id           UUID         PRIMARY KEY,
+    resource_id  UUID         NOT NULL REFERENCES iam.resources (id),
+    resource_key VARCHAR(100) NOT NULL,
+    screen_key   VARCHAR(100) NOT NULL,
+    screen_type  VARCHAR(50),
+    is_default   BOOLEAN      NOT NULL DEFAULT false,
+    sort_order   INTEGER      NOT NULL DEFAULT 0,
+    is_active    BOOLEAN      NOT NULL DEFAULT true,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    updated_at   TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
@@ -0,0 +1,8 @@
+-- Only drop the table when the up script created it
+DO $$
+BEGIN
+    IF obj_description(to_regclass('auth.login_attempts'), 'pg_class') = 'owner: edugo-api-iam-platform' THEN
+        DROP TABLE auth.login_attempts;
+    END IF;
+END
+$$;
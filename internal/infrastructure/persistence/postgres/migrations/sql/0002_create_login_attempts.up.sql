@@ -0,0 +1,23 @@
+-- The table is created and marked as owned by this migration only when it is
+-- missing, so the down script never drops a table another service created.
+DO $$
+BEGIN
+    IF to_regclass('auth.login_attempts') IS NULL THEN
+        CREATE TABLE auth.login_attempts (
+            id           SERIAL PRIMARY KEY,
+            identifier   VARCHAR(255) NOT NULL,
+            attempt_type VARCHAR(50)  NOT NULL,
+            successful   BOOLEAN      NOT NULL DEFAULT false,
+            user_agent   TEXT,
+            ip_address   VARCHAR(45),
+            attempted_at TIMESTAMPTZ  NOT NULL DEFAULT now()
+        );
+
+        CREATE INDEX idx_login_attempts_identifier_attempted_at
+            ON auth.login_attempts (identifier, attempted_at DESC)
+            WHERE successful = false;
+
+        COMMENT ON TABLE auth.login_attempts IS 'owner: edugo-api-iam-platform';
+    END IF;
+END
+$$;
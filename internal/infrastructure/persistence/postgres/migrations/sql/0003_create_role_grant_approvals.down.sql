@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS iam.role_grant_requests;
+DROP TABLE IF EXISTS iam.role_grant_policies;
@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS iam.role_grant_policies (
+    role_id           UUID        PRIMARY KEY REFERENCES iam.roles (id),
+    requires_approval BOOLEAN     NOT NULL DEFAULT false,
+    updated_by        UUID,
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS iam.role_grant_requests (
+    id               UUID        PRIMARY KEY,
+    user_id          UUID        NOT NULL,
+    role_id          UUID        NOT NULL REFERENCES iam.roles (id),
+    school_id        UUID,
+    academic_unit_id UUID,
+    grant_expires_at TIMESTAMPTZ,
+    requested_by     UUID,
+    status           VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'approved', 'rejected', 'expired')),
+    decided_by       UUID,
+    decided_at       TIMESTAMPTZ,
+    decision_note    TEXT,
+    user_role_id     UUID,
+    expires_at       TIMESTAMPTZ NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_role_grant_requests_pending
+    ON iam.role_grant_requests (expires_at)
+    WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS idx_role_grant_requests_user_role
+    ON iam.role_grant_requests (user_id, role_id);
+
+-- At most one pending request per grant. NULL scopes are folded so global and
+-- school-level grants are covered too.
+CREATE UNIQUE INDEX IF NOT EXISTS uq_role_grant_requests_pending
+    ON iam.role_grant_requests (
+        user_id, role_id,
+        COALESCE(school_id, '00000000-0000-0000-0000-000000000000'),
+        COALESCE(academic_unit_id, '00000000-0000-0000-0000-000000000000')
+    )
+    WHERE status = 'pending';
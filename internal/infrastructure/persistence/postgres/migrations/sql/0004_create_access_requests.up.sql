@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS iam.access_requests (
+    id                    UUID        PRIMARY KEY,
+    user_id               UUID        NOT NULL,
+    role_id               UUID        NOT NULL REFERENCES iam.roles (id),
+    school_id             UUID        NOT NULL,
+    academic_unit_id      UUID,
+    justification         TEXT        NOT NULL,
+    status                VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'approved', 'rejected', 'cancelled')),
+    decided_by            UUID,
+    decided_at            TIMESTAMPTZ,
+    decision_note         TEXT,
+    grant_expires_at      TIMESTAMPTZ,
+    user_role_id          UUID,
+    role_grant_request_id UUID REFERENCES iam.role_grant_requests (id),
+    created_at            TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at            TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_access_requests_school_status
+    ON iam.access_requests (school_id, status, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_access_requests_user
+    ON iam.access_requests (user_id, created_at DESC);
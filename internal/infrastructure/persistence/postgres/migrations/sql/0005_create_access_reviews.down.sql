@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS iam.access_review_items;
+DROP TABLE IF EXISTS iam.access_review_campaigns;
@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS iam.access_review_campaigns (
+    id               UUID         PRIMARY KEY,
+    name             VARCHAR(200) NOT NULL,
+    description      TEXT,
+    school_id        UUID,
+    role_id          UUID REFERENCES iam.roles (id),
+    academic_unit_id UUID,
+    deadline         TIMESTAMPTZ  NOT NULL,
+    status           VARCHAR(20)  NOT NULL DEFAULT 'active'
+        CHECK (status IN ('active', 'completed')),
+    created_by       UUID,
+    completed_at     TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    updated_at       TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_access_review_campaigns_due
+    ON iam.access_review_campaigns (deadline)
+    WHERE status = 'active';
+
+CREATE TABLE IF NOT EXISTS iam.access_review_items (
+    id               UUID        PRIMARY KEY,
+    campaign_id      UUID        NOT NULL REFERENCES iam.access_review_campaigns (id) ON DELETE CASCADE,
+    user_role_id     UUID        NOT NULL,
+    user_id          UUID        NOT NULL,
+    role_id          UUID        NOT NULL,
+    school_id        UUID,
+    academic_unit_id UUID,
+    reviewer_id      UUID,
+    decision         VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (decision IN ('pending', 'certified', 'revoked', 'auto_revoked')),
+    decided_by       UUID,
+    decided_at       TIMESTAMPTZ,
+    note             TEXT,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (campaign_id, user_role_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_access_review_items_reviewer
+    ON iam.access_review_items (reviewer_id, decision);
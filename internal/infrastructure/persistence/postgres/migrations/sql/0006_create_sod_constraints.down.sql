@@ -0,0 +1 @@
+DROP TABLE IF EXISTS iam.sod_constraints;
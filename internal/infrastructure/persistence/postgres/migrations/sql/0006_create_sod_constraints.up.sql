@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS iam.sod_constraints (
+    id          UUID         PRIMARY KEY,
+    name        VARCHAR(150) NOT NULL,
+    description TEXT,
+    kind        VARCHAR(20)  NOT NULL CHECK (kind IN ('role', 'permission')),
+    left_id     UUID         NOT NULL,
+    right_id    UUID         NOT NULL,
+    school_id   UUID,
+    is_active   BOOLEAN      NOT NULL DEFAULT true,
+    created_by  UUID,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    CHECK (left_id <> right_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sod_constraints_active
+    ON iam.sod_constraints (kind, left_id, right_id)
+    WHERE is_active;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS auth.impersonation_sessions (
+    id                 UUID         PRIMARY KEY,
+    impersonator_id    UUID         NOT NULL,
+    target_user_id     UUID         NOT NULL,
+    school_id          UUID,
+    reason             TEXT         NOT NULL,
+    token_jti          VARCHAR(255) NOT NULL UNIQUE,
+    started_at         TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    expires_at         TIMESTAMPTZ  NOT NULL,
+    ended_at           TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_impersonation_sessions_target
+    ON auth.impersonation_sessions (target_user_id, started_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_impersonation_sessions_active
+    ON auth.impersonation_sessions (expires_at)
+    WHERE ended_at IS NULL;
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS iam.role_delegations (
+    id                     UUID        PRIMARY KEY,
+    delegator_id           UUID        NOT NULL,
+    delegate_id            UUID        NOT NULL,
+    source_user_role_id    UUID        NOT NULL REFERENCES iam.user_roles (id),
+    role_id                UUID        NOT NULL REFERENCES iam.roles (id),
+    school_id              UUID        NOT NULL,
+    academic_unit_id       UUID        NOT NULL,
+    starts_at              TIMESTAMPTZ NOT NULL,
+    ends_at                TIMESTAMPTZ NOT NULL,
+    reason                 TEXT,
+    status                 VARCHAR(20) NOT NULL DEFAULT 'scheduled'
+        CHECK (status IN ('scheduled', 'active', 'expired', 'revoked')),
+    delegated_user_role_id UUID REFERENCES iam.user_roles (id),
+    revoked_by             UUID,
+    ended_at               TIMESTAMPTZ,
+    created_at             TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at             TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (delegator_id <> delegate_id),
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_role_delegations_delegator
+    ON iam.role_delegations (delegator_id, starts_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_role_delegations_delegate
+    ON iam.role_delegations (delegate_id, starts_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_role_delegations_open
+    ON iam.role_delegations (starts_at, ends_at)
+    WHERE status IN ('scheduled', 'active');
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS iam.break_glass_elevations;
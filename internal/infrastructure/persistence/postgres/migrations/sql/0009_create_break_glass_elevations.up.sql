@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS iam.break_glass_elevations (
+    id            UUID        PRIMARY KEY,
+    user_id       UUID        NOT NULL,
+    role_id       UUID        NOT NULL REFERENCES iam.roles (id),
+    user_role_id  UUID        NOT NULL REFERENCES iam.user_roles (id),
+    school_id     UUID,
+    justification TEXT        NOT NULL,
+    client_ip     VARCHAR(64),
+    activated_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at    TIMESTAMPTZ NOT NULL,
+    ended_at      TIMESTAMPTZ,
+    ended_by      UUID
+);
+
+CREATE INDEX IF NOT EXISTS idx_break_glass_elevations_user
+    ON iam.break_glass_elevations (user_id, activated_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_break_glass_elevations_open
+    ON iam.break_glass_elevations (expires_at)
+    WHERE ended_at IS NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS iam.role_import_rows;
+DROP TABLE IF EXISTS iam.role_import_jobs;
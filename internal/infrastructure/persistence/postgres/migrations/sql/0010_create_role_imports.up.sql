@@ -0,0 +1,49 @@
+CREATE TABLE IF NOT EXISTS iam.role_import_jobs (
+    id             UUID         PRIMARY KEY,
+    requested_by   UUID         NOT NULL,
+    file_name      VARCHAR(255) NOT NULL,
+    format         VARCHAR(10)  NOT NULL,
+    dry_run        BOOLEAN      NOT NULL DEFAULT false,
+    status         VARCHAR(20)  NOT NULL DEFAULT 'pending',
+    total_rows     INTEGER      NOT NULL DEFAULT 0,
+    processed_rows INTEGER      NOT NULL DEFAULT 0,
+    valid_rows     INTEGER      NOT NULL DEFAULT 0,
+    invalid_rows   INTEGER      NOT NULL DEFAULT 0,
+    skipped_rows   INTEGER      NOT NULL DEFAULT 0,
+    applied_rows   INTEGER      NOT NULL DEFAULT 0,
+    error          TEXT,
+    started_at     TIMESTAMPTZ,
+    finished_at    TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    updated_at     TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_role_import_jobs_requested_by
+    ON iam.role_import_jobs (requested_by, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_role_import_jobs_pending
+    ON iam.role_import_jobs (created_at)
+    WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS iam.role_import_rows (
+    id               UUID         PRIMARY KEY,
+    job_id           UUID         NOT NULL REFERENCES iam.role_import_jobs (id) ON DELETE CASCADE,
+    line_number      INTEGER      NOT NULL,
+    email            VARCHAR(255) NOT NULL,
+    role_name        VARCHAR(100) NOT NULL,
+    school           VARCHAR(255) NOT NULL,
+    unit             VARCHAR(255) NOT NULL,
+    expires_at_raw   VARCHAR(64)  NOT NULL,
+    status           VARCHAR(20)  NOT NULL DEFAULT 'pending',
+    message          TEXT,
+    user_id          UUID,
+    role_id          UUID,
+    school_id        UUID,
+    academic_unit_id UUID,
+    expires_at       TIMESTAMPTZ,
+    user_role_id     UUID,
+    UNIQUE (job_id, line_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_role_import_rows_job_status
+    ON iam.role_import_rows (job_id, status);
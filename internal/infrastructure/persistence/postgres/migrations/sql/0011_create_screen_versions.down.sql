@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS ui_config.screen_instance_versions;
+DROP TABLE IF EXISTS ui_config.screen_template_versions;
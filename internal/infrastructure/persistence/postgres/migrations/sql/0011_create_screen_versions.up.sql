@@ -0,0 +1,37 @@
+-- Append-only history of screen templates and instances. Every change that
+-- touches the definition or the slot data stores the resulting state; the
+-- current state of existing rows is recorded as their first entry.
+CREATE TABLE IF NOT EXISTS ui_config.screen_template_versions (
+    id            UUID         PRIMARY KEY,
+    template_id   UUID         NOT NULL REFERENCES ui_config.screen_templates (id),
+    version       INTEGER      NOT NULL,
+    pattern       VARCHAR(100) NOT NULL,
+    name          VARCHAR(255) NOT NULL,
+    description   TEXT,
+    definition    JSONB        NOT NULL,
+    source        VARCHAR(20)  NOT NULL,
+    restored_from INTEGER,
+    created_at    TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    UNIQUE (template_id, version)
+);
+
+CREATE TABLE IF NOT EXISTS ui_config.screen_instance_versions (
+    id            UUID         PRIMARY KEY,
+    instance_id   UUID         NOT NULL REFERENCES ui_config.screen_instances (id),
+    version       INTEGER      NOT NULL,
+    slot_data     JSONB        NOT NULL,
+    source        VARCHAR(20)  NOT NULL,
+    restored_from INTEGER,
+    created_at    TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    UNIQUE (instance_id, version)
+);
+
+INSERT INTO ui_config.screen_template_versions (id, template_id, version, pattern, name, description, definition, source, created_at)
+SELECT gen_random_uuid(), t.id, t.version, t.pattern, t.name, t.description, t.definition, 'baseline', t.updated_at
+FROM ui_config.screen_templates t
+ON CONFLICT (template_id, version) DO NOTHING;
+
+INSERT INTO ui_config.screen_instance_versions (id, instance_id, version, slot_data, source, created_at)
+SELECT gen_random_uuid(), i.id, 1, COALESCE(i.slot_data, '{}'::jsonb), 'baseline', i.updated_at
+FROM ui_config.screen_instances i
+ON CONFLICT (instance_id, version) DO NOTHING;
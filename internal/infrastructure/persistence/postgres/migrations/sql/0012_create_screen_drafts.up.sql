@@ -0,0 +1,36 @@
+-- Unpublished edits of screen templates and instances. The rows in
+-- screen_templates/screen_instances stay the published revision; at most one
+-- draft exists per template or instance until it is published or discarded.
+CREATE TABLE IF NOT EXISTS ui_config.screen_template_drafts (
+    template_id UUID         PRIMARY KEY REFERENCES ui_config.screen_templates (id),
+    pattern     VARCHAR(100) NOT NULL,
+    name        VARCHAR(255) NOT NULL,
+    description TEXT,
+    definition  JSONB        NOT NULL,
+    publish_at  TIMESTAMPTZ,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_screen_template_drafts_publish_at
+    ON ui_config.screen_template_drafts (publish_at)
+    WHERE publish_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS ui_config.screen_instance_drafts (
+    instance_id         UUID         PRIMARY KEY REFERENCES ui_config.screen_instances (id),
+    screen_key          VARCHAR(100) NOT NULL,
+    template_id         UUID         NOT NULL,
+    name                VARCHAR(255) NOT NULL,
+    description         TEXT,
+    slot_data           JSONB        NOT NULL,
+    scope               VARCHAR(50)  NOT NULL,
+    required_permission VARCHAR(100),
+    handler_key         VARCHAR(100),
+    publish_at          TIMESTAMPTZ,
+    created_at          TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    updated_at          TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_screen_instance_drafts_publish_at
+    ON ui_config.screen_instance_drafts (publish_at)
+    WHERE publish_at IS NOT NULL;
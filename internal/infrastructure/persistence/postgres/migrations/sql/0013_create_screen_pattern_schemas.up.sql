@@ -0,0 +1,10 @@
+-- JSON Schemas declared per template pattern: definition_schema validates
+-- the definition of templates with that pattern and slot_data_schema the
+-- slot data of their instances. A NULL schema disables that validation.
+CREATE TABLE IF NOT EXISTS ui_config.screen_pattern_schemas (
+    pattern           VARCHAR(100) PRIMARY KEY,
+    definition_schema JSONB,
+    slot_data_schema  JSONB,
+    created_at        TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    updated_at        TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS ui_config.screen_overrides;
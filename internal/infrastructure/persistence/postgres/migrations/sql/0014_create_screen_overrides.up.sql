@@ -0,0 +1,20 @@
+-- Per-school and per-role adjustments of a screen instance's slot data. Each
+-- row is a JSON merge patch (RFC 7386) applied when resolving the screen for
+-- a context of that school: first the school-wide row (role_id NULL), then the
+-- one of the active role.
+CREATE TABLE IF NOT EXISTS ui_config.screen_overrides (
+    id          UUID        PRIMARY KEY,
+    instance_id UUID        NOT NULL REFERENCES ui_config.screen_instances (id) ON DELETE CASCADE,
+    school_id   UUID        NOT NULL,
+    role_id     UUID        REFERENCES iam.roles (id) ON DELETE CASCADE,
+    slot_data   JSONB       NOT NULL,
+    created_by  UUID,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_screen_overrides_context
+    ON ui_config.screen_overrides (instance_id, school_id, COALESCE(role_id, '00000000-0000-0000-0000-000000000000'));
+
+CREATE INDEX IF NOT EXISTS idx_screen_overrides_school
+    ON ui_config.screen_overrides (school_id, role_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS iam.user_preferences;
+DROP TABLE IF EXISTS ui_config.translations;
@@ -0,0 +1,22 @@
+-- Per-locale values of translatable names: the display name of menu resources
+-- and the name of screen instances. Text inside slot data is translated inline
+-- with {"$i18n": {"<locale>": "..."}} values.
+CREATE TABLE IF NOT EXISTS ui_config.translations (
+    entity_type VARCHAR(50)  NOT NULL,
+    entity_id   UUID         NOT NULL,
+    field       VARCHAR(50)  NOT NULL,
+    locale      VARCHAR(10)  NOT NULL,
+    value       TEXT         NOT NULL,
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    PRIMARY KEY (entity_type, entity_id, field, locale)
+);
+
+CREATE INDEX IF NOT EXISTS idx_translations_locale
+    ON ui_config.translations (entity_type, field, locale);
+
+-- Per-user settings that are not part of the shared user account
+CREATE TABLE IF NOT EXISTS iam.user_preferences (
+    user_id    UUID        PRIMARY KEY,
+    locale     VARCHAR(10),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
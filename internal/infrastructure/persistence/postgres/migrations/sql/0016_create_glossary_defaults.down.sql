@@ -0,0 +1 @@
+DROP TABLE IF EXISTS ui_config.glossary_defaults;
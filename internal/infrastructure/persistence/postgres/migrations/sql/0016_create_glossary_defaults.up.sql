@@ -0,0 +1,9 @@
+-- Platform-wide default terminology of the glossary. Schools override single
+-- terms in academic.school_concepts; the effective glossary of a school is
+-- these defaults with its own terms on top.
+CREATE TABLE IF NOT EXISTS ui_config.glossary_defaults (
+    term_key    VARCHAR(100) PRIMARY KEY,
+    term_value  VARCHAR(255) NOT NULL,
+    description TEXT,
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
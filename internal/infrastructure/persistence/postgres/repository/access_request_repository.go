@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type postgresAccessRequestRepository struct{ db *gorm.DB }
+
+func NewPostgresAccessRequestRepository(db *gorm.DB) repository.AccessRequestRepository {
+	return &postgresAccessRequestRepository{db: db}
+}
+
+func (r *postgresAccessRequestRepository) Create(ctx context.Context, req *model.AccessRequest) error {
+	return r.db.WithContext(ctx).Create(req).Error
+}
+
+func (r *postgresAccessRequestRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.AccessRequest, error) {
+	var req model.AccessRequest
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&req).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &req, nil
+}
+
+func (r *postgresAccessRequestRepository) List(ctx context.Context, filter repository.AccessRequestFilter, filters sharedrepo.ListFilters) ([]*model.AccessRequest, int, error) {
+	type requestWithTotal struct {
+		model.AccessRequest
+		Total int64 `gorm:"column:_total"`
+	}
+
+	query := r.db.WithContext(ctx).Table(model.AccessRequest{}.TableName()).Select("*, COUNT(*) OVER() as _total")
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.SchoolID != nil {
+		query = query.Where("school_id = ?", *filter.SchoolID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	query = query.Order("created_at DESC")
+	query = filters.ApplyPagination(query)
+
+	var results []requestWithTotal
+	if err := query.Find(&results).Error; err != nil {
+		return nil, 0, err
+	}
+
+	total := int64(0)
+	if len(results) > 0 {
+		total = results[0].Total
+	}
+
+	requests := make([]*model.AccessRequest, len(results))
+	for i := range results {
+		req := results[i].AccessRequest
+		requests[i] = &req
+	}
+	return requests, int(total), nil
+}
+
+func (r *postgresAccessRequestRepository) Update(ctx context.Context, req *model.AccessRequest) error {
+	return r.db.WithContext(ctx).Save(req).Error
+}
+
+func (r *postgresAccessRequestRepository) Decide(ctx context.Context, req *model.AccessRequest) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.AccessRequest{}).
+		Where("id = ? AND status = ?", req.ID, model.AccessRequestPending).
+		Updates(map[string]interface{}{
+			"status":           req.Status,
+			"decided_by":       req.DecidedBy,
+			"decided_at":       req.DecidedAt,
+			"decision_note":    req.DecisionNote,
+			"grant_expires_at": req.GrantExpiresAt,
+			"updated_at":       req.UpdatedAt,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *postgresAccessRequestRepository) Reopen(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&model.AccessRequest{}).
+		Where("id = ? AND status = ?", id, model.AccessRequestApproved).
+		Updates(map[string]interface{}{
+			"status":           model.AccessRequestPending,
+			"decided_by":       nil,
+			"decided_at":       nil,
+			"decision_note":    nil,
+			"grant_expires_at": nil,
+			"updated_at":       time.Now(),
+		}).Error
+}
+
+func (r *postgresAccessRequestRepository) HasPending(ctx context.Context, userID, roleID, schoolID uuid.UUID, unitID *uuid.UUID) (bool, error) {
+	query := r.db.WithContext(ctx).Model(&model.AccessRequest{}).
+		Where("user_id = ? AND role_id = ? AND school_id = ? AND status = ?", userID, roleID, schoolID, model.AccessRequestPending)
+	if unitID != nil {
+		query = query.Where("academic_unit_id = ?", *unitID)
+	} else {
+		query = query.Where("academic_unit_id IS NULL")
+	}
+	var count int64
+	err := query.Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// reviewItemBatchSize bounds the rows per INSERT when snapshotting a campaign
+const reviewItemBatchSize = 500
+
+type postgresAccessReviewRepository struct{ db *gorm.DB }
+
+func NewPostgresAccessReviewRepository(db *gorm.DB) repository.AccessReviewRepository {
+	return &postgresAccessReviewRepository{db: db}
+}
+
+// CreateCampaign stores the campaign and its snapshot of items atomically
+func (r *postgresAccessReviewRepository) CreateCampaign(ctx context.Context, campaign *model.AccessReviewCampaign, items []*model.AccessReviewItem) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(campaign).Error; err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(items, reviewItemBatchSize).Error
+	})
+}
+
+func (r *postgresAccessReviewRepository) FindCampaign(ctx context.Context, id uuid.UUID) (*model.AccessReviewCampaign, error) {
+	var c model.AccessReviewCampaign
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&c).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *postgresAccessReviewRepository) ListCampaigns(ctx context.Context, status string, filters sharedrepo.ListFilters) ([]*model.AccessReviewCampaign, int, error) {
+	type campaignWithTotal struct {
+		model.AccessReviewCampaign
+		Total int64 `gorm:"column:_total"`
+	}
+
+	query := r.db.WithContext(ctx).Table(model.AccessReviewCampaign{}.TableName()).Select("*, COUNT(*) OVER() as _total")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	query = filters.ApplySearch(query)
+	query = query.Order("created_at DESC")
+	query = filters.ApplyPagination(query)
+
+	var results []campaignWithTotal
+	if err := query.Find(&results).Error; err != nil {
+		return nil, 0, err
+	}
+
+	total := int64(0)
+	if len(results) > 0 {
+		total = results[0].Total
+	}
+
+	campaigns := make([]*model.AccessReviewCampaign, len(results))
+	for i := range results {
+		c := results[i].AccessReviewCampaign
+		campaigns[i] = &c
+	}
+	return campaigns, int(total), nil
+}
+
+func (r *postgresAccessReviewRepository) UpdateCampaign(ctx context.Context, campaign *model.AccessReviewCampaign) error {
+	return r.db.WithContext(ctx).Save(campaign).Error
+}
+
+func (r *postgresAccessReviewRepository) FindDueCampaigns(ctx context.Context, now time.Time) ([]*model.AccessReviewCampaign, error) {
+	var campaigns []*model.AccessReviewCampaign
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND deadline <= ?", model.ReviewCampaignActive, now).
+		Order("deadline ASC").Find(&campaigns).Error
+	return campaigns, err
+}
+
+func (r *postgresAccessReviewRepository) FindItem(ctx context.Context, id uuid.UUID) (*model.AccessReviewItem, error) {
+	var item model.AccessReviewItem
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&item).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *postgresAccessReviewRepository) ListItems(ctx context.Context, filter repository.AccessReviewItemFilter) ([]*model.AccessReviewItem, error) {
+	query := r.db.WithContext(ctx).Model(&model.AccessReviewItem{})
+	if filter.CampaignID != nil {
+		query = query.Where("campaign_id = ?", *filter.CampaignID)
+	}
+	if filter.ReviewerID != nil {
+		query = query.Where("reviewer_id = ?", *filter.ReviewerID)
+	}
+	if filter.Decision != "" {
+		query = query.Where("decision = ?", filter.Decision)
+	}
+	var items []*model.AccessReviewItem
+	err := query.Order("user_id ASC, role_id ASC").Find(&items).Error
+	return items, err
+}
+
+func (r *postgresAccessReviewRepository) DecideItem(ctx context.Context, item *model.AccessReviewItem, revoke bool) (bool, error) {
+	decided := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.AccessReviewItem{}).
+			Where("id = ? AND decision = ?", item.ID, model.ReviewDecisionPending).
+			Updates(map[string]interface{}{
+				"decision":   item.Decision,
+				"decided_by": item.DecidedBy,
+				"decided_at": item.DecidedAt,
+				"note":       item.Note,
+				"updated_at": item.UpdatedAt,
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		if revoke {
+			// By ID: the same role held in other schools is outside the
+			// campaign scope. An assignment revoked elsewhere is left alone.
+			if err := tx.Model(&entities.UserRole{}).Where("id = ? AND is_active = true", item.UserRoleID).
+				Updates(map[string]interface{}{"is_active": false, "updated_at": item.UpdatedAt}).Error; err != nil {
+				return err
+			}
+		}
+		decided = true
+		return nil
+	})
+	return decided, err
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type postgresBreakGlassRepository struct{ db *gorm.DB }
+
+func NewPostgresBreakGlassRepository(db *gorm.DB) repository.BreakGlassRepository {
+	return &postgresBreakGlassRepository{db: db}
+}
+
+// errBreakGlassUnavailable rolls back an activation that lost the race
+var errBreakGlassUnavailable = errors.New("break-glass elevation is not available")
+
+// Activate takes a transaction-scoped advisory lock on the user so concurrent
+// activations re-check the open elevation and the rate limit one at a time,
+// then inserts the grant before the elevation that references it.
+func (r *postgresBreakGlassRepository) Activate(ctx context.Context, elevation *model.BreakGlassElevation, grant *entities.UserRole, since time.Time, maxActivations int) (bool, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "break_glass:"+elevation.UserID.String()).Error; err != nil {
+			return err
+		}
+		var open, recent int64
+		if err := tx.Model(&model.BreakGlassElevation{}).
+			Where("user_id = ? AND ended_at IS NULL AND expires_at > ?", elevation.UserID, elevation.ActivatedAt).
+			Count(&open).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.BreakGlassElevation{}).
+			Where("user_id = ? AND activated_at >= ?", elevation.UserID, since).
+			Count(&recent).Error; err != nil {
+			return err
+		}
+		if open > 0 || int(recent) >= maxActivations {
+			return errBreakGlassUnavailable
+		}
+		if err := tx.Create(grant).Error; err != nil {
+			return err
+		}
+		return tx.Create(elevation).Error
+	})
+	if errors.Is(err, errBreakGlassUnavailable) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *postgresBreakGlassRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.BreakGlassElevation, error) {
+	var elevation model.BreakGlassElevation
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&elevation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &elevation, nil
+}
+
+func (r *postgresBreakGlassRepository) FindActiveByUser(ctx context.Context, userID uuid.UUID, now time.Time) (*model.BreakGlassElevation, error) {
+	var elevation model.BreakGlassElevation
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND ended_at IS NULL AND expires_at > ?", userID, now).
+		Order("activated_at DESC").
+		First(&elevation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &elevation, nil
+}
+
+func (r *postgresBreakGlassRepository) CountSince(ctx context.Context, userID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.BreakGlassElevation{}).
+		Where("user_id = ? AND activated_at >= ?", userID, since).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *postgresBreakGlassRepository) List(ctx context.Context, filters sharedrepo.ListFilters) ([]*model.BreakGlassElevation, int, error) {
+	type elevationWithTotal struct {
+		model.BreakGlassElevation
+		Total int64 `gorm:"column:_total"`
+	}
+
+	query := r.db.WithContext(ctx).Table(model.BreakGlassElevation{}.TableName()).Select("*, COUNT(*) OVER() as _total")
+	query = query.Order("activated_at DESC")
+	query = filters.ApplyPagination(query)
+
+	var results []elevationWithTotal
+	if err := query.Find(&results).Error; err != nil {
+		return nil, 0, err
+	}
+
+	total := int64(0)
+	if len(results) > 0 {
+		total = results[0].Total
+	}
+
+	elevations := make([]*model.BreakGlassElevation, len(results))
+	for i := range results {
+		e := results[i].BreakGlassElevation
+		elevations[i] = &e
+	}
+	return elevations, int(total), nil
+}
+
+func (r *postgresBreakGlassRepository) FindExpired(ctx context.Context, now time.Time) ([]*model.BreakGlassElevation, error) {
+	var elevations []*model.BreakGlassElevation
+	err := r.db.WithContext(ctx).
+		Where("ended_at IS NULL AND expires_at <= ?", now).
+		Order("expires_at").
+		Find(&elevations).Error
+	return elevations, err
+}
+
+func (r *postgresBreakGlassRepository) End(ctx context.Context, id uuid.UUID, endedAt time.Time, endedBy *uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&model.BreakGlassElevation{}).
+		Where("id = ? AND ended_at IS NULL", id).
+		Updates(map[string]interface{}{"ended_at": endedAt, "ended_by": endedBy}).Error
+}
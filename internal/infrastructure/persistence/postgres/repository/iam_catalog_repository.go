@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type postgresIAMCatalogRepository struct{ db *gorm.DB }
+
+func NewPostgresIAMCatalogRepository(db *gorm.DB) repository.IAMCatalogRepository {
+	return &postgresIAMCatalogRepository{db: db}
+}
+
+func (r *postgresIAMCatalogRepository) Snapshot(ctx context.Context) (*repository.IAMCatalogSnapshot, error) {
+	return iamCatalogSnapshot(r.db.WithContext(ctx))
+}
+
+func iamCatalogSnapshot(db *gorm.DB) (*repository.IAMCatalogSnapshot, error) {
+	snap := &repository.IAMCatalogSnapshot{}
+	if err := db.Order("sort_order, key").Find(&snap.Resources).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Order("name").Find(&snap.Permissions).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Order("name").Find(&snap.Roles).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Find(&snap.RolePermissions).Error; err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// Reconcile holds a transaction-scoped advisory lock while it reads the
+// catalog, plans and writes, so a plan is never applied over a catalog that
+// changed after it was read
+func (r *postgresIAMCatalogRepository) Reconcile(ctx context.Context, plan func(snap *repository.IAMCatalogSnapshot) (*repository.IAMCatalogChangeSet, error)) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "iam.catalog").Error; err != nil {
+			return err
+		}
+		snap, err := iamCatalogSnapshot(tx)
+		if err != nil {
+			return err
+		}
+		changes, err := plan(snap)
+		if err != nil || changes == nil {
+			return err
+		}
+		return applyIAMCatalogChanges(tx, changes)
+	})
+}
+
+// applyIAMCatalogChanges writes a change set. Resources are expected in
+// parent-first order so that parent_id references are satisfied.
+func applyIAMCatalogChanges(tx *gorm.DB, changes *repository.IAMCatalogChangeSet) error {
+	for _, res := range changes.UpsertResources {
+		if err := tx.Save(res).Error; err != nil {
+			return err
+		}
+	}
+	for _, perm := range changes.UpsertPermissions {
+		if err := tx.Save(perm).Error; err != nil {
+			return err
+		}
+	}
+	for _, role := range changes.UpsertRoles {
+		if err := tx.Save(role).Error; err != nil {
+			return err
+		}
+	}
+	for roleID, permIDs := range changes.RolePermissions {
+		if err := tx.Where("role_id = ?", roleID).Delete(&entities.RolePermission{}).Error; err != nil {
+			return err
+		}
+		if len(permIDs) == 0 {
+			continue
+		}
+		rps := make([]entities.RolePermission, len(permIDs))
+		for i, pid := range permIDs {
+			rps[i] = entities.RolePermission{ID: uuid.New(), RoleID: roleID, PermissionID: pid}
+		}
+		if err := tx.Create(&rps).Error; err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	deactivate := map[string][]uuid.UUID{
+		"iam.roles":       changes.DeactivateRoles,
+		"iam.permissions": changes.DeactivatePermissions,
+		"iam.resources":   changes.DeactivateResources,
+	}
+	for _, table := range []string{"iam.roles", "iam.permissions", "iam.resources"} {
+		ids := deactivate[table]
+		if len(ids) == 0 {
+			continue
+		}
+		if err := tx.Table(table).Where("id IN ?", ids).
+			Updates(map[string]interface{}{"is_active": false, "updated_at": now}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
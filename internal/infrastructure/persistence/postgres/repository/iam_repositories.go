@@ -10,6 +10,7 @@ import (
 	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ==================== Role ====================
@@ -251,18 +252,63 @@ func (r *postgresRolePermissionRepository) Exists(ctx context.Context, roleID, p
 
 type postgresUserRoleRepository struct{ db *gorm.DB }
 
+// unexpiredUserRole keeps out assignments past their expiry that the sweep
+// has not deactivated yet
+const unexpiredUserRole = "(expires_at IS NULL OR expires_at > now())"
+
 func NewPostgresUserRoleRepository(db *gorm.DB) repository.UserRoleRepository {
 	return &postgresUserRoleRepository{db: db}
 }
 
 func (r *postgresUserRoleRepository) FindByUser(ctx context.Context, userID uuid.UUID) ([]*entities.UserRole, error) {
 	var userRoles []*entities.UserRole
-	err := r.db.WithContext(ctx).Where("user_id = ? AND is_active = true", userID).Order("school_id ASC, role_id ASC, academic_unit_id ASC").Find(&userRoles).Error
+	err := r.db.WithContext(ctx).Where("user_id = ? AND is_active = true", userID).Where(unexpiredUserRole).Order("school_id ASC, role_id ASC, academic_unit_id ASC").Find(&userRoles).Error
+	return userRoles, err
+}
+
+func (r *postgresUserRoleRepository) FindActiveByRole(ctx context.Context, roleID uuid.UUID) ([]*entities.UserRole, error) {
+	var userRoles []*entities.UserRole
+	err := r.db.WithContext(ctx).Where("role_id = ? AND is_active = true", roleID).Where(unexpiredUserRole).Order("user_id ASC").Find(&userRoles).Error
 	return userRoles, err
 }
 
+// FindActiveInScope lists active assignments matching every non-nil filter
+func (r *postgresUserRoleRepository) FindActiveInScope(ctx context.Context, schoolID, unitID, roleID *uuid.UUID) ([]*entities.UserRole, error) {
+	query := r.db.WithContext(ctx).Where("is_active = true").Where(unexpiredUserRole)
+	if schoolID != nil {
+		query = query.Where("school_id = ?", *schoolID)
+	}
+	if unitID != nil {
+		query = query.Where("academic_unit_id = ?", *unitID)
+	}
+	if roleID != nil {
+		query = query.Where("role_id = ?", *roleID)
+	}
+	var userRoles []*entities.UserRole
+	err := query.Order("user_id ASC, role_id ASC").Find(&userRoles).Error
+	return userRoles, err
+}
+
+// userRoleBatchSize bounds the IN list of batched lookups below the
+// Postgres bind parameter limit
+const userRoleBatchSize = 1000
+
+func (r *postgresUserRoleRepository) FindActiveByUsers(ctx context.Context, userIDs []uuid.UUID) ([]*entities.UserRole, error) {
+	var userRoles []*entities.UserRole
+	for start := 0; start < len(userIDs); start += userRoleBatchSize {
+		end := min(start+userRoleBatchSize, len(userIDs))
+		var batch []*entities.UserRole
+		if err := r.db.WithContext(ctx).Where("user_id IN ? AND is_active = true", userIDs[start:end]).Where(unexpiredUserRole).
+			Order("user_id ASC, created_at ASC").Find(&batch).Error; err != nil {
+			return nil, err
+		}
+		userRoles = append(userRoles, batch...)
+	}
+	return userRoles, nil
+}
+
 func (r *postgresUserRoleRepository) FindByUserInContext(ctx context.Context, userID uuid.UUID, schoolID *uuid.UUID, unitID *uuid.UUID) ([]*entities.UserRole, error) {
-	query := r.db.WithContext(ctx).Where("user_id = ? AND is_active = true", userID)
+	query := r.db.WithContext(ctx).Where("user_id = ? AND is_active = true", userID).Where(unexpiredUserRole)
 	if schoolID != nil {
 		query = query.Where("school_id = ?", *schoolID)
 	}
@@ -291,7 +337,8 @@ func (r *postgresUserRoleRepository) RevokeByUserAndRole(ctx context.Context, us
 }
 
 func (r *postgresUserRoleRepository) UserHasRole(ctx context.Context, userID, roleID uuid.UUID, schoolID, unitID *uuid.UUID) (bool, error) {
-	query := r.db.WithContext(ctx).Model(&entities.UserRole{}).Where("user_id = ? AND role_id = ? AND is_active = true", userID, roleID)
+	query := r.db.WithContext(ctx).Model(&entities.UserRole{}).Where("user_id = ? AND role_id = ? AND is_active = true", userID, roleID).
+		Where(unexpiredUserRole)
 	if schoolID != nil {
 		query = query.Where("school_id = ?", *schoolID)
 	}
@@ -303,11 +350,22 @@ func (r *postgresUserRoleRepository) UserHasRole(ctx context.Context, userID, ro
 	return count > 0, err
 }
 
+// DeactivateExpired deactivates every active assignment whose expiry has
+// passed and returns the affected rows.
+func (r *postgresUserRoleRepository) DeactivateExpired(ctx context.Context, now time.Time) ([]*entities.UserRole, error) {
+	var expired []*entities.UserRole
+	err := r.db.WithContext(ctx).Model(&expired).Clauses(clause.Returning{}).
+		Where("is_active = true AND expires_at IS NOT NULL AND expires_at <= ?", now).
+		Updates(map[string]interface{}{"is_active": false, "updated_at": now}).Error
+	return expired, err
+}
+
 func (r *postgresUserRoleRepository) GetUserPermissions(ctx context.Context, userID uuid.UUID, schoolID, unitID *uuid.UUID) ([]string, error) {
 	query := `SELECT DISTINCT p.name FROM iam.permissions p
 		INNER JOIN iam.role_permissions rp ON p.id = rp.permission_id
 		INNER JOIN iam.user_roles ur ON rp.role_id = ur.role_id
-		WHERE ur.user_id = ? AND ur.is_active = true AND p.is_active = true`
+		WHERE ur.user_id = ? AND ur.is_active = true AND p.is_active = true
+			AND (ur.expires_at IS NULL OR ur.expires_at > now())`
 	args := []any{userID}
 	if schoolID != nil {
 		query += ` AND ur.school_id = ?`
@@ -323,6 +381,34 @@ func (r *postgresUserRoleRepository) GetUserPermissions(ctx context.Context, use
 	return perms, err
 }
 
+// GetContextPermissions returns the permissions of an active context. Global
+// roles (no school) always apply. With a school, its school-level roles apply
+// and, unless a unit is given, every unit role held in it; with a unit only
+// that unit's roles are added.
+func (r *postgresUserRoleRepository) GetContextPermissions(ctx context.Context, userID uuid.UUID, schoolID, unitID *uuid.UUID) ([]string, error) {
+	query := `SELECT DISTINCT p.name FROM iam.permissions p
+		INNER JOIN iam.role_permissions rp ON p.id = rp.permission_id
+		INNER JOIN iam.user_roles ur ON rp.role_id = ur.role_id
+		WHERE ur.user_id = ? AND ur.is_active = true AND p.is_active = true
+			AND (ur.expires_at IS NULL OR ur.expires_at > now())`
+	args := []any{userID}
+	switch {
+	case schoolID != nil && unitID != nil:
+		query += ` AND (ur.school_id IS NULL OR (ur.school_id = ? AND (ur.academic_unit_id IS NULL OR ur.academic_unit_id = ?)))`
+		args = append(args, *schoolID, *unitID)
+	case schoolID != nil:
+		query += ` AND (ur.school_id IS NULL OR ur.school_id = ?)`
+		args = append(args, *schoolID)
+	case unitID != nil:
+		query += ` AND (ur.school_id IS NULL OR ur.academic_unit_id = ?)`
+		args = append(args, *unitID)
+	}
+	query += ` ORDER BY p.name`
+	perms := make([]string, 0)
+	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&perms).Error
+	return perms, err
+}
+
 // ==================== Resource ====================
 
 type postgresResourceRepository struct{ db *gorm.DB }
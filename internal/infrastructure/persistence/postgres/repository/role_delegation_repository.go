@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type postgresRoleDelegationRepository struct{ db *gorm.DB }
+
+func NewPostgresRoleDelegationRepository(db *gorm.DB) repository.RoleDelegationRepository {
+	return &postgresRoleDelegationRepository{db: db}
+}
+
+func (r *postgresRoleDelegationRepository) Create(ctx context.Context, delegations []*model.RoleDelegation, grants []*entities.UserRole) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(grants) > 0 {
+			if err := tx.Create(&grants).Error; err != nil {
+				return err
+			}
+		}
+		if len(delegations) == 0 {
+			return nil
+		}
+		return tx.Create(&delegations).Error
+	})
+}
+
+func (r *postgresRoleDelegationRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.RoleDelegation, error) {
+	var delegation model.RoleDelegation
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&delegation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &delegation, nil
+}
+
+func (r *postgresRoleDelegationRepository) List(ctx context.Context, filter repository.RoleDelegationFilter) ([]*model.RoleDelegation, error) {
+	query := r.db.WithContext(ctx)
+	if filter.DelegatorID != nil {
+		query = query.Where("delegator_id = ?", *filter.DelegatorID)
+	}
+	if filter.DelegateID != nil {
+		query = query.Where("delegate_id = ?", *filter.DelegateID)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	var delegations []*model.RoleDelegation
+	err := query.Order("starts_at DESC").Find(&delegations).Error
+	return delegations, err
+}
+
+func (r *postgresRoleDelegationRepository) Update(ctx context.Context, delegation *model.RoleDelegation) error {
+	return r.db.WithContext(ctx).Save(delegation).Error
+}
+
+// errDelegationNotScheduled rolls back an activation that lost the race
+var errDelegationNotScheduled = errors.New("delegation is no longer scheduled")
+
+// Activate inserts the grant first so that delegated_user_role_id can point
+// at it, then moves the delegation out of scheduled. When another run got
+// there first the grant is rolled back and false is returned.
+func (r *postgresRoleDelegationRepository) Activate(ctx context.Context, delegation *model.RoleDelegation, grant *entities.UserRole) (bool, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(grant).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&model.RoleDelegation{}).
+			Where("id = ? AND status = ?", delegation.ID, model.DelegationScheduled).
+			Updates(map[string]interface{}{
+				"status":                 delegation.Status,
+				"delegated_user_role_id": delegation.DelegatedUserRoleID,
+				"updated_at":             delegation.UpdatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errDelegationNotScheduled
+		}
+		return nil
+	})
+	if errors.Is(err, errDelegationNotScheduled) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *postgresRoleDelegationRepository) Overlaps(ctx context.Context, sourceUserRoleID, delegateID uuid.UUID, startsAt, endsAt time.Time) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.RoleDelegation{}).
+		Where("source_user_role_id = ? AND delegate_id = ? AND status IN ?", sourceUserRoleID, delegateID,
+			[]string{model.DelegationScheduled, model.DelegationActive}).
+		Where("starts_at < ? AND ends_at > ?", endsAt, startsAt).
+		Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==================== RoleGrantPolicy ====================
+
+type postgresRoleGrantPolicyRepository struct{ db *gorm.DB }
+
+func NewPostgresRoleGrantPolicyRepository(db *gorm.DB) repository.RoleGrantPolicyRepository {
+	return &postgresRoleGrantPolicyRepository{db: db}
+}
+
+func (r *postgresRoleGrantPolicyRepository) FindByRole(ctx context.Context, roleID uuid.UUID) (*model.RoleGrantPolicy, error) {
+	var p model.RoleGrantPolicy
+	if err := r.db.WithContext(ctx).Where("role_id = ?", roleID).First(&p).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *postgresRoleGrantPolicyRepository) Upsert(ctx context.Context, policy *model.RoleGrantPolicy) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "role_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"requires_approval", "updated_by", "updated_at"}),
+	}).Create(policy).Error
+}
+
+// ==================== RoleGrantRequest ====================
+
+type postgresRoleGrantRequestRepository struct{ db *gorm.DB }
+
+func NewPostgresRoleGrantRequestRepository(db *gorm.DB) repository.RoleGrantRequestRepository {
+	return &postgresRoleGrantRequestRepository{db: db}
+}
+
+// Create relies on the partial unique index over pending requests, so two
+// concurrent requests for the same grant cannot both be stored
+func (r *postgresRoleGrantRequestRepository) Create(ctx context.Context, req *model.RoleGrantRequest) (bool, error) {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(req)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *postgresRoleGrantRequestRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.RoleGrantRequest, error) {
+	var req model.RoleGrantRequest
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&req).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &req, nil
+}
+
+func (r *postgresRoleGrantRequestRepository) List(ctx context.Context, status string, filters sharedrepo.ListFilters) ([]*model.RoleGrantRequest, int, error) {
+	type requestWithTotal struct {
+		model.RoleGrantRequest
+		Total int64 `gorm:"column:_total"`
+	}
+
+	query := r.db.WithContext(ctx).Table(model.RoleGrantRequest{}.TableName()).Select("*, COUNT(*) OVER() as _total")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	query = filters.ApplyFieldFilters(query, []string{"user_id", "role_id"})
+	query = query.Order("created_at DESC")
+	query = filters.ApplyPagination(query)
+
+	var results []requestWithTotal
+	if err := query.Find(&results).Error; err != nil {
+		return nil, 0, err
+	}
+
+	total := int64(0)
+	if len(results) > 0 {
+		total = results[0].Total
+	}
+
+	requests := make([]*model.RoleGrantRequest, len(results))
+	for i := range results {
+		req := results[i].RoleGrantRequest
+		requests[i] = &req
+	}
+	return requests, int(total), nil
+}
+
+func (r *postgresRoleGrantRequestRepository) Decide(ctx context.Context, req *model.RoleGrantRequest, grant *entities.UserRole) (bool, error) {
+	decided := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.RoleGrantRequest{}).
+			Where("id = ? AND status = ? AND expires_at > ?", req.ID, model.GrantRequestPending, req.UpdatedAt).
+			Updates(map[string]interface{}{
+				"status":        req.Status,
+				"decided_by":    req.DecidedBy,
+				"decided_at":    req.DecidedAt,
+				"decision_note": req.DecisionNote,
+				"user_role_id":  req.UserRoleID,
+				"updated_at":    req.UpdatedAt,
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		if grant != nil {
+			if err := tx.Create(grant).Error; err != nil {
+				return err
+			}
+		}
+		decided = true
+		return nil
+	})
+	return decided, err
+}
+
+func (r *postgresRoleGrantRequestRepository) HasPending(ctx context.Context, userID, roleID uuid.UUID, schoolID, unitID *uuid.UUID) (bool, error) {
+	query := r.db.WithContext(ctx).Model(&model.RoleGrantRequest{}).
+		Where("user_id = ? AND role_id = ? AND status = ?", userID, roleID, model.GrantRequestPending)
+	if schoolID != nil {
+		query = query.Where("school_id = ?", *schoolID)
+	} else {
+		query = query.Where("school_id IS NULL")
+	}
+	if unitID != nil {
+		query = query.Where("academic_unit_id = ?", *unitID)
+	} else {
+		query = query.Where("academic_unit_id IS NULL")
+	}
+	var count int64
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// ExpirePending marks every pending request past its deadline as expired and
+// returns the affected rows.
+func (r *postgresRoleGrantRequestRepository) ExpirePending(ctx context.Context, now time.Time) ([]*model.RoleGrantRequest, error) {
+	var expired []*model.RoleGrantRequest
+	err := r.db.WithContext(ctx).Model(&expired).Clauses(clause.Returning{}).
+		Where("status = ? AND expires_at <= ?", model.GrantRequestPending, now).
+		Updates(map[string]interface{}{"status": model.GrantRequestExpired, "updated_at": now}).Error
+	return expired, err
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// importRowBatchSize bounds the rows per INSERT when storing an uploaded file
+const importRowBatchSize = 500
+
+type postgresRoleImportRepository struct{ db *gorm.DB }
+
+func NewPostgresRoleImportRepository(db *gorm.DB) repository.RoleImportRepository {
+	return &postgresRoleImportRepository{db: db}
+}
+
+func (r *postgresRoleImportRepository) Create(ctx context.Context, job *model.RoleImportJob, rows []*model.RoleImportRow) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(job).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(rows, importRowBatchSize).Error
+	})
+}
+
+func (r *postgresRoleImportRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.RoleImportJob, error) {
+	var job model.RoleImportJob
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&job).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *postgresRoleImportRepository) List(ctx context.Context, requestedBy *uuid.UUID, filters sharedrepo.ListFilters) ([]*model.RoleImportJob, int, error) {
+	type jobWithTotal struct {
+		model.RoleImportJob
+		Total int64 `gorm:"column:_total"`
+	}
+
+	query := r.db.WithContext(ctx).Table(model.RoleImportJob{}.TableName()).Select("*, COUNT(*) OVER() as _total")
+	if requestedBy != nil {
+		query = query.Where("requested_by = ?", *requestedBy)
+	}
+	query = query.Order("created_at DESC")
+	query = filters.ApplyPagination(query)
+
+	var results []jobWithTotal
+	if err := query.Find(&results).Error; err != nil {
+		return nil, 0, err
+	}
+
+	total := int64(0)
+	if len(results) > 0 {
+		total = results[0].Total
+	}
+
+	jobs := make([]*model.RoleImportJob, len(results))
+	for i := range results {
+		j := results[i].RoleImportJob
+		jobs[i] = &j
+	}
+	return jobs, int(total), nil
+}
+
+func (r *postgresRoleImportRepository) FindPending(ctx context.Context, limit int) ([]*model.RoleImportJob, error) {
+	var jobs []*model.RoleImportJob
+	err := r.db.WithContext(ctx).
+		Where("status = ?", model.RoleImportPending).
+		Order("created_at").
+		Limit(limit).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+func (r *postgresRoleImportRepository) Claim(ctx context.Context, id uuid.UUID, now time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.RoleImportJob{}).
+		Where("id = ? AND status = ?", id, model.RoleImportPending).
+		Updates(map[string]interface{}{"status": model.RoleImportRunning, "started_at": now, "updated_at": now})
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *postgresRoleImportRepository) UpdateJob(ctx context.Context, job *model.RoleImportJob) error {
+	return r.db.WithContext(ctx).Save(job).Error
+}
+
+func (r *postgresRoleImportRepository) FindRows(ctx context.Context, jobID uuid.UUID, status string, filters sharedrepo.ListFilters) ([]*model.RoleImportRow, int, error) {
+	type rowWithTotal struct {
+		model.RoleImportRow
+		Total int64 `gorm:"column:_total"`
+	}
+
+	query := r.db.WithContext(ctx).Table(model.RoleImportRow{}.TableName()).Select("*, COUNT(*) OVER() as _total").
+		Where("job_id = ?", jobID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	query = query.Order("line_number")
+	query = filters.ApplyPagination(query)
+
+	var results []rowWithTotal
+	if err := query.Find(&results).Error; err != nil {
+		return nil, 0, err
+	}
+
+	total := int64(0)
+	if len(results) > 0 {
+		total = results[0].Total
+	}
+
+	rows := make([]*model.RoleImportRow, len(results))
+	for i := range results {
+		row := results[i].RoleImportRow
+		rows[i] = &row
+	}
+	return rows, int(total), nil
+}
+
+func (r *postgresRoleImportRepository) SaveRows(ctx context.Context, rows []*model.RoleImportRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, row := range rows {
+			if err := tx.Save(row).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *postgresRoleImportRepository) Apply(ctx context.Context, userRoles []*entities.UserRole, rows []*model.RoleImportRow) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(userRoles) > 0 {
+			if err := tx.CreateInBatches(userRoles, importRowBatchSize).Error; err != nil {
+				return err
+			}
+		}
+		for _, row := range rows {
+			if err := tx.Save(row).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
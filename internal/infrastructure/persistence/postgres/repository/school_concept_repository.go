@@ -2,13 +2,20 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"maps"
+	"slices"
 
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
 	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+const schoolConceptsTable = "academic.school_concepts"
+
 type postgresSchoolConceptRepository struct{ db *gorm.DB }
 
 func NewPostgresSchoolConceptRepository(db *gorm.DB) repository.SchoolConceptRepository {
@@ -17,8 +24,102 @@ func NewPostgresSchoolConceptRepository(db *gorm.DB) repository.SchoolConceptRep
 
 func (r *postgresSchoolConceptRepository) FindBySchoolID(ctx context.Context, schoolID uuid.UUID) ([]*entities.SchoolConcept, error) {
 	var concepts []*entities.SchoolConcept
-	err := r.db.WithContext(ctx).Table("academic.school_concepts").
+	err := r.db.WithContext(ctx).Table(schoolConceptsTable).
 		Where("school_id = ?", schoolID).
 		Find(&concepts).Error
 	return concepts, err
 }
+
+func (r *postgresSchoolConceptRepository) Save(ctx context.Context, schoolID uuid.UUID, termKey, termValue string) error {
+	return r.SaveBatch(ctx, schoolID, map[string]string{termKey: termValue}, false)
+}
+
+func (r *postgresSchoolConceptRepository) Delete(ctx context.Context, schoolID uuid.UUID, termKey string) (bool, error) {
+	result := r.db.WithContext(ctx).Table(schoolConceptsTable).
+		Where("school_id = ? AND term_key = ?", schoolID, termKey).
+		Delete(&entities.SchoolConcept{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// SaveBatch replaces the rows of the given keys instead of upserting, since
+// the table is shared and may lack a unique (school_id, term_key) index
+func (r *postgresSchoolConceptRepository) SaveBatch(ctx context.Context, schoolID uuid.UUID, terms map[string]string, replace bool) error {
+	if len(terms) == 0 && !replace {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		q := tx.Table(schoolConceptsTable).Where("school_id = ?", schoolID)
+		if !replace {
+			q = q.Where("term_key IN ?", slices.Collect(maps.Keys(terms)))
+		}
+		if err := q.Delete(&entities.SchoolConcept{}).Error; err != nil {
+			return err
+		}
+		if len(terms) == 0 {
+			return nil
+		}
+		concepts := make([]*entities.SchoolConcept, 0, len(terms))
+		for _, key := range slices.Sorted(maps.Keys(terms)) {
+			concepts = append(concepts, &entities.SchoolConcept{
+				ID: uuid.New(), SchoolID: schoolID, TermKey: key, TermValue: terms[key],
+			})
+		}
+		return tx.Table(schoolConceptsTable).Create(&concepts).Error
+	})
+}
+
+type postgresGlossaryDefaultRepository struct{ db *gorm.DB }
+
+func NewPostgresGlossaryDefaultRepository(db *gorm.DB) repository.GlossaryDefaultRepository {
+	return &postgresGlossaryDefaultRepository{db: db}
+}
+
+func (r *postgresGlossaryDefaultRepository) List(ctx context.Context) ([]*model.GlossaryDefault, error) {
+	var terms []*model.GlossaryDefault
+	err := r.db.WithContext(ctx).Order("term_key").Find(&terms).Error
+	return terms, err
+}
+
+func (r *postgresGlossaryDefaultRepository) Get(ctx context.Context, termKey string) (*model.GlossaryDefault, error) {
+	var term model.GlossaryDefault
+	if err := r.db.WithContext(ctx).Where("term_key = ?", termKey).First(&term).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &term, nil
+}
+
+func (r *postgresGlossaryDefaultRepository) Save(ctx context.Context, term *model.GlossaryDefault) error {
+	return r.SaveBatch(ctx, []*model.GlossaryDefault{term}, false)
+}
+
+func (r *postgresGlossaryDefaultRepository) Delete(ctx context.Context, termKey string) error {
+	return r.db.WithContext(ctx).Where("term_key = ?", termKey).Delete(&model.GlossaryDefault{}).Error
+}
+
+func (r *postgresGlossaryDefaultRepository) SaveBatch(ctx context.Context, terms []*model.GlossaryDefault, replace bool) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if replace {
+			keys := make([]string, len(terms))
+			for i, t := range terms {
+				keys[i] = t.TermKey
+			}
+			q := tx.Where("1 = 1")
+			if len(keys) > 0 {
+				q = tx.Where("term_key NOT IN ?", keys)
+			}
+			if err := q.Delete(&model.GlossaryDefault{}).Error; err != nil {
+				return err
+			}
+		}
+		if len(terms) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "term_key"}},
+			DoUpdates: clause.AssignmentColumns([]string{"term_value", "description", "updated_at"}),
+		}).Create(&terms).Error
+	})
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type postgresScreenConfigBundleRepository struct{ db *gorm.DB }
+
+func NewPostgresScreenConfigBundleRepository(db *gorm.DB) repository.ScreenConfigBundleRepository {
+	return &postgresScreenConfigBundleRepository{db: db}
+}
+
+func (r *postgresScreenConfigBundleRepository) Snapshot(ctx context.Context) (*repository.ScreenConfigSnapshot, error) {
+	return screenConfigSnapshot(r.db.WithContext(ctx))
+}
+
+func screenConfigSnapshot(db *gorm.DB) (*repository.ScreenConfigSnapshot, error) {
+	snap := &repository.ScreenConfigSnapshot{}
+	if err := db.Table("ui_config.screen_templates").Order("pattern, created_at").Find(&snap.Templates).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Table("ui_config.screen_instances").Order("screen_key, created_at").Find(&snap.Instances).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Table("ui_config.resource_screens").Order("resource_key, sort_order").Find(&snap.ResourceScreens).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Table("iam.resources").Where("is_active = true").Find(&snap.Resources).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Table("iam.permissions").Where("is_active = true").Find(&snap.Permissions).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Find(&snap.TemplateDrafts).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Find(&snap.InstanceDrafts).Error; err != nil {
+		return nil, err
+	}
+
+	var latest []struct {
+		InstanceID uuid.UUID
+		Version    int
+	}
+	if err := db.Table(model.ScreenInstanceVersion{}.TableName()).
+		Select("instance_id, MAX(version) AS version").Group("instance_id").Scan(&latest).Error; err != nil {
+		return nil, err
+	}
+	snap.LatestInstanceVersions = make(map[uuid.UUID]int, len(latest))
+	for _, l := range latest {
+		snap.LatestInstanceVersions[l.InstanceID] = l.Version
+	}
+	return snap, nil
+}
+
+func (r *postgresScreenConfigBundleRepository) VisibleLeafResource(ctx context.Context, id uuid.UUID) (*entities.Resource, error) {
+	var resources []*entities.Resource
+	err := r.db.WithContext(ctx).Table("iam.resources AS r").Select("r.*").
+		Where("r.id = ? AND r.is_active = true AND r.is_menu_visible = true", id).
+		Where("NOT EXISTS (SELECT 1 FROM iam.resources c WHERE c.parent_id = r.id AND c.is_active = true)").
+		Limit(1).Find(&resources).Error
+	if err != nil || len(resources) == 0 {
+		return nil, err
+	}
+	return resources[0], nil
+}
+
+// lockScreenConfig serializes the transactions that write the published
+// screen configuration until the calling transaction ends
+func lockScreenConfig(tx *gorm.DB) error {
+	return tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "ui_config.screen_config").Error
+}
+
+// Apply writes the whole change set in a single transaction
+func (r *postgresScreenConfigBundleRepository) Apply(ctx context.Context, changes *repository.ScreenConfigChangeSet) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockScreenConfig(tx); err != nil {
+			return err
+		}
+		return applyScreenConfigChanges(tx, changes)
+	})
+}
+
+func (r *postgresScreenConfigBundleRepository) Reconcile(ctx context.Context, plan func(snap *repository.ScreenConfigSnapshot) (*repository.ScreenConfigChangeSet, error)) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockScreenConfig(tx); err != nil {
+			return err
+		}
+		snap, err := screenConfigSnapshot(tx)
+		if err != nil {
+			return err
+		}
+		changes, err := plan(snap)
+		if err != nil || changes == nil {
+			return err
+		}
+		return applyScreenConfigChanges(tx, changes)
+	})
+}
+
+// applyScreenConfigChanges writes a change set. Templates go first so that
+// the instances referencing new templates are satisfied; removals run after
+// the upserts.
+func applyScreenConfigChanges(tx *gorm.DB, changes *repository.ScreenConfigChangeSet) error {
+	for _, t := range changes.UpsertTemplates {
+		if err := tx.Table("ui_config.screen_templates").Save(t).Error; err != nil {
+			return err
+		}
+	}
+	for _, i := range changes.UpsertInstances {
+		if err := tx.Table("ui_config.screen_instances").Save(i).Error; err != nil {
+			return err
+		}
+	}
+	for _, rs := range changes.UpsertResourceScreens {
+		if err := tx.Table("ui_config.resource_screens").Save(rs).Error; err != nil {
+			return err
+		}
+	}
+	if len(changes.TemplateVersions) > 0 {
+		if err := tx.Create(&changes.TemplateVersions).Error; err != nil {
+			return err
+		}
+	}
+	if len(changes.InstanceVersions) > 0 {
+		if err := tx.Create(&changes.InstanceVersions).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(changes.DeleteResourceScreens) > 0 {
+		if err := tx.Table("ui_config.resource_screens").Where("id IN ?", changes.DeleteResourceScreens).
+			Delete(&entities.ResourceScreen{}).Error; err != nil {
+			return err
+		}
+	}
+	if len(changes.DeleteTemplateDrafts) > 0 {
+		if err := tx.Where("template_id IN ?", changes.DeleteTemplateDrafts).
+			Delete(&model.ScreenTemplateDraft{}).Error; err != nil {
+			return err
+		}
+	}
+	if len(changes.DeleteInstanceDrafts) > 0 {
+		if err := tx.Where("instance_id IN ?", changes.DeleteInstanceDrafts).
+			Delete(&model.ScreenInstanceDraft{}).Error; err != nil {
+			return err
+		}
+	}
+	now := time.Now()
+	deactivate := map[string][]uuid.UUID{
+		"ui_config.screen_instances": changes.DeactivateInstances,
+		"ui_config.screen_templates": changes.DeactivateTemplates,
+		"iam.resources":              changes.DeactivateResources,
+	}
+	for _, table := range []string{"ui_config.screen_instances", "ui_config.screen_templates", "iam.resources"} {
+		ids := deactivate[table]
+		if len(ids) == 0 {
+			continue
+		}
+		if err := tx.Table(table).Where("id IN ?", ids).
+			Updates(map[string]interface{}{"is_active": false, "updated_at": now}).Error; err != nil {
+			return err
+		}
+	}
+	if len(changes.HideResources) > 0 {
+		if err := tx.Table("iam.resources").Where("id IN ?", changes.HideResources).
+			Updates(map[string]interface{}{"is_menu_visible": false, "updated_at": now}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type postgresScreenDraftRepository struct{ db *gorm.DB }
+
+func NewPostgresScreenDraftRepository(db *gorm.DB) repository.ScreenDraftRepository {
+	return &postgresScreenDraftRepository{db: db}
+}
+
+func (r *postgresScreenDraftRepository) SaveTemplateDraft(ctx context.Context, draft *model.ScreenTemplateDraft) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "template_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"pattern", "name", "description", "definition", "publish_at", "updated_at"}),
+	}).Create(draft).Error
+}
+
+func (r *postgresScreenDraftRepository) GetTemplateDraft(ctx context.Context, templateID uuid.UUID) (*model.ScreenTemplateDraft, error) {
+	var draft model.ScreenTemplateDraft
+	if err := r.db.WithContext(ctx).Where("template_id = ?", templateID).First(&draft).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &draft, nil
+}
+
+func (r *postgresScreenDraftRepository) ListTemplateDrafts(ctx context.Context) ([]*model.ScreenTemplateDraft, error) {
+	var drafts []*model.ScreenTemplateDraft
+	err := r.db.WithContext(ctx).Order("updated_at DESC").Find(&drafts).Error
+	return drafts, err
+}
+
+func (r *postgresScreenDraftRepository) DeleteTemplateDraft(ctx context.Context, templateID uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("template_id = ?", templateID).Delete(&model.ScreenTemplateDraft{}).Error
+}
+
+func (r *postgresScreenDraftRepository) FindDueTemplateDrafts(ctx context.Context, now time.Time) ([]*model.ScreenTemplateDraft, error) {
+	var drafts []*model.ScreenTemplateDraft
+	err := r.db.WithContext(ctx).
+		Where("publish_at IS NOT NULL AND publish_at <= ?", now).
+		Order("publish_at").
+		Find(&drafts).Error
+	return drafts, err
+}
+
+func (r *postgresScreenDraftRepository) SaveInstanceDraft(ctx context.Context, draft *model.ScreenInstanceDraft) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "instance_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"screen_key", "template_id", "name", "description", "slot_data", "scope",
+			"required_permission", "handler_key", "publish_at", "updated_at",
+		}),
+	}).Create(draft).Error
+}
+
+func (r *postgresScreenDraftRepository) GetInstanceDraft(ctx context.Context, instanceID uuid.UUID) (*model.ScreenInstanceDraft, error) {
+	var draft model.ScreenInstanceDraft
+	if err := r.db.WithContext(ctx).Where("instance_id = ?", instanceID).First(&draft).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &draft, nil
+}
+
+func (r *postgresScreenDraftRepository) ListInstanceDrafts(ctx context.Context) ([]*model.ScreenInstanceDraft, error) {
+	var drafts []*model.ScreenInstanceDraft
+	err := r.db.WithContext(ctx).Order("updated_at DESC").Find(&drafts).Error
+	return drafts, err
+}
+
+func (r *postgresScreenDraftRepository) DeleteInstanceDraft(ctx context.Context, instanceID uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("instance_id = ?", instanceID).Delete(&model.ScreenInstanceDraft{}).Error
+}
+
+func (r *postgresScreenDraftRepository) FindDueInstanceDrafts(ctx context.Context, now time.Time) ([]*model.ScreenInstanceDraft, error) {
+	var drafts []*model.ScreenInstanceDraft
+	err := r.db.WithContext(ctx).
+		Where("publish_at IS NOT NULL AND publish_at <= ?", now).
+		Order("publish_at").
+		Find(&drafts).Error
+	return drafts, err
+}
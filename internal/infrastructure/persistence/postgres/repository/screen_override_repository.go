@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type postgresScreenOverrideRepository struct{ db *gorm.DB }
+
+func NewPostgresScreenOverrideRepository(db *gorm.DB) repository.ScreenOverrideRepository {
+	return &postgresScreenOverrideRepository{db: db}
+}
+
+func (r *postgresScreenOverrideRepository) Create(ctx context.Context, override *model.ScreenOverride) error {
+	return r.db.WithContext(ctx).Create(override).Error
+}
+
+func (r *postgresScreenOverrideRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.ScreenOverride, error) {
+	return r.first(r.db.WithContext(ctx).Where("id = ?", id))
+}
+
+func (r *postgresScreenOverrideRepository) GetByContext(ctx context.Context, instanceID, schoolID uuid.UUID, roleID *uuid.UUID) (*model.ScreenOverride, error) {
+	q := r.db.WithContext(ctx).Where("instance_id = ? AND school_id = ?", instanceID, schoolID)
+	if roleID == nil {
+		q = q.Where("role_id IS NULL")
+	} else {
+		q = q.Where("role_id = ?", *roleID)
+	}
+	return r.first(q)
+}
+
+func (r *postgresScreenOverrideRepository) first(q *gorm.DB) (*model.ScreenOverride, error) {
+	var override model.ScreenOverride
+	if err := q.First(&override).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &override, nil
+}
+
+func (r *postgresScreenOverrideRepository) Update(ctx context.Context, override *model.ScreenOverride) error {
+	return r.db.WithContext(ctx).Save(override).Error
+}
+
+func (r *postgresScreenOverrideRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&model.ScreenOverride{}).Error
+}
+
+func (r *postgresScreenOverrideRepository) ListByInstance(ctx context.Context, instanceID uuid.UUID, schoolID *uuid.UUID) ([]*model.ScreenOverride, error) {
+	q := r.db.WithContext(ctx).Where("instance_id = ?", instanceID)
+	if schoolID != nil {
+		q = q.Where("school_id = ?", *schoolID)
+	}
+	var overrides []*model.ScreenOverride
+	err := q.Order("school_id, role_id NULLS FIRST").Find(&overrides).Error
+	return overrides, err
+}
+
+func (r *postgresScreenOverrideRepository) FindForContext(ctx context.Context, schoolID uuid.UUID, roleID *uuid.UUID, instanceID *uuid.UUID) ([]*model.ScreenOverride, error) {
+	q := r.db.WithContext(ctx).Where("school_id = ?", schoolID)
+	if roleID == nil {
+		q = q.Where("role_id IS NULL")
+	} else {
+		q = q.Where("(role_id IS NULL OR role_id = ?)", *roleID)
+	}
+	if instanceID != nil {
+		q = q.Where("instance_id = ?", *instanceID)
+	}
+	var overrides []*model.ScreenOverride
+	err := q.Order("role_id NULLS FIRST").Find(&overrides).Error
+	return overrides, err
+}
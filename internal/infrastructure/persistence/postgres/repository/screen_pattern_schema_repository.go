@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type postgresScreenPatternSchemaRepository struct{ db *gorm.DB }
+
+func NewPostgresScreenPatternSchemaRepository(db *gorm.DB) repository.ScreenPatternSchemaRepository {
+	return &postgresScreenPatternSchemaRepository{db: db}
+}
+
+func (r *postgresScreenPatternSchemaRepository) Get(ctx context.Context, pattern string) (*model.ScreenPatternSchema, error) {
+	var schema model.ScreenPatternSchema
+	if err := r.db.WithContext(ctx).Where("pattern = ?", pattern).First(&schema).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &schema, nil
+}
+
+func (r *postgresScreenPatternSchemaRepository) List(ctx context.Context) ([]*model.ScreenPatternSchema, error) {
+	var schemas []*model.ScreenPatternSchema
+	err := r.db.WithContext(ctx).Order("pattern").Find(&schemas).Error
+	return schemas, err
+}
+
+func (r *postgresScreenPatternSchemaRepository) Save(ctx context.Context, schema *model.ScreenPatternSchema) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "pattern"}},
+		DoUpdates: clause.AssignmentColumns([]string{"definition_schema", "slot_data_schema", "updated_at"}),
+	}).Create(schema).Error
+}
+
+func (r *postgresScreenPatternSchemaRepository) Delete(ctx context.Context, pattern string) error {
+	return r.db.WithContext(ctx).Where("pattern = ?", pattern).Delete(&model.ScreenPatternSchema{}).Error
+}
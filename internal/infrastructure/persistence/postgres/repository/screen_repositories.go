@@ -67,6 +67,13 @@ func (r *postgresScreenTemplateRepository) List(ctx context.Context, filters sha
 	return templates, int(total), nil
 }
 
+func (r *postgresScreenTemplateRepository) ListAfter(ctx context.Context, after uuid.UUID, limit int) ([]*entities.ScreenTemplate, error) {
+	var templates []*entities.ScreenTemplate
+	err := r.db.WithContext(ctx).Table("ui_config.screen_templates").
+		Where("is_active = true AND id > ?", after).Order("id").Limit(limit).Find(&templates).Error
+	return templates, err
+}
+
 func (r *postgresScreenTemplateRepository) Update(ctx context.Context, t *entities.ScreenTemplate) error {
 	return r.db.WithContext(ctx).Table("ui_config.screen_templates").Save(t).Error
 }
@@ -142,6 +149,20 @@ func (r *postgresScreenInstanceRepository) List(ctx context.Context, filters sha
 	return instances, int(total), nil
 }
 
+func (r *postgresScreenInstanceRepository) ListAfter(ctx context.Context, after uuid.UUID, limit int) ([]*entities.ScreenInstance, error) {
+	var instances []*entities.ScreenInstance
+	err := r.db.WithContext(ctx).Table("ui_config.screen_instances").
+		Where("is_active = true AND id > ?", after).Order("id").Limit(limit).Find(&instances).Error
+	return instances, err
+}
+
+func (r *postgresScreenInstanceRepository) ListByTemplate(ctx context.Context, templateID uuid.UUID) ([]*entities.ScreenInstance, error) {
+	var instances []*entities.ScreenInstance
+	err := r.db.WithContext(ctx).Table("ui_config.screen_instances").
+		Where("template_id = ? AND is_active = true", templateID).Order("screen_key").Find(&instances).Error
+	return instances, err
+}
+
 func (r *postgresScreenInstanceRepository) Update(ctx context.Context, i *entities.ScreenInstance) error {
 	return r.db.WithContext(ctx).Table("ui_config.screen_instances").Save(i).Error
 }
@@ -163,6 +184,17 @@ func (r *postgresResourceScreenRepository) Create(ctx context.Context, rs *entit
 	return r.db.WithContext(ctx).Table("ui_config.resource_screens").Create(rs).Error
 }
 
+func (r *postgresResourceScreenRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.ResourceScreen, error) {
+	var rs entities.ResourceScreen
+	if err := r.db.WithContext(ctx).Table("ui_config.resource_screens").First(&rs, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rs, nil
+}
+
 func (r *postgresResourceScreenRepository) GetByResourceID(ctx context.Context, resourceID uuid.UUID) ([]*entities.ResourceScreen, error) {
 	var result []*entities.ResourceScreen
 	err := r.db.WithContext(ctx).Table("ui_config.resource_screens").
@@ -187,6 +219,16 @@ func (r *postgresResourceScreenRepository) GetByResourceKeys(ctx context.Context
 	return result, err
 }
 
+func (r *postgresResourceScreenRepository) GetByScreenKeys(ctx context.Context, keys []string) ([]*entities.ResourceScreen, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	var result []*entities.ResourceScreen
+	err := r.db.WithContext(ctx).Table("ui_config.resource_screens").
+		Where("screen_key IN ? AND is_active = true", keys).Order("resource_key, sort_order").Find(&result).Error
+	return result, err
+}
+
 func (r *postgresResourceScreenRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.db.WithContext(ctx).Table("ui_config.resource_screens").Where("id = ?", id).Delete(&entities.ResourceScreen{}).Error
 }
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type postgresScreenVersionRepository struct{ db *gorm.DB }
+
+func NewPostgresScreenVersionRepository(db *gorm.DB) repository.ScreenVersionRepository {
+	return &postgresScreenVersionRepository{db: db}
+}
+
+func (r *postgresScreenVersionRepository) CreateTemplateVersion(ctx context.Context, v *model.ScreenTemplateVersion) error {
+	return r.db.WithContext(ctx).Create(v).Error
+}
+
+func (r *postgresScreenVersionRepository) ListTemplateVersions(ctx context.Context, templateID uuid.UUID, filters sharedrepo.ListFilters) ([]*model.ScreenTemplateVersion, int, error) {
+	type versionWithTotal struct {
+		model.ScreenTemplateVersion
+		Total int64 `gorm:"column:_total"`
+	}
+
+	query := r.db.WithContext(ctx).Table(model.ScreenTemplateVersion{}.TableName()).
+		Select("*, COUNT(*) OVER() as _total").
+		Where("template_id = ?", templateID).
+		Order("version DESC")
+	query = filters.ApplyPagination(query)
+
+	var results []versionWithTotal
+	if err := query.Find(&results).Error; err != nil {
+		return nil, 0, err
+	}
+
+	total := int64(0)
+	if len(results) > 0 {
+		total = results[0].Total
+	}
+
+	versions := make([]*model.ScreenTemplateVersion, len(results))
+	for i := range results {
+		v := results[i].ScreenTemplateVersion
+		versions[i] = &v
+	}
+	return versions, int(total), nil
+}
+
+func (r *postgresScreenVersionRepository) GetTemplateVersion(ctx context.Context, templateID uuid.UUID, version int) (*model.ScreenTemplateVersion, error) {
+	var v model.ScreenTemplateVersion
+	if err := r.db.WithContext(ctx).Where("template_id = ? AND version = ?", templateID, version).First(&v).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *postgresScreenVersionRepository) CreateInstanceVersion(ctx context.Context, v *model.ScreenInstanceVersion) error {
+	return r.db.WithContext(ctx).Create(v).Error
+}
+
+func (r *postgresScreenVersionRepository) ListInstanceVersions(ctx context.Context, instanceID uuid.UUID, filters sharedrepo.ListFilters) ([]*model.ScreenInstanceVersion, int, error) {
+	type versionWithTotal struct {
+		model.ScreenInstanceVersion
+		Total int64 `gorm:"column:_total"`
+	}
+
+	query := r.db.WithContext(ctx).Table(model.ScreenInstanceVersion{}.TableName()).
+		Select("*, COUNT(*) OVER() as _total").
+		Where("instance_id = ?", instanceID).
+		Order("version DESC")
+	query = filters.ApplyPagination(query)
+
+	var results []versionWithTotal
+	if err := query.Find(&results).Error; err != nil {
+		return nil, 0, err
+	}
+
+	total := int64(0)
+	if len(results) > 0 {
+		total = results[0].Total
+	}
+
+	versions := make([]*model.ScreenInstanceVersion, len(results))
+	for i := range results {
+		v := results[i].ScreenInstanceVersion
+		versions[i] = &v
+	}
+	return versions, int(total), nil
+}
+
+func (r *postgresScreenVersionRepository) GetInstanceVersion(ctx context.Context, instanceID uuid.UUID, version int) (*model.ScreenInstanceVersion, error) {
+	var v model.ScreenInstanceVersion
+	if err := r.db.WithContext(ctx).Where("instance_id = ? AND version = ?", instanceID, version).First(&v).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *postgresScreenVersionRepository) LatestInstanceVersion(ctx context.Context, instanceID uuid.UUID) (int, error) {
+	var latest int
+	err := r.db.WithContext(ctx).Model(&model.ScreenInstanceVersion{}).
+		Where("instance_id = ?", instanceID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&latest).Error
+	return latest, err
+}
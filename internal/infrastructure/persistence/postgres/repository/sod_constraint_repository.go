@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type postgresSoDConstraintRepository struct{ db *gorm.DB }
+
+func NewPostgresSoDConstraintRepository(db *gorm.DB) repository.SoDConstraintRepository {
+	return &postgresSoDConstraintRepository{db: db}
+}
+
+func (r *postgresSoDConstraintRepository) Create(ctx context.Context, constraint *model.SoDConstraint) error {
+	return r.db.WithContext(ctx).Create(constraint).Error
+}
+
+func (r *postgresSoDConstraintRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.SoDConstraint, error) {
+	var constraint model.SoDConstraint
+	if err := r.db.WithContext(ctx).Where("id = ? AND is_active = true", id).First(&constraint).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &constraint, nil
+}
+
+func (r *postgresSoDConstraintRepository) FindActive(ctx context.Context) ([]*model.SoDConstraint, error) {
+	var constraints []*model.SoDConstraint
+	err := r.db.WithContext(ctx).Where("is_active = true").Order("created_at ASC").Find(&constraints).Error
+	return constraints, err
+}
+
+// Exists matches the pair in either order, since exclusion is symmetric.
+func (r *postgresSoDConstraintRepository) Exists(ctx context.Context, kind string, leftID, rightID uuid.UUID, schoolID *uuid.UUID) (bool, error) {
+	query := r.db.WithContext(ctx).Model(&model.SoDConstraint{}).
+		Where("kind = ? AND is_active = true", kind).
+		Where("(left_id = ? AND right_id = ?) OR (left_id = ? AND right_id = ?)", leftID, rightID, rightID, leftID)
+	if schoolID != nil {
+		query = query.Where("school_id = ?", *schoolID)
+	} else {
+		query = query.Where("school_id IS NULL")
+	}
+	var count int64
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+func (r *postgresSoDConstraintRepository) Deactivate(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&model.SoDConstraint{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"is_active": false, "updated_at": time.Now()}).Error
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type postgresTranslationRepository struct{ db *gorm.DB }
+
+func NewPostgresTranslationRepository(db *gorm.DB) repository.TranslationRepository {
+	return &postgresTranslationRepository{db: db}
+}
+
+func (r *postgresTranslationRepository) ListForEntity(ctx context.Context, entityType string, entityID uuid.UUID) ([]*model.Translation, error) {
+	var translations []*model.Translation
+	err := r.db.WithContext(ctx).
+		Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Order("field, locale").
+		Find(&translations).Error
+	return translations, err
+}
+
+func (r *postgresTranslationRepository) FindByLocale(ctx context.Context, entityType, field, locale string) ([]*model.Translation, error) {
+	var translations []*model.Translation
+	err := r.db.WithContext(ctx).
+		Where("entity_type = ? AND field = ? AND locale = ?", entityType, field, locale).
+		Find(&translations).Error
+	return translations, err
+}
+
+func (r *postgresTranslationRepository) ReplaceForEntity(ctx context.Context, entityType string, entityID uuid.UUID, translations []*model.Translation) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+			Delete(&model.Translation{}).Error; err != nil {
+			return err
+		}
+		if len(translations) == 0 {
+			return nil
+		}
+		return tx.Create(&translations).Error
+	})
+}
+
+type postgresUserPreferenceRepository struct{ db *gorm.DB }
+
+func NewPostgresUserPreferenceRepository(db *gorm.DB) repository.UserPreferenceRepository {
+	return &postgresUserPreferenceRepository{db: db}
+}
+
+func (r *postgresUserPreferenceRepository) Get(ctx context.Context, userID uuid.UUID) (*model.UserPreference, error) {
+	var pref model.UserPreference
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&pref).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &pref, nil
+}
+
+func (r *postgresUserPreferenceRepository) Save(ctx context.Context, pref *model.UserPreference) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"locale", "updated_at"}),
+	}).Create(pref).Error
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	authmodel "github.com/EduGoGroup/edugo-api-iam-platform/internal/auth/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"gorm.io/gorm"
+)
+
+type postgresUserErasureRepository struct{ db *gorm.DB }
+
+func NewPostgresUserErasureRepository(db *gorm.DB) repository.UserErasureRepository {
+	return &postgresUserErasureRepository{db: db}
+}
+
+// Anonymize deletes the login attempts rather than rewriting their identifier:
+// they only feed the login rate limit, which no longer applies to the account.
+func (r *postgresUserErasureRepository) Anonymize(ctx context.Context, user *entities.User, loginIdentifier string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(user).Error; err != nil {
+			return err
+		}
+		return tx.Where("identifier = ?", loginIdentifier).Delete(&authmodel.LoginAttempt{}).Error
+	})
+}
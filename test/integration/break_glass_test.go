@@ -0,0 +1,85 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/infrastructure/persistence/postgres/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/google/uuid"
+)
+
+func TestBreakGlassRepository_Activate(t *testing.T) {
+	db := openDB(t)
+	ctx := context.Background()
+	repo := repository.NewPostgresBreakGlassRepository(db)
+
+	now := time.Now()
+	role := &entities.Role{ID: uuid.New(), Name: "break-glass-" + uuid.NewString()[:8], DisplayName: "Break glass", IsActive: true,
+		CreatedAt: now, UpdatedAt: now}
+	if err := db.Create(role).Error; err != nil {
+		t.Fatalf("rol: %v", err)
+	}
+	// elevation builds the grant and the elevation like the service does
+	elevation := func(userID uuid.UUID) (*model.BreakGlassElevation, *entities.UserRole) {
+		now := time.Now()
+		expiresAt := now.Add(time.Hour)
+		grant := &entities.UserRole{ID: uuid.New(), UserID: userID, RoleID: role.ID, IsActive: true, GrantedBy: &userID,
+			GrantedAt: now, ExpiresAt: &expiresAt, CreatedAt: now, UpdatedAt: now}
+		return &model.BreakGlassElevation{ID: uuid.New(), UserID: userID, RoleID: role.ID, UserRoleID: grant.ID,
+			Justification: "prueba", ActivatedAt: now, ExpiresAt: expiresAt}, grant
+	}
+
+	t.Run("activaciones concurrentes del mismo usuario dejan una sola elevación", func(t *testing.T) {
+		userID := uuid.New()
+		var wg sync.WaitGroup
+		results := make([]bool, 5)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				e, grant := elevation(userID)
+				activated, err := repo.Activate(ctx, e, grant, now.Add(-24*time.Hour), 5)
+				if err != nil {
+					t.Errorf("error inesperado: %v", err)
+				}
+				results[i] = activated
+			}(i)
+		}
+		wg.Wait()
+
+		won := 0
+		for _, activated := range results {
+			if activated {
+				won++
+			}
+		}
+		var elevations, grants int64
+		db.Model(&model.BreakGlassElevation{}).Where("user_id = ?", userID).Count(&elevations)
+		db.Model(&entities.UserRole{}).Where("user_id = ?", userID).Count(&grants)
+		if won != 1 || elevations != 1 || grants != 1 {
+			t.Errorf("se esperaba una elevación: ganadas=%d elevaciones=%d asignaciones=%d", won, elevations, grants)
+		}
+	})
+
+	t.Run("respeta el límite de activaciones", func(t *testing.T) {
+		userID := uuid.New()
+		e, grant := elevation(userID)
+		if _, err := repo.Activate(ctx, e, grant, now.Add(-24*time.Hour), 1); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if err := repo.End(ctx, e.ID, time.Now(), &userID); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		again, second := elevation(userID)
+		activated, err := repo.Activate(ctx, again, second, now.Add(-24*time.Hour), 1)
+		if err != nil || activated {
+			t.Fatalf("no debería activar otra vez: %v, %v", activated, err)
+		}
+	})
+}
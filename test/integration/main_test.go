@@ -0,0 +1,38 @@
+//go:build integration
+
+// Package integration runs the repositories against a real PostgreSQL. The
+// tests are skipped unless RUN_INTEGRATION_TESTS=true and
+// INTEGRATION_DATABASE_URL points at a disposable database; the embedded
+// migrations are applied to it first.
+package integration
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/infrastructure/persistence/postgres/migrations"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func openDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("INTEGRATION_DATABASE_URL")
+	if os.Getenv("RUN_INTEGRATION_TESTS") != "true" || dsn == "" {
+		t.Skip("set RUN_INTEGRATION_TESTS=true and INTEGRATION_DATABASE_URL to run integration tests")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("conexión fallida: %v", err)
+	}
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("migraciones inválidas: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("migraciones fallidas: %v", err)
+	}
+	return db
+}
@@ -0,0 +1,100 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/domain/model"
+	"github.com/EduGoGroup/edugo-api-iam-platform/internal/infrastructure/persistence/postgres/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// scheduledDelegation stores a role, the delegator's assignment and a
+// delegation of it that is due
+func scheduledDelegation(t *testing.T, db *gorm.DB) *model.RoleDelegation {
+	t.Helper()
+	now := time.Now()
+	role := &entities.Role{ID: uuid.New(), Name: "delegable-" + uuid.NewString()[:8], DisplayName: "Delegable", IsActive: true,
+		CreatedAt: now, UpdatedAt: now}
+	if err := db.Create(role).Error; err != nil {
+		t.Fatalf("rol: %v", err)
+	}
+	schoolID, unitID := uuid.New(), uuid.New()
+	source := &entities.UserRole{ID: uuid.New(), UserID: uuid.New(), RoleID: role.ID, SchoolID: &schoolID,
+		AcademicUnitID: &unitID, IsActive: true, GrantedAt: now, CreatedAt: now, UpdatedAt: now}
+	if err := db.Create(source).Error; err != nil {
+		t.Fatalf("asignación origen: %v", err)
+	}
+	d := &model.RoleDelegation{ID: uuid.New(), DelegatorID: source.UserID, DelegateID: uuid.New(),
+		SourceUserRoleID: source.ID, RoleID: role.ID, SchoolID: schoolID, AcademicUnitID: unitID,
+		StartsAt: now.Add(-time.Minute), EndsAt: now.Add(time.Hour), Status: model.DelegationScheduled,
+		CreatedAt: now, UpdatedAt: now}
+	if err := db.Create(d).Error; err != nil {
+		t.Fatalf("delegación: %v", err)
+	}
+	return d
+}
+
+// activation builds the active delegation and the grant like the service does
+func activation(d *model.RoleDelegation) (*model.RoleDelegation, *entities.UserRole) {
+	now := time.Now()
+	schoolID, unitID := d.SchoolID, d.AcademicUnitID
+	grant := &entities.UserRole{ID: uuid.New(), UserID: d.DelegateID, RoleID: d.RoleID, SchoolID: &schoolID,
+		AcademicUnitID: &unitID, IsActive: true, GrantedBy: &d.DelegatorID, GrantedAt: now, ExpiresAt: &d.EndsAt,
+		CreatedAt: now, UpdatedAt: now}
+	active := *d
+	active.Status = model.DelegationActive
+	active.DelegatedUserRoleID = &grant.ID
+	active.UpdatedAt = now
+	return &active, grant
+}
+
+func TestRoleDelegationRepository_Activate(t *testing.T) {
+	db := openDB(t)
+	ctx := context.Background()
+	repo := repository.NewPostgresRoleDelegationRepository(db)
+
+	t.Run("activa la delegación y crea la asignación del delegado", func(t *testing.T) {
+		d := scheduledDelegation(t, db)
+		active, grant := activation(d)
+		activated, err := repo.Activate(ctx, active, grant)
+		if err != nil || !activated {
+			t.Fatalf("esperaba activar: %v, %v", activated, err)
+		}
+		stored, err := repo.FindByID(ctx, d.ID)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if stored.Status != model.DelegationActive || stored.DelegatedUserRoleID == nil || *stored.DelegatedUserRoleID != grant.ID {
+			t.Errorf("delegación no activada: %+v", stored)
+		}
+		var count int64
+		db.Model(&entities.UserRole{}).Where("id = ? AND user_id = ?", grant.ID, d.DelegateID).Count(&count)
+		if count != 1 {
+			t.Error("la asignación del delegado no existe")
+		}
+	})
+
+	t.Run("una segunda activación no escribe nada", func(t *testing.T) {
+		d := scheduledDelegation(t, db)
+		active, grant := activation(d)
+		if _, err := repo.Activate(ctx, active, grant); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		again, second := activation(d)
+		activated, err := repo.Activate(ctx, again, second)
+		if err != nil || activated {
+			t.Fatalf("no debería activar otra vez: %v, %v", activated, err)
+		}
+		var count int64
+		db.Model(&entities.UserRole{}).Where("id = ?", second.ID).Count(&count)
+		if count != 0 {
+			t.Error("la asignación de la carrera perdida debería revertirse")
+		}
+	})
+}